@@ -0,0 +1,213 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+// CanonicalMode selects the canonical JSON form records are marshaled in.
+// Record CIDs are computed over the canonical bytes, so a record may have
+// a different CID in each mode. CanonicalModeDefault is used unless another mode is selected.
+type CanonicalMode string
+
+const (
+	// CanonicalModeLegacy is the compact canonical JSON produced by encoding/json.
+	// Object keys are sorted by their UTF-8 bytes and the HTML characters <, > and &
+	// are escaped. Repeated fields keep their order. Records pushed before repeated
+	// fields were sorted have CIDs computed in this mode, which remain valid.
+	CanonicalModeLegacy CanonicalMode = "legacy"
+
+	// CanonicalModeSorted is the legacy encoding with the elements of repeated fields
+	// whose order carries no meaning, such as skills and locators, sorted.
+	// Records that only differ in the order of these elements share a CID in this mode.
+	CanonicalModeSorted CanonicalMode = "sorted"
+
+	// CanonicalModeJCS is the JSON Canonicalization Scheme of RFC 8785.
+	// Object keys are sorted by their UTF-16 code units and only the characters
+	// required by RFC 8785 are escaped. Repeated fields are sorted as in CanonicalModeSorted.
+	CanonicalModeJCS CanonicalMode = "jcs"
+
+	// CanonicalModeDefault is the mode of Record.Marshal and Record.GetCid.
+	CanonicalModeDefault = CanonicalModeSorted
+)
+
+// CanonicalModes lists the supported canonical modes.
+var CanonicalModes = []CanonicalMode{CanonicalModeLegacy, CanonicalModeSorted, CanonicalModeJCS}
+
+// sortsRepeatedFields reports whether the mode sorts the elements of repeated fields.
+func (m CanonicalMode) sortsRepeatedFields() bool {
+	return m == CanonicalModeSorted || m == CanonicalModeJCS
+}
+
+// ParseCanonicalMode parses the name of a canonical mode.
+// An empty name selects the default mode.
+func ParseCanonicalMode(name string) (CanonicalMode, error) {
+	if name == "" {
+		return CanonicalModeDefault, nil
+	}
+
+	mode := CanonicalMode(name)
+	if !slices.Contains(CanonicalModes, mode) {
+		names := make([]string, len(CanonicalModes))
+		for i, m := range CanonicalModes {
+			names[i] = string(m)
+		}
+
+		return "", fmt.Errorf("unsupported canonical mode %q, expected one of: %s", name, strings.Join(names, ", "))
+	}
+
+	return mode, nil
+}
+
+// canonicalJSON encodes a normalized JSON value in the given canonical mode.
+func canonicalJSON(value any, mode CanonicalMode) ([]byte, error) {
+	switch mode {
+	case "", CanonicalModeLegacy, CanonicalModeSorted:
+		//nolint:wrapcheck
+		return json.Marshal(value)
+
+	case CanonicalModeJCS:
+		var buf bytes.Buffer
+		if err := encodeJCS(&buf, value); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported canonical mode %q", mode)
+	}
+}
+
+// encodeJCS writes a value decoded by encoding/json as RFC 8785 canonical JSON.
+func encodeJCS(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("unsupported number %v", v)
+		}
+
+		// Negative zero is serialized as zero.
+		if v == 0 {
+			buf.WriteByte('0')
+
+			break
+		}
+
+		// encoding/json formats float64 values like the ECMAScript
+		// Number.prototype.toString algorithm required by RFC 8785.
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode number: %w", err)
+		}
+
+		buf.Write(encoded)
+
+	case string:
+		encodeJCSString(buf, v)
+
+	case []any:
+		buf.WriteByte('[')
+
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := encodeJCS(buf, element); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		slices.SortFunc(keys, compareUTF16)
+
+		buf.WriteByte('{')
+
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			encodeJCSString(buf, key)
+			buf.WriteByte(':')
+
+			if err := encodeJCS(buf, v[key]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+
+	default:
+		return fmt.Errorf("unsupported JSON value of type %T", value)
+	}
+
+	return nil
+}
+
+// encodeJCSString writes a string escaping only quotes, backslashes and control characters.
+func encodeJCSString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 { //nolint:mnd
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+}
+
+// compareUTF16 orders strings by their UTF-16 code units, as required by RFC 8785.
+func compareUTF16(a, b string) int {
+	return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+}
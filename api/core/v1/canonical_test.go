@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalJSON_JCSConformance checks the JCS mode against the examples of RFC 8785.
+func TestCanonicalJSON_JCSConformance(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			// RFC 8785, section 3.2.2
+			name: "primitive data types",
+			input: `{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`,
+			want: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			// RFC 8785, section 3.2.3
+			name: "sorting of properties",
+			input: `{
+				"\u20ac": "Euro Sign",
+				"\r": "Carriage Return",
+				"\ufb33": "Hebrew Letter Dalet With Dagesh",
+				"1": "One",
+				"\ud83d\ude00": "Emoji: Grinning Face",
+				"\u0080": "Control",
+				"\u00f6": "Latin Small Letter O With Diaeresis"
+			}`,
+			want: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\"," +
+				"\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			// RFC 8785, appendix B
+			name:  "number serialization",
+			input: `[0, -0, 1, -1, 1e21, 1e20, 1e-6, 1e-7, 9007199254740992, 295147905179352830000, 4.35, 0.1]`,
+			want:  `[0,0,1,-1,1e+21,100000000000000000000,0.000001,1e-7,9007199254740992,295147905179352830000,4.35,0.1]`,
+		},
+		{
+			name:  "html and line separators are not escaped",
+			input: `{"html":"<a href=\"x\">&amp;</a>","separators":"\u2028\u2029"}`,
+			want:  "{\"html\":\"<a href=\\\"x\\\">&amp;</a>\",\"separators\":\"\u2028\u2029\"}",
+		},
+		{
+			name:  "nested structures",
+			input: `{"b":[{"d":1,"c":2}],"a":{"z":{},"y":[]}}`,
+			want:  `{"a":{"y":[],"z":{}},"b":[{"c":2,"d":1}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &value))
+
+			got, err := canonicalJSON(value, CanonicalModeJCS)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCanonicalJSON_LegacyUnchanged(t *testing.T) {
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(`{"b":"<&>","a":1}`), &value))
+
+	got, err := canonicalJSON(value, CanonicalModeLegacy)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":"\u003c\u0026\u003e"}`, string(got))
+}
+
+func TestParseCanonicalMode(t *testing.T) {
+	mode, err := ParseCanonicalMode("")
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalModeDefault, mode)
+
+	mode, err = ParseCanonicalMode("legacy")
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalModeLegacy, mode)
+
+	mode, err = ParseCanonicalMode("sorted")
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalModeSorted, mode)
+
+	mode, err = ParseCanonicalMode("jcs")
+	require.NoError(t, err)
+	assert.Equal(t, CanonicalModeJCS, mode)
+
+	_, err = ParseCanonicalMode("unknown")
+	assert.ErrorContains(t, err, "unsupported canonical mode")
+}
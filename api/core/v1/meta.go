@@ -0,0 +1,29 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import "time"
+
+// ExpiryTime returns the time at which the record expires.
+// It returns false if the record does not expire or its expiry timestamp is invalid.
+func (x *RecordMeta) ExpiryTime() (time.Time, bool) {
+	if x.GetExpiresAt() == "" {
+		return time.Time{}, false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, x.GetExpiresAt())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiresAt, true
+}
+
+// IsExpired reports whether the record has expired at the given time.
+// Records without an expiry never expire.
+func (x *RecordMeta) IsExpired(now time.Time) bool {
+	expiresAt, ok := x.ExpiryTime()
+
+	return ok && !now.Before(expiresAt)
+}
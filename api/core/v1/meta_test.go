@@ -0,0 +1,39 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordMeta_IsExpired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt string
+		expired   bool
+	}{
+		{name: "no expiry", expiresAt: "", expired: false},
+		{name: "invalid expiry", expiresAt: "tomorrow", expired: false},
+		{name: "future expiry", expiresAt: "2025-01-01T13:00:00Z", expired: false},
+		{name: "past expiry", expiresAt: "2025-01-01T11:00:00Z", expired: true},
+		{name: "expiry now", expiresAt: "2025-01-01T12:00:00Z", expired: true},
+		{name: "expiry with offset", expiresAt: "2025-01-01T12:30:00+01:00", expired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &corev1.RecordMeta{ExpiresAt: tt.expiresAt}
+			assert.Equal(t, tt.expired, meta.IsExpired(now))
+		})
+	}
+
+	var nilMeta *corev1.RecordMeta
+	assert.False(t, nilMeta.IsExpired(now))
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 )
 
+// LatestSchemaVersion is the most recent OASF schema version supported for records.
+const LatestSchemaVersion = "0.7.0"
+
 // DecodedRecord is an interface representing a decoded OASF record.
 // It provides methods to access the underlying record data.
 type DecodedRecord interface {
@@ -4,9 +4,11 @@
 package v1
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 	"github.com/agntcy/oasf-sdk/pkg/validator"
@@ -30,15 +32,38 @@ func init() {
 
 // GetCid calculates and returns the CID for this record.
 // The CID is calculated from the record's content using CIDv1, codec 1, SHA2-256.
-// Uses canonical JSON marshaling to ensure consistent, cross-language compatible results.
+// Uses canonical JSON marshaling in the default mode to ensure consistent, cross-language compatible results.
 // Returns empty string if calculation fails.
 func (r *Record) GetCid() string {
+	return r.GetCanonicalCid(CanonicalModeDefault)
+}
+
+// HasCid reports whether the CID is the CID of this record in any canonical mode.
+// Records keep the CID of the form they were pushed in, e.g. legacy CIDs of records
+// pushed before repeated fields were sorted, so checks against a known CID must use HasCid.
+func (r *Record) HasCid(cid string) bool {
+	if cid == "" {
+		return false
+	}
+
+	for _, mode := range CanonicalModes {
+		if r.GetCanonicalCid(mode) == cid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetCanonicalCid calculates and returns the CID of this record marshaled in the given canonical mode.
+// Returns empty string if calculation fails.
+func (r *Record) GetCanonicalCid(mode CanonicalMode) string {
 	if r == nil || r.GetData() == nil {
 		return ""
 	}
 
 	// Use canonical marshaling for CID calculation
-	canonicalBytes, err := r.Marshal()
+	canonicalBytes, err := r.MarshalCanonical(mode)
 	if err != nil {
 		return ""
 	}
@@ -61,7 +86,14 @@ func (r *Record) GetCid() string {
 // Marshal marshals the Record using canonical JSON serialization.
 // This ensures deterministic, cross-language compatible byte representation.
 // The output represents the pure Record data and is used for both CID calculation and storage.
+// Repeated fields whose order carries no meaning are sorted, see CanonicalModeDefault.
 func (r *Record) Marshal() ([]byte, error) {
+	return r.MarshalCanonical(CanonicalModeDefault)
+}
+
+// MarshalCanonical marshals the Record using the canonical JSON serialization of the given mode.
+// See CanonicalMode for the differences between modes.
+func (r *Record) MarshalCanonical(mode CanonicalMode) ([]byte, error) {
 	if r == nil || r.GetData() == nil {
 		return nil, nil
 	}
@@ -81,9 +113,18 @@ func (r *Record) Marshal() ([]byte, error) {
 		return nil, fmt.Errorf("failed to normalize JSON for canonical ordering: %w", err)
 	}
 
-	// Step 3: Marshal with sorted keys for deterministic output.
-	// encoding/json.Marshal sorts map keys alphabetically.
-	canonicalBytes, err := json.Marshal(normalized)
+	// Step 3: Sort repeated fields whose order carries no meaning, if the mode does.
+	// Records that differ only in the order of e.g. their skills then share a CID.
+	// The legacy mode keeps the order, so that CIDs of records pushed in it can be reproduced.
+	if mode.sortsRepeatedFields() {
+		if err := sortRepeatedFields(normalized); err != nil {
+			return nil, fmt.Errorf("failed to sort repeated fields for canonical ordering: %w", err)
+		}
+	}
+
+	// Step 4: Marshal with sorted keys for deterministic output.
+	// All modes sort map keys, see CanonicalMode for how they differ.
+	canonicalBytes, err := canonicalJSON(normalized, mode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal normalized JSON with sorted keys: %w", err)
 	}
@@ -91,6 +132,64 @@ func (r *Record) Marshal() ([]byte, error) {
 	return canonicalBytes, nil
 }
 
+// canonicalRepeatedFields are the record fields whose element order carries no meaning.
+var canonicalRepeatedFields = []string{"skills", "domains", "locators", "modules", "extensions"}
+
+// sortRepeatedFields sorts the elements of the canonical repeated fields of a normalized record
+// by name, then version. Remaining ties, such as locators which have no name,
+// are broken by the canonical JSON encoding of the elements.
+func sortRepeatedFields(record any) error {
+	fields, ok := record.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	type sortableElement struct {
+		name    string
+		version string
+		encoded string
+		value   any
+	}
+
+	for _, field := range canonicalRepeatedFields {
+		values, ok := fields[field].([]any)
+		if !ok || len(values) < 2 { //nolint:mnd
+			continue
+		}
+
+		elements := make([]sortableElement, len(values))
+
+		for i, value := range values {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s element: %w", field, err)
+			}
+
+			element := sortableElement{encoded: string(encoded), value: value}
+			if object, ok := value.(map[string]any); ok {
+				element.name, _ = object["name"].(string)
+				element.version, _ = object["version"].(string)
+			}
+
+			elements[i] = element
+		}
+
+		slices.SortFunc(elements, func(a, b sortableElement) int {
+			return cmp.Or(
+				cmp.Compare(a.name, b.name),
+				cmp.Compare(a.version, b.version),
+				cmp.Compare(a.encoded, b.encoded),
+			)
+		})
+
+		for i, element := range elements {
+			values[i] = element.value
+		}
+	}
+
+	return nil
+}
+
 func (r *Record) GetSchemaVersion() string {
 	if r == nil || r.GetData() == nil {
 		return ""
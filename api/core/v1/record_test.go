@@ -4,6 +4,9 @@
 package v1_test
 
 import (
+	"math/rand/v2"
+	"slices"
+	"strings"
 	"testing"
 
 	oasfv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
@@ -120,6 +123,117 @@ func TestRecord_GetCid_CrossVersion_Difference(t *testing.T) {
 	assert.NotEqual(t, cid1, cid2, "Different record versions should have different CIDs")
 }
 
+func TestRecord_MarshalCanonical_RepeatedFieldOrdering(t *testing.T) {
+	skills := []*oasfv1alpha1.Skill{
+		{Name: "natural_language_processing/text_completion", Id: 10201},
+		{Name: "images_computer_vision/image_segmentation", Id: 20201},
+		{Name: "audio/speech_recognition", Id: 30101},
+	}
+	domains := []*oasfv1alpha1.Domain{
+		{Name: "technology/networking", Id: 103},
+		{Name: "research/scientific_discovery", Id: 201},
+	}
+	locators := []*oasfv1alpha1.Locator{
+		{Type: "docker_image", Url: "ghcr.io/agntcy/agent:v1"},
+		{Type: "source_code", Url: "https://github.com/agntcy/agent"},
+		{Type: "docker_image", Url: "ghcr.io/agntcy/agent:v2"},
+	}
+	modules := []*oasfv1alpha1.Module{
+		{Name: "runtime/mcp", Id: 202},
+		{Name: "integration/a2a", Id: 203},
+	}
+
+	newRecord := func(skills []*oasfv1alpha1.Skill, domains []*oasfv1alpha1.Domain, locators []*oasfv1alpha1.Locator, modules []*oasfv1alpha1.Module) *corev1.Record {
+		return corev1.New(&oasfv1alpha1.Record{
+			Name:          "ordering-agent",
+			SchemaVersion: "0.7.0",
+			Version:       "1.0.0",
+			Skills:        skills,
+			Domains:       domains,
+			Locators:      locators,
+			Modules:       modules,
+		})
+	}
+
+	want, err := newRecord(skills, domains, locators, modules).Marshal()
+	assert.NoError(t, err)
+
+	wantCID := newRecord(skills, domains, locators, modules).GetCid()
+	assert.NotEmpty(t, wantCID)
+
+	rng := rand.New(rand.NewPCG(1, 2)) //nolint:gosec
+
+	for range 20 {
+		shuffledSkills := slices.Clone(skills)
+		rng.Shuffle(len(shuffledSkills), func(i, j int) { shuffledSkills[i], shuffledSkills[j] = shuffledSkills[j], shuffledSkills[i] })
+
+		shuffledDomains := slices.Clone(domains)
+		rng.Shuffle(len(shuffledDomains), func(i, j int) { shuffledDomains[i], shuffledDomains[j] = shuffledDomains[j], shuffledDomains[i] })
+
+		shuffledLocators := slices.Clone(locators)
+		rng.Shuffle(len(shuffledLocators), func(i, j int) { shuffledLocators[i], shuffledLocators[j] = shuffledLocators[j], shuffledLocators[i] })
+
+		shuffledModules := slices.Clone(modules)
+		rng.Shuffle(len(shuffledModules), func(i, j int) { shuffledModules[i], shuffledModules[j] = shuffledModules[j], shuffledModules[i] })
+
+		record := newRecord(shuffledSkills, shuffledDomains, shuffledLocators, shuffledModules)
+
+		got, err := record.Marshal()
+		assert.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "Marshaled bytes should not depend on the order of repeated fields")
+
+		assert.Equal(t, wantCID, record.GetCid(), "CID should not depend on the order of repeated fields")
+	}
+
+	// Elements are sorted by name
+	assert.Less(t, strings.Index(string(want), "audio/speech_recognition"), strings.Index(string(want), "natural_language_processing/text_completion"))
+
+	// The legacy form keeps the input order, so that CIDs of records pushed in it can be reproduced
+	record := newRecord(skills, domains, locators, modules)
+
+	legacy, err := record.MarshalCanonical(corev1.CanonicalModeLegacy)
+	assert.NoError(t, err)
+	assert.Less(t, strings.Index(string(legacy), "natural_language_processing/text_completion"), strings.Index(string(legacy), "audio/speech_recognition"))
+
+	legacyCID := record.GetCanonicalCid(corev1.CanonicalModeLegacy)
+	assert.NotEqual(t, wantCID, legacyCID)
+	assert.True(t, record.HasCid(legacyCID), "legacy CIDs should remain valid")
+	assert.True(t, record.HasCid(wantCID))
+	assert.False(t, record.HasCid(newRecord(skills[:1], domains, locators, modules).GetCid()))
+	assert.False(t, record.HasCid(""))
+}
+
+func TestRecord_MarshalCanonical(t *testing.T) {
+	record := corev1.New(&oasfv1alpha1.Record{
+		Name:          "canonical-agent",
+		SchemaVersion: "0.7.0",
+		Version:       "1.0.0",
+		Description:   "Converts <html> & markdown",
+	})
+
+	legacy, err := record.MarshalCanonical(corev1.CanonicalModeLegacy)
+	assert.NoError(t, err)
+
+	marshaled, err := record.Marshal()
+	assert.NoError(t, err)
+
+	// Without repeated fields to sort, the default form matches the legacy form
+	assert.Equal(t, string(marshaled), string(legacy))
+	assert.Equal(t, record.GetCid(), record.GetCanonicalCid(corev1.CanonicalModeLegacy))
+	assert.Contains(t, string(legacy), `\u003chtml\u003e \u0026 markdown`)
+
+	jcs, err := record.MarshalCanonical(corev1.CanonicalModeJCS)
+	assert.NoError(t, err)
+	assert.Contains(t, string(jcs), "<html> & markdown")
+
+	// Changing the canonical form changes the CID
+	assert.NotEmpty(t, record.GetCanonicalCid(corev1.CanonicalModeJCS))
+	assert.NotEqual(t, record.GetCid(), record.GetCanonicalCid(corev1.CanonicalModeJCS))
+
+	_, err = record.MarshalCanonical("unknown")
+	assert.Error(t, err)
+}
+
 func TestRecord_Validate(t *testing.T) {
 	tests := []struct {
 		name      string
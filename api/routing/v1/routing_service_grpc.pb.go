@@ -11,6 +11,7 @@ package v1
 
 import (
 	context "context"
+	v1 "github.com/agntcy/dir/api/core/v1"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -23,10 +24,14 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	RoutingService_Publish_FullMethodName   = "/agntcy.dir.routing.v1.RoutingService/Publish"
-	RoutingService_Unpublish_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
-	RoutingService_Search_FullMethodName    = "/agntcy.dir.routing.v1.RoutingService/Search"
-	RoutingService_List_FullMethodName      = "/agntcy.dir.routing.v1.RoutingService/List"
+	RoutingService_Publish_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/Publish"
+	RoutingService_Unpublish_FullMethodName       = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
+	RoutingService_Search_FullMethodName          = "/agntcy.dir.routing.v1.RoutingService/Search"
+	RoutingService_List_FullMethodName            = "/agntcy.dir.routing.v1.RoutingService/List"
+	RoutingService_ListPeers_FullMethodName       = "/agntcy.dir.routing.v1.RoutingService/ListPeers"
+	RoutingService_PullFromPeer_FullMethodName    = "/agntcy.dir.routing.v1.RoutingService/PullFromPeer"
+	RoutingService_PullFromNetwork_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/PullFromNetwork"
+	RoutingService_ListProvided_FullMethodName    = "/agntcy.dir.routing.v1.RoutingService/ListProvided"
 )
 
 // RoutingServiceClient is the client API for RoutingService service.
@@ -63,6 +68,24 @@ type RoutingServiceClient interface {
 	// that match the given parameters.
 	// This operation does not interact with the network.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (RoutingService_ListClient, error)
+	// List peers known to this node's routing layer.
+	// Includes connected peers and peers from the DHT routing table,
+	// together with their advertised Directory API address and GossipSub status.
+	// This operation does not interact with the network.
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// Pull a record directly from a peer at the given multiaddr,
+	// bypassing DHT discovery.
+	// Fails if the remote peer does not have the record.
+	PullFromPeer(ctx context.Context, in *PullFromPeerRequest, opts ...grpc.CallOption) (*v1.Record, error)
+	// Pull a record from a peer providing it on the network,
+	// discovered via DHT, and cache it in the local store.
+	// Fails if no reachable provider has the record.
+	PullFromNetwork(ctx context.Context, in *PullFromNetworkRequest, opts ...grpc.CallOption) (*v1.Record, error)
+	// List records that this node has announced to the network as a provider.
+	// Records are tracked as they are published and republished,
+	// and removed once unpublished.
+	// This operation does not interact with the network.
+	ListProvided(ctx context.Context, in *ListProvidedRequest, opts ...grpc.CallOption) (*ListProvidedResponse, error)
 }
 
 type routingServiceClient struct {
@@ -159,6 +182,46 @@ func (x *routingServiceListClient) Recv() (*ListResponse, error) {
 	return m, nil
 }
 
+func (c *routingServiceClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPeersResponse)
+	err := c.cc.Invoke(ctx, RoutingService_ListPeers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) PullFromPeer(ctx context.Context, in *PullFromPeerRequest, opts ...grpc.CallOption) (*v1.Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.Record)
+	err := c.cc.Invoke(ctx, RoutingService_PullFromPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) PullFromNetwork(ctx context.Context, in *PullFromNetworkRequest, opts ...grpc.CallOption) (*v1.Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.Record)
+	err := c.cc.Invoke(ctx, RoutingService_PullFromNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) ListProvided(ctx context.Context, in *ListProvidedRequest, opts ...grpc.CallOption) (*ListProvidedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidedResponse)
+	err := c.cc.Invoke(ctx, RoutingService_ListProvided_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoutingServiceServer is the server API for RoutingService service.
 // All implementations should embed UnimplementedRoutingServiceServer
 // for forward compatibility.
@@ -193,6 +256,24 @@ type RoutingServiceServer interface {
 	// that match the given parameters.
 	// This operation does not interact with the network.
 	List(*ListRequest, RoutingService_ListServer) error
+	// List peers known to this node's routing layer.
+	// Includes connected peers and peers from the DHT routing table,
+	// together with their advertised Directory API address and GossipSub status.
+	// This operation does not interact with the network.
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// Pull a record directly from a peer at the given multiaddr,
+	// bypassing DHT discovery.
+	// Fails if the remote peer does not have the record.
+	PullFromPeer(context.Context, *PullFromPeerRequest) (*v1.Record, error)
+	// Pull a record from a peer providing it on the network,
+	// discovered via DHT, and cache it in the local store.
+	// Fails if no reachable provider has the record.
+	PullFromNetwork(context.Context, *PullFromNetworkRequest) (*v1.Record, error)
+	// List records that this node has announced to the network as a provider.
+	// Records are tracked as they are published and republished,
+	// and removed once unpublished.
+	// This operation does not interact with the network.
+	ListProvided(context.Context, *ListProvidedRequest) (*ListProvidedResponse, error)
 }
 
 // UnimplementedRoutingServiceServer should be embedded to have
@@ -214,6 +295,18 @@ func (UnimplementedRoutingServiceServer) Search(*SearchRequest, RoutingService_S
 func (UnimplementedRoutingServiceServer) List(*ListRequest, RoutingService_ListServer) error {
 	return status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedRoutingServiceServer) ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeers not implemented")
+}
+func (UnimplementedRoutingServiceServer) PullFromPeer(context.Context, *PullFromPeerRequest) (*v1.Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullFromPeer not implemented")
+}
+func (UnimplementedRoutingServiceServer) PullFromNetwork(context.Context, *PullFromNetworkRequest) (*v1.Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullFromNetwork not implemented")
+}
+func (UnimplementedRoutingServiceServer) ListProvided(context.Context, *ListProvidedRequest) (*ListProvidedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProvided not implemented")
+}
 func (UnimplementedRoutingServiceServer) testEmbeddedByValue() {}
 
 // UnsafeRoutingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -312,6 +405,78 @@ func (x *routingServiceListServer) Send(m *ListResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RoutingService_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_ListPeers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_PullFromPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullFromPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).PullFromPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_PullFromPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).PullFromPeer(ctx, req.(*PullFromPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_PullFromNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullFromNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).PullFromNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_PullFromNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).PullFromNetwork(ctx, req.(*PullFromNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_ListProvided_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).ListProvided(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_ListProvided_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).ListProvided(ctx, req.(*ListProvidedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoutingService_ServiceDesc is the grpc.ServiceDesc for RoutingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -327,6 +492,22 @@ var RoutingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Unpublish",
 			Handler:    _RoutingService_Unpublish_Handler,
 		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _RoutingService_ListPeers_Handler,
+		},
+		{
+			MethodName: "PullFromPeer",
+			Handler:    _RoutingService_PullFromPeer_Handler,
+		},
+		{
+			MethodName: "PullFromNetwork",
+			Handler:    _RoutingService_PullFromNetwork_Handler,
+		},
+		{
+			MethodName: "ListProvided",
+			Handler:    _RoutingService_ListProvided_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,560 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// QueryExpressionOp is the operator of a query expression node.
+type QueryExpressionOp int
+
+const (
+	// QueryExpressionMatch matches records by a single record query.
+	QueryExpressionMatch QueryExpressionOp = iota
+	// QueryExpressionAnd matches records matched by all operands.
+	QueryExpressionAnd
+	// QueryExpressionOr matches records matched by any operand.
+	QueryExpressionOr
+	// QueryExpressionNot matches records not matched by its single operand.
+	QueryExpressionNot
+)
+
+// QueryExpression is a boolean combination of record queries, parsed from
+// an expression such as "skill-name:nlp* AND version:v3.* AND NOT name:*test*".
+type QueryExpression struct {
+	Op QueryExpressionOp
+	// Query is the record query of a QueryExpressionMatch node.
+	Query *RecordQuery
+	// Operands are the operands of AND and OR nodes, or the single operand of a NOT node.
+	Operands []*QueryExpression
+}
+
+const (
+	// MaxQueryExpressionDepth is the maximum nesting depth of parentheses and NOT operators in a query expression.
+	MaxQueryExpressionDepth = 32
+	// MaxQueryExpressionTerms is the maximum number of field:value terms in a query expression.
+	MaxQueryExpressionTerms = 100
+)
+
+// QueryExpressionError reports a syntax error in a query expression.
+type QueryExpressionError struct {
+	// Input is the parsed expression.
+	Input string
+	// Pos is the byte offset of the offending token, or the input length at the end of the input.
+	Pos int
+	// Token is the offending token, empty at the end of the input.
+	Token string
+	// Message describes the error.
+	Message string
+}
+
+func (e *QueryExpressionError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("invalid query expression: %s at end of input", e.Message)
+	}
+
+	return fmt.Sprintf("invalid query expression: %s at position %d near %q", e.Message, e.Pos+1, e.Token)
+}
+
+// queryExpressionFields maps the field names accepted in query expressions to query types.
+// Fuzzy name queries rank records by distance and cannot be combined with boolean operators.
+var queryExpressionFields = map[string]RecordQueryType{
+	"name":           RecordQueryType_RECORD_QUERY_TYPE_NAME,
+	"version":        RecordQueryType_RECORD_QUERY_TYPE_VERSION,
+	"skill-id":       RecordQueryType_RECORD_QUERY_TYPE_SKILL_ID,
+	"skill-name":     RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME,
+	"skill":          RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME,
+	"locator":        RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
+	"locator-digest": RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST,
+	"module":         RecordQueryType_RECORD_QUERY_TYPE_MODULE,
+	"module-data":    RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA,
+}
+
+// queryExpressionFieldNames maps query types to their canonical field names in query expressions.
+var queryExpressionFieldNames = map[RecordQueryType]string{
+	RecordQueryType_RECORD_QUERY_TYPE_NAME:           "name",
+	RecordQueryType_RECORD_QUERY_TYPE_VERSION:        "version",
+	RecordQueryType_RECORD_QUERY_TYPE_SKILL_ID:       "skill-id",
+	RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME:     "skill-name",
+	RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:        "locator",
+	RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST: "locator-digest",
+	RecordQueryType_RECORD_QUERY_TYPE_MODULE:         "module",
+	RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA:    "module-data",
+}
+
+// QueryExpressionFields returns the field names accepted in query expressions, sorted.
+func QueryExpressionFields() []string {
+	fields := make([]string, 0, len(queryExpressionFields))
+	for field := range queryExpressionFields {
+		fields = append(fields, field)
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
+
+// ParseQueryExpression parses a query expression.
+//
+// An expression combines "field:value" terms with the AND, OR and NOT operators
+// (case-insensitive) and parentheses. NOT binds tighter than AND, which binds tighter
+// than OR. Values support the same wildcards as record queries and are double-quoted
+// when they contain spaces or parentheses, e.g. name:"my agent". Operators between
+// terms are required, so "a:x b:y" is rejected rather than read as an implicit AND.
+// Expressions nesting deeper than MaxQueryExpressionDepth or with more than
+// MaxQueryExpressionTerms terms are rejected.
+func ParseQueryExpression(input string) (*QueryExpression, error) {
+	tokens, err := lexQueryExpression(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryExpressionParser{input: input, tokens: tokens}
+
+	if len(tokens) == 0 {
+		return nil, p.errorf("empty query expression")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok != nil {
+		if tok.kind == tokenRParen {
+			return nil, p.errorf("unexpected ')'")
+		}
+
+		return nil, p.errorf("expected AND or OR")
+	}
+
+	return expr, nil
+}
+
+// Queries returns the record queries of the expression in order of appearance.
+func (e *QueryExpression) Queries() []*RecordQuery {
+	if e == nil {
+		return nil
+	}
+
+	if e.Op == QueryExpressionMatch {
+		return []*RecordQuery{e.Query}
+	}
+
+	var queries []*RecordQuery
+	for _, operand := range e.Operands {
+		queries = append(queries, operand.Queries()...)
+	}
+
+	return queries
+}
+
+// String returns the expression in canonical form.
+// Parsing the result yields an equivalent expression.
+func (e *QueryExpression) String() string {
+	if e == nil {
+		return ""
+	}
+
+	switch e.Op {
+	case QueryExpressionMatch:
+		return queryExpressionFieldNames[e.Query.GetType()] + ":" + quoteQueryValue(e.Query.GetValue())
+
+	case QueryExpressionNot:
+		return "NOT " + e.Operands[0].operandString(QueryExpressionNot)
+
+	case QueryExpressionAnd, QueryExpressionOr:
+		keyword := " AND "
+		if e.Op == QueryExpressionOr {
+			keyword = " OR "
+		}
+
+		parts := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			parts = append(parts, operand.operandString(e.Op))
+		}
+
+		return strings.Join(parts, keyword)
+	}
+
+	return ""
+}
+
+// operandString formats the expression as an operand of the parent operator,
+// adding parentheses where precedence requires them.
+func (e *QueryExpression) operandString(parent QueryExpressionOp) string {
+	if e.Op == QueryExpressionMatch || e.Op == QueryExpressionNot || e.Op == parent {
+		return e.String()
+	}
+
+	if parent == QueryExpressionOr && e.Op == QueryExpressionAnd {
+		return e.String()
+	}
+
+	return "(" + e.String() + ")"
+}
+
+// quoteQueryValue quotes a value that cannot be written bare in a query expression.
+func quoteQueryValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, "()\"\\") && !strings.ContainsFunc(value, unicode.IsSpace) {
+		return value
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+type tokenKind int
+
+const (
+	tokenTerm tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type queryToken struct {
+	kind  tokenKind
+	pos   int
+	text  string
+	query *RecordQuery
+}
+
+// lexQueryExpression splits the input into tokens, validating terms.
+//
+//nolint:cyclop
+func lexQueryExpression(input string) ([]queryToken, error) {
+	var tokens []queryToken
+
+	pos, terms := 0, 0
+	for pos < len(input) {
+		c := input[pos]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+
+			continue
+
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, pos: pos, text: "("})
+			pos++
+
+			continue
+
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, pos: pos, text: ")"})
+			pos++
+
+			continue
+		}
+
+		start := pos
+		for pos < len(input) && !isQueryDelimiter(input[pos]) && input[pos] != ':' && input[pos] != '"' {
+			pos++
+		}
+
+		word := input[start:pos]
+
+		if pos < len(input) && input[pos] == ':' {
+			token, end, err := lexQueryTerm(input, start, word, pos+1)
+			if err != nil {
+				return nil, err
+			}
+
+			terms++
+			if terms > MaxQueryExpressionTerms {
+				return nil, &QueryExpressionError{
+					Input:   input,
+					Pos:     start,
+					Token:   token.text,
+					Message: fmt.Sprintf("too many terms, expected at most %d", MaxQueryExpressionTerms),
+				}
+			}
+
+			tokens = append(tokens, token)
+			pos = end
+
+			continue
+		}
+
+		if word == "" {
+			// A stray quote outside of a term
+			return nil, &QueryExpressionError{Input: input, Pos: start, Token: input[start : start+1], Message: "unexpected quote, expected a field:value term"}
+		}
+
+		switch strings.ToUpper(word) {
+		case "AND":
+			tokens = append(tokens, queryToken{kind: tokenAnd, pos: start, text: word})
+		case "OR":
+			tokens = append(tokens, queryToken{kind: tokenOr, pos: start, text: word})
+		case "NOT":
+			tokens = append(tokens, queryToken{kind: tokenNot, pos: start, text: word})
+		default:
+			return nil, &QueryExpressionError{Input: input, Pos: start, Token: word, Message: "expected a field:value term, AND, OR or NOT"}
+		}
+	}
+
+	return tokens, nil
+}
+
+// lexQueryTerm reads the value of a "field:value" term starting at valueStart
+// and returns the term token and the offset after the value.
+//
+//nolint:cyclop
+func lexQueryTerm(input string, start int, field string, valueStart int) (queryToken, int, error) {
+	if field == "" {
+		return queryToken{}, 0, &QueryExpressionError{Input: input, Pos: start, Token: ":", Message: "missing field name"}
+	}
+
+	queryType, ok := queryExpressionFields[strings.ToLower(field)]
+	if !ok {
+		return queryToken{}, 0, &QueryExpressionError{
+			Input:   input,
+			Pos:     start,
+			Token:   field,
+			Message: fmt.Sprintf("unknown field %q, expected one of: %s", field, strings.Join(QueryExpressionFields(), ", ")),
+		}
+	}
+
+	var (
+		value string
+		end   int
+	)
+
+	if valueStart < len(input) && input[valueStart] == '"' {
+		var builder strings.Builder
+
+		end = valueStart + 1
+		for {
+			if end >= len(input) {
+				return queryToken{}, 0, &QueryExpressionError{Input: input, Pos: valueStart, Token: input[valueStart:], Message: "unterminated quoted value"}
+			}
+
+			if input[end] == '\\' && end+1 < len(input) {
+				builder.WriteByte(input[end+1])
+				end += 2
+
+				continue
+			}
+
+			if input[end] == '"' {
+				end++
+
+				break
+			}
+
+			builder.WriteByte(input[end])
+			end++
+		}
+
+		value = builder.String()
+	} else {
+		end = valueStart
+		for end < len(input) && !isQueryDelimiter(input[end]) {
+			end++
+		}
+
+		value = input[valueStart:end]
+	}
+
+	text := input[start:end]
+
+	if strings.TrimSpace(value) == "" {
+		return queryToken{}, 0, &QueryExpressionError{Input: input, Pos: start, Token: text, Message: fmt.Sprintf("missing value for field %q", field)}
+	}
+
+	if err := validateQueryValue(queryType, value); err != nil {
+		return queryToken{}, 0, &QueryExpressionError{Input: input, Pos: valueStart, Token: input[valueStart:end], Message: err.Error()}
+	}
+
+	return queryToken{
+		kind:  tokenTerm,
+		pos:   start,
+		text:  text,
+		query: &RecordQuery{Type: queryType, Value: value},
+	}, end, nil
+}
+
+// validateQueryValue checks values with a structure of their own.
+func validateQueryValue(queryType RecordQueryType, value string) error {
+	switch queryType { //nolint:exhaustive
+	case RecordQueryType_RECORD_QUERY_TYPE_SKILL_ID:
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("skill ID %q is not a number", value)
+		}
+
+	case RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA:
+		path, _, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(path) == "" {
+			return fmt.Errorf("module data %q is not in the <path>=<value> format", value)
+		}
+	}
+
+	return nil
+}
+
+func isQueryDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')'
+}
+
+type queryExpressionParser struct {
+	input  string
+	tokens []queryToken
+	pos    int
+	depth  int
+}
+
+func (p *queryExpressionParser) peek() *queryToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+
+	return &p.tokens[p.pos]
+}
+
+// errorf reports an error at the current token, or at the end of the input.
+func (p *queryExpressionParser) errorf(format string, args ...any) error {
+	err := &QueryExpressionError{Input: p.input, Pos: len(p.input), Message: fmt.Sprintf(format, args...)}
+
+	if tok := p.peek(); tok != nil {
+		err.Pos = tok.pos
+		err.Token = tok.text
+	}
+
+	return err
+}
+
+// enter descends into a nested expression at the current token, bounding the nesting depth.
+func (p *queryExpressionParser) enter() error {
+	if p.depth >= MaxQueryExpressionDepth {
+		return p.errorf("expression nested too deeply, expected at most %d levels", MaxQueryExpressionDepth)
+	}
+
+	p.depth++
+
+	return nil
+}
+
+// leave returns from a nested expression.
+func (p *queryExpressionParser) leave() {
+	p.depth--
+}
+
+// parseOr parses "and (OR and)*".
+func (p *queryExpressionParser) parseOr() (*QueryExpression, error) {
+	return p.parseBinary(tokenOr, QueryExpressionOr, p.parseAnd)
+}
+
+// parseAnd parses "unary (AND unary)*".
+func (p *queryExpressionParser) parseAnd() (*QueryExpression, error) {
+	return p.parseBinary(tokenAnd, QueryExpressionAnd, p.parseUnary)
+}
+
+// parseBinary parses operands separated by the operator token, flattening nested
+// nodes of the same operator into a single node.
+func (p *queryExpressionParser) parseBinary(kind tokenKind, op QueryExpressionOp, operand func() (*QueryExpression, error)) (*QueryExpression, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*QueryExpression{first}
+
+	for tok := p.peek(); tok != nil && tok.kind == kind; tok = p.peek() {
+		p.pos++
+
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, next)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	flattened := make([]*QueryExpression, 0, len(operands))
+	for _, expr := range operands {
+		if expr.Op == op {
+			flattened = append(flattened, expr.Operands...)
+		} else {
+			flattened = append(flattened, expr)
+		}
+	}
+
+	return &QueryExpression{Op: op, Operands: flattened}, nil
+}
+
+// parseUnary parses "NOT unary | primary".
+func (p *queryExpressionParser) parseUnary() (*QueryExpression, error) {
+	if tok := p.peek(); tok != nil && tok.kind == tokenNot {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		p.pos++
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &QueryExpression{Op: QueryExpressionNot, Operands: []*QueryExpression{operand}}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// parsePrimary parses "'(' or ')' | term".
+func (p *queryExpressionParser) parsePrimary() (*QueryExpression, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, p.errorf("expected a field:value term or '('")
+	}
+
+	if tok.kind == tokenTerm {
+		p.pos++
+
+		return &QueryExpression{Op: QueryExpressionMatch, Query: tok.query}, nil
+	}
+
+	if tok.kind == tokenLParen {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		open := tok.pos
+		p.pos++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if next := p.peek(); next == nil || next.kind != tokenRParen {
+			if next != nil && next.kind == tokenTerm {
+				return nil, p.errorf("expected AND or OR")
+			}
+
+			return nil, p.errorf("missing ')' for '(' at position %d", open+1)
+		}
+
+		p.pos++
+
+		return expr, nil
+	}
+
+	return nil, p.errorf("expected a field:value term or '('")
+}
@@ -0,0 +1,170 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "single term",
+			input:    "skill-name:nlp*",
+			expected: "skill-name:nlp*",
+		},
+		{
+			name:     "and with not",
+			input:    "skill-name:nlp* AND version:v3.* AND NOT name:*test*",
+			expected: "skill-name:nlp* AND version:v3.* AND NOT name:*test*",
+		},
+		{
+			name:     "and binds tighter than or",
+			input:    "name:a OR name:b AND version:v1",
+			expected: "name:a OR name:b AND version:v1",
+		},
+		{
+			name:     "parentheses",
+			input:    "(name:a OR name:b) AND version:v1",
+			expected: "(name:a OR name:b) AND version:v1",
+		},
+		{
+			name:     "not of group",
+			input:    "not (locator:docker-image OR module:runtime/*)",
+			expected: "NOT (locator:docker-image OR module:runtime/*)",
+		},
+		{
+			name:     "case-insensitive operators and alias",
+			input:    "skill:nlp and not skill-id:10201",
+			expected: "skill-name:nlp AND NOT skill-id:10201",
+		},
+		{
+			name:     "nested groups are flattened",
+			input:    "name:a AND (version:v1 AND (module:x))",
+			expected: "name:a AND version:v1 AND module:x",
+		},
+		{
+			name:     "quoted value",
+			input:    `name:"my (agent)" OR module-data:"framework.name=lang chain"`,
+			expected: `name:"my (agent)" OR module-data:"framework.name=lang chain"`,
+		},
+		{
+			name:     "value with colons",
+			input:    "locator:docker-image:https://ghcr.io/agntcy/agent AND locator-digest:sha256:abc",
+			expected: "locator:docker-image:https://ghcr.io/agntcy/agent AND locator-digest:sha256:abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseQueryExpression(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expr.String())
+
+			// The canonical form parses back to the same expression
+			reparsed, err := ParseQueryExpression(expr.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reparsed.String())
+		})
+	}
+}
+
+func TestParseQueryExpressionTree(t *testing.T) {
+	expr, err := ParseQueryExpression("skill-name:nlp* AND (version:v3.* OR NOT name:*test*)")
+	require.NoError(t, err)
+
+	require.Equal(t, QueryExpressionAnd, expr.Op)
+	require.Len(t, expr.Operands, 2)
+	assert.Equal(t, RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME, expr.Operands[0].Query.GetType())
+	assert.Equal(t, "nlp*", expr.Operands[0].Query.GetValue())
+
+	or := expr.Operands[1]
+	require.Equal(t, QueryExpressionOr, or.Op)
+	require.Len(t, or.Operands, 2)
+	assert.Equal(t, QueryExpressionNot, or.Operands[1].Op)
+
+	queries := expr.Queries()
+	require.Len(t, queries, 3)
+	assert.Equal(t, RecordQueryType_RECORD_QUERY_TYPE_NAME, queries[2].GetType())
+	assert.Equal(t, "*test*", queries[2].GetValue())
+}
+
+func TestParseQueryExpressionLimits(t *testing.T) {
+	nested := strings.Repeat("(", MaxQueryExpressionDepth) + "name:a" + strings.Repeat(")", MaxQueryExpressionDepth)
+	_, err := ParseQueryExpression(nested)
+	require.NoError(t, err)
+
+	terms := strings.Repeat("name:a OR ", MaxQueryExpressionTerms-1) + "name:b"
+	expr, err := ParseQueryExpression(terms)
+	require.NoError(t, err)
+	assert.Len(t, expr.Queries(), MaxQueryExpressionTerms)
+}
+
+func TestParseQueryExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		pos     int
+		token   string
+		message string
+	}{
+		{name: "empty", input: "  ", pos: 2, message: "empty query expression"},
+		{name: "unknown field", input: "name:a AND skil:nlp", pos: 11, token: "skil", message: `unknown field "skil"`},
+		{name: "bare word", input: "name:a AND nlp", pos: 11, token: "nlp", message: "expected a field:value term, AND, OR or NOT"},
+		{name: "missing operator", input: "name:a version:v1", pos: 7, token: "version:v1", message: "expected AND or OR"},
+		{name: "dangling operator", input: "name:a AND", pos: 10, message: "expected a field:value term or '('"},
+		{name: "double operator", input: "name:a AND OR name:b", pos: 11, token: "OR", message: "expected a field:value term or '('"},
+		{name: "missing value", input: "name: AND version:v1", pos: 0, token: "name:", message: `missing value for field "name"`},
+		{name: "missing field", input: ":nlp", pos: 0, token: ":", message: "missing field name"},
+		{name: "unclosed parenthesis", input: "(name:a OR name:b", pos: 17, message: "missing ')' for '(' at position 1"},
+		{name: "unexpected parenthesis", input: "name:a)", pos: 6, token: ")", message: "unexpected ')'"},
+		{name: "unterminated quote", input: `name:"my agent`, pos: 5, token: `"my agent`, message: "unterminated quoted value"},
+		{name: "invalid skill id", input: "skill-id:abc", pos: 9, token: "abc", message: `skill ID "abc" is not a number`},
+		{name: "invalid module data", input: "module-data:framework", pos: 12, token: "framework", message: "<path>=<value>"},
+		{name: "fuzzy name", input: "name-fuzzy:agnt", pos: 0, token: "name-fuzzy", message: `unknown field "name-fuzzy"`},
+		{
+			name:    "nested too deeply",
+			input:   strings.Repeat("(", MaxQueryExpressionDepth+1) + "name:a" + strings.Repeat(")", MaxQueryExpressionDepth+1),
+			pos:     MaxQueryExpressionDepth,
+			token:   "(",
+			message: "expression nested too deeply",
+		},
+		{
+			name:    "negated too deeply",
+			input:   strings.Repeat("NOT ", MaxQueryExpressionDepth+1) + "name:a",
+			pos:     MaxQueryExpressionDepth * len("NOT "),
+			token:   "NOT",
+			message: "expression nested too deeply",
+		},
+		{
+			name:    "too many terms",
+			input:   strings.Repeat("name:a OR ", MaxQueryExpressionTerms) + "name:b",
+			pos:     MaxQueryExpressionTerms * len("name:a OR "),
+			token:   "name:b",
+			message: "too many terms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQueryExpression(tt.input)
+			require.Error(t, err)
+
+			var exprErr *QueryExpressionError
+			require.True(t, errors.As(err, &exprErr))
+			assert.Equal(t, tt.pos, exprErr.Pos)
+			assert.Equal(t, tt.token, exprErr.Token)
+			assert.Contains(t, exprErr.Message, tt.message)
+		})
+	}
+}
@@ -48,6 +48,17 @@ const (
 	// Query for a module.
 	// Supports wildcard patterns: "*-plugin", "*-module", "core*", "mod-?", "plugin-[0-9]"
 	RecordQueryType_RECORD_QUERY_TYPE_MODULE RecordQueryType = 6
+	// Query for a value in module data, in the "<path>=<value>" format.
+	// The path addresses a nested field of the module data, e.g. "framework.version".
+	// The value supports wildcard patterns: "framework.version=1.*"
+	RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA RecordQueryType = 7
+	// Query for a record name with typo tolerance, in the "<term>[~<max-distance>]" format.
+	// Matches names within the given edit distance of the term (default 2, at most 3).
+	// No wildcard support. Results are ordered by ascending distance.
+	RecordQueryType_RECORD_QUERY_TYPE_NAME_FUZZY RecordQueryType = 8
+	// Query for the digest of a locator artifact, e.g. "sha256:...".
+	// Exact match only, no wildcard support.
+	RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST RecordQueryType = 9
 )
 
 // Enum value maps for RecordQueryType.
@@ -60,15 +71,21 @@ var (
 		4: "RECORD_QUERY_TYPE_SKILL_NAME",
 		5: "RECORD_QUERY_TYPE_LOCATOR",
 		6: "RECORD_QUERY_TYPE_MODULE",
+		7: "RECORD_QUERY_TYPE_MODULE_DATA",
+		8: "RECORD_QUERY_TYPE_NAME_FUZZY",
+		9: "RECORD_QUERY_TYPE_LOCATOR_DIGEST",
 	}
 	RecordQueryType_value = map[string]int32{
-		"RECORD_QUERY_TYPE_UNSPECIFIED": 0,
-		"RECORD_QUERY_TYPE_NAME":        1,
-		"RECORD_QUERY_TYPE_VERSION":     2,
-		"RECORD_QUERY_TYPE_SKILL_ID":    3,
-		"RECORD_QUERY_TYPE_SKILL_NAME":  4,
-		"RECORD_QUERY_TYPE_LOCATOR":     5,
-		"RECORD_QUERY_TYPE_MODULE":      6,
+		"RECORD_QUERY_TYPE_UNSPECIFIED":    0,
+		"RECORD_QUERY_TYPE_NAME":           1,
+		"RECORD_QUERY_TYPE_VERSION":        2,
+		"RECORD_QUERY_TYPE_SKILL_ID":       3,
+		"RECORD_QUERY_TYPE_SKILL_NAME":     4,
+		"RECORD_QUERY_TYPE_LOCATOR":        5,
+		"RECORD_QUERY_TYPE_MODULE":         6,
+		"RECORD_QUERY_TYPE_MODULE_DATA":    7,
+		"RECORD_QUERY_TYPE_NAME_FUZZY":     8,
+		"RECORD_QUERY_TYPE_LOCATOR_DIGEST": 9,
 	}
 )
 
@@ -180,7 +197,7 @@ var file_agntcy_dir_search_v1_record_query_proto_rawDesc = string([]byte{
 	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
 	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a,
-	0xee, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
+	0xd9, 0x02, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
 	0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
 	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
 	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
@@ -195,19 +212,26 @@ var file_agntcy_dir_search_v1_record_query_proto_rawDesc = string([]byte{
 	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x4f, 0x52,
 	0x10, 0x05, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45,
 	0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x06,
-	0x42, 0xc4, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
-	0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
-	0x01, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67,
-	0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa, 0x02, 0x14, 0x41,
-	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72,
-	0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x20, 0x41, 0x67, 0x6e,
-	0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56,
-	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17,
-	0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x10, 0x07, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
+	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x5f, 0x46, 0x55,
+	0x5a, 0x5a, 0x59, 0x10, 0x08, 0x12, 0x24, 0x0a, 0x20, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
+	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54,
+	0x4f, 0x52, 0x5f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x10, 0x09, 0x42, 0xc4, 0x01, 0x0a, 0x18,
+	0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x23, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f,
+	0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2f, 0x76,
+	0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x53, 0xaa, 0x02, 0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x44, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x56, 0x31, 0xca, 0x02,
+	0x14, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x20, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44,
+	0x69, 0x72, 0x5c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17, 0x41, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x3a, 0x3a,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -22,7 +22,8 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	SearchService_Search_FullMethodName = "/agntcy.dir.search.v1.SearchService/Search"
+	SearchService_Search_FullMethodName     = "/agntcy.dir.search.v1.SearchService/Search"
+	SearchService_ListSkills_FullMethodName = "/agntcy.dir.search.v1.SearchService/ListSkills"
 )
 
 // SearchServiceClient is the client API for SearchService service.
@@ -32,6 +33,10 @@ type SearchServiceClient interface {
 	// List records that this peer is currently providing that match the given parameters.
 	// This operation does not interact with the network.
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (SearchService_SearchClient, error)
+	// ListSkills returns the distinct skills of the records indexed by this peer,
+	// with the number of records using each skill.
+	// This operation does not interact with the network.
+	ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (*ListSkillsResponse, error)
 }
 
 type searchServiceClient struct {
@@ -75,6 +80,16 @@ func (x *searchServiceSearchClient) Recv() (*SearchResponse, error) {
 	return m, nil
 }
 
+func (c *searchServiceClient) ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (*ListSkillsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSkillsResponse)
+	err := c.cc.Invoke(ctx, SearchService_ListSkills_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SearchServiceServer is the server API for SearchService service.
 // All implementations should embed UnimplementedSearchServiceServer
 // for forward compatibility.
@@ -82,6 +97,10 @@ type SearchServiceServer interface {
 	// List records that this peer is currently providing that match the given parameters.
 	// This operation does not interact with the network.
 	Search(*SearchRequest, SearchService_SearchServer) error
+	// ListSkills returns the distinct skills of the records indexed by this peer,
+	// with the number of records using each skill.
+	// This operation does not interact with the network.
+	ListSkills(context.Context, *ListSkillsRequest) (*ListSkillsResponse, error)
 }
 
 // UnimplementedSearchServiceServer should be embedded to have
@@ -94,6 +113,9 @@ type UnimplementedSearchServiceServer struct{}
 func (UnimplementedSearchServiceServer) Search(*SearchRequest, SearchService_SearchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedSearchServiceServer) ListSkills(context.Context, *ListSkillsRequest) (*ListSkillsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSkills not implemented")
+}
 func (UnimplementedSearchServiceServer) testEmbeddedByValue() {}
 
 // UnsafeSearchServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -135,13 +157,36 @@ func (x *searchServiceSearchServer) Send(m *SearchResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _SearchService_ListSkills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSkillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).ListSkills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_ListSkills_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).ListSkills(ctx, req.(*ListSkillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SearchService_ServiceDesc is the grpc.ServiceDesc for SearchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var SearchService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "agntcy.dir.search.v1.SearchService",
 	HandlerType: (*SearchServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSkills",
+			Handler:    _SearchService_ListSkills_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Search",
@@ -0,0 +1,9 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// NoCacheMetadataKey is the gRPC metadata key requesting that record reads bypass the server-side cache
+// of the store, with the value "true". Pull and Lookup then read from the backing store, e.g. to rule out
+// stale cache entries after an out-of-band registry change. The records read still refresh the cache.
+const NoCacheMetadataKey = "x-dir-no-cache"
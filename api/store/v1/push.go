@@ -0,0 +1,15 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// PushAnnotationMetadataKey is the gRPC metadata key of the annotations attached to records on push.
+// Each value holds one "key=value" annotation. The annotations are stored in the record manifest
+// and reported in the record metadata, but are not part of the record, so they do not affect its CID.
+const PushAnnotationMetadataKey = "x-dir-push-annotation"
+
+// PushTTLMetadataKey is the gRPC metadata key of the time to live of the records on push, as a Go duration
+// such as "1h". Records expire once their TTL has elapsed since they were first stored, and are then removed
+// by the server. Like push annotations, the expiry is not part of the record, so it does not affect its CID.
+// The expiry of stored records cannot be changed, so pushes of stored records with a TTL are rejected.
+const PushTTLMetadataKey = "x-dir-push-ttl"
@@ -24,12 +24,18 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	StoreService_Push_FullMethodName         = "/agntcy.dir.store.v1.StoreService/Push"
-	StoreService_Pull_FullMethodName         = "/agntcy.dir.store.v1.StoreService/Pull"
-	StoreService_Lookup_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Lookup"
-	StoreService_Delete_FullMethodName       = "/agntcy.dir.store.v1.StoreService/Delete"
-	StoreService_PushReferrer_FullMethodName = "/agntcy.dir.store.v1.StoreService/PushReferrer"
-	StoreService_PullReferrer_FullMethodName = "/agntcy.dir.store.v1.StoreService/PullReferrer"
+	StoreService_Push_FullMethodName             = "/agntcy.dir.store.v1.StoreService/Push"
+	StoreService_Pull_FullMethodName             = "/agntcy.dir.store.v1.StoreService/Pull"
+	StoreService_Lookup_FullMethodName           = "/agntcy.dir.store.v1.StoreService/Lookup"
+	StoreService_Delete_FullMethodName           = "/agntcy.dir.store.v1.StoreService/Delete"
+	StoreService_PushReferrer_FullMethodName     = "/agntcy.dir.store.v1.StoreService/PushReferrer"
+	StoreService_PullReferrer_FullMethodName     = "/agntcy.dir.store.v1.StoreService/PullReferrer"
+	StoreService_GarbageCollect_FullMethodName   = "/agntcy.dir.store.v1.StoreService/GarbageCollect"
+	StoreService_CheckConsistency_FullMethodName = "/agntcy.dir.store.v1.StoreService/CheckConsistency"
+	StoreService_GetStats_FullMethodName         = "/agntcy.dir.store.v1.StoreService/GetStats"
+	StoreService_PullStream_FullMethodName       = "/agntcy.dir.store.v1.StoreService/PullStream"
+	StoreService_Exists_FullMethodName           = "/agntcy.dir.store.v1.StoreService/Exists"
+	StoreService_VerifyRecords_FullMethodName    = "/agntcy.dir.store.v1.StoreService/VerifyRecords"
 )
 
 // StoreServiceClient is the client API for StoreService service.
@@ -64,6 +70,29 @@ type StoreServiceClient interface {
 	PushReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PushReferrerClient, error)
 	// PullReferrer performs read operation for record referrers.
 	PullReferrer(ctx context.Context, opts ...grpc.CallOption) (StoreService_PullReferrerClient, error)
+	// GarbageCollect deletes blobs that are no longer referenced by any
+	// record, referrer or index entry.
+	// Only supported by local storage backends.
+	GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
+	// CheckConsistency compares the records in the store with the records
+	// indexed in the search database and optionally repairs the differences.
+	CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*CheckConsistencyResponse, error)
+	// GetStats reports statistics and limits of the store, such as the
+	// maximum accepted record size and, on request, the storage usage.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// PullStream pulls a batch of records in a single request.
+	// The server fetches the records concurrently and streams each one back
+	// as soon as it is fetched, so responses may arrive in any order.
+	// Records that cannot be pulled are reported inline without ending the stream.
+	PullStream(ctx context.Context, in *PullStreamRequest, opts ...grpc.CallOption) (StoreService_PullStreamClient, error)
+	// Exists reports whether a record is in the store.
+	// Unlike Lookup, a missing record is not an error and no metadata is fetched.
+	Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
+	// VerifyRecords recomputes the CID of every stored record from its content
+	// and reports records whose content no longer matches their CID.
+	// Records are verified concurrently and streamed back as soon as each one
+	// is checked, so responses may arrive in any order.
+	VerifyRecords(ctx context.Context, in *VerifyRecordsRequest, opts ...grpc.CallOption) (StoreService_VerifyRecordsClient, error)
 }
 
 type storeServiceClient struct {
@@ -269,6 +298,112 @@ func (x *storeServicePullReferrerClient) Recv() (*PullReferrerResponse, error) {
 	return m, nil
 }
 
+func (c *storeServiceClient) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GarbageCollectResponse)
+	err := c.cc.Invoke(ctx, StoreService_GarbageCollect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*CheckConsistencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckConsistencyResponse)
+	err := c.cc.Invoke(ctx, StoreService_CheckConsistency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, StoreService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) PullStream(ctx context.Context, in *PullStreamRequest, opts ...grpc.CallOption) (StoreService_PullStreamClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[6], StoreService_PullStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServicePullStreamClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoreService_PullStreamClient interface {
+	Recv() (*PullStreamResponse, error)
+	grpc.ClientStream
+}
+
+type storeServicePullStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServicePullStreamClient) Recv() (*PullStreamResponse, error) {
+	m := new(PullStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeServiceClient) Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExistsResponse)
+	err := c.cc.Invoke(ctx, StoreService_Exists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) VerifyRecords(ctx context.Context, in *VerifyRecordsRequest, opts ...grpc.CallOption) (StoreService_VerifyRecordsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[7], StoreService_VerifyRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServiceVerifyRecordsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoreService_VerifyRecordsClient interface {
+	Recv() (*VerifyRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type storeServiceVerifyRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServiceVerifyRecordsClient) Recv() (*VerifyRecordsResponse, error) {
+	m := new(VerifyRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StoreServiceServer is the server API for StoreService service.
 // All implementations should embed UnimplementedStoreServiceServer
 // for forward compatibility.
@@ -301,6 +436,29 @@ type StoreServiceServer interface {
 	PushReferrer(StoreService_PushReferrerServer) error
 	// PullReferrer performs read operation for record referrers.
 	PullReferrer(StoreService_PullReferrerServer) error
+	// GarbageCollect deletes blobs that are no longer referenced by any
+	// record, referrer or index entry.
+	// Only supported by local storage backends.
+	GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error)
+	// CheckConsistency compares the records in the store with the records
+	// indexed in the search database and optionally repairs the differences.
+	CheckConsistency(context.Context, *CheckConsistencyRequest) (*CheckConsistencyResponse, error)
+	// GetStats reports statistics and limits of the store, such as the
+	// maximum accepted record size and, on request, the storage usage.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// PullStream pulls a batch of records in a single request.
+	// The server fetches the records concurrently and streams each one back
+	// as soon as it is fetched, so responses may arrive in any order.
+	// Records that cannot be pulled are reported inline without ending the stream.
+	PullStream(*PullStreamRequest, StoreService_PullStreamServer) error
+	// Exists reports whether a record is in the store.
+	// Unlike Lookup, a missing record is not an error and no metadata is fetched.
+	Exists(context.Context, *ExistsRequest) (*ExistsResponse, error)
+	// VerifyRecords recomputes the CID of every stored record from its content
+	// and reports records whose content no longer matches their CID.
+	// Records are verified concurrently and streamed back as soon as each one
+	// is checked, so responses may arrive in any order.
+	VerifyRecords(*VerifyRecordsRequest, StoreService_VerifyRecordsServer) error
 }
 
 // UnimplementedStoreServiceServer should be embedded to have
@@ -328,6 +486,24 @@ func (UnimplementedStoreServiceServer) PushReferrer(StoreService_PushReferrerSer
 func (UnimplementedStoreServiceServer) PullReferrer(StoreService_PullReferrerServer) error {
 	return status.Errorf(codes.Unimplemented, "method PullReferrer not implemented")
 }
+func (UnimplementedStoreServiceServer) GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GarbageCollect not implemented")
+}
+func (UnimplementedStoreServiceServer) CheckConsistency(context.Context, *CheckConsistencyRequest) (*CheckConsistencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConsistency not implemented")
+}
+func (UnimplementedStoreServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedStoreServiceServer) PullStream(*PullStreamRequest, StoreService_PullStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PullStream not implemented")
+}
+func (UnimplementedStoreServiceServer) Exists(context.Context, *ExistsRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exists not implemented")
+}
+func (UnimplementedStoreServiceServer) VerifyRecords(*VerifyRecordsRequest, StoreService_VerifyRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyRecords not implemented")
+}
 func (UnimplementedStoreServiceServer) testEmbeddedByValue() {}
 
 // UnsafeStoreServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -504,13 +680,144 @@ func (x *storeServicePullReferrerServer) Recv() (*PullReferrerRequest, error) {
 	return m, nil
 }
 
+func _StoreService_GarbageCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GarbageCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_GarbageCollect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GarbageCollect(ctx, req.(*GarbageCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_CheckConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConsistencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).CheckConsistency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_CheckConsistency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).CheckConsistency(ctx, req.(*CheckConsistencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_PullStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PullStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoreServiceServer).PullStream(m, &storeServicePullStreamServer{ServerStream: stream})
+}
+
+type StoreService_PullStreamServer interface {
+	Send(*PullStreamResponse) error
+	grpc.ServerStream
+}
+
+type storeServicePullStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServicePullStreamServer) Send(m *PullStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StoreService_Exists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Exists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_Exists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Exists(ctx, req.(*ExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_VerifyRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoreServiceServer).VerifyRecords(m, &storeServiceVerifyRecordsServer{ServerStream: stream})
+}
+
+type StoreService_VerifyRecordsServer interface {
+	Send(*VerifyRecordsResponse) error
+	grpc.ServerStream
+}
+
+type storeServiceVerifyRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServiceVerifyRecordsServer) Send(m *VerifyRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // StoreService_ServiceDesc is the grpc.ServiceDesc for StoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StoreService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "agntcy.dir.store.v1.StoreService",
 	HandlerType: (*StoreServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GarbageCollect",
+			Handler:    _StoreService_GarbageCollect_Handler,
+		},
+		{
+			MethodName: "CheckConsistency",
+			Handler:    _StoreService_CheckConsistency_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _StoreService_GetStats_Handler,
+		},
+		{
+			MethodName: "Exists",
+			Handler:    _StoreService_Exists_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Push",
@@ -547,6 +854,16 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "PullStream",
+			Handler:       _StoreService_PullStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "VerifyRecords",
+			Handler:       _StoreService_VerifyRecords_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "agntcy/dir/store/v1/store_service.proto",
 }
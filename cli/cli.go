@@ -10,6 +10,7 @@ import (
 	"syscall"
 
 	"github.com/agntcy/dir/cli/cmd"
+	"github.com/agntcy/dir/cli/util/exitcode"
 )
 
 func main() {
@@ -17,6 +18,6 @@ func main() {
 
 	if err := cmd.Run(ctx); err != nil {
 		cancel()
-		os.Exit(1)
+		os.Exit(exitcode.Code(err))
 	}
 }
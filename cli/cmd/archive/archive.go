@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive provides commands to export and import the contents of a
+// Directory node as a tar archive.
+//
+// Archive layout:
+//
+//	records/<cid>.json                  canonical record data
+//	referrers/<cid>/<index>.json        referrers (signatures, public keys) of the record
+//
+// Records always precede their referrers so that an archive can be imported
+// in a single streaming pass.
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	recordsDir   = "records"
+	referrersDir = "referrers"
+	fileMode     = 0o644
+)
+
+func recordEntryName(cid string) string {
+	return path.Join(recordsDir, cid+".json")
+}
+
+func referrerEntryName(cid string, index int) string {
+	return path.Join(referrersDir, cid, fmt.Sprintf("%d.json", index))
+}
+
+// parseEntryName returns the kind of the entry ("records" or "referrers") and the record CID.
+func parseEntryName(name string) (string, string, error) {
+	parts := strings.Split(path.Clean(name), "/")
+
+	switch {
+	case len(parts) == 2 && parts[0] == recordsDir && strings.HasSuffix(parts[1], ".json"): //nolint:mnd
+		return recordsDir, strings.TrimSuffix(parts[1], ".json"), nil
+	case len(parts) == 3 && parts[0] == referrersDir: //nolint:mnd
+		return referrersDir, parts[1], nil
+	default:
+		return "", "", fmt.Errorf("unexpected archive entry: %s", name)
+	}
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    fileMode,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write header for %s: %w", name, err)
+	}
+
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
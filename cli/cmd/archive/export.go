@@ -0,0 +1,158 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package archive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	Output string
+}
+
+// NewExportCommand creates the export command.
+func NewExportCommand() *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all records stored on the Directory node as a tar archive",
+		Long: `Export every record stored on the Directory node, together with its
+signatures and public keys, into a tar archive.
+
+The archive is independent of the storage backend and can be restored
+on any Directory node using "dirctl import".
+
+Usage examples:
+
+1. Export to a file:
+
+	dirctl export --output dir.tar
+
+2. Export to standard output:
+
+	dirctl export > dir.tar
+
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := cmd.OutOrStdout()
+
+			if opts.Output != "" {
+				file, err := os.Create(opts.Output)
+				if err != nil {
+					return fmt.Errorf("could not create file %s: %w", opts.Output, err)
+				}
+				defer file.Close()
+
+				out = file
+			}
+
+			return runExport(cmd, out)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Path of the tar archive to write (default: stdout)")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, out io.Writer) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	// Collect CIDs of all local records
+	ch, err := c.Search(cmd.Context(), &searchv1.SearchRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list records: %w", err)
+	}
+
+	var cids []string
+
+	for cid := range ch {
+		if cid != "" {
+			cids = append(cids, cid)
+		}
+	}
+
+	tw := tar.NewWriter(out)
+
+	progress := presenter.NewProgress(cmd, "Exporting", presenter.ProgressRecords, int64(len(cids)))
+	defer progress.Done()
+
+	for _, cid := range cids {
+		if err := exportRecord(cmd, c, tw, cid); err != nil {
+			return err
+		}
+
+		progress.Add(1)
+	}
+
+	progress.Done()
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	// Report to stderr so that the archive can be streamed to stdout
+	presenter.Errorf(cmd, "Exported %d records\n", len(cids))
+
+	return nil
+}
+
+// exportRecord writes a record and all of its referrers to the archive.
+func exportRecord(cmd *cobra.Command, c *client.Client, tw *tar.Writer, cid string) error {
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return fmt.Errorf("failed to pull record %s: %w", cid, err)
+	}
+
+	recordBytes, err := record.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal record %s: %w", cid, err)
+	}
+
+	if err := writeEntry(tw, recordEntryName(cid), recordBytes); err != nil {
+		return err
+	}
+
+	referrerCh, err := c.PullReferrer(cmd.Context(), &storev1.PullReferrerRequest{
+		RecordRef: &corev1.RecordRef{Cid: cid},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pull referrers for record %s: %w", cid, err)
+	}
+
+	index := 0
+
+	for response := range referrerCh {
+		referrerBytes, err := json.Marshal(response.GetReferrer())
+		if err != nil {
+			return fmt.Errorf("failed to marshal referrer for record %s: %w", cid, err)
+		}
+
+		if err := writeEntry(tw, referrerEntryName(cid, index), referrerBytes); err != nil {
+			return err
+		}
+
+		index++
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package archive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates the import command.
+func NewImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import",
+		Short: "Import records from a tar archive created by dirctl export",
+		Long: `Import records and their referrers from a tar archive created by "dirctl export".
+
+Each record is verified against the CID stored in the archive before it is pushed.
+Records that already exist on the Directory node are skipped.
+
+Usage examples:
+
+1. Import from a file:
+
+	dirctl import dir.tar
+
+2. Import from standard input:
+
+	cat dir.tar | dirctl import
+
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("only one archive path is allowed")
+			}
+
+			if len(args) == 0 {
+				return runImport(cmd, cmd.InOrStdin())
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("could not open file %s: %w", args[0], err)
+			}
+			defer file.Close()
+
+			return runImport(cmd, file)
+		},
+	}
+}
+
+type importStats struct {
+	imported  int
+	skipped   int
+	referrers int
+}
+
+func runImport(cmd *cobra.Command, in io.Reader) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	stats := &importStats{}
+
+	// Tracks records imported from this archive. Referrers of skipped records are not re-pushed.
+	imported := make(map[string]bool)
+
+	tr := tar.NewReader(in)
+
+	// The number of records is not known until the archive is read
+	progress := presenter.NewProgress(cmd, "Importing", presenter.ProgressRecords, 0)
+	defer progress.Done()
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		kind, cid, err := parseEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		switch kind {
+		case recordsDir:
+			pushed, err := importRecord(cmd, c, cid, data)
+			if err != nil {
+				return err
+			}
+
+			imported[cid] = pushed
+
+			if pushed {
+				stats.imported++
+			} else {
+				stats.skipped++
+			}
+
+			progress.Add(1)
+
+		case referrersDir:
+			if !imported[cid] {
+				continue
+			}
+
+			if err := importReferrer(cmd, c, cid, data); err != nil {
+				return err
+			}
+
+			stats.referrers++
+		}
+	}
+
+	progress.Done()
+
+	presenter.Printf(cmd, "Imported %d records (%d referrers), skipped %d existing records\n",
+		stats.imported, stats.referrers, stats.skipped)
+
+	return nil
+}
+
+// importRecord verifies the record against its archived CID and pushes it if it does not exist yet.
+// Returns true if the record was pushed.
+func importRecord(cmd *cobra.Command, c *client.Client, cid string, data []byte) (bool, error) {
+	record, err := corev1.UnmarshalRecord(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode record %s: %w", cid, err)
+	}
+
+	if !record.HasCid(cid) {
+		return false, fmt.Errorf("CID mismatch for archived record: expected %s, got %s", cid, record.GetCid())
+	}
+
+	exists, err := c.Exists(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return false, fmt.Errorf("failed to check record %s: %w", cid, err)
+	}
+
+	if exists {
+		return false, nil
+	}
+
+	ref, err := c.Push(cmd.Context(), record)
+	if err != nil {
+		return false, fmt.Errorf("failed to push record %s: %w", cid, err)
+	}
+
+	if ref.GetCid() != cid {
+		return false, fmt.Errorf("CID mismatch after push: expected %s, got %s", cid, ref.GetCid())
+	}
+
+	return true, nil
+}
+
+func importReferrer(cmd *cobra.Command, c *client.Client, cid string, data []byte) error {
+	referrer := &corev1.RecordReferrer{}
+	if err := json.Unmarshal(data, referrer); err != nil {
+		return fmt.Errorf("failed to decode referrer for record %s: %w", cid, err)
+	}
+
+	if err := c.PushReferrer(cmd.Context(), &storev1.PushReferrerRequest{
+		RecordRef: &corev1.RecordRef{Cid: cid},
+		Referrer:  referrer,
+	}); err != nil {
+		return fmt.Errorf("failed to push referrer for record %s: %w", cid, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/spf13/cobra"
+)
+
+const templateFileMode = 0o644
+
+// NewCommand creates the build command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "build --from <cid>",
+		Short: "Create a record template from an existing record",
+		Long: `This command pulls an existing record and emits a template for a new
+version of it, to be edited and pushed.
+
+The signature of the source record is removed and created_at is set to the
+current time. Unless --link-previous=false is given, previous_record_cid is
+set to the source record CID to preserve the history chain. This field is not
+available for OASF v0.3.1 records.
+
+Usage examples:
+
+1. Create a template from an existing record:
+
+	dirctl build --from <cid>
+
+2. Create a template for the next minor version and write it to a file:
+
+	dirctl build --from <cid> --bump minor -o agent.json
+
+3. Create a template without linking it to the source record:
+
+	dirctl build --from <cid> --link-previous=false
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return exitcode.Invalid(errors.New("build takes no arguments, use --from <cid>"))
+			}
+
+			if opts.From == "" {
+				return exitcode.Invalid(errors.New("--from is required"))
+			}
+
+			return runCommand(cmd, opts)
+		},
+	}
+
+	addFlags(cmd, opts)
+
+	return cmd
+}
+
+func runCommand(cmd *cobra.Command, opts *options) error {
+	// Get the client from the context.
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	// Expand an abbreviated cid, so that the template links the full cid
+	cid, err := c.ResolveCID(cmd.Context(), opts.From)
+	if err != nil {
+		return fmt.Errorf("failed to resolve cid: %w", err)
+	}
+
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return fmt.Errorf("failed to pull record %s: %w", cid, err)
+	}
+
+	template, warnings, err := newTemplate(record, cid, templateOptions{
+		Bump:         opts.Bump,
+		LinkPrevious: opts.LinkPrevious,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		presenter.Infof(cmd, "Warning: %s\n", warning)
+	}
+
+	output, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %w", err)
+	}
+
+	if opts.Output == "" {
+		presenter.Println(cmd, string(output))
+
+		return nil
+	}
+
+	if err := os.WriteFile(opts.Output, append(output, '\n'), templateFileMode); err != nil {
+		return fmt.Errorf("failed to write template to %s: %w", opts.Output, err)
+	}
+
+	if !presenter.GetOutputOptions(cmd).Quiet {
+		presenter.Printf(cmd, "Template written to %s\n", opts.Output)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import "github.com/spf13/cobra"
+
+type options struct {
+	From         string
+	Bump         string
+	LinkPrevious bool
+	Output       string
+}
+
+// addFlags adds the command flags bound to the options.
+func addFlags(cmd *cobra.Command, opts *options) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.From, "from", "", "CID of the existing record to use as template (required)")
+	flags.StringVar(&opts.Bump, "bump", "", "Bump the version of the template: major, minor or patch")
+	flags.BoolVar(&opts.LinkPrevious, "link-previous", true, "Set previous_record_cid of the template to the source record CID")
+	flags.StringVarP(&opts.Output, "output", "o", "", "Path of the template file to write (default: stdout)")
+}
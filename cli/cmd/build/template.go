@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/util/exitcode"
+)
+
+// Version parts that can be bumped.
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
+
+// templateOptions controls how a template is derived from a record.
+type templateOptions struct {
+	// Bump is the version part to increment, if any.
+	Bump string
+
+	// LinkPrevious sets previous_record_cid to the source record CID.
+	LinkPrevious bool
+
+	// CreatedAt is the creation time of the new record.
+	CreatedAt time.Time
+}
+
+// newTemplate derives the template of a new record version from an existing record.
+// It returns the template along with warnings about options that could not be applied.
+func newTemplate(record *corev1.Record, sourceCID string, opts templateOptions) (map[string]any, []string, error) {
+	data, err := record.Marshal()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	template := make(map[string]any)
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode record: %w", err)
+	}
+
+	var warnings []string
+
+	// The signature covers the source record only
+	delete(template, "signature")
+
+	template["created_at"] = opts.CreatedAt.Format(time.RFC3339)
+
+	if opts.Bump != "" {
+		version, _ := template["version"].(string)
+
+		bumped, err := bumpVersion(version, opts.Bump)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		template["version"] = bumped
+	}
+
+	if opts.LinkPrevious {
+		decoded, err := record.Decode()
+		if err == nil && decoded.HasV1Alpha1() {
+			template["previous_record_cid"] = sourceCID
+		} else {
+			warnings = append(warnings, fmt.Sprintf("schema version %s does not support previous_record_cid, the template is not linked to %s",
+				record.GetSchemaVersion(), sourceCID))
+		}
+	}
+
+	return template, warnings, nil
+}
+
+// bumpVersion increments the given part of a semantic version, resetting the lower parts.
+// A "v" prefix is preserved, while pre-release and build metadata are dropped.
+func bumpVersion(version, part string) (string, error) {
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix = "v"
+	}
+
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, _, _ = strings.Cut(core, "-")
+
+	fields := strings.Split(core, ".")
+	if len(fields) != 3 { //nolint:mnd
+		return "", exitcode.Invalidf("cannot bump version %q: not a semantic version", version)
+	}
+
+	numbers := make([]int, len(fields))
+
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 0 {
+			return "", exitcode.Invalidf("cannot bump version %q: not a semantic version", version)
+		}
+
+		numbers[i] = number
+	}
+
+	switch part {
+	case BumpMajor:
+		numbers = []int{numbers[0] + 1, 0, 0}
+	case BumpMinor:
+		numbers = []int{numbers[0], numbers[1] + 1, 0}
+	case BumpPatch:
+		numbers = []int{numbers[0], numbers[1], numbers[2] + 1}
+	default:
+		return "", exitcode.Invalidf("unsupported version bump %q, expected one of: %s, %s, %s", part, BumpMajor, BumpMinor, BumpPatch)
+	}
+
+	return fmt.Sprintf("%s%d.%d.%d", prefix, numbers[0], numbers[1], numbers[2]), nil
+}
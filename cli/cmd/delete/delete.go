@@ -14,28 +14,33 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func init() {
-	// Add output format flags
-	presenter.AddOutputFlags(Command)
-}
-
-var Command = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete record from Directory store",
-	Long: `This command deletes a record from the Directory store.
+// NewCommand creates the delete command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete record from Directory store",
+		Long: `This command deletes a record from the Directory store.
 
 Usage example:
 
 	dirctl delete <cid>
 
+An unambiguous cid prefix may be given instead of the full cid.
+
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return errors.New("cid is a required argument")
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("cid is a required argument")
+			}
+
+			return runCommand(cmd, args[0])
+		},
+	}
 
-		return runCommand(cmd, args[0])
-	},
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runCommand(cmd *cobra.Command, cid string) error {
@@ -45,8 +50,14 @@ func runCommand(cmd *cobra.Command, cid string) error {
 		return errors.New("failed to get client from context")
 	}
 
+	// Expand an abbreviated cid, so that the deleted record is reported by its full cid
+	cid, err := c.ResolveCID(cmd.Context(), cid)
+	if err != nil {
+		return err
+	}
+
 	// Delete object from store
-	err := c.Delete(cmd.Context(), &corev1.RecordRef{
+	err = c.Delete(cmd.Context(), &corev1.RecordRef{
 		Cid: cid,
 	})
 	if err != nil {
@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ChangeType describes how a field changed between two records.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "changed"
+)
+
+// Change is a single field-level difference between two records.
+type Change struct {
+	Path string     `json:"path"`
+	Type ChangeType `json:"type"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+// comparedFields are the top-level record fields included in the diff.
+var comparedFields = []string{"name", "version", "skills", "locators", "extensions", "modules", "annotations"}
+
+// Compare returns the changes between two normalized records.
+func Compare(oldRecord, newRecord map[string]any) []Change {
+	var changes []Change
+
+	for _, field := range comparedFields {
+		changes = compareValues(changes, field, oldRecord[field], newRecord[field])
+	}
+
+	return changes
+}
+
+func compareValues(changes []Change, path string, oldValue, newValue any) []Change {
+	switch {
+	case oldValue == nil && newValue == nil:
+		return changes
+	case oldValue == nil:
+		return append(changes, Change{Path: path, Type: ChangeAdded, New: newValue})
+	case newValue == nil:
+		return append(changes, Change{Path: path, Type: ChangeRemoved, Old: oldValue})
+	}
+
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+
+	if oldIsMap && newIsMap {
+		return compareMaps(changes, path, oldMap, newMap)
+	}
+
+	oldList, oldIsList := oldValue.([]any)
+	newList, newIsList := newValue.([]any)
+
+	if oldIsList && newIsList {
+		return compareLists(changes, path, oldList, newList)
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		changes = append(changes, Change{Path: path, Type: ChangeModified, Old: oldValue, New: newValue})
+	}
+
+	return changes
+}
+
+func compareMaps(changes []Change, path string, oldMap, newMap map[string]any) []Change {
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		changes = compareValues(changes, path+"."+key, oldMap[key], newMap[key])
+	}
+
+	return changes
+}
+
+// compareLists matches list entries by name if all entries have one, and by value otherwise.
+func compareLists(changes []Change, path string, oldList, newList []any) []Change {
+	oldNamed, oldOK := indexByName(oldList)
+	newNamed, newOK := indexByName(newList)
+
+	if oldOK && newOK {
+		return compareMaps(changes, path, oldNamed, newNamed)
+	}
+
+	oldKeys := indexByValue(oldList)
+	newKeys := indexByValue(newList)
+
+	for _, entry := range oldList {
+		if _, ok := newKeys[valueKey(entry)]; !ok {
+			changes = append(changes, Change{Path: path + "[]", Type: ChangeRemoved, Old: entry})
+		}
+	}
+
+	for _, entry := range newList {
+		if _, ok := oldKeys[valueKey(entry)]; !ok {
+			changes = append(changes, Change{Path: path + "[]", Type: ChangeAdded, New: entry})
+		}
+	}
+
+	return changes
+}
+
+// indexByName indexes list entries by their name field.
+// It returns false if an entry has no name or names are not unique.
+func indexByName(list []any) (map[string]any, bool) {
+	named := make(map[string]any, len(list))
+
+	for _, entry := range list {
+		object, ok := entry.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		name, ok := object["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+
+		if _, exists := named[name]; exists {
+			return nil, false
+		}
+
+		named[name] = entry
+	}
+
+	return named, true
+}
+
+func indexByValue(list []any) map[string]struct{} {
+	keys := make(map[string]struct{}, len(list))
+	for _, entry := range list {
+		keys[valueKey(entry)] = struct{}{}
+	}
+
+	return keys
+}
+
+// valueKey returns the canonical JSON encoding of a value.
+func valueKey(value any) string {
+	output, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(output)
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the diff command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <cid1> <cid2>",
+		Short: "Compare two records",
+		Long: `Pull two records and show a field-level diff between them.
+
+The records are normalized using canonical JSON before being compared.
+The name, version, skills, locators, extensions, modules and annotations
+fields are compared. List entries with a name are matched by name, other
+list entries are matched by value.
+
+Usage examples:
+
+1. Compare two versions of a record:
+
+	dirctl diff <cid1> <cid2>
+
+2. Compare two records in JSON format:
+
+	dirctl diff <cid1> <cid2> --json
+
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 { //nolint:mnd
+				return errors.New("exactly two arguments are required which are the cids of the records to compare")
+			}
+
+			return runCommand(cmd, args[0], args[1])
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runCommand(cmd *cobra.Command, oldCID, newCID string) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	oldRecord, err := pullNormalized(cmd, c, oldCID)
+	if err != nil {
+		return err
+	}
+
+	newRecord, err := pullNormalized(cmd, c, newCID)
+	if err != nil {
+		return err
+	}
+
+	changes := Compare(oldRecord, newRecord)
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "changes", "Changes", changes) //nolint:wrapcheck
+	}
+
+	displayChanges(cmd, changes)
+
+	return nil
+}
+
+// pullNormalized pulls a record and returns its canonical JSON representation as a map.
+func pullNormalized(cmd *cobra.Command, c *client.Client, cid string) (map[string]any, error) {
+	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{Cid: cid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull record %s: %w", cid, err)
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record %s: %w", cid, err)
+	}
+
+	normalized := make(map[string]any)
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to normalize record %s: %w", cid, err)
+	}
+
+	return normalized, nil
+}
+
+// displayChanges prints changes in a human-readable format.
+func displayChanges(cmd *cobra.Command, changes []Change) {
+	if len(changes) == 0 {
+		presenter.Printf(cmd, "Records are identical.\n")
+
+		return
+	}
+
+	for _, change := range changes {
+		switch change.Type {
+		case ChangeAdded:
+			presenter.Printf(cmd, "+ %s: %s\n", change.Path, formatValue(change.New))
+		case ChangeRemoved:
+			presenter.Printf(cmd, "- %s: %s\n", change.Path, formatValue(change.Old))
+		case ChangeModified:
+			presenter.Printf(cmd, "~ %s: %s -> %s\n", change.Path, formatValue(change.Old), formatValue(change.New))
+		}
+	}
+}
+
+// formatValue formats a JSON value on a single line.
+func formatValue(value any) string {
+	output, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(output)
+}
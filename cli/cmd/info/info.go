@@ -11,31 +11,63 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
-func init() {
-	// Add output format flags
-	presenter.AddOutputFlags(Command)
+type options struct {
+	Concurrency int
 }
 
-var Command = &cobra.Command{
-	Use:   "info",
-	Short: "Check info about an object in Directory store",
-	Long: `Lookup and get basic metadata about an object pushed to the Directory store.
+// NewCommand creates the info command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Check info about an object in Directory store",
+		Long: `Lookup and get basic metadata about an object pushed to the Directory store.
 
 Usage example:
 
 	dirctl info <cid>
 
+An unambiguous cid prefix may be given instead of the full cid.
+
+Several records can be looked up at once. Lookups run concurrently, with at most
+--concurrency lookups in flight (8 by default, or the batch_concurrency setting
+of the client config). Records that do not exist are reported on stderr and
+the command exits with the not-found exit code:
+
+	dirctl info <cid> <cid> <cid> --concurrency 4
+
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return errors.New("exactly one argument is required which is the cid of the object")
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return exitcode.Invalid(errors.New("at least one argument is required which is the cid of the object"))
+			}
+
+			if opts.Concurrency < 0 || opts.Concurrency > client.MaxBatchConcurrency {
+				return exitcode.Invalidf("--concurrency must be between 0 (default) and %d", client.MaxBatchConcurrency)
+			}
+
+			if len(args) > 1 {
+				return runBatchCommand(cmd, args, opts)
+			}
+
+			return runCommand(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 0,
+		fmt.Sprintf("Maximum number of concurrent lookups, at most %d (default %d, or batch_concurrency of the client config)",
+			client.MaxBatchConcurrency, client.DefaultBatchConcurrency))
 
-		return runCommand(cmd, args[0])
-	},
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runCommand(cmd *cobra.Command, cid string) error {
@@ -56,3 +88,43 @@ func runCommand(cmd *cobra.Command, cid string) error {
 	// Output in the appropriate format
 	return presenter.PrintMessage(cmd, "info", "Record information", info)
 }
+
+// runBatchCommand looks up several records concurrently and prints the metadata of those found.
+func runBatchCommand(cmd *cobra.Command, cids []string, opts *options) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	refs := make([]*corev1.RecordRef, len(cids))
+	for i, cid := range cids {
+		refs[i] = &corev1.RecordRef{Cid: cid}
+	}
+
+	metas, err := c.LookupMany(client.WithConcurrency(cmd.Context(), opts.Concurrency), refs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup records: %w", err)
+	}
+
+	found := make([]*corev1.RecordMeta, 0, len(metas))
+
+	for i, meta := range metas {
+		if meta == nil {
+			presenter.Infof(cmd, "Record %s not found\n", cids[i])
+
+			continue
+		}
+
+		found = append(found, meta)
+	}
+
+	if err := presenter.PrintMessage(cmd, "info", "Record information", found); err != nil {
+		return err
+	}
+
+	if missing := len(cids) - len(found); missing > 0 {
+		return fmt.Errorf("%d of %d records not found: %w", missing, len(cids), client.ErrNotFound)
+	}
+
+	return nil
+}
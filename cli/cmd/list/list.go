@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package list
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the list command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List records stored locally on the Directory node",
+		Long: `List the CIDs of records held by the Directory node.
+
+This operation only returns records indexed by the node you are connected to
+and does not interact with the network.
+
+Usage examples:
+
+1. List all records (first 100):
+
+	dirctl list
+
+2. List records with pagination:
+
+	dirctl list --limit 10 --offset 20
+
+3. List records filtered by name or skill:
+
+	dirctl list --name "web-*" --skill-name "audio"
+
+4. Output CIDs together with record metadata as JSON:
+
+	dirctl list --json
+
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCommand(cmd, opts)
+		},
+	}
+
+	addFlags(cmd, opts)
+
+	return cmd
+}
+
+func runCommand(cmd *cobra.Command, opts *options) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	ch, err := c.Search(cmd.Context(), &searchv1.SearchRequest{
+		Limit:   &opts.Limit,
+		Offset:  &opts.Offset,
+		Queries: buildQueriesFromFlags(opts),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list records: %w", err)
+	}
+
+	var cids []string
+
+	for recordCid := range ch {
+		if recordCid == "" {
+			continue
+		}
+
+		cids = append(cids, recordCid)
+	}
+
+	// JSON output includes record metadata for each CID
+	if presenter.GetOutputOptions(cmd).Format == presenter.FormatJSON {
+		return printWithMetadata(cmd, cids)
+	}
+
+	results := make([]interface{}, 0, len(cids))
+	for _, cid := range cids {
+		results = append(results, cid)
+	}
+
+	return presenter.PrintMessage(cmd, "records", "Record CIDs", results)
+}
+
+// printWithMetadata looks up metadata for the listed CIDs and prints them together.
+func printWithMetadata(cmd *cobra.Command, cids []string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	refs := make([]*corev1.RecordRef, 0, len(cids))
+	for _, cid := range cids {
+		refs = append(refs, &corev1.RecordRef{Cid: cid})
+	}
+
+	metas, err := c.LookupBatch(cmd.Context(), refs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup record metadata: %w", err)
+	}
+
+	results := make([]interface{}, 0, len(metas))
+	for _, meta := range metas {
+		results = append(results, meta)
+	}
+
+	return presenter.PrintMessage(cmd, "records", "Records", results)
+}
+
+// buildQueriesFromFlags builds API queries.
+func buildQueriesFromFlags(opts *options) []*searchv1.RecordQuery {
+	queries := make([]*searchv1.RecordQuery, 0, len(opts.Names)+len(opts.SkillNames))
+
+	for _, name := range opts.Names {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME,
+			Value: name,
+		})
+	}
+
+	for _, skillName := range opts.SkillNames {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME,
+			Value: skillName,
+		})
+	}
+
+	return queries
+}
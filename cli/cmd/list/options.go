@@ -0,0 +1,31 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	Limit  uint32
+	Offset uint32
+
+	Names      []string
+	SkillNames []string
+}
+
+// addFlags adds the command flags bound to the options.
+func addFlags(cmd *cobra.Command, opts *options) {
+	flags := cmd.Flags()
+
+	flags.Uint32Var(&opts.Limit, "limit", 100, "Maximum number of records to list (default: 100)") //nolint:mnd
+	flags.Uint32Var(&opts.Offset, "offset", 0, "Pagination offset (default: 0)")
+
+	flags.StringArrayVar(&opts.Names, "name", nil, "List records with specific name (e.g., --name 'my-agent' --name 'web-*')")
+	flags.StringArrayVar(&opts.SkillNames, "skill-name", nil, "List records with specific skill name (e.g., --skill-name 'audio')")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+}
@@ -16,10 +16,12 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
-var Command = &cobra.Command{
-	Use:   "info",
-	Short: "Generates the peer id from a private key, enabling connection to the DHT network",
-	Long: `This command requires a private key stored on the host filesystem. From this key
+// NewCommand creates the network info command.
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Generates the peer id from a private key, enabling connection to the DHT network",
+		Long: `This command requires a private key stored on the host filesystem. From this key
 a peer id will be generated that is needed for the host to connect to the network.
 
 Usage examples:
@@ -29,17 +31,18 @@ Usage examples:
 	dirctl network info <path_to_private_key>
 
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return errors.New("expected exactly one argument")
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("expected exactly one argument")
+			}
 
-		if args[0] == "" {
-			return errors.New("expected a non-empty argument")
-		}
+			if args[0] == "" {
+				return errors.New("expected a non-empty argument")
+			}
 
-		return runCommand(cmd, args[0])
-	},
+			return runCommand(cmd, args[0])
+		},
+	}
 }
 
 func runCommand(cmd *cobra.Command, path string) error {
@@ -18,10 +18,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "init",
-	Short: "Generates the peer id from a newly generated private key, enabling connection to the DHT network",
-	Long: `This command generates a peer id from a newly generated private key. From this key
+// NewCommand creates the network init command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generates the peer id from a newly generated private key, enabling connection to the DHT network",
+		Long: `This command generates a peer id from a newly generated private key. From this key
 a peer id will be generated that is needed for the host to connect to the network.
 
 Usage examples:
@@ -35,12 +39,18 @@ Usage examples:
 	dirctl network init --output /path/to/private/key.pem
 
 `,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runCommand(cmd)
-	},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCommand(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Output, "output", "o", "", "Path to the output file, where the generated private key will be stored.")
+
+	return cmd
 }
 
-func runCommand(cmd *cobra.Command) error {
+func runCommand(cmd *cobra.Command, opts *options) error {
 	publicKey, privateKey, err := GenerateED25519OpenSSLKey()
 	if err != nil {
 		return fmt.Errorf("failed to generate ED25519 key pair: %w", err)
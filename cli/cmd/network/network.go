@@ -6,18 +6,23 @@ package network
 import (
 	infoCmd "github.com/agntcy/dir/cli/cmd/network/info"
 	initCmd "github.com/agntcy/dir/cli/cmd/network/init"
+	peersCmd "github.com/agntcy/dir/cli/cmd/network/peers"
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "network",
-	Short: "CLI tool to interact with routing network",
-	Long:  `This command provides a set of subcommands to interact with the routing network.`,
-}
+// NewCommand creates the network command with its subcommands.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "CLI tool to interact with routing network",
+		Long:  `This command provides a set of subcommands to interact with the routing network.`,
+	}
 
-func init() {
-	Command.AddCommand(
-		infoCmd.Command,
-		initCmd.Command,
+	cmd.AddCommand(
+		infoCmd.NewCommand(),
+		initCmd.NewCommand(),
+		peersCmd.NewCommand(),
 	)
+
+	return cmd
 }
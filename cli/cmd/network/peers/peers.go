@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package peers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the network peers command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "peers",
+		Short: "List peers known to the routing layer",
+		Long: `List peers known to the routing layer of the connected Directory node.
+
+The output combines connected peers and peers from the DHT routing table.
+For each peer it shows the known multiaddrs, the Directory API address
+advertised via the /dir/ protocol, and whether the peer is part of the
+GossipSub labels topic.
+
+This is useful for debugging discovery problems where records are
+published but never found by other peers.
+
+Usage examples:
+
+1. List known peers:
+
+	dirctl network peers
+
+2. List known peers in JSON format:
+
+	dirctl network peers --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCommand(cmd)
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runCommand(cmd *cobra.Command) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.ListPeers(cmd.Context(), &routingv1.ListPeersRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list peers: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format == presenter.FormatJSON {
+		output, err := json.MarshalIndent(resp.GetPeers(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		presenter.Print(cmd, string(output)+"\n")
+
+		return nil
+	}
+
+	displayPeers(cmd, resp.GetPeers())
+
+	return nil
+}
+
+// displayPeers prints peers in a human-readable format.
+func displayPeers(cmd *cobra.Command, peers []*routingv1.PeerStatus) {
+	if len(peers) == 0 {
+		presenter.Printf(cmd, "No peers found.\n")
+
+		return
+	}
+
+	presenter.Printf(cmd, "Known peers: %d\n\n", len(peers))
+
+	for _, p := range peers {
+		presenter.Printf(cmd, "%s\n", p.GetPeer().GetId())
+		presenter.Printf(cmd, "  Connection:    %s\n", connectionName(p.GetPeer().GetConnection()))
+		presenter.Printf(cmd, "  Routing table: %t\n", p.GetInRoutingTable())
+		presenter.Printf(cmd, "  Gossip mesh:   %t\n", p.GetInGossipsubMesh())
+
+		if dirAddr := p.GetDirectoryAddress(); dirAddr != "" {
+			presenter.Printf(cmd, "  Directory API: %s\n", dirAddr)
+		} else {
+			presenter.Printf(cmd, "  Directory API: (not advertised)\n")
+		}
+
+		if rejected := p.GetRejectedAnnouncements(); rejected > 0 {
+			presenter.Printf(cmd, "  Rejected:      %d announcements\n", rejected)
+		}
+
+		presenter.Printf(cmd, "  Addresses:\n")
+
+		for _, addr := range p.GetPeer().GetAddrs() {
+			presenter.Printf(cmd, "    %s\n", addr)
+		}
+
+		presenter.Printf(cmd, "\n")
+	}
+}
+
+// connectionName returns a short lowercase name for the connection type.
+func connectionName(c routingv1.PeerConnectionType) string {
+	return strings.ToLower(strings.TrimPrefix(c.String(), "PEER_CONNECTION_TYPE_"))
+}
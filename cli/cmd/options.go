@@ -4,21 +4,18 @@
 package cmd
 
 import (
+	"github.com/agntcy/dir/cli/presenter"
 	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
 )
 
-var clientConfig = &client.DefaultConfig
-
-func init() {
-	// load config
-	if cfg, err := client.LoadConfig(); err == nil {
-		clientConfig = cfg
-	}
-
-	// set flags
-	flags := RootCmd.PersistentFlags()
+// addFlags adds the root command flags bound to the client config.
+func addFlags(cmd *cobra.Command, clientConfig *client.Config) {
+	flags := cmd.PersistentFlags()
 	flags.StringVar(&clientConfig.ServerAddress, "server-addr", clientConfig.ServerAddress, "Directory Server API address")
 	flags.StringVar(&clientConfig.SpiffeSocketPath, "spiffe-socket-path", clientConfig.SpiffeSocketPath, "")
 
-	RootCmd.MarkFlagRequired("server-addr") //nolint:errcheck
+	cmd.MarkFlagRequired("server-addr") //nolint:errcheck
+
+	presenter.AddQuietFlag(cmd)
 }
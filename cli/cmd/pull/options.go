@@ -3,20 +3,28 @@
 
 package pull
 
-import "github.com/agntcy/dir/cli/presenter"
-
-var opts = &options{}
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
 
 type options struct {
 	PublicKey bool
 	Signature bool
+	From      string
+	Network   bool
+	NoCache   bool
 }
 
-func init() {
-	flags := Command.Flags()
+// addFlags adds the command flags bound to the options.
+func addFlags(cmd *cobra.Command, opts *options) {
+	flags := cmd.Flags()
 	flags.BoolVar(&opts.PublicKey, "public-key", false, "Pull the public key for the record.")
 	flags.BoolVar(&opts.Signature, "signature", false, "Pull the signature for the record.")
+	flags.StringVar(&opts.From, "from", "", "Pull directly from the peer at the given multiaddr (must include /p2p/<peer-id>), bypassing DHT discovery.")
+	flags.BoolVar(&opts.Network, "network", false, "Fall back to providers on the network if the record is not stored locally.")
+	flags.BoolVar(&opts.NoCache, "no-cache", false, "Bypass the server-side store cache and read the record from the backing store.")
 
 	// Add output format flags
-	presenter.AddOutputFlags(Command)
+	presenter.AddOutputFlags(cmd)
 }
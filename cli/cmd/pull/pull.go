@@ -9,17 +9,24 @@ import (
 	"fmt"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "pull",
-	Short: "Pull record from Directory server",
-	Long: `This command pulls the record from Directory API. The data can be validated against its hash, as
+// NewCommand creates the pull command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Pull record from Directory server",
+		Long: `This command pulls the record from Directory API. The data can be validated against its hash, as
 the returned object is content-addressable.
 
 Usage examples:
@@ -35,28 +42,100 @@ Usage examples:
 3. Pull by cid and output signature
 
 	dirctl pull <cid> --signature
+
+4. Pull by cid directly from a known peer, bypassing DHT discovery
+
+	dirctl pull <cid> --from /ip4/1.2.3.4/tcp/8999/p2p/<peer-id>
+
+5. Pull by an unambiguous cid prefix of a locally stored record
+
+	dirctl pull bafybeigdyr
+
+6. Pull from a provider on the network if the record is not stored locally
+
+	dirctl pull <cid> --network
+
+7. Pull from the backing store, bypassing the server-side store cache
+
+	dirctl pull <cid> --no-cache
+
+With --network, records that are not stored locally are pulled from a peer
+providing them on the network, discovered via DHT, and cached in the local store.
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			return errors.New("cid is a required argument")
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return exitcode.Invalid(errors.New("cid is a required argument"))
+			}
+
+			return runCommand(cmd, args[0], opts)
+		},
+	}
+
+	addFlags(cmd, opts)
 
-		return runCommand(cmd, args[0])
-	},
+	return cmd
 }
 
 //nolint:cyclop,gocognit
-func runCommand(cmd *cobra.Command, cid string) error {
+func runCommand(cmd *cobra.Command, cid string, opts *options) error {
 	// Get the client from the context.
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
 
-	// Fetch record from store
-	record, err := c.Pull(cmd.Context(), &corev1.RecordRef{
+	// Fetch record directly from a remote peer
+	if opts.From != "" {
+		if opts.PublicKey || opts.Signature {
+			return exitcode.Invalid(errors.New("--from cannot be combined with --public-key or --signature"))
+		}
+
+		record, err := c.PullFromPeer(cmd.Context(), &routingv1.PullFromPeerRequest{
+			RecordRef: &corev1.RecordRef{
+				Cid: cid,
+			},
+			Multiaddr: opts.From,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to pull data from peer: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "record", "Record data", record.GetData())
+	}
+
+	// Expand an abbreviated cid, so that referrers are pulled for the same record
+	cid, err := c.ResolveCID(cmd.Context(), cid)
+	if err != nil {
+		return err
+	}
+
+	// Fetch record from store, falling back to a provider on the network if requested
+	pullCtx := cmd.Context()
+	if opts.NoCache {
+		pullCtx = client.WithNoCache(pullCtx)
+	}
+
+	progress := presenter.NewProgress(cmd, "Pulling record", presenter.ProgressBytes, 0)
+	pullCtx = client.WithProgress(pullCtx, func(p client.Progress) {
+		progress.Set(p.Bytes, p.TotalBytes)
+	})
+
+	record, err := c.Pull(pullCtx, &corev1.RecordRef{
 		Cid: cid,
 	})
+
+	progress.Done()
+
+	if errors.Is(err, client.ErrNotFound) && opts.Network {
+		presenter.Infof(cmd, "Record %s not found locally, pulling from the network\n", cid)
+
+		record, err = c.PullFromNetwork(cmd.Context(), &routingv1.PullFromNetworkRequest{
+			RecordRef: &corev1.RecordRef{
+				Cid: cid,
+			},
+		})
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to pull data: %w", err)
 	}
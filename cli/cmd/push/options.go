@@ -4,33 +4,58 @@
 package push
 
 import (
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	signcmd "github.com/agntcy/dir/cli/cmd/sign"
 	"github.com/agntcy/dir/cli/presenter"
 	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
 )
 
-var opts = &options{}
+// Record data formats.
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
 
 type options struct {
-	FromStdin bool
-	Sign      bool
+	FromStdin     bool
+	Format        string
+	SchemaVersion string
+	Sign          bool
+	Annotations   []string
+	TTL           time.Duration
 
 	// Signing options
 	client.SignOpts
 }
 
-func init() {
-	flags := Command.Flags()
+// addFlags adds the command flags bound to the options.
+func addFlags(cmd *cobra.Command, opts *options) {
+	flags := cmd.Flags()
 	flags.BoolVar(&opts.FromStdin, "stdin", false,
 		"Read compiled data from standard input. Useful for piping. Reads from file if empty. "+
 			"Ignored if file is provided as an argument.",
 	)
+	flags.StringVar(&opts.Format, "format", "",
+		"Format of the record data: json or yaml. Detected from the file extension if empty, defaults to json.",
+	)
+	flags.StringVar(&opts.SchemaVersion, "schema-version", corev1.LatestSchemaVersion,
+		"OASF schema version set on records without a schema_version field.",
+	)
 	flags.BoolVar(&opts.Sign, "sign", false,
 		"Sign the record with the specified signing options.",
 	)
+	flags.StringArrayVar(&opts.Annotations, "annotation", nil,
+		"Annotation as key=value to store alongside the record without changing its CID (can be repeated).",
+	)
+	flags.DurationVar(&opts.TTL, "ttl", 0,
+		"Time to live of the record, e.g. 1h. Once expired, the record is unpublished and deleted. Never expires if zero.",
+	)
 
-	signcmd.AddSigningFlags(flags)
+	signcmd.AddSigningFlags(flags, &opts.SignOpts)
 
 	// Add output format flags
-	presenter.AddOutputFlags(Command)
+	presenter.AddOutputFlags(cmd)
 }
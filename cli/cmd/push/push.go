@@ -5,22 +5,33 @@
 package push
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	signcmd "github.com/agntcy/dir/cli/cmd/sign"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
-var Command = &cobra.Command{
-	Use:   "push",
-	Short: "Push record to Directory server",
-	Long: `This command pushes the record to local storage layer via Directory API. The data is stored into
+// NewCommand creates the push command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Push record to Directory server",
+		Long: `This command pushes the record to local storage layer via Directory API. The data is stored into
 content-addressable object store.
 
 Usage examples:
@@ -37,37 +48,84 @@ Usage examples:
 
 	dirctl push model.json --sign
 
+4. From a YAML record file, detected by its .yaml or .yml extension:
+
+	dirctl push model.yaml
+
+5. YAML data from standard input:
+
+	cat model.yaml | dirctl push --stdin --format yaml
+
+6. Attach deployment annotations, reported by 'dirctl info' but not part of the record or its CID.
+   Pushing a record already stored with other annotations fails:
+
+	dirctl push model.json --annotation env=prod --annotation pushed-by=ci
+
+7. Push an ephemeral record that is deleted one hour after it is stored.
+   Pushing a record already stored with a TTL fails:
+
+	dirctl push model.json --ttl 1h
+
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		var path string
-		if len(args) > 1 {
-			return errors.New("only one file path is allowed")
-		} else if len(args) == 1 {
-			path = args[0]
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var path string
+			if len(args) > 1 {
+				return exitcode.Invalid(errors.New("only one file path is allowed"))
+			} else if len(args) == 1 {
+				path = args[0]
+			}
 
-		// get source
-		if path == "" && !opts.FromStdin {
-			return errors.New("if no path defined --stdin flag must be set")
-		}
+			// get source
+			if path == "" && !opts.FromStdin {
+				return exitcode.Invalid(errors.New("if no path defined --stdin flag must be set"))
+			}
 
-		// if path is empty, read from stdin
-		if path == "" {
-			return runCommand(cmd, cmd.InOrStdin())
-		}
+			format, err := recordFormat(path, opts.Format)
+			if err != nil {
+				return err
+			}
 
-		// otherwise, read from file
-		source, err := os.Open(path)
-		if err != nil {
-			return fmt.Errorf("could not open file %s: %w", path, err)
-		}
-		defer source.Close()
+			// if path is empty, read from stdin
+			if path == "" {
+				return runCommand(cmd, opts, cmd.InOrStdin(), format)
+			}
+
+			// otherwise, read from file
+			source, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("could not open file %s: %w", path, err)
+			}
+			defer source.Close()
+
+			return runCommand(cmd, opts, source, format)
+		},
+	}
+
+	addFlags(cmd, opts)
+
+	return cmd
+}
+
+// recordFormat returns the format of the record data.
+// Unless set with --format, it is detected from the file extension, defaulting to JSON.
+func recordFormat(path, formatFlag string) (string, error) {
+	switch format := strings.ToLower(formatFlag); format {
+	case formatJSON, formatYAML:
+		return format, nil
+	case "":
+	default:
+		return "", exitcode.Invalidf("unsupported format %q, expected %s or %s", formatFlag, formatJSON, formatYAML)
+	}
 
-		return runCommand(cmd, source)
-	},
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return formatYAML, nil
+	default:
+		return formatJSON, nil
+	}
 }
 
-func runCommand(cmd *cobra.Command, source io.Reader) error {
+func runCommand(cmd *cobra.Command, opts *options, source io.Reader, format string) error {
 	// Get the client from the context.
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
@@ -80,22 +138,62 @@ func runCommand(cmd *cobra.Command, source io.Reader) error {
 		return fmt.Errorf("failed to read source data: %w", err)
 	}
 
+	// YAML is converted to JSON, so that the record and its CID are the same as for equivalent JSON
+	if format == formatYAML {
+		sourceData, err = yaml.YAMLToJSON(sourceData)
+		if err != nil {
+			return exitcode.Invalidf("failed to convert YAML to JSON: %w", err)
+		}
+	}
+
+	sourceData, err = withSchemaVersion(cmd, sourceData, opts.SchemaVersion)
+	if err != nil {
+		return err
+	}
+
 	// Load OASF data into a Record
 	record, err := corev1.UnmarshalRecord(sourceData)
 	if err != nil {
-		return fmt.Errorf("failed to load OASF: %w", err)
+		return exitcode.Invalidf("failed to load OASF: %w", err)
+	}
+
+	annotations, err := parseAnnotations(opts.Annotations)
+	if err != nil {
+		return err
+	}
+
+	if opts.TTL < 0 {
+		return exitcode.Invalidf("invalid ttl %s, expected a positive duration", opts.TTL)
+	}
+
+	// Fail fast if the record exceeds the server's size limit
+	if err := checkRecordSize(cmd, c, record); err != nil {
+		return err
 	}
 
 	var recordRef *corev1.RecordRef
 
+	pushCtx := client.WithPushAnnotations(cmd.Context(), annotations)
+	if opts.TTL > 0 {
+		pushCtx = client.WithPushTTL(pushCtx, opts.TTL)
+	}
+
+	progress := presenter.NewProgress(cmd, "Pushing record", presenter.ProgressBytes, 0)
+	pushCtx = client.WithProgress(pushCtx, func(p client.Progress) {
+		progress.Set(p.Bytes, p.TotalBytes)
+	})
+
 	// Use the client's Push method to send the record
-	recordRef, err = c.Push(cmd.Context(), record)
+	recordRef, err = c.Push(pushCtx, record)
+
+	progress.Done()
+
 	if err != nil {
 		return fmt.Errorf("failed to push data: %w", err)
 	}
 
 	if opts.Sign {
-		err = signcmd.Sign(cmd.Context(), c, recordRef.GetCid())
+		err = signcmd.Sign(cmd.Context(), c, recordRef.GetCid(), opts.SignOpts)
 		if err != nil {
 			return fmt.Errorf("failed to sign record: %w", err)
 		}
@@ -104,3 +202,74 @@ func runCommand(cmd *cobra.Command, source io.Reader) error {
 	// Output in the appropriate format
 	return presenter.PrintMessage(cmd, "record", "Pushed record with CID", recordRef.GetCid())
 }
+
+// parseAnnotations parses the key=value annotations given with --annotation.
+func parseAnnotations(values []string) (map[string]string, error) {
+	annotations := make(map[string]string, len(values))
+
+	for _, value := range values {
+		key, val, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, exitcode.Invalidf("invalid annotation %q, expected key=value", value)
+		}
+
+		annotations[key] = val
+	}
+
+	return annotations, nil
+}
+
+// withSchemaVersion sets the schema version of records that do not declare one.
+// A warning is printed unless the version was chosen with --schema-version.
+func withSchemaVersion(cmd *cobra.Command, data []byte, schemaVersion string) ([]byte, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, exitcode.Invalidf("failed to parse record: %w", err)
+	}
+
+	if fields == nil {
+		return nil, exitcode.Invalid(errors.New("record must be a JSON object"))
+	}
+
+	if version, ok := fields["schema_version"].(string); ok && version != "" {
+		return data, nil
+	}
+
+	if !cmd.Flags().Changed("schema-version") {
+		presenter.Infof(cmd, "Warning: record has no schema_version, defaulting to %s\n", schemaVersion)
+	}
+
+	fields["schema_version"] = schemaVersion
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	return data, nil
+}
+
+// checkRecordSize checks the marshaled record size against the limit reported by the server.
+// The check is skipped if the server does not report its limits.
+func checkRecordSize(cmd *cobra.Command, c *client.Client, record *corev1.Record) error {
+	stats, err := c.GetStats(cmd.Context(), &storev1.GetStatsRequest{})
+	if err != nil {
+		return nil //nolint:nilerr // the server enforces the limit on push anyway
+	}
+
+	limit := stats.GetMaxRecordBytes()
+	if limit == 0 {
+		return nil
+	}
+
+	recordBytes, err := record.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	if uint64(len(recordBytes)) > limit {
+		return exitcode.Invalidf("record size %d bytes exceeds the maximum of %d bytes accepted by the server", len(recordBytes), limit)
+	}
+
+	return nil
+}
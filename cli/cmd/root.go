@@ -7,80 +7,156 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/agntcy/dir/cli/cmd/archive"
+	"github.com/agntcy/dir/cli/cmd/build"
 	"github.com/agntcy/dir/cli/cmd/delete"
+	"github.com/agntcy/dir/cli/cmd/diff"
 	hubCmd "github.com/agntcy/dir/cli/cmd/hub"
 	"github.com/agntcy/dir/cli/cmd/info"
+	"github.com/agntcy/dir/cli/cmd/list"
 	"github.com/agntcy/dir/cli/cmd/network"
 	"github.com/agntcy/dir/cli/cmd/pull"
 	"github.com/agntcy/dir/cli/cmd/push"
 	"github.com/agntcy/dir/cli/cmd/routing"
 	"github.com/agntcy/dir/cli/cmd/search"
 	"github.com/agntcy/dir/cli/cmd/sign"
+	"github.com/agntcy/dir/cli/cmd/skills"
+	"github.com/agntcy/dir/cli/cmd/store"
 	"github.com/agntcy/dir/cli/cmd/sync"
 	"github.com/agntcy/dir/cli/cmd/verify"
 	"github.com/agntcy/dir/cli/cmd/version"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
 	"github.com/agntcy/dir/client"
 	"github.com/agntcy/dir/hub"
 	"github.com/spf13/cobra"
 )
 
-var RootCmd = &cobra.Command{
-	Use:          "dirctl",
-	Short:        "CLI tool to interact with Directory",
-	Long:         ``,
-	SilenceUsage: true,
-	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
-		// Set client via context for all requests
-		// TODO: make client config configurable via CLI args
-		c, err := client.New(client.WithConfig(clientConfig))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
+// NewRootCommand creates the dirctl root command.
+// Each call returns an independent command tree with its own flags and options,
+// so that commands can be executed concurrently in-process.
+// A client set in the execution context with ctxUtils.SetClientForContext is used
+// instead of creating one from the client flags.
+func NewRootCommand() *cobra.Command {
+	// load config
+	clientConfig := client.DefaultConfig
+	if cfg, err := client.LoadConfig(); err == nil {
+		clientConfig = *cfg
+	}
 
-		ctx := ctxUtils.SetClientForContext(cmd.Context(), c)
-		cmd.SetContext(ctx)
+	cmd := &cobra.Command{
+		Use:          "dirctl",
+		Short:        "CLI tool to interact with Directory",
+		Long:         ``,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// Use the client injected by the caller
+			if _, ok := ctxUtils.GetClientFromContext(cmd.Context()); ok {
+				return nil
+			}
 
-		cobra.OnFinalize(func() {
-			if err := c.Close(); err != nil {
-				presenter.Printf(cmd, "failed to close client: %v\n", err)
+			// Set client via context for all requests
+			c, err := client.New(client.WithConfig(&clientConfig))
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
 			}
-		})
 
-		return nil
-	},
-}
+			ctx := ctxUtils.SetClientForContext(cmd.Context(), c)
+			cmd.SetContext(ctx)
 
-func init() {
-	network.Command.Hidden = true
+			return nil
+		},
+	}
+
+	addFlags(cmd, &clientConfig)
 
-	RootCmd.AddCommand(
+	networkCmd := network.NewCommand()
+	networkCmd.Hidden = true
+
+	cmd.AddCommand(
 		// local commands
-		version.Command,
+		version.NewCommand(),
 		// initialize.Command, // REMOVED: Initialize functionality
-		sign.Command,
-		verify.Command,
+		sign.NewCommand(),
+		verify.NewCommand(),
 		// storage commands
-		info.Command,
-		pull.Command,
-		push.Command,
-		delete.Command,
+		info.NewCommand(),
+		list.NewCommand(),
+		pull.NewCommand(),
+		push.NewCommand(),
+		delete.NewCommand(),
+		diff.NewCommand(),
+		build.NewCommand(),
+		archive.NewExportCommand(),
+		archive.NewImportCommand(),
+		store.NewCommand(),
 		// routing commands (all under routing subcommand)
-		routing.Command, // Contains: publish, unpublish, list, search
-		network.Command,
+		routing.NewCommand(), // Contains: publish, unpublish, list, search
+		networkCmd,
 		hubCmd.NewCommand(hub.NewHub()),
 		// search commands
-		search.Command, // General search (searchv1)
+		search.NewCommand(), // General search (searchv1)
+		skills.NewCommand(),
 		// sync commands
-		sync.Command,
+		sync.NewCommand(),
 	)
+
+	// Invalid flags and arguments exit with the validation exit code
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitcode.Invalid(err)
+	})
+	wrapArgsValidation(cmd)
+
+	return cmd
 }
 
-func Run(ctx context.Context) error {
-	if err := RootCmd.ExecuteContext(ctx); err != nil {
+// wrapArgsValidation marks the errors of the argument validators of the command tree as validation errors.
+func wrapArgsValidation(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return exitcode.Invalid(validate(cmd, args))
+		}
+	}
+
+	for _, subCmd := range cmd.Commands() {
+		wrapArgsValidation(subCmd)
+	}
+}
+
+// Execute executes a root command created with NewRootCommand.
+// The client created for the execution is closed once the command returns,
+// while a client injected through the context is left open for the caller.
+func Execute(ctx context.Context, root *cobra.Command) error {
+	cmd, err := root.ExecuteContextC(ctx)
+
+	if cmd != nil {
+		closeClient(ctx, cmd)
+	}
+
+	if err != nil {
 		return fmt.Errorf("failed to execute command: %w", err)
 	}
 
 	return nil
 }
+
+// closeClient closes the client created for the executed command.
+func closeClient(ctx context.Context, cmd *cobra.Command) {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return
+	}
+
+	if injected, ok := ctxUtils.GetClientFromContext(ctx); ok && injected == c {
+		return
+	}
+
+	if err := c.Close(); err != nil {
+		presenter.Printf(cmd, "failed to close client: %v\n", err)
+	}
+}
+
+func Run(ctx context.Context) error {
+	return Execute(ctx, NewRootCommand())
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var infoCmd = &cobra.Command{
-	Use:   "info",
-	Short: "Show routing statistics and summary information",
-	Long: `Show routing statistics and summary information for local records.
+// newInfoCommand creates the routing info command.
+func newInfoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show routing statistics and summary information",
+		Long: `Show routing statistics and summary information for local records.
 
 This command provides aggregated statistics about locally published records,
 including record counts and label distribution.
@@ -36,15 +38,16 @@ Usage examples:
 
 Note: For network-wide statistics, use 'dirctl routing search' with broad queries.
 `,
-	//nolint:gocritic // Lambda required due to signature mismatch - runInfoCommand doesn't use args
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runInfoCommand(cmd)
-	},
-}
+		//nolint:gocritic // Lambda required due to signature mismatch - runInfoCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runInfoCommand(cmd)
+		},
+	}
 
-func init() {
 	// Add output format flags
-	presenter.AddOutputFlags(infoCmd)
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runInfoCommand(cmd *cobra.Command) error {
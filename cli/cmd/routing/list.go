@@ -15,10 +15,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List local records with optional filtering",
-	Long: `List local records with optional filtering.
+// newListCommand creates the routing list command.
+func newListCommand() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List local records with optional filtering",
+		Long: `List local records with optional filtering.
 
 This command queries records that are stored locally on this peer only.
 It does NOT query the network or other peers.
@@ -45,14 +49,35 @@ Usage examples:
 
 Note: For network-wide discovery, use 'dirctl routing search' instead.
 `,
-	//nolint:gocritic // Lambda required due to signature mismatch - runListCommand doesn't use args
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runListCommand(cmd)
-	},
+		//nolint:gocritic // Lambda required due to signature mismatch - runListCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListCommand(cmd, opts)
+		},
+	}
+
+	// Add flags for list options
+	cmd.Flags().StringVar(&opts.Cid, "cid", "", "List specific record by CID")
+	cmd.Flags().StringArrayVar(&opts.Skills, "skill", nil, "Filter by skill (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Locators, "locator", nil, "Filter by locator type (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Domains, "domain", nil, "Filter by domain (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Modules, "module", nil, "Filter by module (can be repeated)")
+	cmd.Flags().Uint32Var(&opts.Limit, "limit", 0, "Maximum number of results (0 = no limit)")
+
+	// Add examples in flag help
+	cmd.Flags().Lookup("skill").Usage = "Filter by skill (e.g., --skill 'AI' --skill 'web-development')"
+	cmd.Flags().Lookup("locator").Usage = "Filter by locator type (e.g., --locator 'docker-image')"
+	cmd.Flags().Lookup("domain").Usage = "Filter by domain (e.g., --domain 'research' --domain 'analytics')"
+	cmd.Flags().Lookup("module").Usage = "Filter by module (e.g., --module 'runtime/language' --module 'runtime/framework')"
+	cmd.Flags().Lookup("cid").Usage = "List specific record by CID"
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
-// List command options.
-var listOpts struct {
+// listOptions are the list command options.
+type listOptions struct {
 	Cid      string
 	Skills   []string
 	Locators []string
@@ -61,27 +86,7 @@ var listOpts struct {
 	Limit    uint32
 }
 
-func init() {
-	// Add flags for list options
-	listCmd.Flags().StringVar(&listOpts.Cid, "cid", "", "List specific record by CID")
-	listCmd.Flags().StringArrayVar(&listOpts.Skills, "skill", nil, "Filter by skill (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listOpts.Locators, "locator", nil, "Filter by locator type (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listOpts.Domains, "domain", nil, "Filter by domain (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listOpts.Modules, "module", nil, "Filter by module (can be repeated)")
-	listCmd.Flags().Uint32Var(&listOpts.Limit, "limit", 0, "Maximum number of results (0 = no limit)")
-
-	// Add examples in flag help
-	listCmd.Flags().Lookup("skill").Usage = "Filter by skill (e.g., --skill 'AI' --skill 'web-development')"
-	listCmd.Flags().Lookup("locator").Usage = "Filter by locator type (e.g., --locator 'docker-image')"
-	listCmd.Flags().Lookup("domain").Usage = "Filter by domain (e.g., --domain 'research' --domain 'analytics')"
-	listCmd.Flags().Lookup("module").Usage = "Filter by module (e.g., --module 'runtime/language' --module 'runtime/framework')"
-	listCmd.Flags().Lookup("cid").Usage = "List specific record by CID"
-
-	// Add output format flags
-	presenter.AddOutputFlags(listCmd)
-}
-
-func runListCommand(cmd *cobra.Command) error {
+func runListCommand(cmd *cobra.Command, opts *listOptions) error {
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
@@ -89,15 +94,15 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Handle CID-specific listing
-	if listOpts.Cid != "" {
-		return listByCID(cmd, c, listOpts.Cid)
+	if opts.Cid != "" {
+		return listByCID(cmd, c, opts)
 	}
 
 	// Build queries from flags
-	queries := make([]*routingv1.RecordQuery, 0, len(listOpts.Skills)+len(listOpts.Locators)+len(listOpts.Domains)+len(listOpts.Modules))
+	queries := make([]*routingv1.RecordQuery, 0, len(opts.Skills)+len(opts.Locators)+len(opts.Domains)+len(opts.Modules))
 
 	// Add skill queries
-	for _, skill := range listOpts.Skills {
+	for _, skill := range opts.Skills {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
 			Value: skill,
@@ -105,7 +110,7 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Add locator queries
-	for _, locator := range listOpts.Locators {
+	for _, locator := range opts.Locators {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
 			Value: locator,
@@ -113,7 +118,7 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Add domain queries
-	for _, domain := range listOpts.Domains {
+	for _, domain := range opts.Domains {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
 			Value: domain,
@@ -121,7 +126,7 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Add module queries
-	for _, module := range listOpts.Modules {
+	for _, module := range opts.Modules {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
 			Value: module,
@@ -134,8 +139,8 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Add optional limit
-	if listOpts.Limit > 0 {
-		req.Limit = &listOpts.Limit
+	if opts.Limit > 0 {
+		req.Limit = &opts.Limit
 	}
 
 	// Execute list
@@ -145,7 +150,7 @@ func runListCommand(cmd *cobra.Command) error {
 	}
 
 	// Collect results and convert to interface{} slice in a single loop
-	results := make([]interface{}, 0, listOpts.Limit)
+	results := make([]interface{}, 0, opts.Limit)
 	for result := range resultCh {
 		results = append(results, result)
 	}
@@ -154,7 +159,7 @@ func runListCommand(cmd *cobra.Command) error {
 }
 
 // listByCID lists a specific record by CID.
-func listByCID(cmd *cobra.Command, c *client.Client, cid string) error {
+func listByCID(cmd *cobra.Command, c *client.Client, opts *listOptions) error {
 	// For CID-specific queries, we can use an empty query list
 	req := &routingv1.ListRequest{
 		Queries: []*routingv1.RecordQuery{}, // Empty = list all, then we filter by CID match
@@ -166,10 +171,10 @@ func listByCID(cmd *cobra.Command, c *client.Client, cid string) error {
 	}
 
 	// Collect results and convert to interface{} slice in a single loop
-	results := make([]interface{}, 0, listOpts.Limit)
+	results := make([]interface{}, 0, opts.Limit)
 
 	for result := range resultCh {
-		if result.GetRecordRef().GetCid() == cid {
+		if result.GetRecordRef().GetCid() == opts.Cid {
 			results = append(results, result)
 		}
 	}
@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// newProvidedCommand creates the routing provided command.
+func newProvidedCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provided",
+		Short: "List records announced to the DHT by this node",
+		Long: `List records that the connected Directory node announces to the DHT as a provider.
+
+Records are tracked as they are published and periodically republished,
+and removed once unpublished. For each record the time of the first and
+the most recent successful announcement is shown.
+
+Unlike 'dirctl routing list', which shows the local routing index, this
+reflects what the node has actually announced to the network. A published
+record missing here was not announced, for example because the node had
+no peers in its routing table at publish time.
+
+The list is kept in memory and starts empty after a server restart until
+records are republished.
+
+Usage examples:
+
+1. List provided records:
+   dirctl routing provided
+
+2. List provided records in JSON format:
+   dirctl routing provided --json
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runProvidedCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runProvidedCommand(cmd)
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runProvidedCommand(cmd *cobra.Command) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.ListProvided(cmd.Context(), &routingv1.ListProvidedRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list provided records: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format == presenter.FormatJSON {
+		output, err := json.MarshalIndent(resp.GetRecords(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+
+		presenter.Print(cmd, string(output)+"\n")
+
+		return nil
+	}
+
+	displayProvidedRecords(cmd, resp.GetRecords())
+
+	return nil
+}
+
+// displayProvidedRecords prints provided records in a human-readable format.
+func displayProvidedRecords(cmd *cobra.Command, records []*routingv1.ProvidedRecord) {
+	if len(records) == 0 {
+		presenter.Printf(cmd, "No provided records found.\n")
+		presenter.Printf(cmd, "Use 'dirctl routing publish' to announce records to the network.\n")
+
+		return
+	}
+
+	presenter.Printf(cmd, "Provided records: %d\n\n", len(records))
+
+	for _, record := range records {
+		presenter.Printf(cmd, "%s\n", record.GetRecordRef().GetCid())
+		presenter.Printf(cmd, "  First provided: %s\n", record.GetFirstProvidedAt())
+		presenter.Printf(cmd, "  Last provided:  %s\n", record.GetLastProvidedAt())
+	}
+}
@@ -16,10 +16,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var publishCmd = &cobra.Command{
-	Use:   "publish <cid>",
-	Short: "Publish record to the network for discovery",
-	Long: `Publish a record to the network to allow content discovery by other peers.
+// newPublishCommand creates the routing publish command.
+func newPublishCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish <cid>",
+		Short: "Publish record to the network for discovery",
+		Long: `Publish a record to the network to allow content discovery by other peers.
 
 This command announces a record that is already stored locally to the distributed
 network, making it discoverable by other peers through the DHT.
@@ -39,10 +41,16 @@ Usage examples:
 
 Note: The record must already be pushed to storage before publishing.
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPublishCommand(cmd, args[0])
-	},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPublishCommand(cmd, args[0])
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runPublishCommand(cmd *cobra.Command, cid string) error {
@@ -3,15 +3,14 @@
 
 package routing
 
-import (
-	"github.com/agntcy/dir/cli/presenter"
-	"github.com/spf13/cobra"
-)
+import "github.com/spf13/cobra"
 
-var Command = &cobra.Command{
-	Use:   "routing",
-	Short: "Routing operations for record discovery and announcement",
-	Long: `Routing operations for record discovery and announcement.
+// NewCommand creates the routing command group.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "routing",
+		Short: "Routing operations for record discovery and announcement",
+		Long: `Routing operations for record discovery and announcement.
 
 This command group provides access to all routing-specific operations:
 
@@ -20,6 +19,7 @@ This command group provides access to all routing-specific operations:
 - list: Query local records with filtering
 - search: Discover remote records from other peers
 - info: Show routing statistics and summary information
+- provided: List records announced to the DHT by this node
 
 Examples:
 
@@ -35,19 +35,22 @@ Examples:
 4. Unpublish a record from the network:
    dirctl routing unpublish <cid>
 
+5. List records this node announces to the DHT:
+   dirctl routing provided
+
 This follows clear service separation - all routing API operations are grouped together.
 `,
-}
+	}
 
-func init() {
 	// Add all routing subcommands
-	Command.AddCommand(publishCmd)
-	Command.AddCommand(unpublishCmd)
-	Command.AddCommand(listCmd)
-	Command.AddCommand(searchCmd)
-	Command.AddCommand(infoCmd)
-
-	// Add output format flags to routing subcommands
-	presenter.AddOutputFlags(publishCmd)
-	presenter.AddOutputFlags(unpublishCmd)
+	cmd.AddCommand(
+		newPublishCommand(),
+		newUnpublishCommand(),
+		newListCommand(),
+		newSearchCommand(),
+		newInfoCommand(),
+		newProvidedCommand(),
+	)
+
+	return cmd
 }
@@ -5,8 +5,10 @@
 package routing
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
@@ -14,10 +16,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var searchCmd = &cobra.Command{
-	Use:   "search",
-	Short: "Search for remote records from other peers",
-	Long: `Search for remote records from other peers using the routing API.
+// newSearchCommand creates the routing search command.
+func newSearchCommand() *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search for remote records from other peers",
+		Long: `Search for remote records from other peers using the routing API.
 
 This command discovers records that have been published by other peers in the network.
 It uses cached network announcements and filters out local records.
@@ -39,15 +45,53 @@ Usage examples:
 3. Search with result limiting:
    dirctl routing search --skill "web-development" --limit 5
 
+4. Exclude records that have not been seen for more than an hour:
+   dirctl routing search --skill "AI" --max-age 1h
+
+5. Also match records labeled with a parent skill (e.g. "AI" or "AI/ML"):
+   dirctl routing search --skill "AI/ML/deep-learning" --expand-parents
+
+6. Keep watching for new matching records as they are announced (Ctrl+C to stop):
+   dirctl routing search --skill "AI" --watch
+
+7. Return the best matches first, most recently seen first among equal scores:
+   dirctl routing search --skill "AI" --skill "ML" --limit 5 --rank
+
+8. Only return records announced by a specific peer:
+   dirctl routing search --skill "AI" --peer 12D3KooWExamplePeerID
+
 `,
-	//nolint:gocritic // Lambda required due to signature mismatch - runSearchCommand doesn't use args
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runSearchCommand(cmd)
-	},
+		//nolint:gocritic // Lambda required due to signature mismatch - runSearchCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSearchCommand(cmd, opts)
+		},
+	}
+
+	// Add flags for search options
+	cmd.Flags().StringArrayVar(&opts.Skills, "skill", nil, "Search for records with specific skill (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Domains, "domain", nil, "Search for records with specific domain (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
+	cmd.Flags().Uint32Var(&opts.Limit, "limit", defaultSearchLimit, "Maximum number of results to return")
+	cmd.Flags().Uint32Var(&opts.MinScore, "min-score", defaultMinScore, "Minimum match score (number of queries that must match)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output results in JSON format")
+	cmd.Flags().DurationVar(&opts.MaxAge, "max-age", 0, "Only return records seen within this duration (e.g., --max-age 1h)")
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "Keep running and print new matching records as they are announced on the network")
+	cmd.Flags().BoolVar(&opts.Rank, "rank", false, "Sort results by match score, then recency, so that --limit returns the best matches (buffers all matches on the server)")
+	cmd.Flags().StringVar(&opts.PeerID, "peer", "", "Only return records announced by the peer with this ID")
+	cmd.Flags().BoolVar(&opts.ExpandParents, "expand-parents", false, "Also match records labeled with a parent of a queried skill (e.g., --skill 'AI/ML' matches a record with skill 'AI')")
+
+	// Add examples in flag help
+	cmd.Flags().Lookup("skill").Usage = "Search for records with specific skill (e.g., --skill 'AI' --skill 'ML')"
+	cmd.Flags().Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
+	cmd.Flags().Lookup("domain").Usage = "Search for records with specific domain (e.g., --domain 'research' --domain 'analytics')"
+	cmd.Flags().Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language' --module 'runtime/framework')"
+
+	return cmd
 }
 
-// Search command options.
-var searchOpts struct {
+// searchOptions are the search command options.
+type searchOptions struct {
 	Skills   []string
 	Locators []string
 	Domains  []string
@@ -55,6 +99,12 @@ var searchOpts struct {
 	Limit    uint32
 	MinScore uint32
 	JSON     bool
+
+	ExpandParents bool
+	MaxAge        time.Duration
+	Watch         bool
+	Rank          bool
+	PeerID        string
 }
 
 const (
@@ -63,24 +113,7 @@ const (
 	defaultMinScore = 1
 )
 
-func init() {
-	// Add flags for search options
-	searchCmd.Flags().StringArrayVar(&searchOpts.Skills, "skill", nil, "Search for records with specific skill (can be repeated)")
-	searchCmd.Flags().StringArrayVar(&searchOpts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
-	searchCmd.Flags().StringArrayVar(&searchOpts.Domains, "domain", nil, "Search for records with specific domain (can be repeated)")
-	searchCmd.Flags().StringArrayVar(&searchOpts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
-	searchCmd.Flags().Uint32Var(&searchOpts.Limit, "limit", defaultSearchLimit, "Maximum number of results to return")
-	searchCmd.Flags().Uint32Var(&searchOpts.MinScore, "min-score", defaultMinScore, "Minimum match score (number of queries that must match)")
-	searchCmd.Flags().BoolVar(&searchOpts.JSON, "json", false, "Output results in JSON format")
-
-	// Add examples in flag help
-	searchCmd.Flags().Lookup("skill").Usage = "Search for records with specific skill (e.g., --skill 'AI' --skill 'ML')"
-	searchCmd.Flags().Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
-	searchCmd.Flags().Lookup("domain").Usage = "Search for records with specific domain (e.g., --domain 'research' --domain 'analytics')"
-	searchCmd.Flags().Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language' --module 'runtime/framework')"
-}
-
-func runSearchCommand(cmd *cobra.Command) error {
+func runSearchCommand(cmd *cobra.Command, opts *searchOptions) error {
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
@@ -88,18 +121,19 @@ func runSearchCommand(cmd *cobra.Command) error {
 	}
 
 	// Build queries from flags
-	queries := make([]*routingv1.RecordQuery, 0, len(searchOpts.Skills)+len(searchOpts.Locators)+len(searchOpts.Domains)+len(searchOpts.Modules))
+	queries := make([]*routingv1.RecordQuery, 0, len(opts.Skills)+len(opts.Locators)+len(opts.Domains)+len(opts.Modules))
 
 	// Add skill queries
-	for _, skill := range searchOpts.Skills {
+	for _, skill := range opts.Skills {
 		queries = append(queries, &routingv1.RecordQuery{
-			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
-			Value: skill,
+			Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+			Value:         skill,
+			ExpandParents: opts.ExpandParents,
 		})
 	}
 
 	// Add locator queries
-	for _, locator := range searchOpts.Locators {
+	for _, locator := range opts.Locators {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
 			Value: locator,
@@ -107,7 +141,7 @@ func runSearchCommand(cmd *cobra.Command) error {
 	}
 
 	// Add domain queries
-	for _, domain := range searchOpts.Domains {
+	for _, domain := range opts.Domains {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
 			Value: domain,
@@ -115,7 +149,7 @@ func runSearchCommand(cmd *cobra.Command) error {
 	}
 
 	// Add module queries
-	for _, module := range searchOpts.Modules {
+	for _, module := range opts.Modules {
 		queries = append(queries, &routingv1.RecordQuery{
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
 			Value: module,
@@ -135,15 +169,23 @@ func runSearchCommand(cmd *cobra.Command) error {
 	// Build search request
 	req := &routingv1.SearchRequest{
 		Queries: queries,
+		Watch:   opts.Watch,
+		Rank:    opts.Rank,
+		PeerId:  opts.PeerID,
 	}
 
 	// Add optional parameters
-	if searchOpts.Limit > 0 {
-		req.Limit = &searchOpts.Limit
+	if opts.Limit > 0 {
+		req.Limit = &opts.Limit
+	}
+
+	if opts.MinScore > 0 {
+		req.MinMatchScore = &opts.MinScore
 	}
 
-	if searchOpts.MinScore > 0 {
-		req.MinMatchScore = &searchOpts.MinScore
+	if opts.MaxAge > 0 {
+		maxAgeSeconds := uint32(opts.MaxAge.Seconds()) //nolint:gosec // durations are small
+		req.MaxAgeSeconds = &maxAgeSeconds
 	}
 
 	// Execute search
@@ -152,11 +194,39 @@ func runSearchCommand(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to search routing: %w", err)
 	}
 
+	if opts.Watch {
+		return printSearchResults(cmd, opts, resultCh)
+	}
+
 	// Collect results
-	results := make([]interface{}, 0, searchOpts.Limit)
+	results := make([]interface{}, 0, opts.Limit)
 	for result := range resultCh {
 		results = append(results, result)
 	}
 
 	return presenter.PrintMessage(cmd, "remote records", "Remote records found", results)
 }
+
+// printSearchResults prints each result as soon as it is received.
+// JSON output is printed as one object per line.
+func printSearchResults(cmd *cobra.Command, opts *searchOptions, resultCh <-chan *routingv1.SearchResponse) error {
+	jsonOutput := opts.JSON || presenter.GetOutputOptions(cmd).Format == presenter.FormatJSON
+
+	for result := range resultCh {
+		if jsonOutput {
+			output, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+
+			presenter.Printf(cmd, "%s\n", output)
+
+			continue
+		}
+
+		presenter.Printf(cmd, "%s (peer: %s, score: %d)\n",
+			result.GetRecordRef().GetCid(), result.GetPeer().GetId(), result.GetMatchScore())
+	}
+
+	return nil
+}
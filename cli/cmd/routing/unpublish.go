@@ -15,10 +15,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var unpublishCmd = &cobra.Command{
-	Use:   "unpublish <cid>",
-	Short: "Unpublish record from the network",
-	Long: `Unpublish a record from the network to stop content discovery by other peers.
+// newUnpublishCommand creates the routing unpublish command.
+func newUnpublishCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpublish <cid>",
+		Short: "Unpublish record from the network",
+		Long: `Unpublish a record from the network to stop content discovery by other peers.
 
 This command removes a record's network announcements, making it no longer
 discoverable by other peers through the DHT. The record remains in local storage.
@@ -36,10 +38,16 @@ Usage examples:
 
 Note: This only removes network announcements. Use 'dirctl delete' to remove the record entirely.
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runUnpublishCommand(cmd, args[0])
-	},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnpublishCommand(cmd, args[0])
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runUnpublishCommand(cmd *cobra.Command, cid string) error {
@@ -3,45 +3,67 @@
 
 package search
 
-import "github.com/agntcy/dir/cli/presenter"
-
-var opts = &options{}
+import (
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
 
 type options struct {
 	Limit  uint32
 	Offset uint32
+	Facets bool
+
+	// Search scope
+	Network    bool
+	RemoteOnly bool
+
+	// Boolean query expression, e.g. "skill-name:nlp* AND NOT name:*test*"
+	Expression string
 
 	// Direct field flags (consistent with routing search)
-	Names      []string
-	Versions   []string
-	SkillIDs   []string
-	SkillNames []string
-	Locators   []string
-	Modules    []string
+	Names          []string
+	NamesFuzzy     []string
+	Versions       []string
+	SkillIDs       []string
+	SkillNames     []string
+	Locators       []string
+	Modules        []string
+	ModuleData     []string
+	LocatorDigests []string
 }
 
-func init() {
-	flags := Command.Flags()
+// addFlags adds the command flags bound to the options.
+func addFlags(cmd *cobra.Command, opts *options) {
+	flags := cmd.Flags()
 
 	flags.Uint32Var(&opts.Limit, "limit", 100, "Maximum number of results to return (default: 100)") //nolint:mnd
-	flags.Uint32Var(&opts.Offset, "offset", 0, "Pagination offset (default: 0)")
+	flags.Uint32Var(&opts.Offset, "offset", 0, "Pagination offset of local results (default: 0)")
+	flags.BoolVar(&opts.Facets, "facets", false, "Include skill, locator and module counts of all matching records")
+	flags.BoolVar(&opts.Network, "network", false, "Also search the records announced by other peers in the network")
+	flags.BoolVar(&opts.RemoteOnly, "remote-only", false, "Only search the records announced by other peers in the network")
 
 	// Direct field flags
 	flags.StringArrayVar(&opts.Names, "name", nil, "Search for records with specific name (can be repeated)")
+	flags.StringArrayVar(&opts.NamesFuzzy, "name-fuzzy", nil, "Search for records with a similar name (can be repeated)")
 	flags.StringArrayVar(&opts.Versions, "version", nil, "Search for records with specific version (can be repeated)")
 	flags.StringArrayVar(&opts.SkillIDs, "skill-id", nil, "Search for records with specific skill ID (can be repeated)")
 	flags.StringArrayVar(&opts.SkillNames, "skill", nil, "Search for records with specific skill name (can be repeated)")
 	flags.StringArrayVar(&opts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
+	flags.StringArrayVar(&opts.LocatorDigests, "locator-digest", nil, "Search for records with a locator artifact of specific digest (can be repeated)")
 	flags.StringArrayVar(&opts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
+	flags.StringArrayVar(&opts.ModuleData, "module-data", nil, "Search for records with specific module data value (can be repeated)")
 
 	// Add examples in flag help
 	flags.Lookup("name").Usage = "Search for records with specific name (e.g., --name 'my-agent' --name 'web-*')"
+	flags.Lookup("name-fuzzy").Usage = "Search for records with a similar name, tolerating typos (e.g., --name-fuzzy 'agnet' --name-fuzzy 'agnet~1')"
 	flags.Lookup("version").Usage = "Search for records with specific version (e.g., --version 'v1.0.0' --version 'v1.*')"
 	flags.Lookup("skill-id").Usage = "Search for records with specific skill ID (e.g., --skill-id '10201')"
 	flags.Lookup("skill").Usage = "Search for records with specific skill name (e.g., --skill 'natural_language_processing' --skill 'audio')"
 	flags.Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
+	flags.Lookup("locator-digest").Usage = "Search for records with a locator artifact of specific digest (e.g., --locator-digest 'sha256:...')"
 	flags.Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language')"
+	flags.Lookup("module-data").Usage = "Search for records with specific module data value as path=value (e.g., --module-data 'framework.version=1.*')"
 
 	// Add output format flags
-	presenter.AddOutputFlags(Command)
+	presenter.AddOutputFlags(cmd)
 }
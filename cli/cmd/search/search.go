@@ -7,20 +7,47 @@ package search
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "search",
-	Short: "Search for records",
-	Long: `Search for records in the directory using various filters and options.
+// NewCommand creates the search command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "search [expression]",
+		Short: "Search for records",
+		Long: `Search for records in the directory using various filters and options.
 
 This command provides a consistent interface with routing search commands.
 
+By default, only the records stored locally are searched. With --network, the
+records announced by other peers in the network are searched concurrently and the
+results merged. Records found in both are listed once, as local. Use --remote-only
+to only search the network.
+
+The network can only be searched by skill, module and locator type. Network searches
+that also filter by name, version, skill ID, module data, locator URL or locator digest
+only return local records.
+
+Filters can also be combined in a query expression passed as the argument. Terms
+use the "field:value" format, with the fields name, version, skill-id, skill-name
+(or skill), locator, locator-digest, module and module-data, and are combined with
+AND, OR, NOT and parentheses. NOT binds tighter than AND, which binds tighter than
+OR. Values containing spaces or parentheses are double-quoted. The expression is
+combined with the filter flags by AND. Expressions with NOT, or with fields the
+network cannot answer, only return local records with --network.
+
 Usage examples:
 
 1. Basic search with specific filters and limit:
@@ -51,6 +78,12 @@ Usage examples:
 	# Find agents with plugin modules
 	dirctl search --module "*-plugin*"
 
+	# Find agents whose module data declares a framework version
+	dirctl search --module "runtime/framework" --module-data "framework.version=1.*"
+
+	# Find agents referencing a specific artifact
+	dirctl search --locator-digest "sha256:..."
+
 3. Question mark wildcard (? matches exactly one character):
 
 	# Find version v1.0.x where x is any single digit
@@ -90,49 +123,341 @@ Usage examples:
 	# Combine different wildcard types
 	dirctl search --name "web-[0-9]?" --version "v?.*.?"
 
+6. Typo-tolerant name search (results ordered by similarity):
+
+	# Find agents with a name within 2 edits of "agnet"
+	dirctl search --name-fuzzy "agnet"
+
+	# Allow at most 1 edit
+	dirctl search --name-fuzzy "agnet~1"
+
+7. Facet counts:
+
+	# Show skill, locator and module counts of all matching records
+	dirctl search --name "web*" --facets
+
+8. Search scope:
+
+	# Also search the records announced by other peers
+	dirctl search --skill "audio" --network
+
+	# Only search the records announced by other peers
+	dirctl search --skill "audio" --remote-only
+
+9. Query expressions:
+
+	# Find NLP agents of version 3 that are not test agents
+	dirctl search 'skill-name:nlp* AND version:v3.* AND NOT name:*test*'
+
+	# Find agents with either skill, also in the network
+	dirctl search '(skill:audio OR skill:speech*) AND locator:docker-image'
+
+	# Quote values with spaces
+	dirctl search 'skill:"Text Completion" OR module-data:"framework.name=lang chain"'
+
 `,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runCommand(cmd)
-	},
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Expression = args[0]
+			}
+
+			return runCommand(cmd, opts)
+		},
+	}
+
+	addFlags(cmd, opts)
+
+	return cmd
 }
 
-func runCommand(cmd *cobra.Command) error {
+func runCommand(cmd *cobra.Command, opts *options) error {
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
 
+	// Parse the expression early to report syntax errors before searching
+	var expr *searchv1.QueryExpression
+
+	if opts.Expression != "" {
+		var err error
+
+		expr, err = searchv1.ParseQueryExpression(opts.Expression)
+		if err != nil {
+			return expressionError(err)
+		}
+	}
+
 	// Build queries from direct field flags
-	queries := buildQueriesFromFlags()
+	queries := buildQueriesFromFlags(opts)
 
-	ch, err := c.Search(cmd.Context(), &searchv1.SearchRequest{
-		Limit:   &opts.Limit,
-		Offset:  &opts.Offset,
-		Queries: queries,
-	})
+	if opts.Facets {
+		if opts.RemoteOnly {
+			return exitcode.Invalid(errors.New("--facets is only supported for local searches"))
+		}
+
+		return runFacetsCommand(cmd, c, opts, queries)
+	}
+
+	req := &client.UnifiedSearchRequest{
+		Limit: opts.Limit,
+	}
+
+	if !opts.RemoteOnly {
+		req.Local = &searchv1.SearchRequest{
+			Limit:           &opts.Limit,
+			Offset:          &opts.Offset,
+			Queries:         queries,
+			QueryExpression: opts.Expression,
+		}
+	}
+
+	if opts.Network || opts.RemoteOnly {
+		remoteReq, err := buildRemoteRequest(opts, expr)
+
+		switch {
+		case err == nil:
+			req.Remote = remoteReq
+		case opts.RemoteOnly:
+			return err
+		}
+	}
+
+	results, err := c.SearchAll(cmd.Context(), req)
 	if err != nil {
 		return fmt.Errorf("failed to search: %w", err)
 	}
 
-	// Collect results and convert to interface{} slice
-	results := make([]interface{}, 0, opts.Limit)
+	return printResults(cmd, results, req.Remote != nil)
+}
+
+// printResults prints the merged search results, marking where each record was found.
+// JSON output lists the record CIDs, or the results with their source for network searches.
+func printResults(cmd *cobra.Command, results []*client.UnifiedSearchResult, network bool) error {
+	outputOpts := presenter.GetOutputOptions(cmd)
+
+	switch outputOpts.Format {
+	case presenter.FormatJSON:
+		if network {
+			return presenter.PrintMessage(cmd, "records", "Records found", results)
+		}
+
+		fallthrough
+
+	case presenter.FormatRaw:
+		cids := make([]interface{}, 0, len(results))
+		for _, result := range results {
+			cids = append(cids, result.Cid)
+		}
+
+		return presenter.PrintMessage(cmd, "record CIDs", "Record CIDs found", cids)
+
+	case presenter.FormatHuman:
+	}
+
+	// Only print the CIDs with --quiet
+	if outputOpts.Quiet {
+		for _, result := range results {
+			presenter.Printf(cmd, "%s\n", result.Cid)
+		}
 
-	for recordCid := range ch {
-		if recordCid == "" {
-			continue
+		return nil
+	}
+
+	if len(results) == 0 {
+		presenter.Printf(cmd, "No records found\n")
+
+		return nil
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Local && result.SchemaVersion != "":
+			presenter.Printf(cmd, "%s (local, schema: %s)\n", result.Cid, result.SchemaVersion)
+		case result.Local:
+			presenter.Printf(cmd, "%s (local)\n", result.Cid)
+		default:
+			presenter.Printf(cmd, "%s (peer: %s, score: %d)\n", result.Cid, result.Peer, result.MatchScore)
+		}
+	}
+
+	// Warn when the query matched records of different schema versions,
+	// as their fields may not be interpreted the same way
+	if versions := schemaVersions(results); len(versions) > 1 {
+		presenter.Infof(cmd, "Results use %d schema versions: %s\n", len(versions), strings.Join(versions, ", "))
+	}
+
+	return nil
+}
+
+// schemaVersions returns the distinct schema versions of the results, sorted.
+func schemaVersions(results []*client.UnifiedSearchResult) []string {
+	var versions []string
+
+	for _, result := range results {
+		if result.SchemaVersion != "" && !slices.Contains(versions, result.SchemaVersion) {
+			versions = append(versions, result.SchemaVersion)
+		}
+	}
+
+	slices.Sort(versions)
+
+	return versions
+}
+
+// expressionError formats a query expression error with a marker under the offending token.
+func expressionError(err error) error {
+	var exprErr *searchv1.QueryExpressionError
+	if !errors.As(err, &exprErr) {
+		return exitcode.Invalid(err)
+	}
+
+	offset := utf8.RuneCountInString(exprErr.Input[:exprErr.Pos])
+
+	return exitcode.Invalidf("%w\n\n  %s\n  %s^", err, exprErr.Input, strings.Repeat(" ", offset))
+}
+
+// buildRemoteRequest builds the routing search request equivalent to the flags and the expression.
+// It fails if the flags or the expression use filters that the network cannot answer.
+func buildRemoteRequest(opts *options, expr *searchv1.QueryExpression) (*routingv1.SearchRequest, error) {
+	if len(opts.Names) > 0 || len(opts.NamesFuzzy) > 0 || len(opts.Versions) > 0 ||
+		len(opts.SkillIDs) > 0 || len(opts.ModuleData) > 0 || len(opts.LocatorDigests) > 0 {
+		return nil, exitcode.Invalid(errors.New("remote search only supports the --skill, --module and --locator filters"))
+	}
+
+	queries := make([]*routingv1.RecordQuery, 0, len(opts.SkillNames)+len(opts.Locators)+len(opts.Modules))
+
+	for _, skill := range opts.SkillNames {
+		queries = append(queries, &routingv1.RecordQuery{
+			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+			Value: skill,
+		})
+	}
+
+	for _, locator := range opts.Locators {
+		// Only the locator type is announced to the network
+		if strings.Contains(locator, ":") {
+			return nil, exitcode.Invalidf("remote search only supports locator types, not locator URLs: %q", locator)
+		}
+
+		queries = append(queries, &routingv1.RecordQuery{
+			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
+			Value: locator,
+		})
+	}
+
+	for _, module := range opts.Modules {
+		queries = append(queries, &routingv1.RecordQuery{
+			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
+			Value: module,
+		})
+	}
+
+	// Values of the same filter are alternatives while different filters must all match,
+	// as in local searches. This is approximated by requiring one match per filter used.
+	var minScore uint32
+
+	for _, values := range [][]string{opts.SkillNames, opts.Locators, opts.Modules} {
+		if len(values) > 0 {
+			minScore++
+		}
+	}
+
+	if expr != nil {
+		groups, err := remoteQueryGroups(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		// Each group of alternatives must match, as for the filter flags
+		for _, group := range groups {
+			queries = append(queries, group...)
+			minScore++
+		}
+	}
+
+	if len(queries) == 0 {
+		return nil, exitcode.Invalid(errors.New("remote search requires at least one of the --skill, --module or --locator filters"))
+	}
+
+	return &routingv1.SearchRequest{
+		Queries:       queries,
+		Limit:         &opts.Limit,
+		MinMatchScore: &minScore,
+	}, nil
+}
+
+// remoteQueryGroups converts a query expression to groups of routing queries, where
+// the groups must all match and the queries of a group are alternatives.
+// Only ANDs of skill, module and locator type terms, optionally ORed with terms of
+// the same field, can be answered by the network.
+func remoteQueryGroups(expr *searchv1.QueryExpression) ([][]*routingv1.RecordQuery, error) {
+	operands := []*searchv1.QueryExpression{expr}
+	if expr.Op == searchv1.QueryExpressionAnd {
+		operands = expr.Operands
+	}
+
+	groups := make([][]*routingv1.RecordQuery, 0, len(operands))
+
+	for _, operand := range operands {
+		terms := []*searchv1.QueryExpression{operand}
+		if operand.Op == searchv1.QueryExpressionOr {
+			terms = operand.Operands
+		}
+
+		group := make([]*routingv1.RecordQuery, 0, len(terms))
+
+		for _, term := range terms {
+			if term.Op != searchv1.QueryExpressionMatch {
+				return nil, exitcode.Invalidf("remote search does not support %q, only ANDs of terms or of ORed terms of the same field", operand.String())
+			}
+
+			query, err := remoteQuery(term.Query)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(group) > 0 && group[0].GetType() != query.GetType() {
+				return nil, exitcode.Invalidf("remote search only supports ORed terms of the same field: %q", operand.String())
+			}
+
+			group = append(group, query)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// remoteQuery converts an expression term to a routing query.
+func remoteQuery(query *searchv1.RecordQuery) (*routingv1.RecordQuery, error) {
+	switch query.GetType() { //nolint:exhaustive
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME:
+		return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: query.GetValue()}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, Value: query.GetValue()}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		// Only the locator type is announced to the network
+		if strings.Contains(query.GetValue(), ":") {
+			return nil, exitcode.Invalidf("remote search only supports locator types, not locator URLs: %q", query.GetValue())
 		}
 
-		results = append(results, recordCid)
+		return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, Value: query.GetValue()}, nil
 	}
 
-	return presenter.PrintMessage(cmd, "record CIDs", "Record CIDs found", results)
+	return nil, exitcode.Invalid(errors.New("remote search only supports the skill-name, module and locator fields"))
 }
 
 // buildQueriesFromFlags builds API queries.
-func buildQueriesFromFlags() []*searchv1.RecordQuery {
+func buildQueriesFromFlags(opts *options) []*searchv1.RecordQuery {
 	queries := make([]*searchv1.RecordQuery, 0,
-		len(opts.Names)+len(opts.Versions)+len(opts.SkillIDs)+
-			len(opts.SkillNames)+len(opts.Locators)+len(opts.Modules))
+		len(opts.Names)+len(opts.NamesFuzzy)+len(opts.Versions)+len(opts.SkillIDs)+
+			len(opts.SkillNames)+len(opts.Locators)+len(opts.Modules)+
+			len(opts.ModuleData)+len(opts.LocatorDigests))
 
 	// Add name queries
 	for _, name := range opts.Names {
@@ -142,6 +467,14 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 		})
 	}
 
+	// Add fuzzy name queries
+	for _, name := range opts.NamesFuzzy {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME_FUZZY,
+			Value: name,
+		})
+	}
+
 	// Add version queries
 	for _, version := range opts.Versions {
 		queries = append(queries, &searchv1.RecordQuery{
@@ -174,6 +507,14 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 		})
 	}
 
+	// Add locator digest queries
+	for _, digest := range opts.LocatorDigests {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST,
+			Value: digest,
+		})
+	}
+
 	// Add module queries
 	for _, module := range opts.Modules {
 		queries = append(queries, &searchv1.RecordQuery{
@@ -182,5 +523,66 @@ func buildQueriesFromFlags() []*searchv1.RecordQuery {
 		})
 	}
 
+	// Add module data queries
+	for _, moduleData := range opts.ModuleData {
+		queries = append(queries, &searchv1.RecordQuery{
+			Type:  searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA,
+			Value: moduleData,
+		})
+	}
+
 	return queries
 }
+
+// runFacetsCommand searches for records and prints them together with the facet counts.
+func runFacetsCommand(cmd *cobra.Command, c *client.Client, opts *options, queries []*searchv1.RecordQuery) error {
+	cids, facets, err := c.SearchWithFacets(cmd.Context(), &searchv1.SearchRequest{
+		Limit:           &opts.Limit,
+		Offset:          &opts.Offset,
+		Queries:         queries,
+		QueryExpression: opts.Expression,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search: %w", err)
+	}
+
+	outputOpts := presenter.GetOutputOptions(cmd)
+
+	if outputOpts.Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "records", "Search results", map[string]any{
+			"record_cids": cids,
+			"facets":      facets,
+		})
+	}
+
+	// Only print the CIDs with --quiet
+	if outputOpts.Quiet {
+		for _, cid := range cids {
+			presenter.Printf(cmd, "%s\n", cid)
+		}
+
+		return nil
+	}
+
+	if len(cids) == 0 {
+		presenter.Printf(cmd, "No record CIDs found\n")
+	}
+
+	for _, cid := range cids {
+		presenter.Printf(cmd, "%s\n", cid)
+	}
+
+	for _, facet := range facets {
+		if len(facet.GetValues()) == 0 {
+			continue
+		}
+
+		presenter.Printf(cmd, "\n%s:\n", facet.GetNamespace())
+
+		for _, value := range facet.GetValues() {
+			presenter.Printf(cmd, "  %-40s %d\n", value.GetValue(), value.GetCount())
+		}
+	}
+
+	return nil
+}
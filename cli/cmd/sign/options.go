@@ -4,29 +4,18 @@
 package sign
 
 import (
-	"github.com/agntcy/dir/cli/presenter"
 	"github.com/agntcy/dir/client"
 	"github.com/agntcy/dir/utils/cosign"
 	"github.com/spf13/pflag"
 )
 
-var opts = &options{}
-
 type options struct {
 	// Signing options
 	client.SignOpts
 }
 
-func init() {
-	flags := Command.Flags()
-
-	AddSigningFlags(flags)
-
-	// Add output format flags
-	presenter.AddOutputFlags(Command)
-}
-
-func AddSigningFlags(flags *pflag.FlagSet) {
+// AddSigningFlags adds the flags of the signing options to a flag set.
+func AddSigningFlags(flags *pflag.FlagSet, opts *client.SignOpts) {
 	flags.StringVar(&opts.FulcioURL, "fulcio-url", cosign.DefaultFulcioURL,
 		"Sigstore Fulcio URL")
 	flags.StringVar(&opts.RekorURL, "rekor-url", cosign.DefaultRekorURL,
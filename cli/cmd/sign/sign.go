@@ -11,8 +11,6 @@ import (
 	"os"
 	"path/filepath"
 
-	corev1 "github.com/agntcy/dir/api/core/v1"
-	signv1 "github.com/agntcy/dir/api/sign/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
 	"github.com/agntcy/dir/client"
@@ -21,10 +19,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "sign",
-	Short: "Sign record using identity-based OIDC or key-based signing",
-	Long: `This command signs the record using identity-based signing.
+// NewCommand creates the sign command.
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign record using identity-based OIDC or key-based signing",
+		Long: `This command signs the record using identity-based signing.
 It uses a short-lived signing certificate issued by Sigstore Fulcio
 along with a local ephemeral signing key and OIDC identity.
 
@@ -44,28 +46,36 @@ Usage examples:
 
 	dirctl sign <record-cid> --key <key-file>
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		var recordCID string
-		if len(args) > 1 {
-			return errors.New("only one record CID is allowed")
-		} else if len(args) == 1 {
-			recordCID = args[0]
-		} else {
-			return errors.New("record CID is required")
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var recordCID string
+			if len(args) > 1 {
+				return errors.New("only one record CID is allowed")
+			} else if len(args) == 1 {
+				recordCID = args[0]
+			} else {
+				return errors.New("record CID is required")
+			}
+
+			return runCommand(cmd, recordCID, opts)
+		},
+	}
+
+	AddSigningFlags(cmd.Flags(), &opts.SignOpts)
 
-		return runCommand(cmd, recordCID)
-	},
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
-func runCommand(cmd *cobra.Command, recordCID string) error {
+func runCommand(cmd *cobra.Command, recordCID string, opts *options) error {
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
 
-	err := Sign(cmd.Context(), c, recordCID)
+	err := Sign(cmd.Context(), c, recordCID, opts.SignOpts)
 	if err != nil {
 		return fmt.Errorf("failed to sign record: %w", err)
 	}
@@ -74,91 +84,59 @@ func runCommand(cmd *cobra.Command, recordCID string) error {
 	return presenter.PrintMessage(cmd, "signature", "Record is", "signed")
 }
 
-func Sign(ctx context.Context, c *client.Client, recordCID string) error {
-	switch {
-	case opts.Key != "":
+// Sign signs a record with the signer selected by the signing options.
+func Sign(ctx context.Context, c *client.Client, recordCID string, opts client.SignOpts) error {
+	signer, err := newSigner(opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.SignWithSigner(ctx, recordCID, signer)
+	if err != nil {
+		return fmt.Errorf("failed to sign record: %w", err)
+	}
+
+	return nil
+}
+
+// newSigner picks the signing backend based on the provided options.
+// A key takes precedence over an OIDC token, and the interactive OIDC flow is used otherwise.
+func newSigner(opts client.SignOpts) (client.Signer, error) {
+	if opts.Key != "" {
 		// Load the key from file
 		rawKey, err := os.ReadFile(filepath.Clean(opts.Key))
 		if err != nil {
-			return fmt.Errorf("failed to read key file: %w", err)
+			return nil, fmt.Errorf("failed to read key file: %w", err)
 		}
 
 		// Read password from environment variable
 		pw, err := cosign.ReadPrivateKeyPassword()()
 		if err != nil {
-			return fmt.Errorf("failed to read password: %w", err)
-		}
-
-		req := &signv1.SignRequest{
-			RecordRef: &corev1.RecordRef{Cid: recordCID},
-			Provider: &signv1.SignRequestProvider{
-				Request: &signv1.SignRequestProvider_Key{
-					Key: &signv1.SignWithKey{
-						PrivateKey: rawKey,
-						Password:   pw,
-					},
-				},
-			},
+			return nil, fmt.Errorf("failed to read password: %w", err)
 		}
 
-		// Sign the record using the provided key
-		_, err = c.SignWithKey(ctx, req)
-		if err != nil {
-			return fmt.Errorf("failed to sign record with key: %w", err)
-		}
-	case opts.OIDCToken != "":
-		req := &signv1.SignRequest{
-			RecordRef: &corev1.RecordRef{Cid: recordCID},
-			Provider: &signv1.SignRequestProvider{
-				Request: &signv1.SignRequestProvider_Oidc{
-					Oidc: &signv1.SignWithOIDC{
-						IdToken: opts.OIDCToken,
-						Options: &signv1.SignWithOIDC_SignOpts{
-							FulcioUrl:       &opts.FulcioURL,
-							RekorUrl:        &opts.RekorURL,
-							TimestampUrl:    &opts.TimestampURL,
-							OidcProviderUrl: &opts.OIDCProviderURL,
-						},
-					},
-				},
-			},
-		}
+		return &client.KeySigner{
+			PrivateKey: rawKey,
+			Password:   pw,
+		}, nil
+	}
 
-		// Sign the record using the OIDC provider
-		_, err := c.SignWithOIDC(ctx, req)
-		if err != nil {
-			return fmt.Errorf("failed to sign record: %w", err)
-		}
-	default:
+	idToken := opts.OIDCToken
+	if idToken == "" {
 		// Retrieve the token from the OIDC provider
 		token, err := oauthflow.OIDConnect(opts.OIDCProviderURL, opts.OIDCClientID, "", "", oauthflow.DefaultIDTokenGetter)
 		if err != nil {
-			return fmt.Errorf("failed to get OIDC token: %w", err)
-		}
-
-		req := &signv1.SignRequest{
-			RecordRef: &corev1.RecordRef{Cid: recordCID},
-			Provider: &signv1.SignRequestProvider{
-				Request: &signv1.SignRequestProvider_Oidc{
-					Oidc: &signv1.SignWithOIDC{
-						IdToken: token.RawString,
-						Options: &signv1.SignWithOIDC_SignOpts{
-							FulcioUrl:       &opts.FulcioURL,
-							RekorUrl:        &opts.RekorURL,
-							TimestampUrl:    &opts.TimestampURL,
-							OidcProviderUrl: &opts.OIDCProviderURL,
-						},
-					},
-				},
-			},
+			return nil, fmt.Errorf("failed to get OIDC token: %w", err)
 		}
 
-		// Sign the record using the OIDC provider
-		_, err = c.SignWithOIDC(ctx, req)
-		if err != nil {
-			return fmt.Errorf("failed to sign record: %w", err)
-		}
+		idToken = token.RawString
 	}
 
-	return nil
+	return &client.OIDCSigner{
+		IDToken:         idToken,
+		FulcioURL:       opts.FulcioURL,
+		RekorURL:        opts.RekorURL,
+		TimestampURL:    opts.TimestampURL,
+		OIDCProviderURL: opts.OIDCProviderURL,
+	}, nil
 }
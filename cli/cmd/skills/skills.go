@@ -0,0 +1,86 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package skills
+
+import (
+	"errors"
+	"fmt"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the skills command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skills",
+		Short: "List the skills of the indexed records",
+		Long: `List all distinct skills of the records indexed by the Directory node,
+with the number of records using each skill.
+
+Skills carry both an ID and a name. Records using different schema versions
+may use different names for the same skill ID, in which case each name is
+listed separately. Use the listed values with the --skill and --skill-id
+flags of the search command.
+
+Usage examples:
+
+1. List all skills:
+
+	dirctl skills
+
+2. List all skills in JSON format:
+
+	dirctl skills --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCommand(cmd)
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runCommand(cmd *cobra.Command) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.ListSkills(cmd.Context(), &searchv1.ListSkillsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list skills: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "skills", "Skills found", resp.GetSkills()) //nolint:wrapcheck
+	}
+
+	displaySkills(cmd, resp.GetSkills())
+
+	return nil
+}
+
+// displaySkills prints skills in a human-readable format.
+func displaySkills(cmd *cobra.Command, skills []*searchv1.SkillInfo) {
+	if len(skills) == 0 {
+		presenter.Printf(cmd, "No skills found.\n")
+
+		return
+	}
+
+	presenter.Printf(cmd, "%-10s %-8s %s\n", "ID", "RECORDS", "NAME")
+
+	for _, skill := range skills {
+		presenter.Printf(cmd, "%-10d %-8d %s\n", skill.GetId(), skill.GetRecordCount(), skill.GetName())
+	}
+}
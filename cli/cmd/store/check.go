@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// checkOptions are the check command options.
+type checkOptions struct {
+	Repair bool
+}
+
+// newCheckCommand creates the store check command.
+func newCheckCommand() *cobra.Command {
+	opts := &checkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the search index against the store",
+		Long: `Check that the records indexed in the search database match the records in the store.
+
+The search database and the store can drift apart, for example after a
+failed indexing step or a manual change to the store. Records present in
+the store but missing from the database are not searchable, and records
+present in the database but missing from the store cannot be pulled.
+
+With --repair, records missing from the database are pulled from the store
+and re-indexed, and database entries without a record in the store are removed.
+
+Usage examples:
+
+1. Report differences between the store and the search database:
+
+	dirctl store check
+
+2. Repair the differences:
+
+	dirctl store check --repair
+
+3. Output the result as JSON:
+
+	dirctl store check --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runCheckCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCheckCommand(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Repair, "repair", false, "Re-index records missing from the database and remove dangling database entries")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runCheckCommand(cmd *cobra.Command, opts *checkOptions) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.CheckConsistency(cmd.Context(), &storev1.CheckConsistencyRequest{
+		Repair: opts.Repair,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check consistency: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		if err := presenter.PrintMessage(cmd, "check", "Consistency check result", resp); err != nil {
+			return err //nolint:wrapcheck
+		}
+	} else {
+		for _, cid := range resp.GetMissingInDatabase() {
+			presenter.Printf(cmd, "missing in database: %s\n", cid)
+		}
+
+		for _, cid := range resp.GetMissingInStore() {
+			presenter.Printf(cmd, "missing in store: %s\n", cid)
+		}
+
+		for _, repairErr := range resp.GetRepairErrors() {
+			presenter.Printf(cmd, "repair failed: %s\n", repairErr)
+		}
+
+		presenter.Printf(cmd, "%d record(s) missing in database, %d record(s) missing in store\n",
+			len(resp.GetMissingInDatabase()), len(resp.GetMissingInStore()))
+	}
+
+	if len(resp.GetRepairErrors()) > 0 {
+		return fmt.Errorf("failed to repair %d record(s)", len(resp.GetRepairErrors()))
+	}
+
+	return nil
+}
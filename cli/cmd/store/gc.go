@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// gcOptions are the gc command options.
+type gcOptions struct {
+	DryRun bool
+}
+
+// newGCCommand creates the store gc command.
+func newGCCommand() *cobra.Command {
+	opts := &gcOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove unreferenced blobs from the store",
+		Long: `Remove blobs that are no longer referenced by any record from the store.
+
+Deleting a record removes its manifest, but blobs may be left behind,
+for example after an interrupted push or delete. This command removes
+blobs that are not referenced by any record, signature or other referrer.
+Recently written blobs are always kept to avoid racing with in-flight pushes.
+
+Garbage collection is only supported by nodes using a local OCI store.
+Remote registries run their own garbage collection.
+
+Usage examples:
+
+1. Show which blobs would be removed:
+
+	dirctl store gc --dry-run
+
+2. Remove unreferenced blobs:
+
+	dirctl store gc
+
+3. Remove unreferenced blobs and output the result as JSON:
+
+	dirctl store gc --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runGCCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runGCCommand(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report unreferenced blobs without deleting them")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runGCCommand(cmd *cobra.Command, opts *gcOptions) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.GarbageCollect(cmd.Context(), &storev1.GarbageCollectRequest{
+		DryRun: opts.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run garbage collection: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "gc", "Garbage collection result", resp)
+	}
+
+	action := "Removed"
+	if opts.DryRun {
+		action = "Would remove"
+	}
+
+	for _, blob := range resp.GetDeletedBlobs() {
+		presenter.Printf(cmd, "%s\n", blob)
+	}
+
+	presenter.Printf(cmd, "%s %d blob(s), %d bytes\n", action, len(resp.GetDeletedBlobs()), resp.GetReclaimedBytes())
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// newStatsCommand creates the store stats command.
+func newStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Report store size and record count",
+		Long: `Report how much the store holds and the limits it enforces.
+
+The report includes the total size of all blobs, the number of manifests,
+records and signed records, and the maximum accepted record size.
+For remote registries, it also reports whether the OCI referrers API
+is supported. When the server limits the rate of requests, the limits
+and the requests currently available to this client are reported too.
+
+Computing the usage walks the whole store, which may take a while
+for large remote registries.
+
+Usage examples:
+
+1. Show store statistics:
+
+	dirctl store stats
+
+2. Output store statistics as JSON:
+
+	dirctl store stats --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runStatsCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatsCommand(cmd)
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runStatsCommand(cmd *cobra.Command) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.GetStats(cmd.Context(), &storev1.GetStatsRequest{
+		IncludeUsage: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get store stats: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "stats", "Store statistics", resp)
+	}
+
+	presenter.Printf(cmd, "Total blob size:     %d bytes\n", resp.GetTotalBlobBytes())
+	presenter.Printf(cmd, "Manifests:           %d\n", resp.GetManifestCount())
+	presenter.Printf(cmd, "Records:             %d\n", resp.GetRecordCount())
+	presenter.Printf(cmd, "Signed records:      %d\n", resp.GetSignedRecordCount())
+
+	if resp.GetMaxRecordBytes() > 0 {
+		presenter.Printf(cmd, "Max record size:     %d bytes\n", resp.GetMaxRecordBytes())
+	} else {
+		presenter.Printf(cmd, "Max record size:     unlimited\n")
+	}
+
+	if resp.ReferrersApiSupported != nil {
+		presenter.Printf(cmd, "Referrers API:       %t\n", resp.GetReferrersApiSupported())
+	}
+
+	if limits := resp.GetRateLimits(); limits != nil {
+		presenter.Printf(cmd, "Read rate limit:     %g/s (burst %d, %.1f available, %d rejected)\n",
+			limits.GetReadRequestsPerSecond(), limits.GetReadBurst(), limits.GetCallerReadTokens(), limits.GetRejectedReadRequests())
+		presenter.Printf(cmd, "Write rate limit:    %g/s (burst %d, %.1f available, %d rejected)\n",
+			limits.GetWriteRequestsPerSecond(), limits.GetWriteBurst(), limits.GetCallerWriteTokens(), limits.GetRejectedWriteRequests())
+		presenter.Printf(cmd, "Active clients:      %d\n", limits.GetActiveClients())
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the store command group.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "Maintenance operations for the Directory store",
+		Long: `Maintenance operations for the Directory store.
+
+This command group provides access to store administration operations:
+
+- gc: Remove blobs that are no longer referenced by any record
+- check: Check the search index against the store and optionally repair it
+- stats: Report store size, record count and limits
+- verify: Recompute the CIDs of all stored records and optionally remove mismatches
+
+Examples:
+
+1. Show which blobs would be removed:
+   dirctl store gc --dry-run
+
+2. Remove unreferenced blobs:
+   dirctl store gc
+
+3. Re-index records missing from the search database:
+   dirctl store check --repair
+
+4. Show how much the store holds:
+   dirctl store stats
+
+5. Remove records whose content no longer matches their CID:
+   dirctl store verify --repair
+`,
+	}
+
+	cmd.AddCommand(
+		newGCCommand(),
+		newCheckCommand(),
+		newStatsCommand(),
+		newVerifyCommand(),
+	)
+
+	return cmd
+}
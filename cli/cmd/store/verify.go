@@ -0,0 +1,172 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+// verifyOptions are the verify command options.
+type verifyOptions struct {
+	Repair bool
+}
+
+// verifySummary is the result of a verification run in JSON and YAML output.
+type verifySummary struct {
+	Total      uint64                           `json:"total"`
+	Verified   uint64                           `json:"verified"`
+	Mismatched []*storev1.VerifyRecordsResponse `json:"mismatched,omitempty"`
+	Failed     []*storev1.VerifyRecordsResponse `json:"failed,omitempty"`
+}
+
+// newVerifyCommand creates the store verify command.
+func newVerifyCommand() *cobra.Command {
+	opts := &verifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Recompute and verify the CIDs of all stored records",
+		Long: `Recompute the CID of every record in the store and report records whose
+content no longer matches their CID.
+
+Every record is pulled from the store and its CID is recomputed from its
+content. A mismatch means the stored content was changed or corrupted after
+the record was pushed. Records are verified concurrently by the server and
+the progress is shown on stderr.
+
+If the recomputed CID differs, the digest of the stored bytes is checked,
+so that records that only re-marshal differently are not reported. With
+--repair, records whose stored bytes do not match their CID are removed from
+the store and the search database. Records that could not be pulled, or
+whose stored bytes could not be read, are reported but never removed.
+
+The command fails if any record does not match its CID or could not be
+verified, unless all mismatching records were removed.
+
+Usage examples:
+
+1. Verify all stored records:
+
+	dirctl store verify
+
+2. Remove records whose content does not match their CID:
+
+	dirctl store verify --repair
+
+3. Output the result as JSON:
+
+	dirctl store verify --json
+
+`,
+		//nolint:gocritic // Lambda required due to signature mismatch - runVerifyCommand doesn't use args
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runVerifyCommand(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Repair, "repair", false, "Remove records whose content does not match their CID")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+func runVerifyCommand(cmd *cobra.Command, opts *verifyOptions) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	result, err := c.VerifyRecords(cmd.Context(), &storev1.VerifyRecordsRequest{
+		Repair: opts.Repair,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify records: %w", err)
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+	summary := verifySummary{}
+
+	var (
+		streamErr error
+		remaining int
+	)
+
+	for done := false; !done; {
+		select {
+		case err := <-result.ErrCh():
+			streamErr = errors.Join(streamErr, err)
+		case resp := <-result.ResCh():
+			summary.Total = resp.GetTotal()
+			summary.Verified++
+
+			switch {
+			case resp.GetErrorMessage() != "" && resp.GetComputedCid() == "":
+				summary.Failed = append(summary.Failed, resp)
+			case !resp.GetValid():
+				summary.Mismatched = append(summary.Mismatched, resp)
+
+				if !resp.GetRemoved() {
+					remaining++
+				}
+			}
+
+			if human {
+				presenter.Errorf(cmd, "\rVerified %d/%d record(s)", summary.Verified, summary.Total)
+			}
+		case <-result.DoneCh():
+			done = true
+		}
+	}
+
+	if human && summary.Verified > 0 {
+		presenter.Errorf(cmd, "\n")
+	}
+
+	if streamErr != nil {
+		return fmt.Errorf("failed to verify records: %w", streamErr)
+	}
+
+	if !human {
+		if err := presenter.PrintMessage(cmd, "verify", "Verification result", summary); err != nil {
+			return err //nolint:wrapcheck
+		}
+	} else {
+		for _, resp := range summary.Mismatched {
+			presenter.Printf(cmd, "mismatch: %s (computed %s)\n", resp.GetCid(), resp.GetComputedCid())
+
+			switch {
+			case resp.GetRemoved():
+				presenter.Printf(cmd, "removed: %s\n", resp.GetCid())
+			case resp.GetErrorMessage() != "":
+				presenter.Printf(cmd, "repair failed: %s: %s\n", resp.GetCid(), resp.GetErrorMessage())
+			}
+		}
+
+		for _, resp := range summary.Failed {
+			presenter.Printf(cmd, "verification failed: %s: %s\n", resp.GetCid(), resp.GetErrorMessage())
+		}
+
+		presenter.Printf(cmd, "%d record(s) verified, %d mismatched, %d failed\n",
+			summary.Verified, len(summary.Mismatched), len(summary.Failed))
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("%d record(s) do not match their CID", remaining)
+	}
+
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("failed to verify %d record(s)", len(summary.Failed))
+	}
+
+	return nil
+}
@@ -17,18 +17,35 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "sync",
-	Short: "Manage synchronization operations with remote Directory nodes",
-	Long: `Sync command allows you to manage synchronization operations between Directory nodes.
+// NewCommand creates the sync command group.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Manage synchronization operations with remote Directory nodes",
+		Long: `Sync command allows you to manage synchronization operations between Directory nodes.
 It provides subcommands to create, list, monitor, and delete sync operations.`,
+	}
+
+	opts := &options{}
+
+	// Add subcommands
+	cmd.AddCommand(
+		newCreateCommand(opts),
+		newListCommand(opts),
+		newStatusCommand(),
+		newDeleteCommand(),
+		newZotCommand(opts),
+	)
+
+	return cmd
 }
 
-// Create sync subcommand.
-var createCmd = &cobra.Command{
-	Use:   "create <remote-directory-url>",
-	Short: "Create a new synchronization operation",
-	Long: `Create initiates a new synchronization operation from a remote Directory node.
+// newCreateCommand creates the sync create subcommand.
+func newCreateCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <remote-directory-url>",
+		Short: "Create a new synchronization operation",
+		Long: `Create initiates a new synchronization operation from a remote Directory node.
 The operation is asynchronous and returns a sync ID for tracking progress.
 
 When --stdin flag is used, the command parses JSON routing search output from stdin
@@ -44,66 +61,93 @@ Usage examples:
 
 3. Create sync from routing search output:
   dirctl routing search --skill "AI" --json | dirctl sync create --stdin`,
-	Args: func(cmd *cobra.Command, args []string) error {
-		if opts.Stdin {
-			return cobra.MaximumNArgs(0)(cmd, args)
-		}
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Stdin {
+				return cobra.MaximumNArgs(0)(cmd, args)
+			}
 
-		return cobra.ExactArgs(1)(cmd, args)
-	},
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if opts.Stdin {
-			return runCreateSyncFromStdin(cmd)
-		}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Stdin {
+				return runCreateSyncFromStdin(cmd)
+			}
+
+			return runCreateSync(cmd, args[0], opts.CIDs)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.CIDs, "cids", []string{}, "List of CIDs to synchronize from the remote Directory. If empty, all objects will be synchronized.")
+	flags.BoolVar(&opts.Stdin, "stdin", false, "Parse routing search output from stdin to create sync operations for each provider")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
 
-		return runCreateSync(cmd, args[0], opts.CIDs)
-	},
+	return cmd
 }
 
-// List syncs subcommand.
-var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all synchronization operations",
-	Long: `List displays all sync operations known to the system, including active, 
+// newListCommand creates the sync list subcommand.
+func newListCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all synchronization operations",
+		Long: `List displays all sync operations known to the system, including active, 
 completed, and failed synchronizations.
 
 Pagination can be controlled using --limit and --offset flags:
   dir sync list --limit 10 --offset 20`,
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		return runListSyncs(cmd)
-	},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListSyncs(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Uint32Var(&opts.Limit, "limit", 100, "Maximum number of sync operations to return (default: 100)") //nolint:mnd
+	flags.Uint32Var(&opts.Offset, "offset", 0, "Number of sync operations to skip (for pagination)")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
-// Get sync status subcommand.
-var statusCmd = &cobra.Command{
-	Use:   "status <sync-id>",
-	Short: "Get detailed status of a synchronization operation",
-	Long: `Status retrieves comprehensive information about a specific sync operation,
+// newStatusCommand creates the sync status subcommand.
+func newStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status <sync-id>",
+		Short: "Get detailed status of a synchronization operation",
+		Long: `Status retrieves comprehensive information about a specific sync operation,
 including progress, timing, and error details if applicable.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runGetSyncStatus(cmd, args[0])
-	},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGetSyncStatus(cmd, args[0])
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
-// Delete sync subcommand.
-var deleteCmd = &cobra.Command{
-	Use:   "delete <sync-id>",
-	Short: "Delete a synchronization operation",
-	Long: `Delete removes a sync operation from the system. For active syncs,
+// newDeleteCommand creates the sync delete subcommand.
+func newDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <sync-id>",
+		Short: "Delete a synchronization operation",
+		Long: `Delete removes a sync operation from the system. For active syncs,
 this will attempt to cancel the operation gracefully.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runDeleteSync(cmd, args[0])
-	},
-}
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeleteSync(cmd, args[0])
+		},
+	}
 
-func init() {
-	// Add subcommands
-	Command.AddCommand(createCmd)
-	Command.AddCommand(listCmd)
-	Command.AddCommand(statusCmd)
-	Command.AddCommand(deleteCmd)
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 func runCreateSync(cmd *cobra.Command, remoteURL string, cids []string) error {
@@ -126,7 +170,7 @@ func runCreateSync(cmd *cobra.Command, remoteURL string, cids []string) error {
 	return presenter.PrintMessage(cmd, "sync", "Sync created with ID", syncID)
 }
 
-func runListSyncs(cmd *cobra.Command) error {
+func runListSyncs(cmd *cobra.Command, opts *options) error {
 	client, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
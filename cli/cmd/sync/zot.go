@@ -0,0 +1,266 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/agntcy/dir/cli/presenter"
+	zotutils "github.com/agntcy/dir/utils/zot"
+	"github.com/spf13/cobra"
+	zotsyncconfig "zotregistry.dev/zot/pkg/extensions/config/sync"
+)
+
+// newZotCommand creates the zot sync configuration subcommand.
+func newZotCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zot",
+		Short: "Manage registries synchronized by the local zot registry",
+		Long: `Zot command manages the sync extension of a local zot registry configuration file,
+so that registries can be mirrored without editing the zot JSON configuration by hand.
+
+The commands operate directly on the zot configuration file, which zot reloads automatically.`,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&opts.ZotConfigPath, "config", zotutils.DefaultZotConfigPath, "Path to the zot configuration file")
+	flags.StringVar(&opts.Registry, "registry", "", "Remote registry address")
+
+	cmd.AddCommand(
+		newZotAddCommand(opts),
+		newZotRemoveCommand(opts),
+		newZotListCommand(opts),
+	)
+
+	return cmd
+}
+
+// newZotAddCommand creates the zot add subcommand.
+func newZotAddCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a registry to the zot sync configuration",
+		Long: `Add configures zot to synchronize records from a remote registry.
+
+Usage examples:
+
+1. Sync all records from a remote registry:
+  dirctl sync zot add --registry registry.example.com --repo dir
+
+2. Sync specific records:
+  dirctl sync zot add --registry registry.example.com --repo dir --cids cid1,cid2
+
+3. Sync from a registry with a private CA:
+  dirctl sync zot add --registry registry.example.com:443 --repo dir --tls-verify --ca-cert-dir /etc/zot/certs`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runZotAdd(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Repository, "repo", "", "Remote repository to synchronize")
+	flags.StringSliceVar(&opts.ZotCIDs, "cids", []string{}, "List of CIDs to synchronize. If empty, all records will be synchronized.")
+	flags.StringVar(&opts.Username, "username", "", "Username for the remote registry")
+	flags.StringVar(&opts.Password, "password", "", "Password for the remote registry")
+	flags.BoolVar(&opts.TLSVerify, "tls-verify", false, "Verify the TLS certificate of the remote registry")
+	flags.StringVar(&opts.CACertDir, "ca-cert-dir", "", "Directory with the CA certificate (ca.crt) of the remote registry")
+	flags.StringVar(&opts.CredentialsDir, "credentials-dir", zotutils.DefaultCredentialsDir, "Directory where the zot credentials file is written")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+// newZotRemoveCommand creates the zot remove subcommand.
+func newZotRemoveCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a registry from the zot sync configuration",
+		Long: `Remove stops zot from synchronizing a remote registry.
+
+When --repo is set, only the content filter for that repository is removed
+and the registry is kept as long as other content filters remain.
+
+Usage examples:
+
+1. Remove a registry:
+  dirctl sync zot remove --registry registry.example.com
+
+2. Remove a single repository from a registry:
+  dirctl sync zot remove --registry registry.example.com --repo dir`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runZotRemove(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Repository, "repo", "", "Only remove the content filter for this repository")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+// newZotListCommand creates the zot list subcommand.
+func newZotListCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registries in the zot sync configuration",
+		Long: `List displays the registries synchronized by zot together with their content filters.
+
+Usage examples:
+
+1. List synced registries:
+  dirctl sync zot list
+
+2. List synced registries in JSON format:
+  dirctl sync zot list --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runZotList(cmd, opts)
+		},
+	}
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
+}
+
+// validateZotConfigPath checks that the zot configuration file exists.
+func validateZotConfigPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("zot config %s is not accessible: %w", path, err)
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("zot config %s must be a file", path)
+	}
+
+	return nil
+}
+
+func runZotAdd(cmd *cobra.Command, opts *options) error {
+	if opts.Registry == "" {
+		return errors.New("--registry is required")
+	}
+
+	if opts.Repository == "" {
+		return errors.New("--repo is required")
+	}
+
+	if err := validateZotConfigPath(opts.ZotConfigPath); err != nil {
+		return err
+	}
+
+	// Zot ignores duplicate registries, so report them explicitly
+	exists, err := zotutils.HasSyncRegistry(opts.ZotConfigPath, opts.Registry)
+	if err != nil {
+		return fmt.Errorf("failed to read zot sync config: %w", err)
+	}
+
+	if exists {
+		return presenter.PrintMessage(cmd, "registry", "Registry already synced, no duplicate added", opts.Registry)
+	}
+
+	err = zotutils.AddRegistryToSyncConfig(
+		opts.ZotConfigPath,
+		opts.Registry,
+		opts.Repository,
+		zotsyncconfig.Credentials{
+			Username: opts.Username,
+			Password: opts.Password,
+		},
+		opts.ZotCIDs,
+		zotutils.TLSOptions{
+			Verify:    opts.TLSVerify,
+			CACertDir: opts.CACertDir,
+		},
+		zotutils.FileCredentialStore{Dir: opts.CredentialsDir},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add registry to zot sync config: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "registry", "Registry added to zot sync", opts.Registry)
+}
+
+func runZotRemove(cmd *cobra.Command, opts *options) error {
+	if opts.Registry == "" {
+		return errors.New("--registry is required")
+	}
+
+	if err := validateZotConfigPath(opts.ZotConfigPath); err != nil {
+		return err
+	}
+
+	exists, err := zotutils.HasSyncRegistry(opts.ZotConfigPath, opts.Registry)
+	if err != nil {
+		return fmt.Errorf("failed to read zot sync config: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("registry %s is not synced", opts.Registry)
+	}
+
+	if opts.Repository != "" {
+		if err := zotutils.RemoveContentFromSyncConfig(opts.ZotConfigPath, opts.Registry, opts.Repository); err != nil {
+			return fmt.Errorf("failed to remove content from zot sync config: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "repository", "Repository removed from zot sync", opts.Repository)
+	}
+
+	if err := zotutils.RemoveRegistryFromSyncConfig(opts.ZotConfigPath, opts.Registry); err != nil {
+		return fmt.Errorf("failed to remove registry from zot sync config: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "registry", "Registry removed from zot sync", opts.Registry)
+}
+
+// zotSyncRegistry is the output representation of a synced registry.
+type zotSyncRegistry struct {
+	URLs    []string         `json:"urls"`
+	Content []zotSyncContent `json:"content,omitempty"`
+}
+
+// zotSyncContent is the output representation of a sync content filter.
+type zotSyncContent struct {
+	Prefix   string `json:"prefix"`
+	TagRegex string `json:"tag_regex,omitempty"`
+}
+
+func runZotList(cmd *cobra.Command, opts *options) error {
+	if err := validateZotConfigPath(opts.ZotConfigPath); err != nil {
+		return err
+	}
+
+	registries, err := zotutils.ListSyncRegistries(opts.ZotConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read zot sync config: %w", err)
+	}
+
+	results := make([]interface{}, 0, len(registries))
+
+	for _, registry := range registries {
+		item := zotSyncRegistry{URLs: registry.URLs}
+
+		for _, content := range registry.Content {
+			filter := zotSyncContent{Prefix: content.Prefix}
+			if content.Tags != nil && content.Tags.Regex != nil {
+				filter.TagRegex = *content.Tags.Regex
+			}
+
+			item.Content = append(item.Content, filter)
+		}
+
+		results = append(results, item)
+	}
+
+	return presenter.PrintMessage(cmd, "registries", "Synced registries", results)
+}
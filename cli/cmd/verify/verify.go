@@ -7,24 +7,34 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/cli/util/exitcode"
+	"github.com/agntcy/dir/client"
 	"github.com/spf13/cobra"
 )
 
-func init() {
-	// Add output format flags
-	presenter.AddOutputFlags(Command)
+type options struct {
+	RekorURL       string
+	RekorPublicKey string
+	Offline        bool
+	PublicKey      string
 }
 
+// NewCommand creates the verify command.
+//
 //nolint:mnd
-var Command = &cobra.Command{
-	Use:   "verify",
-	Short: "Verify record signature against identity-based OIDC or key-based signing",
-	Long: `This command verifies the record signature against
+func NewCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify record signature against identity-based OIDC or key-based signing",
+		Long: `This command verifies the record signature against
 identity-based OIDC or key-based signing process.
 
 Usage examples:
@@ -32,40 +42,129 @@ Usage examples:
 1. Verify a record from file:
 
 	dirctl verify <record-cid>
+
+2. Verify a keyless signed record and its inclusion in the Rekor transparency log:
+
+	dirctl verify <record-cid> --rekor-url https://rekor.sigstage.dev
+
+   The public keys of the default Rekor log are obtained from the Sigstore TUF root.
+   Other logs require their trusted public key:
+
+	dirctl verify <record-cid> --rekor-url https://rekor.example.com --rekor-public-key rekor.pub
+
+3. Verify a key-based signature locally against a trusted public key,
+   without server-side verification or a Zot registry:
+
+	dirctl verify <record-cid> --offline --public-key cosign.pub
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		var recordRef string
-		if len(args) > 1 {
-			return errors.New("one argument is allowed")
-		} else if len(args) == 1 {
-			recordRef = args[0]
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var recordRef string
+			if len(args) > 1 {
+				return errors.New("one argument is allowed")
+			} else if len(args) == 1 {
+				recordRef = args[0]
+			}
+
+			return runCommand(cmd, recordRef, opts)
+		},
+	}
 
-		return runCommand(cmd, recordRef)
-	},
+	cmd.Flags().StringVar(&opts.RekorURL, "rekor-url", "",
+		"Also require the signature to be included in the Rekor transparency log at this URL")
+	cmd.Flags().StringVar(&opts.RekorPublicKey, "rekor-public-key", "",
+		"Path to the trusted public key of the Rekor transparency log, required for logs other than the default one")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false,
+		"Verify the signature locally against --public-key, without server-side verification")
+	cmd.Flags().StringVar(&opts.PublicKey, "public-key", "",
+		"Path to the trusted public key to verify the signature against with --offline")
+
+	// Add output format flags
+	presenter.AddOutputFlags(cmd)
+
+	return cmd
 }
 
 // nolint:mnd
-func runCommand(cmd *cobra.Command, recordRef string) error {
+func runCommand(cmd *cobra.Command, recordRef string, opts *options) error {
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
 		return errors.New("failed to get client from context")
 	}
 
-	response, err := c.Verify(cmd.Context(), &signv1.VerifyRequest{
+	if opts.Offline || opts.PublicKey != "" {
+		return runOfflineCommand(cmd, c, recordRef, opts)
+	}
+
+	req := &signv1.VerifyRequest{
 		RecordRef: &corev1.RecordRef{
 			Cid: recordRef,
 		},
-	})
+	}
+
+	if opts.RekorURL != "" {
+		req.RekorUrl = &opts.RekorURL
+	}
+
+	var rekorPublicKey []byte
+
+	if opts.RekorPublicKey != "" {
+		if opts.RekorURL == "" {
+			return exitcode.Invalid(errors.New("--rekor-public-key requires --rekor-url"))
+		}
+
+		var err error
+
+		rekorPublicKey, err = os.ReadFile(opts.RekorPublicKey)
+		if err != nil {
+			return exitcode.Invalid(fmt.Errorf("failed to read rekor public key: %w", err))
+		}
+	}
+
+	response, err := c.VerifyWithRekorPublicKey(cmd.Context(), req, rekorPublicKey)
 	if err != nil {
 		return fmt.Errorf("failed to verify record with Zot: %w", err)
 	}
 
 	// Output in the appropriate format
+	status := "trusted"
+
+	switch {
+	case !response.GetSuccess():
+		status = "not trusted"
+	case response.GetRekorVerified():
+		status = "trusted and included in the Rekor transparency log"
+	}
+
+	return presenter.PrintMessage(cmd, "signature", "Record signature is", status)
+}
+
+// runOfflineCommand verifies the record signature on the client against the trusted public key.
+func runOfflineCommand(cmd *cobra.Command, c *client.Client, recordRef string, opts *options) error {
+	switch {
+	case !opts.Offline:
+		return exitcode.Invalid(errors.New("--public-key requires --offline"))
+	case opts.PublicKey == "":
+		return exitcode.Invalid(errors.New("--offline requires --public-key"))
+	case opts.RekorURL != "":
+		return exitcode.Invalid(errors.New("--offline cannot be combined with --rekor-url"))
+	}
+
+	publicKey, err := os.ReadFile(opts.PublicKey)
+	if err != nil {
+		return exitcode.Invalid(fmt.Errorf("failed to read public key: %w", err))
+	}
+
+	response, err := c.VerifyWithPublicKey(cmd.Context(), recordRef, publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify record: %w", err)
+	}
+
 	status := "trusted"
 	if !response.GetSuccess() {
 		status = "not trusted"
+
+		presenter.Infof(cmd, "Verification failed: %s\n", response.GetErrorMessage())
 	}
 
 	return presenter.PrintMessage(cmd, "signature", "Record signature is", status)
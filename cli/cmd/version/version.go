@@ -9,10 +9,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var Command = &cobra.Command{
-	Use:   "version",
-	Short: "Print the version of the application",
-	Run: func(cmd *cobra.Command, _ []string) {
-		presenter.Print(cmd, "Application Version: ", version.String())
-	},
+// NewCommand creates the version command.
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version of the application",
+		Run: func(cmd *cobra.Command, _ []string) {
+			presenter.Print(cmd, "Application Version: ", version.String())
+		},
+	}
 }
@@ -19,9 +19,15 @@ const (
 	FormatRaw   OutputFormat = "raw"
 )
 
+// QuietFlag is the name of the persistent flag suppressing decorative output.
+const QuietFlag = "quiet"
+
 // OutputOptions holds the output formatting options.
 type OutputOptions struct {
 	Format OutputFormat
+
+	// Quiet suppresses decorative output, so that only essential results are printed.
+	Quiet bool
 }
 
 // GetOutputOptions extracts output format options from command flags.
@@ -40,9 +46,20 @@ func GetOutputOptions(cmd *cobra.Command) OutputOptions {
 		opts.Format = FormatRaw
 	}
 
+	// Check for the inherited --quiet flag
+	if quietFlag, _ := cmd.Flags().GetBool(QuietFlag); quietFlag {
+		opts.Quiet = true
+	}
+
 	return opts
 }
 
+// AddQuietFlag adds the persistent --quiet flag to a command and its subcommands.
+func AddQuietFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP(QuietFlag, "q", false,
+		"Only print essential results, e.g. the CID of a pushed record, without messages, warnings or progress")
+}
+
 // AddOutputFlags adds standard --json and --raw flags to a command.
 func AddOutputFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("json", false, "Output results in JSON format")
@@ -53,6 +70,12 @@ func AddOutputFlags(cmd *cobra.Command) {
 func PrintMessage(cmd *cobra.Command, title, message string, value any) error {
 	opts := GetOutputOptions(cmd)
 
+	if opts.Quiet && opts.Format == FormatHuman {
+		printQuiet(cmd, value)
+
+		return nil
+	}
+
 	// Handle empty case for multiple values
 	if value == nil {
 		Println(cmd, fmt.Sprintf("No %s found", title))
@@ -94,3 +117,21 @@ func PrintMessage(cmd *cobra.Command, title, message string, value any) error {
 
 	return nil
 }
+
+// printQuiet prints only the value, one item per line for multiple values.
+// Nothing is printed for empty values.
+func printQuiet(cmd *cobra.Command, value any) {
+	if value == nil {
+		return
+	}
+
+	if slice, ok := value.([]interface{}); ok {
+		for _, item := range slice {
+			Println(cmd, fmt.Sprintf("%v", item))
+		}
+
+		return
+	}
+
+	Println(cmd, fmt.Sprintf("%v", value))
+}
@@ -28,3 +28,12 @@ func Error(cmd *cobra.Command, args ...interface{}) {
 func Errorf(cmd *cobra.Command, format string, args ...interface{}) {
 	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), format, args...)
 }
+
+// Infof prints an informational message or warning to stderr, unless --quiet is set.
+func Infof(cmd *cobra.Command, format string, args ...interface{}) {
+	if GetOutputOptions(cmd).Quiet {
+		return
+	}
+
+	Errorf(cmd, format, args...)
+}
@@ -0,0 +1,195 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presenter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ProgressUnit is the unit of the values reported to a Progress.
+type ProgressUnit string
+
+const (
+	ProgressBytes   ProgressUnit = "bytes"
+	ProgressRecords ProgressUnit = "records"
+)
+
+const (
+	// ProgressLogInterval is the interval between progress lines when stderr is not a terminal.
+	ProgressLogInterval = 5 * time.Second
+
+	progressBarWidth = 30
+)
+
+// Progress reports the progress of a long-running operation on stderr.
+// On a terminal, a progress bar is redrawn in place and cleared once done.
+// Otherwise, a progress line is logged at most every ProgressLogInterval,
+// so that the output of scripts and CI jobs stays readable.
+// Nothing is reported unless the output format is human-readable, or with --quiet.
+// A Progress is safe for concurrent use.
+type Progress struct {
+	mu sync.Mutex
+
+	w       io.Writer
+	label   string
+	unit    ProgressUnit
+	current int64
+	total   int64
+
+	enabled bool
+	tty     bool
+	drawn   bool
+	lastLog time.Time
+}
+
+// NewProgress creates a progress reporter for the command.
+// The total may be zero if it is not known in advance.
+func NewProgress(cmd *cobra.Command, label string, unit ProgressUnit, total int64) *Progress {
+	w := cmd.ErrOrStderr()
+	outputOpts := GetOutputOptions(cmd)
+
+	return &Progress{
+		w:       w,
+		label:   label,
+		unit:    unit,
+		total:   total,
+		enabled: outputOpts.Format == FormatHuman && !outputOpts.Quiet,
+		tty:     isTerminal(w),
+		lastLog: time.Now(),
+	}
+}
+
+// Set updates the current and total values.
+func (p *Progress) Set(current, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = current
+	p.total = total
+
+	p.report()
+}
+
+// Add adds n to the current value.
+func (p *Progress) Add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current += n
+
+	p.report()
+}
+
+// Done ends the progress report.
+// The progress bar is cleared, and the final progress is logged if progress lines were logged before.
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.enabled || !p.drawn {
+		return
+	}
+
+	if p.tty {
+		_, _ = fmt.Fprint(p.w, "\r\033[K")
+	} else {
+		_, _ = fmt.Fprintln(p.w, p.line())
+	}
+
+	p.drawn = false
+}
+
+func (p *Progress) report() {
+	if !p.enabled {
+		return
+	}
+
+	if p.tty {
+		_, _ = fmt.Fprintf(p.w, "\r\033[K%s", p.bar())
+		p.drawn = true
+
+		return
+	}
+
+	if now := time.Now(); now.Sub(p.lastLog) >= ProgressLogInterval {
+		_, _ = fmt.Fprintln(p.w, p.line())
+		p.lastLog = now
+		p.drawn = true
+	}
+}
+
+// bar renders the progress as a bar, e.g. "Pushing [=======>      ] 50% 1.2 MiB/2.4 MiB".
+func (p *Progress) bar() string {
+	if p.total <= 0 {
+		return fmt.Sprintf("%s %s", p.label, p.values())
+	}
+
+	filled := int(min(p.current, p.total) * progressBarWidth / p.total)
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	return fmt.Sprintf("%s [%s] %3d%% %s", p.label, bar, p.percent(), p.values())
+}
+
+// line renders the progress as a log line, e.g. "Pushing: 50% (1.2 MiB/2.4 MiB)".
+func (p *Progress) line() string {
+	if p.total <= 0 {
+		return fmt.Sprintf("%s: %s", p.label, p.values())
+	}
+
+	return fmt.Sprintf("%s: %d%% (%s)", p.label, p.percent(), p.values())
+}
+
+func (p *Progress) percent() int64 {
+	return min(p.current, p.total) * 100 / p.total //nolint:mnd
+}
+
+// values renders the current and total values, e.g. "1.2 MiB/2.4 MiB" or "3/10 records".
+func (p *Progress) values() string {
+	switch {
+	case p.unit == ProgressBytes && p.total > 0:
+		return formatBytes(p.current) + "/" + formatBytes(p.total)
+	case p.unit == ProgressBytes:
+		return formatBytes(p.current)
+	case p.total > 0:
+		return fmt.Sprintf("%d/%d %s", p.current, p.total, p.unit)
+	default:
+		return fmt.Sprintf("%d %s", p.current, p.unit)
+	}
+}
+
+// formatBytes formats a size in bytes using binary units, e.g. "1.5 MiB".
+func formatBytes(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd())) //nolint:gosec // file descriptors fit in an int
+}
@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presenter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("terminal", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		progress := &Progress{w: &buf, label: "Pushing", unit: ProgressBytes, enabled: true, tty: true}
+
+		progress.Set(512, 2048)
+		progress.Add(512)
+
+		// The bar is redrawn in place on each update
+		expected := "\r\033[KPushing [=======>                      ]  25% 512 B/2.0 KiB" +
+			"\r\033[KPushing [===============>              ]  50% 1.0 KiB/2.0 KiB"
+		if buf.String() != expected {
+			t.Fatalf("unexpected output %q, expected %q", buf.String(), expected)
+		}
+
+		// The bar is cleared once done
+		buf.Reset()
+		progress.Done()
+
+		if buf.String() != "\r\033[K" {
+			t.Fatalf("expected the bar to be cleared, got %q", buf.String())
+		}
+	})
+
+	t.Run("terminal without total", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		progress := &Progress{w: &buf, label: "Importing", unit: ProgressRecords, enabled: true, tty: true}
+		progress.Add(3)
+
+		if buf.String() != "\r\033[KImporting 3 records" {
+			t.Fatalf("unexpected output %q", buf.String())
+		}
+	})
+
+	t.Run("log lines", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		progress := &Progress{
+			w:       &buf,
+			label:   "Exporting",
+			unit:    ProgressRecords,
+			total:   10,
+			enabled: true,
+			lastLog: time.Now().Add(-ProgressLogInterval),
+		}
+
+		progress.Add(3)
+
+		// Lines are logged at most every ProgressLogInterval
+		progress.Add(2)
+
+		if buf.String() != "Exporting: 30% (3/10 records)\n" {
+			t.Fatalf("unexpected output %q", buf.String())
+		}
+
+		// The final progress is logged once done
+		buf.Reset()
+		progress.Done()
+
+		if buf.String() != "Exporting: 50% (5/10 records)\n" {
+			t.Fatalf("unexpected output %q", buf.String())
+		}
+	})
+
+	t.Run("short operations", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		progress := &Progress{w: &buf, label: "Pulling", unit: ProgressBytes, enabled: true, lastLog: time.Now()}
+
+		progress.Add(100)
+		progress.Done()
+
+		// Nothing is logged for operations shorter than ProgressLogInterval
+		if buf.Len() != 0 {
+			t.Fatalf("expected no output, got %q", buf.String())
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		progress := &Progress{w: &buf, label: "Pushing", unit: ProgressBytes, tty: true}
+
+		progress.Set(1, 2)
+		progress.Done()
+
+		if buf.Len() != 0 {
+			t.Fatalf("expected no output, got %q", buf.String())
+		}
+	})
+
+	t.Run("current above total", func(t *testing.T) {
+		progress := &Progress{label: "Pushing", unit: ProgressBytes, current: 3000, total: 2048}
+
+		if bar := progress.bar(); !strings.Contains(bar, "[==============================] 100%") {
+			t.Fatalf("expected a full bar, got %q", bar)
+		}
+	})
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{size: 0, expected: "0 B"},
+		{size: 1023, expected: "1023 B"},
+		{size: 1024, expected: "1.0 KiB"},
+		{size: 1536, expected: "1.5 KiB"},
+		{size: 5 * 1024 * 1024, expected: "5.0 MiB"},
+		{size: 3 << 30, expected: "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.size); got != tt.expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", tt.size, got, tt.expected)
+		}
+	}
+}
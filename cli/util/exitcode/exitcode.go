@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exitcode defines the exit codes of dirctl, so that scripts can tell failures apart.
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/client"
+)
+
+// Exit codes of dirctl.
+const (
+	// Success is returned when the command succeeded.
+	Success = 0
+	// Error is returned for failures without a more specific exit code.
+	Error = 1
+	// NotFound is returned when the requested record or object does not exist.
+	NotFound = 2
+	// Validation is returned when the flags, arguments or record data are invalid,
+	// or when the server rejects the request arguments.
+	Validation = 3
+)
+
+// ErrValidation is matched by validation errors with errors.Is.
+var ErrValidation = errors.New("validation error")
+
+// validationError is a validation error keeping the message of the original error.
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *validationError) Unwrap() []error {
+	return []error{ErrValidation, e.err}
+}
+
+// Invalid marks an error as a validation error, returning the Validation exit code.
+func Invalid(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &validationError{err: err}
+}
+
+// Invalidf formats a validation error.
+func Invalidf(format string, args ...any) error {
+	return Invalid(fmt.Errorf(format, args...))
+}
+
+// Code returns the exit code for an error returned by a command.
+func Code(err error) int {
+	switch {
+	case err == nil:
+		return Success
+	case errors.Is(err, client.ErrNotFound):
+		return NotFound
+	case errors.Is(err, ErrValidation), errors.Is(err, client.ErrInvalidArgument):
+		return Validation
+	default:
+		return Error
+	}
+}
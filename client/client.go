@@ -5,6 +5,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
@@ -12,7 +13,6 @@ import (
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
-	"google.golang.org/grpc"
 )
 
 type Client struct {
@@ -22,8 +22,11 @@ type Client struct {
 	storev1.SyncServiceClient
 	signv1.SignServiceClient
 
-	config     *Config
-	authClient *workloadapi.Client
+	config           *Config
+	authClient       *workloadapi.Client
+	conns            *connPool
+	meter            *transferMeter
+	batchConcurrency int
 }
 
 func New(opts ...Option) (*Client, error) {
@@ -38,10 +41,13 @@ func New(opts ...Option) (*Client, error) {
 		}
 	}
 
-	// Create client
-	client, err := grpc.NewClient(options.config.ServerAddress, options.authOpts...)
+	// Create client connections
+	meter := &transferMeter{}
+	dialOpts := append(options.authOpts, options.connOpts(meter)...) //nolint:gocritic
+
+	client, err := newConnPool(options.config.ServerAddress, options.connPoolSize(), dialOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+		return nil, err
 	}
 
 	return &Client{
@@ -52,15 +58,24 @@ func New(opts ...Option) (*Client, error) {
 		SignServiceClient:    signv1.NewSignServiceClient(client),
 		config:               options.config,
 		authClient:           options.authClient,
+		conns:                client,
+		meter:                meter,
+		batchConcurrency:     options.batchConcurrencyLimit(),
 	}, nil
 }
 
 func (c *Client) Close() error {
+	var errs error
+
+	// Close server connections
+	if c.conns != nil {
+		errs = errors.Join(errs, c.conns.Close())
+	}
+
 	// Close auth client if it exists
 	if c.authClient != nil {
-		//nolint:wrapcheck
-		return c.authClient.Close()
+		errs = errors.Join(errs, c.authClient.Close())
 	}
 
-	return nil
+	return errs
 }
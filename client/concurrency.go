@@ -0,0 +1,28 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import "context"
+
+type concurrencyContextKey struct{}
+
+// WithConcurrency returns a context overriding the number of concurrent requests
+// of the batch operations made with it, such as LookupMany.
+// Values are capped at MaxBatchConcurrency; zero or negative values keep the client setting.
+func WithConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, concurrencyContextKey{}, n)
+}
+
+// concurrency returns the number of concurrent requests of a batch operation.
+func (c *Client) concurrency(ctx context.Context) int {
+	if n, ok := ctx.Value(concurrencyContextKey{}).(int); ok && n > 0 {
+		return min(n, MaxBatchConcurrency)
+	}
+
+	if c.batchConcurrency > 0 {
+		return c.batchConcurrency
+	}
+
+	return DefaultBatchConcurrency
+}
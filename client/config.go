@@ -6,6 +6,7 @@ package client
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -15,17 +16,46 @@ const (
 	DefaultEnvPrefix = "DIRECTORY_CLIENT"
 
 	DefaultServerAddress = "0.0.0.0:8888"
+
+	// DefaultKeepaliveTime disables keepalive pings by default.
+	// When enabled, it should not be lower than the server's minimum ping interval (5m by default).
+	DefaultKeepaliveTime = 0
+
+	// DefaultKeepaliveTimeout is the time to wait for a keepalive ping ack before closing the connection.
+	DefaultKeepaliveTimeout = 20 * time.Second
+
+	// DefaultMaxMessageSize matches the 4MB record limit of the store service.
+	DefaultMaxMessageSize = 4 * 1024 * 1024
+
+	// DefaultPoolSize uses a single connection to the server.
+	DefaultPoolSize = 1
+
+	// DefaultBatchConcurrency is the default number of concurrent requests of batch operations.
+	// It is kept low, as each request may reach a remote registry that rate-limits clients.
+	DefaultBatchConcurrency = 8
+
+	// MaxBatchConcurrency is the maximum number of concurrent requests of batch operations.
+	MaxBatchConcurrency = 64
 )
 
 var DefaultConfig = Config{
-	ServerAddress: DefaultServerAddress,
+	ServerAddress:    DefaultServerAddress,
+	KeepaliveTimeout: DefaultKeepaliveTimeout,
+	MaxMessageSize:   DefaultMaxMessageSize,
+	PoolSize:         DefaultPoolSize,
+	BatchConcurrency: DefaultBatchConcurrency,
 }
 
 type Config struct {
-	ServerAddress    string `json:"server_address,omitempty"     mapstructure:"server_address"`
-	SpiffeSocketPath string `json:"spiffe_socket_path,omitempty" mapstructure:"spiffe_socket_path"`
-	AuthMode         string `json:"auth_mode,omitempty"          mapstructure:"auth_mode"`
-	JWTAudience      string `json:"jwt_audience,omitempty"       mapstructure:"jwt_audience"`
+	ServerAddress    string        `json:"server_address,omitempty"     mapstructure:"server_address"`
+	SpiffeSocketPath string        `json:"spiffe_socket_path,omitempty" mapstructure:"spiffe_socket_path"`
+	AuthMode         string        `json:"auth_mode,omitempty"          mapstructure:"auth_mode"`
+	JWTAudience      string        `json:"jwt_audience,omitempty"       mapstructure:"jwt_audience"`
+	KeepaliveTime    time.Duration `json:"keepalive_time,omitempty"     mapstructure:"keepalive_time"`
+	KeepaliveTimeout time.Duration `json:"keepalive_timeout,omitempty"  mapstructure:"keepalive_timeout"`
+	MaxMessageSize   int           `json:"max_message_size,omitempty"   mapstructure:"max_message_size"`
+	PoolSize         int           `json:"pool_size,omitempty"          mapstructure:"pool_size"`
+	BatchConcurrency int           `json:"batch_concurrency,omitempty"  mapstructure:"batch_concurrency"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -50,6 +80,21 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("jwt_audience")
 	v.SetDefault("jwt_audience", "")
 
+	_ = v.BindEnv("keepalive_time")
+	v.SetDefault("keepalive_time", DefaultKeepaliveTime)
+
+	_ = v.BindEnv("keepalive_timeout")
+	v.SetDefault("keepalive_timeout", DefaultKeepaliveTimeout)
+
+	_ = v.BindEnv("max_message_size")
+	v.SetDefault("max_message_size", DefaultMaxMessageSize)
+
+	_ = v.BindEnv("pool_size")
+	v.SetDefault("pool_size", DefaultPoolSize)
+
+	_ = v.BindEnv("batch_concurrency")
+	v.SetDefault("batch_concurrency", DefaultBatchConcurrency)
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
@@ -0,0 +1,105 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error kinds returned by client operations.
+// Use errors.Is to check the kind of an error returned by the client.
+var (
+	// ErrNotFound is returned when the requested record or object does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists is returned when the object being created already exists.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrInvalidArgument is returned when the server rejects the request arguments.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrInvalidCID is returned when a record reference does not hold a valid CID.
+	// Errors of this kind also match ErrInvalidArgument.
+	ErrInvalidCID = errors.New("invalid CID")
+
+	// ErrUnauthenticated is returned when the client could not be authenticated.
+	ErrUnauthenticated = errors.New("unauthenticated")
+
+	// ErrPermissionDenied is returned when the client is not allowed to perform the operation.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Error is an error returned by the server, classified by kind.
+// It matches both its kind and the original error with errors.Is.
+type Error struct {
+	// Kind is one of the Err* error kinds.
+	Kind error
+
+	// Code is the gRPC status code returned by the server.
+	Code codes.Code
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.err}
+}
+
+// validateRecordRef checks that the record reference holds a valid CID before it is sent to the server.
+func validateRecordRef(recordRef *corev1.RecordRef) error {
+	if corev1.IsValidCID(recordRef.GetCid()) {
+		return nil
+	}
+
+	return &Error{
+		Kind: ErrInvalidArgument,
+		Code: codes.InvalidArgument,
+		err:  fmt.Errorf("%w: %q", ErrInvalidCID, recordRef.GetCid()),
+	}
+}
+
+// fromStatus classifies a gRPC status error into an Error by its status code.
+// Errors without a known kind are returned unchanged.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var kind error
+
+	switch st.Code() { //nolint:exhaustive
+	case codes.NotFound:
+		kind = ErrNotFound
+	case codes.AlreadyExists:
+		kind = ErrAlreadyExists
+	case codes.InvalidArgument:
+		kind = ErrInvalidArgument
+	case codes.Unauthenticated:
+		kind = ErrUnauthenticated
+	case codes.PermissionDenied:
+		kind = ErrPermissionDenied
+	default:
+		return err
+	}
+
+	return &Error{
+		Kind: kind,
+		Code: st.Code(),
+		err:  err,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{name: "not found", err: status.Error(codes.NotFound, "record not found"), kind: ErrNotFound},
+		{name: "already exists", err: status.Error(codes.AlreadyExists, "sync exists"), kind: ErrAlreadyExists},
+		{name: "invalid argument", err: status.Error(codes.InvalidArgument, "invalid cid"), kind: ErrInvalidArgument},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "no token"), kind: ErrUnauthenticated},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "denied"), kind: ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fromStatus(tt.err)
+
+			if !errors.Is(err, tt.kind) || !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v to match %v and the original error", err, tt.kind)
+			}
+
+			var clientErr *Error
+			if !errors.As(err, &clientErr) || clientErr.Code != status.Code(tt.err) {
+				t.Fatalf("expected a client error with code %s, got %v", status.Code(tt.err), err)
+			}
+		})
+	}
+
+	// Errors without a known kind are returned unchanged
+	for _, err := range []error{status.Error(codes.Internal, "boom"), errors.New("plain")} {
+		if got := fromStatus(err); got != err { //nolint:errorlint
+			t.Fatalf("expected %v to be returned unchanged, got %v", err, got)
+		}
+	}
+
+	if fromStatus(nil) != nil {
+		t.Fatal("expected nil for a nil error")
+	}
+}
+
+func TestInvalidCID(t *testing.T) {
+	// The client is not connected, invalid CIDs are rejected before contacting the server
+	c := &Client{}
+
+	for _, ref := range []*corev1.RecordRef{nil, {}, {Cid: "not-a-cid"}} {
+		_, pullErr := c.Pull(t.Context(), ref)
+		_, lookupErr := c.Lookup(t.Context(), ref)
+		deleteErr := c.Delete(t.Context(), ref)
+
+		for _, err := range []error{pullErr, lookupErr, deleteErr} {
+			if !errors.Is(err, ErrInvalidCID) || !errors.Is(err, ErrInvalidArgument) {
+				t.Fatalf("expected %v to match ErrInvalidCID and ErrInvalidArgument for %q", err, ref.GetCid())
+			}
+
+			var clientErr *Error
+			if !errors.As(err, &clientErr) || clientErr.Code != codes.InvalidArgument {
+				t.Fatalf("expected a client error with code %s, got %v", codes.InvalidArgument, err)
+			}
+		}
+	}
+
+	digest, err := corev1.CalculateDigest([]byte("record"))
+	if err != nil {
+		t.Fatalf("failed to calculate digest: %v", err)
+	}
+
+	cid, err := corev1.ConvertDigestToCID(digest)
+	if err != nil {
+		t.Fatalf("failed to convert digest: %v", err)
+	}
+
+	if err := validateRecordRef(&corev1.RecordRef{Cid: cid}); err != nil {
+		t.Fatalf("unexpected error for a valid CID: %v", err)
+	}
+}
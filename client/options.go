@@ -7,21 +7,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 type Option func(*options) error
 
 // TODO: options need to be granular per key rather than for full config.
 type options struct {
-	config     *Config
-	authOpts   []grpc.DialOption
-	authClient *workloadapi.Client
+	config         *Config
+	authOpts       []grpc.DialOption
+	authClient     *workloadapi.Client
+	transportCreds credentials.TransportCredentials
+
+	// Connection overrides, applied on top of the config
+	keepaliveTime    time.Duration
+	keepaliveTimeout time.Duration
+	maxMessageSize   int
+	poolSize         int
+	batchConcurrency int
 }
 
 func WithEnvConfig() Option {
@@ -42,11 +53,117 @@ func WithConfig(config *Config) Option {
 	}
 }
 
+// WithKeepalive enables keepalive pings sent every interval on idle connections.
+// The connection is closed if a ping is not acknowledged within timeout.
+// The interval should not be lower than the server's minimum ping interval (5m by default).
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(opts *options) error {
+		if interval <= 0 || timeout <= 0 {
+			return errors.New("keepalive interval and timeout must be positive")
+		}
+
+		opts.keepaliveTime = interval
+		opts.keepaliveTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithMaxMessageSize sets the maximum size in bytes of messages sent and received.
+func WithMaxMessageSize(size int) Option {
+	return func(opts *options) error {
+		if size <= 0 {
+			return errors.New("max message size must be positive")
+		}
+
+		opts.maxMessageSize = size
+
+		return nil
+	}
+}
+
+// WithConnectionPool spreads RPCs over size connections to the server,
+// so that concurrent streams do not share a single connection.
+func WithConnectionPool(size int) Option {
+	return func(opts *options) error {
+		if size <= 0 {
+			return errors.New("connection pool size must be positive")
+		}
+
+		opts.poolSize = size
+
+		return nil
+	}
+}
+
+// WithBatchConcurrency sets the number of concurrent requests of batch operations such as LookupMany.
+// It can be overridden per call with WithConcurrency.
+func WithBatchConcurrency(n int) Option {
+	return func(opts *options) error {
+		if n <= 0 || n > MaxBatchConcurrency {
+			return fmt.Errorf("batch concurrency must be between 1 and %d", MaxBatchConcurrency)
+		}
+
+		opts.batchConcurrency = n
+
+		return nil
+	}
+}
+
+// connOpts returns the connection dial options from the config and overrides.
+// The bytes going over the connections are counted with meter to report the progress of transfers.
+func (o *options) connOpts(meter *transferMeter) []grpc.DialOption {
+	keepaliveTime := firstPositive(o.keepaliveTime, o.config.KeepaliveTime)
+	keepaliveTimeout := firstPositive(o.keepaliveTimeout, o.config.KeepaliveTimeout, DefaultKeepaliveTimeout)
+	maxMessageSize := firstPositive(o.maxMessageSize, o.config.MaxMessageSize, DefaultMaxMessageSize)
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize),
+		),
+		grpc.WithTransportCredentials(&meteredCredentials{
+			TransportCredentials: o.transportCreds,
+			meter:                meter,
+		}),
+	}
+
+	if keepaliveTime > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}))
+	}
+
+	return dialOpts
+}
+
+// connPoolSize returns the number of connections to open.
+func (o *options) connPoolSize() int {
+	return firstPositive(o.poolSize, o.config.PoolSize, DefaultPoolSize)
+}
+
+// batchConcurrencyLimit returns the number of concurrent requests of batch operations.
+func (o *options) batchConcurrencyLimit() int {
+	return min(firstPositive(o.batchConcurrency, o.config.BatchConcurrency, DefaultBatchConcurrency), MaxBatchConcurrency)
+}
+
+// firstPositive returns the first positive value, or zero if there is none.
+func firstPositive[T int | time.Duration](values ...T) T {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+
+	return 0
+}
+
 func withAuth(ctx context.Context) Option {
 	return func(o *options) error {
 		// Use insecure access in case SpiffeSocketPath is not set or no auth mode specified
 		if o.config.SpiffeSocketPath == "" || o.config.AuthMode == "" {
-			o.authOpts = append(o.authOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			o.transportCreds = insecure.NewCredentials()
 
 			return nil
 		}
@@ -99,12 +216,8 @@ func (o *options) setupJWTAuth(ctx context.Context, client *workloadapi.Client)
 
 	// Use TLS for transport security (server presents X.509-SVID)
 	// Client authenticates with JWT-SVID via PerRPCCredentials
-	o.authOpts = append(o.authOpts,
-		grpc.WithTransportCredentials(
-			grpccredentials.TLSClientCredentials(bundleSrc, tlsconfig.AuthorizeAny()),
-		),
-		grpc.WithPerRPCCredentials(newJWTCredentials(jwtSource, o.config.JWTAudience)),
-	)
+	o.transportCreds = grpccredentials.TLSClientCredentials(bundleSrc, tlsconfig.AuthorizeAny())
+	o.authOpts = append(o.authOpts, grpc.WithPerRPCCredentials(newJWTCredentials(jwtSource, o.config.JWTAudience)))
 
 	return nil
 }
@@ -127,9 +240,7 @@ func (o *options) setupX509Auth(ctx context.Context, client *workloadapi.Client)
 	}
 
 	// Add auth options to the client
-	o.authOpts = append(o.authOpts, grpc.WithTransportCredentials(
-		grpccredentials.MTLSClientCredentials(x509Src, bundleSrc, tlsconfig.AuthorizeAny()),
-	))
+	o.transportCreds = grpccredentials.MTLSClientCredentials(x509Src, bundleSrc, tlsconfig.AuthorizeAny())
 
 	return nil
 }
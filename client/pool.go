@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// connPool spreads RPCs over multiple connections to the same server in round-robin order.
+// Each stream stays on the connection it was created on.
+type connPool struct {
+	conns []*grpc.ClientConn
+	next  atomic.Uint64
+}
+
+var _ grpc.ClientConnInterface = (*connPool)(nil)
+
+// newConnPool opens size connections to the target.
+func newConnPool(target string, size int, dialOpts ...grpc.DialOption) (*connPool, error) {
+	pool := &connPool{
+		conns: make([]*grpc.ClientConn, 0, size),
+	}
+
+	for range size {
+		conn, err := grpc.NewClient(target, dialOpts...)
+		if err != nil {
+			_ = pool.Close()
+
+			return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+		}
+
+		pool.conns = append(pool.conns, conn)
+	}
+
+	return pool, nil
+}
+
+func (p *connPool) pick() *grpc.ClientConn {
+	n := p.next.Add(1) - 1
+
+	return p.conns[n%uint64(len(p.conns))]
+}
+
+func (p *connPool) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	//nolint:wrapcheck
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *connPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	//nolint:wrapcheck
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Close closes all connections in the pool.
+func (p *connPool) Close() error {
+	var errs error
+
+	for _, conn := range p.conns {
+		errs = errors.Join(errs, conn.Close())
+	}
+
+	return errs
+}
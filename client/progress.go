@@ -0,0 +1,249 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
+)
+
+// Progress is the progress of a push or pull of records.
+type Progress struct {
+	// Records is the number of records transferred so far.
+	Records int
+	// TotalRecords is the number of records to transfer.
+	TotalRecords int
+	// Bytes is the size of the records transferred so far, including the records in transfer.
+	Bytes int64
+	// TotalBytes is the size of the records to transfer.
+	// It is only known for pushes, and zero for pulls.
+	TotalBytes int64
+}
+
+// ProgressFunc is called with the progress of a push or pull once started,
+// as the records go over the connection, and after each transferred record.
+type ProgressFunc func(Progress)
+
+type progressContextKey struct{}
+
+// WithProgress returns a context reporting the progress of the pushes and pulls made with it to fn,
+// e.g. to show a progress bar for large records or batches.
+// fn may be called from the goroutines of the client connections, but never concurrently.
+// The bytes in transfer are counted on the connections of the client,
+// so they are approximate while other requests are made with the same client.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, fn)
+}
+
+// progressTracker tracks the progress of a push or pull and reports it to the context callback.
+type progressTracker struct {
+	mu       sync.Mutex
+	fn       ProgressFunc
+	progress Progress
+
+	// Whether the records are sent rather than received
+	push bool
+
+	// Size of the transferred records, and bytes that went over the connection
+	// since the last transferred record
+	settled  int64
+	inflight int64
+}
+
+// newProgressTracker returns a tracker for the given number of records to push or pull.
+// It returns nil if the context has no progress callback.
+func newProgressTracker(ctx context.Context, totalRecords int, push bool) *progressTracker {
+	fn, ok := ctx.Value(progressContextKey{}).(ProgressFunc)
+	if !ok || fn == nil {
+		return nil
+	}
+
+	return &progressTracker{
+		fn:   fn,
+		push: push,
+		progress: Progress{
+			TotalRecords: totalRecords,
+		},
+	}
+}
+
+// start reports the initial progress, before any record is transferred.
+func (t *progressTracker) start() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fn(t.progress)
+}
+
+// transfer reports n bytes of records in transfer.
+// Pushes never report more than the total size, as the bytes include the framing of the connection.
+func (t *progressTracker) transfer(n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inflight += n
+
+	bytes := t.settled + t.inflight
+	if t.push {
+		bytes = min(bytes, t.progress.TotalBytes)
+	}
+
+	if bytes <= t.progress.Bytes {
+		return
+	}
+
+	t.progress.Bytes = bytes
+
+	t.fn(t.progress)
+}
+
+// add reports a transferred record of the given size.
+func (t *progressTracker) add(size int64) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress.Records++
+	t.settled += size
+
+	if t.push {
+		// Pushed records are acknowledged once sent, so the bytes sent since may belong to the next records
+		t.progress.Bytes = max(t.progress.Bytes, t.settled)
+	} else {
+		// Pulled records are only sized once received, so the size replaces the bytes read for them
+		t.progress.Bytes = t.settled
+		t.inflight = 0
+	}
+
+	t.fn(t.progress)
+}
+
+// setRecords sets the total size of the records to push and returns the wire size of each record.
+func (t *progressTracker) setRecords(records []*corev1.Record) []int64 {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sizes := make([]int64, len(records))
+
+	for i, record := range records {
+		sizes[i] = int64(proto.Size(record))
+		t.progress.TotalBytes += sizes[i]
+	}
+
+	return sizes
+}
+
+// transferMeter counts the bytes going over the connections of a client,
+// and reports them to the trackers of the ongoing pushes and pulls.
+type transferMeter struct {
+	mu       sync.Mutex
+	trackers map[*progressTracker]struct{}
+}
+
+// track reports the bytes going over the connections to the tracker until the returned function is called.
+func (m *transferMeter) track(t *progressTracker) func() {
+	if m == nil || t == nil {
+		return func() {}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.trackers == nil {
+		m.trackers = make(map[*progressTracker]struct{})
+	}
+
+	m.trackers[t] = struct{}{}
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.trackers, t)
+	}
+}
+
+// count reports n bytes sent or received to the trackers of the pushes or pulls respectively.
+func (m *transferMeter) count(n int, sent bool) {
+	if n <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+
+	trackers := make([]*progressTracker, 0, len(m.trackers))
+
+	for t := range m.trackers {
+		if t.push == sent {
+			trackers = append(trackers, t)
+		}
+	}
+
+	m.mu.Unlock()
+
+	for _, t := range trackers {
+		t.transfer(int64(n))
+	}
+}
+
+// meteredConn is a connection counting its bytes with a transfer meter.
+type meteredConn struct {
+	net.Conn
+
+	meter *transferMeter
+}
+
+func (c *meteredConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.meter.count(n, false)
+
+	return n, err //nolint:wrapcheck
+}
+
+func (c *meteredConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.meter.count(n, true)
+
+	return n, err //nolint:wrapcheck
+}
+
+// meteredCredentials are transport credentials whose connections are counted with a transfer meter.
+// The bytes are counted after the handshake, so they are not inflated by the TLS records.
+type meteredCredentials struct {
+	credentials.TransportCredentials
+
+	meter *transferMeter
+}
+
+func (c *meteredCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	return &meteredConn{Conn: conn, meter: c.meter}, authInfo, nil
+}
+
+func (c *meteredCredentials) Clone() credentials.TransportCredentials {
+	return &meteredCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		meter:                c.meter,
+	}
+}
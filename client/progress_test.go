@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// recordProgress returns a context reporting to the returned slice.
+func recordProgress(t *testing.T) (context.Context, *[]Progress) {
+	t.Helper()
+
+	var reported []Progress
+
+	return WithProgress(t.Context(), func(p Progress) { reported = append(reported, p) }), &reported
+}
+
+func TestProgressTracker(t *testing.T) {
+	t.Run("without a callback", func(t *testing.T) {
+		progress := newProgressTracker(t.Context(), 1, true)
+		if progress != nil {
+			t.Fatalf("expected no tracker, got %v", progress)
+		}
+
+		// Nothing is tracked nor reported
+		meter := &transferMeter{}
+		meter.track(progress)()
+		progress.start()
+		progress.add(10)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		ctx, reported := recordProgress(t)
+		meter := &transferMeter{}
+
+		progress := newProgressTracker(ctx, 2, true)
+		progress.progress.TotalBytes = 100
+
+		untrack := meter.track(progress)
+		progress.start()
+
+		meter.count(30, true)
+		meter.count(30, false) // bytes received are not part of a push
+		meter.count(200, true) // framing never exceeds the total
+		progress.add(60)
+		progress.add(40)
+		untrack()
+		meter.count(30, true)
+
+		expected := []Progress{
+			{TotalRecords: 2, TotalBytes: 100},
+			{TotalRecords: 2, Bytes: 30, TotalBytes: 100},
+			{TotalRecords: 2, Bytes: 100, TotalBytes: 100},
+			{Records: 1, TotalRecords: 2, Bytes: 100, TotalBytes: 100},
+			{Records: 2, TotalRecords: 2, Bytes: 100, TotalBytes: 100},
+		}
+		assertProgress(t, *reported, expected)
+	})
+
+	t.Run("pull", func(t *testing.T) {
+		ctx, reported := recordProgress(t)
+		meter := &transferMeter{}
+
+		progress := newProgressTracker(ctx, 2, false)
+		defer meter.track(progress)()
+
+		progress.start()
+
+		meter.count(25, false)
+		meter.count(25, true) // bytes sent are not part of a pull
+		meter.count(20, false)
+		progress.add(40) // the record size replaces the bytes read with their framing
+		meter.count(30, false)
+		progress.add(30)
+
+		expected := []Progress{
+			{TotalRecords: 2},
+			{TotalRecords: 2, Bytes: 25},
+			{TotalRecords: 2, Bytes: 45},
+			{Records: 1, TotalRecords: 2, Bytes: 40},
+			{Records: 1, TotalRecords: 2, Bytes: 70},
+			{Records: 2, TotalRecords: 2, Bytes: 70},
+		}
+		assertProgress(t, *reported, expected)
+	})
+}
+
+func TestMeteredCredentials(t *testing.T) {
+	ctx, reported := recordProgress(t)
+	meter := &transferMeter{}
+
+	progress := newProgressTracker(ctx, 1, false)
+	defer meter.track(progress)()
+
+	creds := (&meteredCredentials{TransportCredentials: insecure.NewCredentials(), meter: meter}).Clone()
+
+	client, server := net.Pipe()
+	defer server.Close()
+
+	conn, _, err := creds.ClientHandshake(t.Context(), "dir", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		_, _ = server.Write(make([]byte, 16))
+	}()
+
+	if _, err := io.ReadFull(conn, make([]byte, 16)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The bytes read from the connection are reported as in transfer
+	var bytes int64
+	for _, p := range *reported {
+		bytes = p.Bytes
+	}
+
+	if bytes != 16 {
+		t.Fatalf("expected 16 bytes in transfer, got %d", bytes)
+	}
+}
+
+func assertProgress(t *testing.T, reported, expected []Progress) {
+	t.Helper()
+
+	if len(reported) != len(expected) {
+		t.Fatalf("expected %d progress reports, got %d: %v", len(expected), len(reported), reported)
+	}
+
+	for i := range expected {
+		if reported[i] != expected[i] {
+			t.Fatalf("unexpected progress report %d: expected %+v, got %+v", i, expected[i], reported[i])
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/utils/logging"
 )
@@ -18,7 +19,7 @@ var logger = logging.Logger("client")
 func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest) error {
 	_, err := c.RoutingServiceClient.Publish(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to publish object: %w", err)
+		return fmt.Errorf("failed to publish object: %w", fromStatus(err))
 	}
 
 	return nil
@@ -27,7 +28,7 @@ func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest) err
 func (c *Client) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
 	stream, err := c.RoutingServiceClient.List(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create list stream: %w", err)
+		return nil, fmt.Errorf("failed to create list stream: %w", fromStatus(err))
 	}
 
 	resCh := make(chan *routingv1.ListResponse, 100) //nolint:mnd
@@ -58,7 +59,7 @@ func (c *Client) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *
 func (c *Client) SearchRouting(ctx context.Context, req *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error) {
 	stream, err := c.RoutingServiceClient.Search(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create search stream: %w", err)
+		return nil, fmt.Errorf("failed to create search stream: %w", fromStatus(err))
 	}
 
 	resCh := make(chan *routingv1.SearchResponse, 100) //nolint:mnd
@@ -73,7 +74,10 @@ func (c *Client) SearchRouting(ctx context.Context, req *routingv1.SearchRequest
 			}
 
 			if err != nil {
-				logger.Error("error receiving search result", "error", err)
+				// Canceling the context is the normal way to end a watch search
+				if ctx.Err() == nil {
+					logger.Error("error receiving search result", "error", err)
+				}
 
 				return
 			}
@@ -89,8 +93,44 @@ func (c *Client) SearchRouting(ctx context.Context, req *routingv1.SearchRequest
 func (c *Client) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) error {
 	_, err := c.RoutingServiceClient.Unpublish(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to unpublish object: %w", err)
+		return fmt.Errorf("failed to unpublish object: %w", fromStatus(err))
 	}
 
 	return nil
 }
+
+func (c *Client) ListPeers(ctx context.Context, req *routingv1.ListPeersRequest) (*routingv1.ListPeersResponse, error) {
+	resp, err := c.RoutingServiceClient.ListPeers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list peers: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) ListProvided(ctx context.Context, req *routingv1.ListProvidedRequest) (*routingv1.ListProvidedResponse, error) {
+	resp, err := c.RoutingServiceClient.ListProvided(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provided records: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) PullFromPeer(ctx context.Context, req *routingv1.PullFromPeerRequest) (*corev1.Record, error) {
+	record, err := c.RoutingServiceClient.PullFromPeer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull from peer: %w", fromStatus(err))
+	}
+
+	return record, nil
+}
+
+func (c *Client) PullFromNetwork(ctx context.Context, req *routingv1.PullFromNetworkRequest) (*corev1.Record, error) {
+	record, err := c.RoutingServiceClient.PullFromNetwork(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull from network: %w", fromStatus(err))
+	}
+
+	return record, nil
+}
@@ -8,18 +8,46 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func (c *Client) Search(ctx context.Context, req *searchv1.SearchRequest) (<-chan string, error) {
-	stream, err := c.SearchServiceClient.Search(ctx, req)
+	responseCh, err := c.SearchResponses(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create search stream: %w", err)
+		return nil, err
 	}
 
 	resultCh := make(chan string)
 
+	go func() {
+		defer close(resultCh)
+
+		for resp := range responseCh {
+			select {
+			case resultCh <- resp.GetRecordCid():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resultCh, nil
+}
+
+// SearchResponses runs a search and streams the complete responses,
+// which include the schema version of each matching record along with its CID.
+func (c *Client) SearchResponses(ctx context.Context, req *searchv1.SearchRequest) (<-chan *searchv1.SearchResponse, error) {
+	stream, err := c.SearchServiceClient.Search(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search stream: %w", fromStatus(err))
+	}
+
+	resultCh := make(chan *searchv1.SearchResponse)
+
 	go func() {
 		defer close(resultCh)
 
@@ -36,7 +64,7 @@ func (c *Client) Search(ctx context.Context, req *searchv1.SearchRequest) (<-cha
 			}
 
 			select {
-			case resultCh <- obj.GetRecordCid():
+			case resultCh <- obj:
 			case <-ctx.Done():
 				logger.Error("context cancelled while receiving search response", "error", ctx.Err())
 
@@ -47,3 +75,196 @@ func (c *Client) Search(ctx context.Context, req *searchv1.SearchRequest) (<-cha
 
 	return resultCh, nil
 }
+
+// SearchWithFacets runs a search and returns the matching record CIDs together with
+// the facet counts of the complete result set in a single round-trip.
+func (c *Client) SearchWithFacets(ctx context.Context, req *searchv1.SearchRequest) ([]string, []*searchv1.Facet, error) {
+	// Request facets without modifying the caller's request
+	req, _ = proto.Clone(req).(*searchv1.SearchRequest)
+	req.IncludeFacets = proto.Bool(true)
+
+	stream, err := c.SearchServiceClient.Search(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create search stream: %w", fromStatus(err))
+	}
+
+	var (
+		cids   []string
+		facets []*searchv1.Facet
+	)
+
+	for {
+		obj, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to receive search response: %w", fromStatus(err))
+		}
+
+		if obj.GetRecordCid() != "" {
+			cids = append(cids, obj.GetRecordCid())
+		}
+
+		if len(obj.GetFacets()) > 0 {
+			facets = obj.GetFacets()
+		}
+	}
+
+	return cids, facets, nil
+}
+
+// ListSkills returns the distinct skills of the records indexed by the server, with record counts.
+func (c *Client) ListSkills(ctx context.Context, req *searchv1.ListSkillsRequest) (*searchv1.ListSkillsResponse, error) {
+	resp, err := c.SearchServiceClient.ListSkills(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+// UnifiedSearchRequest describes a search across the local directory and the network.
+// Either request may be nil to leave out that source.
+type UnifiedSearchRequest struct {
+	// Search of the records stored by the server
+	Local *searchv1.SearchRequest
+
+	// Search of the records announced by other peers
+	Remote *routingv1.SearchRequest
+
+	// Maximum number of merged results, zero for no limit
+	Limit uint32
+}
+
+// UnifiedSearchResult is a record found by a unified search.
+type UnifiedSearchResult struct {
+	Cid string `json:"cid"`
+
+	// Local is set if the record is stored by the server
+	Local bool `json:"local"`
+
+	// OASF schema version of the record, for local records only
+	SchemaVersion string `json:"schema_version,omitempty"`
+
+	// Peer providing the record and its match score, for remote records only
+	Peer       string `json:"peer,omitempty"`
+	MatchScore uint32 `json:"match_score,omitempty"`
+}
+
+// SearchAll searches the local directory and the network concurrently and merges the results.
+// Records found by both are reported once, as local. Local results come first and the limit
+// applies to the merged results.
+func (c *Client) SearchAll(ctx context.Context, req *UnifiedSearchRequest) ([]*UnifiedSearchResult, error) {
+	if req.Local == nil && req.Remote == nil {
+		return nil, errors.New("no search source selected")
+	}
+
+	var (
+		wg                  sync.WaitGroup
+		localResults        []*searchv1.SearchResponse
+		remoteResults       []*routingv1.SearchResponse
+		localErr, remoteErr error
+	)
+
+	if req.Local != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ch, err := c.SearchResponses(ctx, req.Local)
+			if err != nil {
+				localErr = err
+
+				return
+			}
+
+			for result := range ch {
+				if result.GetRecordCid() != "" {
+					localResults = append(localResults, result)
+				}
+			}
+		}()
+	}
+
+	if req.Remote != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ch, err := c.SearchRouting(ctx, req.Remote)
+			if err != nil {
+				remoteErr = err
+
+				return
+			}
+
+			for result := range ch {
+				remoteResults = append(remoteResults, result)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// A failing source only fails the search if there are no other results to return
+	if localErr != nil && (req.Remote == nil || remoteErr != nil) {
+		return nil, errors.Join(localErr, remoteErr)
+	}
+
+	if remoteErr != nil && req.Local == nil {
+		return nil, remoteErr
+	}
+
+	if localErr != nil {
+		logger.Warn("local search failed, returning remote results only", "error", localErr)
+	}
+
+	if remoteErr != nil {
+		logger.Warn("remote search failed, returning local results only", "error", remoteErr)
+	}
+
+	return mergeSearchResults(localResults, remoteResults, req.Limit), nil
+}
+
+// mergeSearchResults merges local and remote results, deduplicating by CID with local results
+// taking precedence, and truncates them to the limit.
+func mergeSearchResults(localResults []*searchv1.SearchResponse, remoteResults []*routingv1.SearchResponse, limit uint32) []*UnifiedSearchResult {
+	seen := make(map[string]struct{}, len(localResults)+len(remoteResults))
+	results := make([]*UnifiedSearchResult, 0, len(localResults)+len(remoteResults))
+
+	add := func(result *UnifiedSearchResult) bool {
+		if limit > 0 && len(results) >= int(limit) {
+			return false
+		}
+
+		if _, ok := seen[result.Cid]; !ok {
+			seen[result.Cid] = struct{}{}
+			results = append(results, result)
+		}
+
+		return true
+	}
+
+	for _, result := range localResults {
+		if !add(&UnifiedSearchResult{Cid: result.GetRecordCid(), Local: true, SchemaVersion: result.GetSchemaVersion()}) {
+			return results
+		}
+	}
+
+	for _, result := range remoteResults {
+		cid := result.GetRecordRef().GetCid()
+		if cid == "" {
+			continue
+		}
+
+		if !add(&UnifiedSearchResult{Cid: cid, Peer: result.GetPeer().GetId(), MatchScore: result.GetMatchScore()}) {
+			return results
+		}
+	}
+
+	return results
+}
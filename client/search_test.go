@@ -0,0 +1,211 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream streams the given responses, then io.EOF.
+type fakeStream[T any] struct {
+	grpc.ClientStream
+	responses []*T
+}
+
+func (s *fakeStream[T]) Recv() (*T, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+
+	return resp, nil
+}
+
+type fakeSearchClient struct {
+	searchv1.SearchServiceClient
+	responses []*searchv1.SearchResponse
+	err       error
+}
+
+func (f *fakeSearchClient) Search(context.Context, *searchv1.SearchRequest, ...grpc.CallOption) (searchv1.SearchService_SearchClient, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &fakeStream[searchv1.SearchResponse]{responses: f.responses}, nil
+}
+
+type fakeRoutingClient struct {
+	routingv1.RoutingServiceClient
+	responses []*routingv1.SearchResponse
+	err       error
+}
+
+func (f *fakeRoutingClient) Search(context.Context, *routingv1.SearchRequest, ...grpc.CallOption) (routingv1.RoutingService_SearchClient, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &fakeStream[routingv1.SearchResponse]{responses: f.responses}, nil
+}
+
+func localResponse(cid, schemaVersion string) *searchv1.SearchResponse {
+	return &searchv1.SearchResponse{RecordCid: cid, SchemaVersion: schemaVersion}
+}
+
+func remoteResponse(cid, peerID string, score uint32) *routingv1.SearchResponse {
+	return &routingv1.SearchResponse{
+		RecordRef:  &corev1.RecordRef{Cid: cid},
+		Peer:       &routingv1.Peer{Id: peerID},
+		MatchScore: score,
+	}
+}
+
+func resultCIDs(results []*UnifiedSearchResult) []string {
+	cids := make([]string, 0, len(results))
+	for _, result := range results {
+		cids = append(cids, result.Cid)
+	}
+
+	return cids
+}
+
+func TestMergeSearchResults(t *testing.T) {
+	local := []*searchv1.SearchResponse{
+		localResponse("cid-1", "v0.3.1"),
+		localResponse("cid-2", "v0.5.0"),
+	}
+	remote := []*routingv1.SearchResponse{
+		remoteResponse("cid-2", "peer-a", 2),
+		remoteResponse("", "peer-a", 1),
+		remoteResponse("cid-3", "peer-a", 1),
+		remoteResponse("cid-3", "peer-b", 2),
+	}
+
+	t.Run("deduplicates with local precedence", func(t *testing.T) {
+		results := mergeSearchResults(local, remote, 0)
+
+		want := []*UnifiedSearchResult{
+			{Cid: "cid-1", Local: true, SchemaVersion: "v0.3.1"},
+			{Cid: "cid-2", Local: true, SchemaVersion: "v0.5.0"},
+			{Cid: "cid-3", Peer: "peer-a", MatchScore: 1},
+		}
+		if !reflect.DeepEqual(results, want) {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("limits the merged results", func(t *testing.T) {
+		if cids := resultCIDs(mergeSearchResults(local, remote, 1)); !reflect.DeepEqual(cids, []string{"cid-1"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+
+		// Duplicates do not count towards the limit
+		if cids := resultCIDs(mergeSearchResults(local, remote, 3)); !reflect.DeepEqual(cids, []string{"cid-1", "cid-2", "cid-3"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+	})
+
+	t.Run("remote results only", func(t *testing.T) {
+		if cids := resultCIDs(mergeSearchResults(nil, remote, 0)); !reflect.DeepEqual(cids, []string{"cid-2", "cid-3"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+	})
+}
+
+func TestSearchAll(t *testing.T) {
+	errUnavailable := status.Error(codes.Unavailable, "unavailable")
+
+	newClient := func(searchErr, routingErr error) *Client {
+		return &Client{
+			SearchServiceClient: &fakeSearchClient{
+				responses: []*searchv1.SearchResponse{localResponse("cid-1", "v0.3.1"), localResponse("", "")},
+				err:       searchErr,
+			},
+			RoutingServiceClient: &fakeRoutingClient{
+				responses: []*routingv1.SearchResponse{remoteResponse("cid-1", "peer-a", 1), remoteResponse("cid-2", "peer-a", 1)},
+				err:       routingErr,
+			},
+		}
+	}
+
+	req := &UnifiedSearchRequest{
+		Local:  &searchv1.SearchRequest{},
+		Remote: &routingv1.SearchRequest{},
+	}
+
+	t.Run("merges both sources", func(t *testing.T) {
+		results, err := newClient(nil, nil).SearchAll(t.Context(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cids := resultCIDs(results); !reflect.DeepEqual(cids, []string{"cid-1", "cid-2"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+
+		if !results[0].Local || results[1].Local {
+			t.Fatalf("unexpected sources: %+v", results)
+		}
+	})
+
+	t.Run("local search only", func(t *testing.T) {
+		results, err := newClient(nil, nil).SearchAll(t.Context(), &UnifiedSearchRequest{Local: &searchv1.SearchRequest{}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cids := resultCIDs(results); !reflect.DeepEqual(cids, []string{"cid-1"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+	})
+
+	t.Run("failing source is skipped", func(t *testing.T) {
+		results, err := newClient(nil, errUnavailable).SearchAll(t.Context(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cids := resultCIDs(results); !reflect.DeepEqual(cids, []string{"cid-1"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+
+		results, err = newClient(errUnavailable, nil).SearchAll(t.Context(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cids := resultCIDs(results); !reflect.DeepEqual(cids, []string{"cid-1", "cid-2"}) || results[0].Local {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("fails if all sources fail", func(t *testing.T) {
+		if _, err := newClient(errUnavailable, errUnavailable).SearchAll(t.Context(), req); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if _, err := newClient(nil, errUnavailable).SearchAll(t.Context(), &UnifiedSearchRequest{Remote: &routingv1.SearchRequest{}}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("requires a source", func(t *testing.T) {
+		if _, err := newClient(nil, nil).SearchAll(t.Context(), &UnifiedSearchRequest{}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
@@ -43,7 +43,6 @@ func (c *Client) Sign(ctx context.Context, req *signv1.SignRequest) (*signv1.Sig
 
 // SignWithOIDC signs the record using keyless OIDC service-based signing.
 // The OIDC ID Token can be provided by the caller, or cosign will handle interactive OIDC flow.
-// This implementation uses cosign sign-blob command for OIDC signing.
 func (c *Client) SignWithOIDC(ctx context.Context, req *signv1.SignRequest) (*signv1.SignResponse, error) {
 	// Validate request.
 	if req.GetRecordRef() == nil {
@@ -52,63 +51,34 @@ func (c *Client) SignWithOIDC(ctx context.Context, req *signv1.SignRequest) (*si
 
 	oidcSigner := req.GetProvider().GetOidc()
 
-	digest, err := corev1.ConvertCIDToDigest(req.GetRecordRef().GetCid())
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert CID to digest: %w", err)
-	}
-
-	payloadBytes, err := cosign.GeneratePayload(digest.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate payload: %w", err)
-	}
-
-	// Prepare options for signing
-	signOpts := &cosign.SignBlobOIDCOptions{
-		Payload: payloadBytes,
+	signer := &OIDCSigner{
 		IDToken: oidcSigner.GetIdToken(),
 	}
 
 	// Set URLs from options if provided
 	if opts := oidcSigner.GetOptions(); opts != nil {
-		signOpts.FulcioURL = opts.GetFulcioUrl()
-		signOpts.RekorURL = opts.GetRekorUrl()
-		signOpts.TimestampURL = opts.GetTimestampUrl()
-		signOpts.OIDCProviderURL = opts.GetOidcProviderUrl()
-	}
-
-	// Sign using utility function
-	result, err := cosign.SignBlobWithOIDC(ctx, signOpts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign with OIDC: %w", err)
-	}
-
-	signatureObj := &signv1.Signature{
-		Signature: result.Signature,
-		Annotations: map[string]string{
-			"payload": string(payloadBytes),
-		},
-	}
-
-	// Push signature and public key to store
-	err = c.pushReferrersToStore(ctx, req.GetRecordRef().GetCid(), signatureObj, result.PublicKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to push referrers to store: %w", err)
+		signer.FulcioURL = opts.GetFulcioUrl()
+		signer.RekorURL = opts.GetRekorUrl()
+		signer.TimestampURL = opts.GetTimestampUrl()
+		signer.OIDCProviderURL = opts.GetOidcProviderUrl()
 	}
 
-	return &signv1.SignResponse{
-		Signature: signatureObj,
-	}, nil
+	return c.SignWithSigner(ctx, req.GetRecordRef().GetCid(), signer)
 }
 
 func (c *Client) SignWithKey(ctx context.Context, req *signv1.SignRequest) (*signv1.SignResponse, error) {
 	keySigner := req.GetProvider().GetKey()
 
-	password := keySigner.GetPassword()
-	if password == nil {
-		password = []byte("") // Empty password is valid for cosign.
-	}
+	return c.SignWithSigner(ctx, req.GetRecordRef().GetCid(), &KeySigner{
+		PrivateKey: keySigner.GetPrivateKey(),
+		Password:   keySigner.GetPassword(),
+	})
+}
 
-	digest, err := corev1.ConvertCIDToDigest(req.GetRecordRef().GetCid())
+// SignWithSigner signs the record using the given signing backend
+// and pushes the signature and public key to the store.
+func (c *Client) SignWithSigner(ctx context.Context, recordCID string, signer Signer) (*signv1.SignResponse, error) {
+	digest, err := corev1.ConvertCIDToDigest(recordCID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert CID to digest: %w", err)
 	}
@@ -118,29 +88,20 @@ func (c *Client) SignWithKey(ctx context.Context, req *signv1.SignRequest) (*sig
 		return nil, fmt.Errorf("failed to generate payload: %w", err)
 	}
 
-	// Prepare options for signing
-	signOpts := &cosign.SignBlobKeyOptions{
-		Payload:    payloadBytes,
-		PrivateKey: keySigner.GetPrivateKey(),
-		Password:   password,
-	}
-
-	// Sign using utility function
-	result, err := cosign.SignBlobWithKey(ctx, signOpts)
+	signatureObj, publicKey, err := signer.Sign(ctx, payloadBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign with key: %w", err)
+		return nil, err //nolint:wrapcheck // signers wrap their errors
 	}
 
-	// Create the signature object
-	signatureObj := &signv1.Signature{
-		Signature: result.Signature,
-		Annotations: map[string]string{
-			"payload": string(payloadBytes),
-		},
+	// The payload is required to verify the signature
+	if signatureObj.Annotations == nil {
+		signatureObj.Annotations = make(map[string]string)
 	}
 
+	signatureObj.Annotations["payload"] = string(payloadBytes)
+
 	// Push signature and public key to store
-	err = c.pushReferrersToStore(ctx, req.GetRecordRef().GetCid(), signatureObj, result.PublicKey)
+	err = c.pushReferrersToStore(ctx, recordCID, signatureObj, publicKey.GetKey())
 	if err != nil {
 		return nil, fmt.Errorf("failed to push referrers to store: %w", err)
 	}
@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	"github.com/agntcy/dir/utils/cosign"
+)
+
+// Signer produces record signatures.
+// It decouples how signatures are created from how they are stored,
+// so that local keys, keyless signing and external key managers can be used interchangeably.
+type Signer interface {
+	// Sign signs the payload derived from the record digest.
+	// It returns the signature with its algorithm, signing time and optional certificate populated,
+	// along with the public key needed to verify it.
+	Sign(ctx context.Context, payload []byte) (*signv1.Signature, *signv1.PublicKey, error)
+}
+
+// KeySigner signs with a cosign private key, such as an ECDSA or Ed25519 key.
+type KeySigner struct {
+	// PEM-encoded, possibly encrypted, private key
+	PrivateKey []byte
+
+	// Password to decrypt the private key
+	Password []byte
+}
+
+// Sign implements Signer.
+func (s *KeySigner) Sign(ctx context.Context, payload []byte) (*signv1.Signature, *signv1.PublicKey, error) {
+	password := s.Password
+	if password == nil {
+		password = []byte("") // Empty password is valid for cosign.
+	}
+
+	result, err := cosign.SignBlobWithKey(ctx, &cosign.SignBlobKeyOptions{
+		Payload:    payload,
+		PrivateKey: s.PrivateKey,
+		Password:   password,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign with key: %w", err)
+	}
+
+	signature := &signv1.Signature{
+		Signature: result.Signature,
+		Algorithm: result.Algorithm,
+		SignedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	return signature, &signv1.PublicKey{Key: result.PublicKey}, nil
+}
+
+// OIDCSigner signs keyless with an ephemeral key certified by Sigstore Fulcio
+// for an OIDC identity, and records the signature in Sigstore Rekor.
+type OIDCSigner struct {
+	// OIDC ID token of the signing identity
+	IDToken string
+
+	// Sigstore service URLs, defaults are used if empty
+	FulcioURL       string
+	RekorURL        string
+	TimestampURL    string
+	OIDCProviderURL string
+}
+
+// Sign implements Signer.
+func (s *OIDCSigner) Sign(ctx context.Context, payload []byte) (*signv1.Signature, *signv1.PublicKey, error) {
+	result, err := cosign.SignBlobWithOIDC(ctx, &cosign.SignBlobOIDCOptions{
+		Payload:         payload,
+		IDToken:         s.IDToken,
+		FulcioURL:       s.FulcioURL,
+		RekorURL:        s.RekorURL,
+		TimestampURL:    s.TimestampURL,
+		OIDCProviderURL: s.OIDCProviderURL,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign with OIDC: %w", err)
+	}
+
+	signature := &signv1.Signature{
+		Signature:   result.Signature,
+		Algorithm:   result.Algorithm,
+		SignedAt:    time.Now().UTC().Format(time.RFC3339),
+		Certificate: result.Certificate,
+	}
+
+	return signature, &signv1.PublicKey{Key: result.PublicKey}, nil
+}
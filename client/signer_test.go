@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+)
+
+func TestKeySigner(t *testing.T) {
+	password := []byte("test-password")
+
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return password, nil })
+	if err != nil {
+		t.Fatalf("failed to generate key pair: %v", err)
+	}
+
+	payload := []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:test"}}}`)
+
+	t.Run("signs the payload", func(t *testing.T) {
+		signer := &KeySigner{PrivateKey: keys.PrivateBytes, Password: password}
+
+		signature, publicKey, err := signer.Sign(t.Context(), payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if signature.GetAlgorithm() != "ECDSA_P256_SHA256" {
+			t.Fatalf("unexpected algorithm: %s", signature.GetAlgorithm())
+		}
+
+		if _, err := time.Parse(time.RFC3339, signature.GetSignedAt()); err != nil {
+			t.Fatalf("unexpected signing time %q: %v", signature.GetSignedAt(), err)
+		}
+
+		if signature.GetCertificate() != "" {
+			t.Fatalf("key signatures should not have a certificate, got %q", signature.GetCertificate())
+		}
+
+		// The signature verifies with the returned public key
+		block, _ := pem.Decode([]byte(publicKey.GetKey()))
+		if block == nil {
+			t.Fatalf("public key is not PEM-encoded: %q", publicKey.GetKey())
+		}
+
+		pubKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			t.Fatalf("failed to parse public key: %v", err)
+		}
+
+		ecdsaKey, ok := pubKey.(*ecdsa.PublicKey)
+		if !ok {
+			t.Fatalf("unexpected public key type %T", pubKey)
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(signature.GetSignature())
+		if err != nil {
+			t.Fatalf("signature is not base64-encoded: %v", err)
+		}
+
+		digest := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(ecdsaKey, digest[:], sig) {
+			t.Fatal("signature does not verify with the public key")
+		}
+	})
+
+	t.Run("fails with a wrong password", func(t *testing.T) {
+		signer := &KeySigner{PrivateKey: keys.PrivateBytes, Password: []byte("wrong-password")}
+
+		if _, _, err := signer.Sign(t.Context(), payload); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("fails without a private key", func(t *testing.T) {
+		if _, _, err := (&KeySigner{}).Sign(t.Context(), payload); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestOIDCSigner(t *testing.T) {
+	// Sigstore services that reject every request, so that no real service is contacted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	signer := &OIDCSigner{
+		IDToken:         "invalid-token",
+		FulcioURL:       server.URL,
+		RekorURL:        server.URL,
+		TimestampURL:    server.URL,
+		OIDCProviderURL: server.URL,
+	}
+
+	signature, publicKey, err := signer.Sign(t.Context(), []byte("payload"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if signature != nil || publicKey != nil {
+		t.Fatalf("expected no signature on failure, got %v and %v", signature, publicKey)
+	}
+}
@@ -8,13 +8,54 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/client/streaming"
+	"github.com/agntcy/dir/utils/concurrency"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// WithPushAnnotations returns a context attaching the given annotations to the records pushed with it,
+// e.g. to record the deployment environment or the pushing pipeline. The server stores the annotations
+// in the record manifest and reports them in the record metadata returned by Lookup.
+// The annotations are not part of the record, so they do not affect its CID.
+// Pushing a record that is already stored with other annotations fails with FailedPrecondition,
+// as the annotations of stored records cannot be changed.
+func WithPushAnnotations(ctx context.Context, annotations map[string]string) context.Context {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	pairs := make([]string, 0, 2*len(keys)) //nolint:mnd
+	for _, key := range keys {
+		pairs = append(pairs, storev1.PushAnnotationMetadataKey, key+"="+annotations[key])
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// WithPushTTL returns a context giving the records pushed with it a time to live, e.g. for ephemeral agents.
+// Once expired, the server unpublishes and deletes the records in the background.
+// Like annotations, the expiry does not affect the CID. Pushing a record that is already stored with a TTL
+// fails with FailedPrecondition, as the expiry of stored records cannot be changed.
+func WithPushTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, storev1.PushTTLMetadataKey, ttl.String())
+}
+
+// WithNoCache returns a context whose Pull and Lookup requests bypass the server-side store cache
+// and read from the backing store, e.g. to rule out stale cache entries. The records read still refresh the cache.
+func WithNoCache(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, storev1.NoCacheMetadataKey, "true")
+}
+
 // Push sends a complete record to the store and returns a record reference.
 // This is a convenience wrapper around PushBatch for single-record operations.
 // The record must be ≤4MB as per the v1 store service specification.
@@ -37,16 +78,37 @@ func (c *Client) Push(ctx context.Context, record *corev1.Record) (*corev1.Recor
 func (c *Client) PullStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[corev1.Record], error) {
 	stream, err := c.StoreServiceClient.Pull(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create pull stream: %w", err)
+		return nil, fmt.Errorf("failed to create pull stream: %w", fromStatus(err))
 	}
 
 	//nolint:wrapcheck
 	return streaming.ProcessBidiStream(ctx, stream, refsCh)
 }
 
+// PullConcurrent retrieves multiple records in a single request.
+// The server pulls the records concurrently and streams each one back as soon as it is fetched,
+// so results arrive in any order. Records that cannot be pulled are reported in their response
+// with a non-zero code instead of ending the stream.
+func (c *Client) PullConcurrent(ctx context.Context, recordRefs []*corev1.RecordRef) (streaming.StreamResult[storev1.PullStreamResponse], error) {
+	stream, err := c.StoreServiceClient.PullStream(ctx, &storev1.PullStreamRequest{
+		RecordRefs: recordRefs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull stream: %w", fromStatus(err))
+	}
+
+	//nolint:wrapcheck
+	return streaming.ProcessServerStream(ctx, stream)
+}
+
 // Pull retrieves a single record from the store using its reference.
 // This is a convenience wrapper around PullBatch for single-record operations.
+// References without a valid CID are rejected with ErrInvalidCID.
 func (c *Client) Pull(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
+	if err := validateRecordRef(recordRef); err != nil {
+		return nil, err
+	}
+
 	records, err := c.PullBatch(ctx, []*corev1.RecordRef{recordRef})
 	if err != nil {
 		return nil, err
@@ -63,12 +125,19 @@ func (c *Client) Pull(ctx context.Context, recordRef *corev1.RecordRef) (*corev1
 // This is a convenience method that accepts a slice and returns a slice,
 // built on top of the streaming implementation for consistency.
 func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef) ([]*corev1.Record, error) {
+	// The size of pulled records is only known once received,
+	// and the bytes read are counted from the start of the stream
+	progress := newProgressTracker(ctx, len(recordRefs), false)
+	defer c.meter.track(progress)()
+
 	// Use channel to communicate error safely (no race condition)
 	result, err := c.PullStream(ctx, streaming.SliceToChan(ctx, recordRefs))
 	if err != nil {
 		return nil, err
 	}
 
+	progress.start()
+
 	// Check for results
 	var errs error
 
@@ -77,9 +146,13 @@ func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef)
 	for {
 		select {
 		case err := <-result.ErrCh():
-			errs = errors.Join(errs, err)
+			errs = errors.Join(errs, fromStatus(err))
 		case resp := <-result.ResCh():
 			metas = append(metas, resp)
+
+			if progress != nil {
+				progress.add(int64(proto.Size(resp)))
+			}
 		case <-result.DoneCh():
 			return metas, errs
 		}
@@ -92,7 +165,7 @@ func (c *Client) PullBatch(ctx context.Context, recordRefs []*corev1.RecordRef)
 func (c *Client) PushStream(ctx context.Context, recordsCh <-chan *corev1.Record) (streaming.StreamResult[corev1.RecordRef], error) {
 	stream, err := c.StoreServiceClient.Push(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create push stream: %w", err)
+		return nil, fmt.Errorf("failed to create push stream: %w", fromStatus(err))
 	}
 
 	//nolint:wrapcheck
@@ -103,12 +176,20 @@ func (c *Client) PushStream(ctx context.Context, recordsCh <-chan *corev1.Record
 // This is a convenience method that accepts a slice and returns a slice,
 // built on top of the streaming implementation for consistency.
 func (c *Client) PushBatch(ctx context.Context, records []*corev1.Record) ([]*corev1.RecordRef, error) {
+	// The bytes sent are counted from the start of the stream
+	progress := newProgressTracker(ctx, len(records), true)
+	sizes := progress.setRecords(records)
+
+	defer c.meter.track(progress)()
+
 	// Use channel to communicate error safely (no race condition)
 	result, err := c.PushStream(ctx, streaming.SliceToChan(ctx, records))
 	if err != nil {
 		return nil, err
 	}
 
+	progress.start()
+
 	// Check for results
 	var errs error
 
@@ -117,8 +198,13 @@ func (c *Client) PushBatch(ctx context.Context, records []*corev1.Record) ([]*co
 	for {
 		select {
 		case err := <-result.ErrCh():
-			errs = errors.Join(errs, err)
+			errs = errors.Join(errs, fromStatus(err))
 		case resp := <-result.ResCh():
+			// References are returned in the order the records were pushed
+			if len(refs) < len(sizes) {
+				progress.add(sizes[len(refs)])
+			}
+
 			refs = append(refs, resp)
 		case <-result.DoneCh():
 			return refs, errs
@@ -131,12 +217,12 @@ func (c *Client) PushReferrer(ctx context.Context, req *storev1.PushReferrerRequ
 	// Create streaming client
 	stream, err := c.StoreServiceClient.PushReferrer(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create push referrer stream: %w", err)
+		return fmt.Errorf("failed to create push referrer stream: %w", fromStatus(err))
 	}
 
 	// Send the request
 	if err := stream.Send(req); err != nil {
-		return fmt.Errorf("failed to send push referrer request: %w", err)
+		return fmt.Errorf("failed to send push referrer request: %w", fromStatus(err))
 	}
 
 	// Close send stream
@@ -147,7 +233,7 @@ func (c *Client) PushReferrer(ctx context.Context, req *storev1.PushReferrerRequ
 	// Receive response
 	_, err = stream.Recv()
 	if err != nil {
-		return fmt.Errorf("failed to receive push referrer response: %w", err)
+		return fmt.Errorf("failed to receive push referrer response: %w", fromStatus(err))
 	}
 
 	return nil
@@ -158,12 +244,12 @@ func (c *Client) PullReferrer(ctx context.Context, req *storev1.PullReferrerRequ
 	// Create streaming client
 	stream, err := c.StoreServiceClient.PullReferrer(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create pull referrer stream: %w", err)
+		return nil, fmt.Errorf("failed to create pull referrer stream: %w", fromStatus(err))
 	}
 
 	// Send the request
 	if err := stream.Send(req); err != nil {
-		return nil, fmt.Errorf("failed to send pull referrer request: %w", err)
+		return nil, fmt.Errorf("failed to send pull referrer request: %w", fromStatus(err))
 	}
 
 	// Close send stream
@@ -202,7 +288,12 @@ func (c *Client) PullReferrer(ctx context.Context, req *storev1.PullReferrerRequ
 }
 
 // Lookup retrieves metadata for a record using its reference.
+// References without a valid CID are rejected with ErrInvalidCID.
 func (c *Client) Lookup(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if err := validateRecordRef(recordRef); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.LookupBatch(ctx, []*corev1.RecordRef{recordRef})
 	if err != nil {
 		return nil, err
@@ -215,6 +306,32 @@ func (c *Client) Lookup(ctx context.Context, recordRef *corev1.RecordRef) (*core
 	return resp[0], nil
 }
 
+// Exists reports whether a record is in the store.
+// A missing record is reported as false, errors are only returned for failed checks.
+func (c *Client) Exists(ctx context.Context, recordRef *corev1.RecordRef) (bool, error) {
+	resp, err := c.StoreServiceClient.Exists(ctx, &storev1.ExistsRequest{RecordRef: recordRef})
+	if err != nil {
+		return false, fmt.Errorf("failed to check record existence: %w", fromStatus(err))
+	}
+
+	return resp.GetExists(), nil
+}
+
+// ResolveCID returns the full CID of the record identified by a CID or an unambiguous CID prefix.
+// Full CIDs are returned as they are, prefixes are resolved by the server.
+func (c *Client) ResolveCID(ctx context.Context, cidOrPrefix string) (string, error) {
+	if corev1.IsValidCID(cidOrPrefix) {
+		return cidOrPrefix, nil
+	}
+
+	meta, err := c.Lookup(ctx, &corev1.RecordRef{Cid: cidOrPrefix})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve CID %q: %w", cidOrPrefix, err)
+	}
+
+	return meta.GetCid(), nil
+}
+
 // LookupBatch retrieves metadata for multiple records in a single stream for efficiency.
 func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
 	// Use channel to communicate error safely (no race condition)
@@ -231,7 +348,7 @@ func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 	for {
 		select {
 		case err := <-result.ErrCh():
-			errs = errors.Join(errs, err)
+			errs = errors.Join(errs, fromStatus(err))
 		case resp := <-result.ResCh():
 			metas = append(metas, resp)
 		case <-result.DoneCh():
@@ -240,6 +357,39 @@ func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 	}
 }
 
+// LookupMany checks which records exist and retrieves their metadata.
+// Unlike LookupBatch, missing records do not fail the operation: the result has
+// one entry per reference, in order, with nil entries for records that do not exist.
+// Lookups run concurrently with a bounded number of workers, see WithBatchConcurrency and WithConcurrency.
+func (c *Client) LookupMany(ctx context.Context, recordRefs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	metas := make([]*corev1.RecordMeta, len(recordRefs))
+	errs := make([]error, len(recordRefs))
+
+	err := concurrency.ForEach(ctx, len(recordRefs), c.concurrency(ctx), func(i int) {
+		meta, err := c.Lookup(ctx, recordRefs[i])
+		if errors.Is(err, ErrNotFound) {
+			return
+		}
+
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to lookup record %s: %w", recordRefs[i].GetCid(), err)
+
+			return
+		}
+
+		metas[i] = meta
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup cancelled: %w", err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return metas, nil
+}
+
 // LookupStream provides efficient streaming lookup operations using channels.
 // Record references are sent as they become available and metadata is returned as it's processed.
 // This method maintains a single gRPC stream for all operations, dramatically improving efficiency.
@@ -249,7 +399,7 @@ func (c *Client) LookupBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 func (c *Client) LookupStream(ctx context.Context, refsCh <-chan *corev1.RecordRef) (streaming.StreamResult[corev1.RecordMeta], error) {
 	stream, err := c.StoreServiceClient.Lookup(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create lookup stream: %w", err)
+		return nil, fmt.Errorf("failed to create lookup stream: %w", fromStatus(err))
 	}
 
 	//nolint:wrapcheck
@@ -257,7 +407,12 @@ func (c *Client) LookupStream(ctx context.Context, refsCh <-chan *corev1.RecordR
 }
 
 // Delete removes a record from the store using its reference.
+// References without a valid CID are rejected with ErrInvalidCID.
 func (c *Client) Delete(ctx context.Context, recordRef *corev1.RecordRef) error {
+	if err := validateRecordRef(recordRef); err != nil {
+		return err
+	}
+
 	return c.DeleteBatch(ctx, []*corev1.RecordRef{recordRef})
 }
 
@@ -274,7 +429,7 @@ func (c *Client) DeleteBatch(ctx context.Context, recordRefs []*corev1.RecordRef
 		select {
 		case err := <-result.ErrCh():
 			// If any error occurs, return immediately
-			return err
+			return fromStatus(err)
 		case <-result.ResCh():
 			// We don't expect any results, just confirmations
 		case <-result.DoneCh():
@@ -290,9 +445,55 @@ func (c *Client) DeleteStream(ctx context.Context, refsCh <-chan *corev1.RecordR
 	// Create gRPC stream
 	stream, err := c.StoreServiceClient.Delete(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create delete stream: %w", err)
+		return nil, fmt.Errorf("failed to create delete stream: %w", fromStatus(err))
 	}
 
 	//nolint:wrapcheck
 	return streaming.ProcessClientStream(ctx, stream, refsCh)
 }
+
+// GarbageCollect removes blobs that are no longer referenced from the server's store.
+// In dry-run mode, unreferenced blobs are reported but not deleted.
+func (c *Client) GarbageCollect(ctx context.Context, req *storev1.GarbageCollectRequest) (*storev1.GarbageCollectResponse, error) {
+	resp, err := c.StoreServiceClient.GarbageCollect(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to garbage collect: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+// CheckConsistency compares the records in the store with the records indexed in the search database.
+// If req.Repair is set, the server re-indexes missing records and removes dangling database entries.
+func (c *Client) CheckConsistency(ctx context.Context, req *storev1.CheckConsistencyRequest) (*storev1.CheckConsistencyResponse, error) {
+	resp, err := c.StoreServiceClient.CheckConsistency(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check consistency: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+// GetStats reports the statistics and limits of the server's store, such as the maximum accepted record size.
+// Storage usage is only reported if requested, as it may require walking the whole store.
+func (c *Client) GetStats(ctx context.Context, req *storev1.GetStatsRequest) (*storev1.GetStatsResponse, error) {
+	resp, err := c.StoreServiceClient.GetStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store stats: %w", fromStatus(err))
+	}
+
+	return resp, nil
+}
+
+// VerifyRecords recomputes the CID of every record in the server's store and streams the outcome of each check.
+// The server verifies records concurrently, so results arrive in any order; each result carries the total
+// number of records being verified. If req.Repair is set, records whose content no longer matches their CID are removed.
+func (c *Client) VerifyRecords(ctx context.Context, req *storev1.VerifyRecordsRequest) (streaming.StreamResult[storev1.VerifyRecordsResponse], error) {
+	stream, err := c.StoreServiceClient.VerifyRecords(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create verify stream: %w", fromStatus(err))
+	}
+
+	//nolint:wrapcheck
+	return streaming.ProcessServerStream(ctx, stream)
+}
@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/client/streaming"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blockingStream blocks until its context is done.
+type blockingStream[T any] struct {
+	grpc.ClientStream
+	ctx context.Context //nolint:containedctx
+}
+
+func (s *blockingStream[T]) Recv() (*T, error) {
+	<-s.ctx.Done()
+
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+type fakeStoreClient struct {
+	storev1.StoreServiceClient
+	responses []*storev1.PullStreamResponse
+	err       error
+	block     bool
+	request   *storev1.PullStreamRequest
+}
+
+func (f *fakeStoreClient) PullStream(ctx context.Context, req *storev1.PullStreamRequest, _ ...grpc.CallOption) (storev1.StoreService_PullStreamClient, error) {
+	f.request = req
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.block {
+		return &blockingStream[storev1.PullStreamResponse]{ctx: ctx}, nil
+	}
+
+	return &fakeStream[storev1.PullStreamResponse]{responses: f.responses}, nil
+}
+
+// collectResults reads a stream result until it is done.
+func collectResults[T any](t *testing.T, result streaming.StreamResult[T]) ([]*T, []error) {
+	t.Helper()
+
+	var (
+		results []*T
+		errs    []error
+	)
+
+	for {
+		select {
+		case res := <-result.ResCh():
+			results = append(results, res)
+		case err := <-result.ErrCh():
+			errs = append(errs, err)
+		case <-result.DoneCh():
+			return results, errs
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream did not complete")
+		}
+	}
+}
+
+func TestPullConcurrent(t *testing.T) {
+	errMessage := "record not found"
+	responses := []*storev1.PullStreamResponse{
+		{RecordRef: &corev1.RecordRef{Cid: "cid-2"}, Record: &corev1.Record{}},
+		{RecordRef: &corev1.RecordRef{Cid: "cid-3"}, Code: uint32(codes.NotFound), ErrorMessage: &errMessage},
+		{RecordRef: &corev1.RecordRef{Cid: "cid-1"}, Record: &corev1.Record{}},
+	}
+	refs := []*corev1.RecordRef{{Cid: "cid-1"}, {Cid: "cid-2"}, {Cid: "cid-3"}}
+
+	t.Run("streams responses in arrival order", func(t *testing.T) {
+		storeClient := &fakeStoreClient{responses: responses}
+		c := &Client{StoreServiceClient: storeClient}
+
+		result, err := c.PullConcurrent(t.Context(), refs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, errs := collectResults(t, result)
+		if len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+
+		if !reflect.DeepEqual(storeClient.request.GetRecordRefs(), refs) {
+			t.Fatalf("unexpected request: %v", storeClient.request)
+		}
+
+		cids := make([]string, 0, len(results))
+		for _, res := range results {
+			cids = append(cids, res.GetRecordRef().GetCid())
+		}
+
+		if !reflect.DeepEqual(cids, []string{"cid-2", "cid-3", "cid-1"}) {
+			t.Fatalf("unexpected CIDs: %v", cids)
+		}
+	})
+
+	t.Run("reports partial failures per record", func(t *testing.T) {
+		c := &Client{StoreServiceClient: &fakeStoreClient{responses: responses}}
+
+		result, err := c.PullConcurrent(t.Context(), refs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, errs := collectResults(t, result)
+		if len(errs) != 0 || len(results) != len(refs) {
+			t.Fatalf("unexpected results %v and errors %v", results, errs)
+		}
+
+		for _, res := range results {
+			failed := res.GetRecordRef().GetCid() == "cid-3"
+			if failed != (codes.Code(res.GetCode()) == codes.NotFound) || failed != (res.GetRecord() == nil) {
+				t.Fatalf("unexpected response: %v", res)
+			}
+		}
+	})
+
+	t.Run("fails if the stream cannot be created", func(t *testing.T) {
+		c := &Client{StoreServiceClient: &fakeStoreClient{err: status.Error(codes.Unavailable, "unavailable")}}
+
+		if _, err := c.PullConcurrent(t.Context(), refs); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		c := &Client{StoreServiceClient: &fakeStoreClient{block: true}}
+
+		result, err := c.PullConcurrent(ctx, refs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cancel()
+
+		results, errs := collectResults(t, result)
+		if len(results) != 0 || len(errs) != 1 || status.Code(errors.Unwrap(errs[0])) != codes.Canceled {
+			t.Fatalf("unexpected results %v and errors %v", results, errs)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ServerStream defines the interface for server streaming (one input → many outputs).
+// This pattern is used when sending a single request and receiving multiple responses.
+type ServerStream[OutT any] interface {
+	Recv() (*OutT, error)
+}
+
+// ProcessServerStream handles server streaming pattern (one input → many outputs).
+//
+// Pattern: Recv → Recv → Recv → EOF
+//
+// The request must already have been sent when the stream was created.
+// The processor receives all responses until the server ends the stream.
+//
+// Returns:
+//   - result: StreamResult containing result, error, and done channels
+//   - error: Immediate error if validation fails
+func ProcessServerStream[OutT any](
+	ctx context.Context,
+	stream ServerStream[OutT],
+) (StreamResult[OutT], error) {
+	// Validate inputs
+	if ctx == nil {
+		return nil, errors.New("context is nil")
+	}
+
+	if stream == nil {
+		return nil, errors.New("stream is nil")
+	}
+
+	// Create result channels
+	result := newResult[OutT]()
+
+	go func() {
+		// Close result once the goroutine ends
+		defer result.close()
+
+		// If the context is cancelled, Recv() will return an error,
+		// which terminates this goroutine.
+		for {
+			output, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				result.errCh <- fmt.Errorf("failed to receive: %w", err)
+
+				return
+			}
+
+			// Send output to the output channel
+			result.resCh <- output
+		}
+	}()
+
+	return result, nil
+}
@@ -18,7 +18,7 @@ func (c *Client) CreateSync(ctx context.Context, remoteURL string, cids []string
 		Cids:               cids,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create sync: %w", err)
+		return "", fmt.Errorf("failed to create sync: %w", fromStatus(err))
 	}
 
 	return meta.GetSyncId(), nil
@@ -27,7 +27,7 @@ func (c *Client) CreateSync(ctx context.Context, remoteURL string, cids []string
 func (c *Client) ListSyncs(ctx context.Context, req *storev1.ListSyncsRequest) (<-chan *storev1.ListSyncsItem, error) {
 	stream, err := c.SyncServiceClient.ListSyncs(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create list syncs stream: %w", err)
+		return nil, fmt.Errorf("failed to create list syncs stream: %w", fromStatus(err))
 	}
 
 	resultCh := make(chan *storev1.ListSyncsItem)
@@ -63,7 +63,7 @@ func (c *Client) GetSync(ctx context.Context, syncID string) (*storev1.GetSyncRe
 		SyncId: syncID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get sync: %w", err)
+		return nil, fmt.Errorf("failed to get sync: %w", fromStatus(err))
 	}
 
 	return meta, nil
@@ -74,7 +74,7 @@ func (c *Client) DeleteSync(ctx context.Context, syncID string) error {
 		SyncId: syncID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete sync: %w", err)
+		return fmt.Errorf("failed to delete sync: %w", fromStatus(err))
 	}
 
 	return nil
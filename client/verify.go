@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto"
 	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 
@@ -19,11 +20,48 @@ import (
 )
 
 // Verify verifies the signature of the record.
+// If a Rekor URL is set, the signature must also be included in the Rekor transparency log for the record to be trusted.
+// The public keys of the default Rekor log are obtained from the Sigstore TUF root,
+// other logs must be verified with VerifyWithRekorPublicKey.
 func (c *Client) Verify(ctx context.Context, req *signv1.VerifyRequest) (*signv1.VerifyResponse, error) {
+	return c.VerifyWithRekorPublicKey(ctx, req, nil)
+}
+
+// VerifyWithRekorPublicKey verifies the signature of the record as Verify does,
+// verifying the Rekor transparency log against the given PEM-encoded trusted public key.
+func (c *Client) VerifyWithRekorPublicKey(ctx context.Context, req *signv1.VerifyRequest, rekorPublicKey []byte) (*signv1.VerifyResponse, error) {
+	response, err := c.verifySignature(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetRekorUrl() == "" {
+		return response, nil
+	}
+
+	err = c.verifyRekorInclusion(ctx, req.GetRecordRef().GetCid(), req.GetRekorUrl(), rekorPublicKey)
+	if err != nil {
+		logger.Debug("Rekor verification failed", "error", err)
+
+		errMsg := err.Error()
+		response.Success = false
+		response.ErrorMessage = &errMsg
+
+		return response, nil
+	}
+
+	response.RekorVerified = true
+
+	return response, nil
+}
+
+// verifySignature verifies the signature of the record on the server,
+// falling back to client-side verification.
+func (c *Client) verifySignature(ctx context.Context, req *signv1.VerifyRequest) (*signv1.VerifyResponse, error) {
 	// Server-side verification
 	response, err := c.SignServiceClient.Verify(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("server verification failed: %w", err)
+		return nil, fmt.Errorf("server verification failed: %w", fromStatus(err))
 	}
 
 	if response.GetSuccess() {
@@ -46,6 +84,126 @@ func (c *Client) Verify(ctx context.Context, req *signv1.VerifyRequest) (*signv1
 	}, nil
 }
 
+// VerifyWithPublicKey verifies the signature of the record against a trusted PEM-encoded public key.
+// The verification runs entirely on the client: unlike Verify, it neither relies on server-side
+// verification, which requires a Zot registry, nor on the public keys stored alongside the record.
+// The record is pulled to check that its content matches the CID the signature payload refers to.
+func (c *Client) VerifyWithPublicKey(ctx context.Context, recordCID string, publicKey []byte) (*signv1.VerifyResponse, error) {
+	verifier, err := sigs.LoadPublicKeyRaw(publicKey, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	record, err := c.Pull(ctx, &corev1.RecordRef{Cid: recordCID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.HasCid(recordCID) {
+		return failedVerification(fmt.Sprintf("record content does not match CID %s", recordCID)), nil
+	}
+
+	digest, err := corev1.ConvertCIDToDigest(recordCID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert CID to digest: %w", err)
+	}
+
+	payload, err := cosignutils.GeneratePayload(digest.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate expected payload: %w", err)
+	}
+
+	signatures, err := c.pullSignatureReferrer(ctx, recordCID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull signature referrer: %w", err)
+	}
+
+	if len(signatures) == 0 {
+		return failedVerification("no signature found for the record"), nil
+	}
+
+	for _, signature := range signatures {
+		err := verifier.VerifySignature(bytes.NewReader(decodeSignature(signature)), bytes.NewReader(payload))
+		if err == nil {
+			return &signv1.VerifyResponse{Success: true}, nil
+		}
+
+		logger.Debug("Signature verification failed, trying next signature", "error", err)
+	}
+
+	return failedVerification("no signature of the record was made with the public key"), nil
+}
+
+// failedVerification returns an unsuccessful verification response with the given reason.
+func failedVerification(reason string) *signv1.VerifyResponse {
+	return &signv1.VerifyResponse{
+		Success:      false,
+		ErrorMessage: &reason,
+	}
+}
+
+// verifyRekorInclusion checks that a signature of the record is included in the Rekor transparency log.
+// Keyless signatures are looked up by their signing certificate, other signatures by the record public keys.
+func (c *Client) verifyRekorInclusion(ctx context.Context, recordCID string, rekorURL string, rekorPublicKey []byte) error {
+	digest, err := corev1.ConvertCIDToDigest(recordCID)
+	if err != nil {
+		return fmt.Errorf("failed to convert CID to digest: %w", err)
+	}
+
+	payload, err := cosignutils.GeneratePayload(digest.String())
+	if err != nil {
+		return fmt.Errorf("failed to generate expected payload: %w", err)
+	}
+
+	signatures, err := c.pullSignatureReferrer(ctx, recordCID)
+	if err != nil {
+		return fmt.Errorf("failed to pull signature referrer: %w", err)
+	}
+
+	publicKeys, err := c.pullPublicKeyReferrer(ctx, recordCID)
+	if err != nil {
+		return fmt.Errorf("failed to pull public key referrer: %w", err)
+	}
+
+	lastErr := errors.New("no signature found in referrer responses")
+
+	for _, signature := range signatures {
+		verifiers := make([][]byte, 0, len(publicKeys))
+
+		if certificate := signature.GetCertificate(); certificate != "" {
+			der, err := base64.StdEncoding.DecodeString(certificate)
+			if err != nil {
+				logger.Debug("Failed to decode signing certificate, skipping", "error", err)
+
+				continue
+			}
+
+			verifiers = append(verifiers, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		} else {
+			for _, publicKey := range publicKeys {
+				verifiers = append(verifiers, []byte(publicKey))
+			}
+		}
+
+		for _, verifier := range verifiers {
+			err := cosignutils.VerifyRekorInclusion(ctx, &cosignutils.VerifyRekorOptions{
+				RekorURL:       rekorURL,
+				Payload:        payload,
+				Signature:      signature.GetSignature(),
+				Verifier:       verifier,
+				RekorPublicKey: rekorPublicKey,
+			})
+			if err == nil {
+				return nil
+			}
+
+			lastErr = err
+		}
+	}
+
+	return fmt.Errorf("signature not verified in Rekor transparency log: %w", lastErr)
+}
+
 // verifyClientSide performs client-side signature verification using OCI referrers.
 func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool, error) {
 	logger.Debug("Starting client-side verification", "recordCID", recordCID)
@@ -93,15 +251,8 @@ func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool,
 				continue
 			}
 
-			// Decode base64 signature if needed
-			signatureBytes, err := base64.StdEncoding.DecodeString(signature.GetSignature())
-			if err != nil {
-				// If decoding fails, assume it's already raw bytes
-				signatureBytes = []byte(signature.GetSignature())
-			}
-
 			// Verify signature against the expected payload
-			err = verifier.VerifySignature(bytes.NewReader(signatureBytes), bytes.NewReader(expectedPayload))
+			err = verifier.VerifySignature(bytes.NewReader(decodeSignature(signature)), bytes.NewReader(expectedPayload))
 			if err != nil {
 				// Verification failed for this combination, try the next one
 				logger.Debug("Signature verification failed, trying next combination", "error", err)
@@ -117,6 +268,17 @@ func (c *Client) verifyClientSide(ctx context.Context, recordCID string) (bool,
 	return false, nil
 }
 
+// decodeSignature returns the raw bytes of a signature, decoding it from base64 if needed.
+func decodeSignature(signature *signv1.Signature) []byte {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature.GetSignature())
+	if err != nil {
+		// If decoding fails, assume it's already raw bytes
+		return []byte(signature.GetSignature())
+	}
+
+	return signatureBytes
+}
+
 // pullSignatureReferrer retrieves the signature referrer for a record.
 func (c *Client) pullSignatureReferrer(ctx context.Context, recordCID string) ([]*signv1.Signature, error) {
 	signatureType := corev1.SignatureReferrerType
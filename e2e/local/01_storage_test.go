@@ -24,7 +24,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a local single no
 			ginkgo.Skip("Skipping test, not in local mode")
 		}
 
-		utils.ResetCLIState()
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
@@ -25,7 +25,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests to check search functio
 			ginkgo.Skip("Skipping test, not in local mode")
 		}
 
-		utils.ResetCLIState()
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
@@ -35,7 +35,6 @@ var _ = ginkgo.Describe("Running dirctl routing commands in local single node de
 			ginkgo.Skip("Skipping test, not in local mode")
 		}
 
-		utils.ResetCLIState()
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
@@ -55,7 +55,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests to check signature supp
 			ginkgo.Skip("Skipping test, not in local mode")
 		}
 
-		utils.ResetCLIState()
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
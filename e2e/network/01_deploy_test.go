@@ -74,9 +74,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 	})
 
 	ginkgo.It("should list local records correctly (List is local-only)", func() {
-		// Reset CLI state to ensure clean test environment
-		utils.ResetCLIState()
-
 		// Test that List only returns records on the peer that published them
 		// Peer1 published the record, so it should find it locally
 		output := cli.Routing().List().WithCid(cid).OnServer(utils.Peer1Addr).ShouldSucceed()
@@ -85,9 +82,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 		gomega.Expect(output).To(gomega.ContainSubstring(cid))
 		gomega.Expect(output).To(gomega.ContainSubstring("Local records"))
 
-		// Reset CLI state before testing Peer2
-		utils.ResetCLIState()
-
 		// Peer2 did NOT publish the record, so List should not find it locally
 		// (even though it might be available via DHT/network)
 		output2 := cli.Routing().List().WithCid(cid).OnServer(utils.Peer2Addr).ShouldSucceed()
@@ -97,9 +91,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 	})
 
 	ginkgo.It("should list by skill correctly on local vs remote peers", func() {
-		// Reset CLI state to ensure clean test environment
-		utils.ResetCLIState()
-
 		// Test Peer1 (published the record) - should find it locally
 		output1 := cli.Routing().List().WithSkill("natural_language_processing").OnServer(utils.Peer1Addr).ShouldSucceed()
 
@@ -109,9 +100,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 		gomega.Expect(output1).To(gomega.ContainSubstring("/skills/natural_language_processing/natural_language_generation/text_completion"))
 		gomega.Expect(output1).To(gomega.ContainSubstring("/skills/natural_language_processing/analytical_reasoning/problem_solving"))
 
-		// Reset CLI state again before testing Peer2
-		utils.ResetCLIState()
-
 		// Test Peer2 (did NOT publish the record) - should not find it locally
 		output2 := cli.Routing().List().WithSkill("natural_language_processing").OnServer(utils.Peer2Addr).ShouldSucceed()
 
@@ -121,9 +109,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 	})
 
 	ginkgo.It("should show routing info statistics", func() {
-		// Reset CLI state to ensure clean test environment
-		utils.ResetCLIState()
-
 		// Test routing info on Peer1 (has published records)
 		output1 := cli.Routing().Info().OnServer(utils.Peer1Addr).ShouldSucceed()
 
@@ -132,9 +117,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 		gomega.Expect(output1).To(gomega.ContainSubstring("Total Records:"))
 		gomega.Expect(output1).To(gomega.ContainSubstring("Skills Distribution"))
 
-		// Reset CLI state before testing Peer2
-		utils.ResetCLIState()
-
 		// Test routing info on Peer2 (no published records)
 		output2 := cli.Routing().Info().OnServer(utils.Peer2Addr).ShouldSucceed()
 
@@ -144,9 +126,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests using a network multi p
 	})
 
 	ginkgo.It("should discover remote records via routing search", func() {
-		// Reset CLI state to ensure clean test environment
-		utils.ResetCLIState()
-
 		// Test routing search from Peer2 to discover records published by Peer1
 		// This tests whether DHT propagation is working in the e2e environment
 		output := cli.Routing().Search().
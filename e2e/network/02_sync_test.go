@@ -45,8 +45,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests for sync commands", fun
 			ginkgo.Skip("Skipping test, not in network mode")
 		}
 
-		utils.ResetCLIState()
-
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
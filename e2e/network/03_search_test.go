@@ -40,9 +40,6 @@ var _ = ginkgo.Describe("Running dirctl end-to-end tests for remote routing sear
 			ginkgo.Skip("Skipping test, not in network mode")
 		}
 
-		// ✅ CRITICAL: Reset CLI state to prevent flag accumulation across test executions
-		utils.ResetCLIState()
-
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
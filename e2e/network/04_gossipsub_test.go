@@ -43,9 +43,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			ginkgo.Skip("Skipping test, not in network mode")
 		}
 
-		// Reset CLI state to ensure clean test environment
-		utils.ResetCLIState()
-
 		// Initialize CLI helper
 		cli = utils.NewCLI()
 	})
@@ -76,7 +73,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 			// Verify Peer2 received labels via GossipSub
 			ginkgo.GinkgoWriter.Printf("Testing label discovery on Peer2...")
-			utils.ResetCLIState()
 			output2 := cli.Routing().Search().
 				WithSkill("natural_language_processing").
 				WithLimit(10).
@@ -88,7 +84,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 			// Verify Peer3 also received labels via GossipSub
 			ginkgo.GinkgoWriter.Printf("Testing label discovery on Peer3...")
-			utils.ResetCLIState()
 			output3 := cli.Routing().Search().
 				WithSkill("natural_language_processing").
 				WithLimit(10).
@@ -103,7 +98,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 		ginkgo.It("should verify labels are discoverable from both remote peers", func() {
 			// Additional verification with different skill query
-			utils.ResetCLIState()
 			output2 := cli.Routing().Search().
 				WithSkill("natural_language_processing/natural_language_generation/text_completion").
 				OnServer(utils.Peer2Addr).
@@ -112,7 +106,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			gomega.Expect(output2).To(gomega.ContainSubstring(cid))
 			gomega.Expect(output2).To(gomega.ContainSubstring("match_score"))
 
-			utils.ResetCLIState()
 			output3 := cli.Routing().Search().
 				WithSkill("natural_language_processing/analytical_reasoning/problem_solving").
 				OnServer(utils.Peer3Addr).
@@ -149,7 +142,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 			// Poll for label discovery with short intervals
 			// GossipSub should propagate in ~2-5 seconds
-			utils.ResetCLIState()
 			output := cli.Routing().Search().
 				WithSkill("natural_language_processing").
 				OnServer(utils.Peer2Addr).
@@ -203,7 +195,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			time.Sleep(10 * time.Second)
 
 			// Verify all 5 records are discoverable from Peer2
-			utils.ResetCLIState()
 			successCount := 0
 			for i, bulkCID := range bulkCIDs {
 				output := cli.Routing().Search().
@@ -218,8 +209,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 				} else {
 					ginkgo.GinkgoWriter.Printf("❌ Bulk record %d/%d NOT found on Peer2", i+1, 5)
 				}
-
-				utils.ResetCLIState()
 			}
 
 			// All 5 should be discoverable
@@ -231,7 +220,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 		ginkgo.It("should verify bulk records are also discoverable from peer 3", func() {
 			// Verify propagation to Peer3 as well (proves mesh propagation)
-			utils.ResetCLIState()
 			successCount := 0
 			for i, bulkCID := range bulkCIDs {
 				output := cli.Routing().Search().
@@ -244,8 +232,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 					successCount++
 					ginkgo.GinkgoWriter.Printf("✅ Bulk record %d/%d discovered on Peer3", i+1, 5)
 				}
-
-				utils.ResetCLIState()
 			}
 
 			gomega.Expect(successCount).To(gomega.Equal(5),
@@ -274,7 +260,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			time.Sleep(5 * time.Second)
 
 			// Test search with OR logic across multiple label types
-			utils.ResetCLIState()
 			output := cli.Routing().Search().
 				WithSkill("natural_language_processing"). // Should match
 				WithDomain("life_science").               // Should match (record has life_science/biotechnology)
@@ -295,7 +280,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			// This ensures the fallback to pull is NOT triggered on subsequent searches
 
 			// First search
-			utils.ResetCLIState()
 			output1 := cli.Routing().Search().
 				WithSkill("natural_language_processing").
 				OnServer(utils.Peer2Addr).
@@ -303,7 +287,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			gomega.Expect(output1).To(gomega.ContainSubstring(edgeCID))
 
 			// Second search (should use cached labels, not pull again)
-			utils.ResetCLIState()
 			output2 := cli.Routing().Search().
 				WithSkill("natural_language_processing/analytical_reasoning/problem_solving").
 				OnServer(utils.Peer2Addr).
@@ -311,7 +294,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 			gomega.Expect(output2).To(gomega.ContainSubstring(edgeCID))
 
 			// Third search with different peer
-			utils.ResetCLIState()
 			output3 := cli.Routing().Search().
 				WithSkill("natural_language_processing/natural_language_generation").
 				OnServer(utils.Peer3Addr).
@@ -340,7 +322,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 
 			// Poll for discovery with 1-second intervals
 			ginkgo.GinkgoWriter.Printf("Polling for label discovery (max 10 seconds)...")
-			utils.ResetCLIState()
 
 			found := false
 			maxAttempts := 10
@@ -364,7 +345,6 @@ var _ = ginkgo.Describe("Running GossipSub label announcement tests", ginkgo.Ord
 				}
 
 				time.Sleep(1 * time.Second)
-				utils.ResetCLIState()
 			}
 
 			gomega.Expect(found).To(gomega.BeTrue(), "Labels should be discovered within 10 seconds via GossipSub")
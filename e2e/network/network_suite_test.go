@@ -65,7 +65,6 @@ var _ = ginkgo.BeforeSuite(func() {
 	// Final verification - ensure all routing systems functional
 	ginkgo.GinkgoWriter.Println("⏳ Final routing system verification...")
 	for i, peerAddr := range utils.PeerAddrs {
-		utils.ResetCLIState()
 		ginkgo.GinkgoWriter.Printf("  Peer%d routing...", i+1)
 		cli.Routing().Info().OnServer(peerAddr).ShouldSucceed()
 		ginkgo.GinkgoWriter.Println(" ✅")
@@ -4,7 +4,6 @@
 package utils
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -37,23 +36,20 @@ func ValidateCIDFormat(cidString string) bool {
 
 // MarshalOASFCanonical marshals OASF JSON data using canonical JSON serialization.
 // This ensures deterministic, cross-language compatible byte representation.
-// Mirrors the logic from api/core/v1/oasf.go marshalOASFCanonical function.
+// Uses the same logic as the server, see corev1.Record.Marshal.
 func MarshalOASFCanonical(data []byte) ([]byte, error) {
 	if len(data) == 0 {
 		return nil, errors.New("cannot marshal empty data")
 	}
 
-	// Step 1: Parse the JSON to ensure it's valid
-	var normalized interface{}
-	if err := json.Unmarshal(data, &normalized); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON for canonical marshaling: %w", err)
+	record, err := corev1.UnmarshalRecord(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse record for canonical marshaling: %w", err)
 	}
 
-	// Step 2: Marshal with sorted keys for deterministic output
-	// encoding/json.Marshal sorts map keys alphabetically
-	canonicalBytes, err := json.Marshal(normalized)
+	canonicalBytes, err := record.Marshal()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal canonical JSON with sorted keys: %w", err)
+		return nil, fmt.Errorf("failed to marshal canonical record: %w", err)
 	}
 
 	return canonicalBytes, nil
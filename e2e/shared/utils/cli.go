@@ -5,6 +5,7 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -207,11 +208,7 @@ func (s *StdinCommandBuilder) Execute() (string, error) {
 
 	var errorBuffer bytes.Buffer
 
-	cmd := clicmd.RootCmd
-
-	// Store original stdin to restore later
-	originalIn := cmd.InOrStdin()
-
+	cmd := clicmd.NewRootCommand()
 	cmd.SetOut(&outputBuffer)
 
 	if s.suppressErr {
@@ -222,12 +219,9 @@ func (s *StdinCommandBuilder) Execute() (string, error) {
 	cmd.SetIn(strings.NewReader(s.stdinInput))
 	cmd.SetArgs(args)
 
-	err := cmd.Execute()
+	err := clicmd.Execute(context.Background(), cmd)
 	output := strings.TrimSpace(outputBuffer.String())
 
-	// Restore original stdin
-	cmd.SetIn(originalIn)
-
 	if err != nil {
 		return output, fmt.Errorf("command execution failed: %w", err)
 	}
@@ -296,7 +290,7 @@ func (c *CommandBuilder) Execute() (string, error) {
 
 	var errorBuffer bytes.Buffer
 
-	cmd := clicmd.RootCmd
+	cmd := clicmd.NewRootCommand()
 	cmd.SetOut(&outputBuffer)
 
 	if c.suppressErr {
@@ -305,7 +299,7 @@ func (c *CommandBuilder) Execute() (string, error) {
 
 	cmd.SetArgs(args)
 
-	err := cmd.Execute()
+	err := clicmd.Execute(context.Background(), cmd)
 	output := strings.TrimSpace(outputBuffer.String())
 
 	if err != nil {
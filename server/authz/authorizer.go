@@ -22,8 +22,10 @@ var modelConf string
 // by users outside of our trust domain.
 var allowedExternalAPIMethods = []string{
 	storev1.StoreService_Pull_FullMethodName,                      // store: pull
+	storev1.StoreService_PullStream_FullMethodName,                // store: batch pull
 	storev1.StoreService_PullReferrer_FullMethodName,              // store: pull referrer
 	storev1.StoreService_Lookup_FullMethodName,                    // store: lookup
+	storev1.StoreService_Exists_FullMethodName,                    // store: exists
 	storev1.SyncService_RequestRegistryCredentials_FullMethodName, // sync: negotiate
 }
 
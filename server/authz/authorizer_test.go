@@ -32,6 +32,8 @@ func TestAuthorizer(t *testing.T) {
 		// anyone else: only pull/lookup/sync
 		{"other.com", storev1.StoreService_Pull_FullMethodName, true},
 		{"other.com", storev1.StoreService_Lookup_FullMethodName, true},
+		{"other.com", storev1.StoreService_PullStream_FullMethodName, true},
+		{"other.com", storev1.StoreService_Exists_FullMethodName, true},
 		{"other.com", storev1.SyncService_RequestRegistryCredentials_FullMethodName, true},
 		{"other.com", storev1.StoreService_Push_FullMethodName, false},
 		{"other.com", routingv1.RoutingService_Publish_FullMethodName, false},
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/agntcy/dir/server"
@@ -11,12 +12,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var configPath string
+
 var rootCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Run a server for the Directory services.",
 	Long:  "Run a server for the Directory services.",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		cfg, err := config.LoadConfig()
+		cfg, err := config.LoadConfigFile(configPath)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
@@ -25,6 +28,54 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate-config",
+	Short: "Validate the server configuration without starting the server.",
+	Long: `Validate the server configuration without starting the server.
+
+The configuration is loaded from the config file and the environment, as on startup,
+and every setting is checked. All problems found are reported.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg, err := config.LoadConfigFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		err = server.ValidateConfig(cfg)
+		if err == nil {
+			fmt.Fprintln(cmd.OutOrStdout(), "Configuration is valid")
+
+			return nil
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "Configuration is invalid:")
+
+		for _, problem := range unwrapJoined(err) {
+			fmt.Fprintf(cmd.OutOrStdout(), "  - %v\n", problem)
+		}
+
+		cmd.SilenceUsage = true
+
+		return errors.New("invalid configuration")
+	},
+}
+
+// unwrapJoined returns the errors joined with errors.Join, or the error itself.
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		return joined.Unwrap()
+	}
+
+	return []error{err}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "",
+		fmt.Sprintf("Path to the config file (default %s/%s.%s)", config.DefaultConfigPath, config.DefaultConfigName, config.DefaultConfigType))
+
+	rootCmd.AddCommand(validateConfigCmd)
+}
+
 func main() {
 	cobra.CheckErr(rootCmd.Execute())
 }
@@ -12,12 +12,18 @@ import (
 	authz "github.com/agntcy/dir/server/authz/config"
 	database "github.com/agntcy/dir/server/database/config"
 	sqliteconfig "github.com/agntcy/dir/server/database/sqlite/config"
+	expiry "github.com/agntcy/dir/server/expiry/config"
+	metrics "github.com/agntcy/dir/server/metrics/config"
 	publication "github.com/agntcy/dir/server/publication/config"
+	ratelimit "github.com/agntcy/dir/server/ratelimit/config"
 	routing "github.com/agntcy/dir/server/routing/config"
 	store "github.com/agntcy/dir/server/store/config"
 	oci "github.com/agntcy/dir/server/store/oci/config"
+	upstream "github.com/agntcy/dir/server/store/upstream/config"
+	webhook "github.com/agntcy/dir/server/store/webhook/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	syncmonitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -35,6 +41,7 @@ const (
 
 	DefaultListenAddress      = "0.0.0.0:8888"
 	DefaultHealthCheckAddress = "0.0.0.0:8889"
+	DefaultEnableReflection   = false
 )
 
 var logger = logging.Logger("config")
@@ -44,12 +51,24 @@ type Config struct {
 	ListenAddress      string `json:"listen_address,omitempty"      mapstructure:"listen_address"`
 	HealthCheckAddress string `json:"healthcheck_address,omitempty" mapstructure:"healthcheck_address"`
 
+	// Register the gRPC reflection service, letting tools such as grpcurl list and call the API.
+	// Disabled by default, as it exposes the full API surface to every client.
+	EnableReflection bool `json:"enable_reflection,omitempty" mapstructure:"enable_reflection"`
+
+	// Signature algorithms accepted on push and trusted on verification, e.g. "ED25519".
+	// The algorithm is derived from the signing key or certificate, never taken from the signature.
+	// If empty, all algorithms are allowed, which is the default.
+	AllowedSignatureAlgorithms []string `json:"allowed_signature_algorithms,omitempty" mapstructure:"allowed_signature_algorithms"`
+
 	// Authn configuration (JWT or X.509 authentication)
 	Authn authn.Config `json:"authn,omitempty" mapstructure:"authn"`
 
 	// Authz configuration
 	Authz authz.Config `json:"authz,omitempty" mapstructure:"authz"`
 
+	// Rate limiting configuration
+	RateLimit ratelimit.Config `json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+
 	// Store configuration
 	Store store.Config `json:"store,omitempty" mapstructure:"store"`
 
@@ -64,17 +83,46 @@ type Config struct {
 
 	// Publication configuration
 	Publication publication.Config `json:"publication,omitempty" mapstructure:"publication"`
+
+	// Record expiry configuration
+	Expiry expiry.Config `json:"expiry,omitempty" mapstructure:"expiry"`
+
+	// Metrics configuration
+	Metrics metrics.Config `json:"metrics,omitempty" mapstructure:"metrics"`
+
+	// Tracing configuration
+	Tracing tracing.Config `json:"tracing,omitempty" mapstructure:"tracing"`
+
+	// Logging configuration.
+	// Settings left empty keep the value of the DIRECTORY_LOGGER_* environment variables.
+	// Log levels and format can be changed at runtime by reloading the configuration.
+	Logging logging.Config `json:"logging,omitempty" mapstructure:"logging"`
+
+	// ConfigFile is the path of the loaded config file, empty if none was found.
+	// It is used to reload the configuration at runtime.
+	ConfigFile string `json:"-" mapstructure:"-"`
 }
 
+// LoadConfig loads the configuration from the default config file and the environment.
 func LoadConfig() (*Config, error) {
+	return LoadConfigFile("")
+}
+
+// LoadConfigFile loads the configuration from the given config file and the environment.
+// If path is empty, the default config file is used if it exists.
+func LoadConfigFile(path string) (*Config, error) {
 	v := viper.NewWithOptions(
 		viper.KeyDelimiter("."),
 		viper.EnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_")),
 	)
 
-	v.SetConfigName(DefaultConfigName)
-	v.SetConfigType(DefaultConfigType)
-	v.AddConfigPath(DefaultConfigPath)
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName(DefaultConfigName)
+		v.SetConfigType(DefaultConfigType)
+		v.AddConfigPath(DefaultConfigPath)
+	}
 
 	v.SetEnvPrefix(DefaultEnvPrefix)
 	v.AllowEmptyEnv(true)
@@ -99,6 +147,11 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("healthcheck_address")
 	v.SetDefault("healthcheck_address", DefaultHealthCheckAddress)
 
+	_ = v.BindEnv("enable_reflection")
+	v.SetDefault("enable_reflection", DefaultEnableReflection)
+
+	_ = v.BindEnv("allowed_signature_algorithms")
+
 	//
 	// Authn configuration (authentication: JWT or X.509)
 	//
@@ -123,6 +176,24 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("authz.trust_domain")
 	v.SetDefault("authz.trust_domain", "")
 
+	//
+	// Rate limiting configuration (per client)
+	//
+	_ = v.BindEnv("rate_limit.enabled")
+	v.SetDefault("rate_limit.enabled", ratelimit.DefaultRateLimitEnabled)
+
+	_ = v.BindEnv("rate_limit.read.requests_per_second")
+	v.SetDefault("rate_limit.read.requests_per_second", ratelimit.DefaultReadRequestsPerSecond)
+
+	_ = v.BindEnv("rate_limit.read.burst")
+	v.SetDefault("rate_limit.read.burst", ratelimit.DefaultReadBurst)
+
+	_ = v.BindEnv("rate_limit.write.requests_per_second")
+	v.SetDefault("rate_limit.write.requests_per_second", ratelimit.DefaultWriteRequestsPerSecond)
+
+	_ = v.BindEnv("rate_limit.write.burst")
+	v.SetDefault("rate_limit.write.burst", ratelimit.DefaultWriteBurst)
+
 	//
 	// Store configuration
 	//
@@ -141,6 +212,18 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("store.oci.repository_name")
 	v.SetDefault("store.oci.repository_name", oci.DefaultRepositoryName)
 
+	_ = v.BindEnv("store.oci.compression")
+	v.SetDefault("store.oci.compression", oci.DefaultCompression)
+
+	_ = v.BindEnv("store.oci.max_record_bytes")
+	v.SetDefault("store.oci.max_record_bytes", oci.DefaultMaxRecordBytes)
+
+	_ = v.BindEnv("store.oci.canonical_mode")
+	v.SetDefault("store.oci.canonical_mode", oci.DefaultCanonicalMode)
+
+	_ = v.BindEnv("store.oci.digest_algorithm")
+	v.SetDefault("store.oci.digest_algorithm", oci.DefaultDigestAlgorithm)
+
 	_ = v.BindEnv("store.oci.auth_config.insecure")
 	v.SetDefault("store.oci.auth_config.insecure", oci.DefaultAuthConfigInsecure)
 
@@ -148,6 +231,40 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("store.oci.auth_config.password")
 	_ = v.BindEnv("store.oci.auth_config.access_token")
 	_ = v.BindEnv("store.oci.auth_config.refresh_token")
+	_ = v.BindEnv("store.oci.auth_config.oauth2.token_url")
+	_ = v.BindEnv("store.oci.auth_config.oauth2.client_id")
+	_ = v.BindEnv("store.oci.auth_config.oauth2.client_secret")
+	_ = v.BindEnv("store.oci.auth_config.oauth2.scope")
+
+	_ = v.BindEnv("store.multi.quorum")
+	v.SetDefault("store.multi.quorum", store.DefaultMultiQuorum)
+
+	_ = v.BindEnv("store.webhook.url")
+	v.SetDefault("store.webhook.url", "")
+
+	_ = v.BindEnv("store.webhook.timeout")
+	v.SetDefault("store.webhook.timeout", webhook.DefaultTimeout)
+
+	_ = v.BindEnv("store.webhook.queue_size")
+	v.SetDefault("store.webhook.queue_size", webhook.DefaultQueueSize)
+
+	_ = v.BindEnv("store.webhook.max_retries")
+	v.SetDefault("store.webhook.max_retries", webhook.DefaultMaxRetries)
+
+	_ = v.BindEnv("store.webhook.initial_backoff")
+	v.SetDefault("store.webhook.initial_backoff", webhook.DefaultInitialBackoff)
+
+	_ = v.BindEnv("store.webhook.max_backoff")
+	v.SetDefault("store.webhook.max_backoff", webhook.DefaultMaxBackoff)
+
+	_ = v.BindEnv("store.upstream.address")
+	v.SetDefault("store.upstream.address", "")
+
+	_ = v.BindEnv("store.upstream.timeout")
+	v.SetDefault("store.upstream.timeout", upstream.DefaultTimeout)
+
+	_ = v.BindEnv("store.upstream.tls")
+	v.SetDefault("store.upstream.tls", upstream.DefaultTLS)
 
 	//
 	// Routing configuration
@@ -164,9 +281,63 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("routing.key_path")
 	v.SetDefault("routing.key_path", "")
 
+	_ = v.BindEnv("routing.rendezvous")
+	v.SetDefault("routing.rendezvous", routing.DefaultRendezvous)
+
+	_ = v.BindEnv("routing.protocol_prefix")
+	v.SetDefault("routing.protocol_prefix", routing.DefaultProtocolPrefix)
+
 	_ = v.BindEnv("routing.datastore_dir")
 	v.SetDefault("routing.datastore_dir", "")
 
+	_ = v.BindEnv("routing.datastore_backend")
+	v.SetDefault("routing.datastore_backend", "")
+
+	_ = v.BindEnv("routing.datastore_namespace")
+	v.SetDefault("routing.datastore_namespace", "")
+
+	_ = v.BindEnv("routing.republish_interval")
+	v.SetDefault("routing.republish_interval", routing.DefaultRepublishInterval)
+
+	_ = v.BindEnv("routing.republish_jitter")
+	v.SetDefault("routing.republish_jitter", routing.DefaultRepublishJitter)
+
+	_ = v.BindEnv("routing.republish_rate")
+	v.SetDefault("routing.republish_rate", routing.DefaultRepublishRate)
+
+	_ = v.BindEnv("routing.peer_address_ttl")
+	v.SetDefault("routing.peer_address_ttl", routing.DefaultPeerAddressTTL)
+
+	_ = v.BindEnv("routing.label_max_ages")
+	v.SetDefault("routing.label_max_ages", "")
+
+	_ = v.BindEnv("routing.allowed_peers")
+	v.SetDefault("routing.allowed_peers", "")
+
+	_ = v.BindEnv("routing.denied_peers")
+	v.SetDefault("routing.denied_peers", "")
+
+	_ = v.BindEnv("routing.label_namespaces")
+	v.SetDefault("routing.label_namespaces", "")
+
+	_ = v.BindEnv("routing.label_normalization.unicode")
+	v.SetDefault("routing.label_normalization.unicode", routing.DefaultLabelNormalizationUnicode)
+
+	_ = v.BindEnv("routing.label_normalization.case_fold")
+	v.SetDefault("routing.label_normalization.case_fold", routing.DefaultLabelNormalizationCaseFold)
+
+	_ = v.BindEnv("routing.max_concurrent_pulls")
+	v.SetDefault("routing.max_concurrent_pulls", routing.DefaultMaxConcurrentPulls)
+
+	_ = v.BindEnv("routing.search_timeout")
+	v.SetDefault("routing.search_timeout", routing.DefaultSearchTimeout)
+
+	_ = v.BindEnv("routing.label_resolution_timeout")
+	v.SetDefault("routing.label_resolution_timeout", routing.DefaultLabelResolutionTimeout)
+
+	_ = v.BindEnv("routing.max_search_limit")
+	v.SetDefault("routing.max_search_limit", routing.DefaultMaxSearchLimit)
+
 	//
 	// Routing GossipSub configuration
 	// Note: Only enable/disable is configurable. Protocol parameters (topic, message size)
@@ -175,6 +346,24 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("routing.gossipsub.enabled")
 	v.SetDefault("routing.gossipsub.enabled", routing.DefaultGossipSubEnabled)
 
+	_ = v.BindEnv("routing.verify_announcements.enabled")
+	v.SetDefault("routing.verify_announcements.enabled", routing.DefaultVerifyAnnouncementsEnabled)
+
+	_ = v.BindEnv("routing.verify_announcements.sample_rate")
+	v.SetDefault("routing.verify_announcements.sample_rate", routing.DefaultVerifyAnnouncementsSampleRate)
+
+	_ = v.BindEnv("routing.announcement_limits.max_labels")
+	v.SetDefault("routing.announcement_limits.max_labels", routing.DefaultAnnouncementMaxLabels)
+
+	_ = v.BindEnv("routing.announcement_limits.rate")
+	v.SetDefault("routing.announcement_limits.rate", routing.DefaultAnnouncementRate)
+
+	_ = v.BindEnv("routing.announcement_limits.burst")
+	v.SetDefault("routing.announcement_limits.burst", routing.DefaultAnnouncementBurst)
+
+	_ = v.BindEnv("routing.max_labels_per_record")
+	v.SetDefault("routing.max_labels_per_record", routing.DefaultMaxLabelsPerRecord)
+
 	//
 	// Database configuration
 	//
@@ -197,6 +386,15 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("sync.worker_timeout")
 	v.SetDefault("sync.worker_timeout", sync.DefaultSyncWorkerTimeout)
 
+	_ = v.BindEnv("sync.credentials_store")
+	v.SetDefault("sync.credentials_store", sync.DefaultCredentialsStore)
+
+	_ = v.BindEnv("sync.credentials_dir")
+	v.SetDefault("sync.credentials_dir", "")
+
+	_ = v.BindEnv("sync.credentials_secret_path")
+	v.SetDefault("sync.credentials_secret_path", "")
+
 	_ = v.BindEnv("sync.registry_monitor.check_interval")
 	v.SetDefault("sync.registry_monitor.check_interval", syncmonitor.DefaultCheckInterval)
 
@@ -216,6 +414,45 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("publication.worker_timeout")
 	v.SetDefault("publication.worker_timeout", publication.DefaultPublicationWorkerTimeout)
 
+	//
+	// Record expiry configuration
+	//
+
+	_ = v.BindEnv("expiry.sweep_interval")
+	v.SetDefault("expiry.sweep_interval", expiry.DefaultExpirySweepInterval)
+
+	_ = v.BindEnv("expiry.hide_expired")
+	v.SetDefault("expiry.hide_expired", expiry.DefaultExpiryHideExpired)
+
+	//
+	// Metrics configuration
+	//
+	_ = v.BindEnv("metrics.enabled")
+	v.SetDefault("metrics.enabled", metrics.DefaultMetricsEnabled)
+
+	_ = v.BindEnv("metrics.listen_address")
+	v.SetDefault("metrics.listen_address", metrics.DefaultMetricsListenAddress)
+
+	//
+	// Tracing configuration
+	//
+	_ = v.BindEnv("tracing.exporter")
+	v.SetDefault("tracing.exporter", tracing.DefaultTracingExporter)
+
+	_ = v.BindEnv("tracing.sample_ratio")
+	v.SetDefault("tracing.sample_ratio", tracing.DefaultTracingSampleRatio)
+
+	_ = v.BindEnv("tracing.service_name")
+	v.SetDefault("tracing.service_name", tracing.DefaultTracingServiceName)
+
+	//
+	// Logging configuration
+	//
+	_ = v.BindEnv("logging.log_file")
+	_ = v.BindEnv("logging.log_level")
+	_ = v.BindEnv("logging.log_format")
+	_ = v.BindEnv("logging.log_levels")
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
@@ -228,5 +465,7 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	config.ConfigFile = v.ConfigFileUsed()
+
 	return config, nil
 }
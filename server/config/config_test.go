@@ -5,6 +5,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,12 +14,19 @@ import (
 	authz "github.com/agntcy/dir/server/authz/config"
 	database "github.com/agntcy/dir/server/database/config"
 	sqliteconfig "github.com/agntcy/dir/server/database/sqlite/config"
+	expiry "github.com/agntcy/dir/server/expiry/config"
+	metrics "github.com/agntcy/dir/server/metrics/config"
 	publication "github.com/agntcy/dir/server/publication/config"
+	ratelimit "github.com/agntcy/dir/server/ratelimit/config"
 	routing "github.com/agntcy/dir/server/routing/config"
 	store "github.com/agntcy/dir/server/store/config"
 	oci "github.com/agntcy/dir/server/store/oci/config"
+	upstream "github.com/agntcy/dir/server/store/upstream/config"
+	webhook "github.com/agntcy/dir/server/store/webhook/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	monitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
+	"github.com/agntcy/dir/utils/logging"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,38 +39,102 @@ func TestConfig(t *testing.T) {
 		{
 			Name: "Custom config",
 			EnvVars: map[string]string{
-				"DIRECTORY_SERVER_LISTEN_ADDRESS":                       "example.com:8889",
-				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                  "example.com:18888",
-				"DIRECTORY_SERVER_STORE_PROVIDER":                       "provider",
-				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                  "local-dir",
-				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":           "example.com:5001",
-				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":            "test-dir",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":       "true",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":       "username",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":       "password",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":   "access-token",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":  "refresh-token",
-				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":               "/ip4/1.1.1.1/tcp/1",
-				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":              "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
-				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                     "/path/to/key",
-				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                     "sqlite",
-				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":              "sqlite.db",
-				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":              "1s",
-				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                    "1",
-				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL": "10s",
-				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                  "10s",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":            "sync-user",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":            "sync-password",
-				"DIRECTORY_SERVER_AUTHZ_ENABLED":                        "true",
-				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                    "/test/agent.sock",
-				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                   "dir.com",
-				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":       "10s",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":             "1",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":           "10s",
+				"DIRECTORY_SERVER_LISTEN_ADDRESS":                             "example.com:8889",
+				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                        "example.com:18888",
+				"DIRECTORY_SERVER_ENABLE_REFLECTION":                          "true",
+				"DIRECTORY_SERVER_ALLOWED_SIGNATURE_ALGORITHMS":               "ED25519,RSA_SHA256",
+				"DIRECTORY_SERVER_STORE_PROVIDER":                             "provider",
+				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                        "local-dir",
+				"DIRECTORY_SERVER_STORE_OCI_COMPRESSION":                      "zstd",
+				"DIRECTORY_SERVER_STORE_OCI_MAX_RECORD_BYTES":                 "1048576",
+				"DIRECTORY_SERVER_STORE_OCI_CANONICAL_MODE":                   "jcs",
+				"DIRECTORY_SERVER_STORE_OCI_DIGEST_ALGORITHM":                 "sha512",
+				"DIRECTORY_SERVER_STORE_MULTI_QUORUM":                         "2",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_URL":                          "https://hooks.example.com/dir",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_TIMEOUT":                      "5s",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_QUEUE_SIZE":                   "10",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_MAX_RETRIES":                  "3",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_INITIAL_BACKOFF":              "2s",
+				"DIRECTORY_SERVER_STORE_WEBHOOK_MAX_BACKOFF":                  "1m",
+				"DIRECTORY_SERVER_STORE_UPSTREAM_ADDRESS":                     "central.example.com:8888",
+				"DIRECTORY_SERVER_STORE_UPSTREAM_TIMEOUT":                     "3s",
+				"DIRECTORY_SERVER_STORE_UPSTREAM_TLS":                         "true",
+				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":                 "example.com:5001",
+				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":                  "test-dir",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":             "true",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":             "username",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":             "password",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":         "access-token",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":        "refresh-token",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_OAUTH2_TOKEN_URL":     "https://auth.example.com/token",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_OAUTH2_CLIENT_ID":     "client-id",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_OAUTH2_CLIENT_SECRET": "client-secret",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_OAUTH2_SCOPE":         "registry:catalog:*",
+				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":                     "/ip4/1.1.1.1/tcp/1",
+				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":                    "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
+				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                           "/path/to/key",
+				"DIRECTORY_SERVER_ROUTING_RENDEZVOUS":                         "dir/staging",
+				"DIRECTORY_SERVER_ROUTING_PROTOCOL_PREFIX":                    "dir-staging",
+				"DIRECTORY_SERVER_ROUTING_DATASTORE_BACKEND":                  "leveldb",
+				"DIRECTORY_SERVER_ROUTING_DATASTORE_NAMESPACE":                "dir/routing",
+				"DIRECTORY_SERVER_ROUTING_REPUBLISH_INTERVAL":                 "12h",
+				"DIRECTORY_SERVER_ROUTING_REPUBLISH_JITTER":                   "10m",
+				"DIRECTORY_SERVER_ROUTING_REPUBLISH_RATE":                     "2",
+				"DIRECTORY_SERVER_ROUTING_PEER_ADDRESS_TTL":                   "24h",
+				"DIRECTORY_SERVER_ROUTING_ALLOWED_PEERS":                      "peer-a,peer-b",
+				"DIRECTORY_SERVER_ROUTING_DENIED_PEERS":                       "peer-c",
+				"DIRECTORY_SERVER_ROUTING_LABEL_MAX_AGES":                     "locators=12h,skills=168h",
+				"DIRECTORY_SERVER_ROUTING_LABEL_NAMESPACES":                   "features,tags",
+				"DIRECTORY_SERVER_ROUTING_LABEL_NORMALIZATION_UNICODE":        "true",
+				"DIRECTORY_SERVER_ROUTING_LABEL_NORMALIZATION_CASE_FOLD":      "true",
+				"DIRECTORY_SERVER_ROUTING_MAX_CONCURRENT_PULLS":               "4",
+				"DIRECTORY_SERVER_ROUTING_SEARCH_TIMEOUT":                     "10s",
+				"DIRECTORY_SERVER_ROUTING_LABEL_RESOLUTION_TIMEOUT":           "1s",
+				"DIRECTORY_SERVER_ROUTING_MAX_SEARCH_LIMIT":                   "250",
+				"DIRECTORY_SERVER_ROUTING_VERIFY_ANNOUNCEMENTS_ENABLED":       "true",
+				"DIRECTORY_SERVER_ROUTING_VERIFY_ANNOUNCEMENTS_SAMPLE_RATE":   "0.5",
+				"DIRECTORY_SERVER_ROUTING_ANNOUNCEMENT_LIMITS_MAX_LABELS":     "20",
+				"DIRECTORY_SERVER_ROUTING_ANNOUNCEMENT_LIMITS_RATE":           "2.5",
+				"DIRECTORY_SERVER_ROUTING_ANNOUNCEMENT_LIMITS_BURST":          "5",
+				"DIRECTORY_SERVER_ROUTING_MAX_LABELS_PER_RECORD":              "50",
+				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                           "sqlite",
+				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":                    "sqlite.db",
+				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":                    "1s",
+				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                          "1",
+				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL":       "10s",
+				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                        "10s",
+				"DIRECTORY_SERVER_SYNC_CREDENTIALS_STORE":                     "secret",
+				"DIRECTORY_SERVER_SYNC_CREDENTIALS_DIR":                       "/var/lib/zot",
+				"DIRECTORY_SERVER_SYNC_CREDENTIALS_SECRET_PATH":               "/run/secrets/zot-credentials.json",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":                  "sync-user",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":                  "sync-password",
+				"DIRECTORY_SERVER_AUTHZ_ENABLED":                              "true",
+				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                          "/test/agent.sock",
+				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                         "dir.com",
+				"DIRECTORY_SERVER_RATE_LIMIT_ENABLED":                         "true",
+				"DIRECTORY_SERVER_RATE_LIMIT_READ_REQUESTS_PER_SECOND":        "50",
+				"DIRECTORY_SERVER_RATE_LIMIT_READ_BURST":                      "60",
+				"DIRECTORY_SERVER_RATE_LIMIT_WRITE_REQUESTS_PER_SECOND":       "0.5",
+				"DIRECTORY_SERVER_RATE_LIMIT_WRITE_BURST":                     "2",
+				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":             "10s",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":                   "1",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":                 "10s",
+				"DIRECTORY_SERVER_EXPIRY_SWEEP_INTERVAL":                      "30s",
+				"DIRECTORY_SERVER_EXPIRY_HIDE_EXPIRED":                        "true",
+				"DIRECTORY_SERVER_METRICS_ENABLED":                            "true",
+				"DIRECTORY_SERVER_METRICS_LISTEN_ADDRESS":                     "0.0.0.0:9191",
+				"DIRECTORY_SERVER_TRACING_EXPORTER":                           "log",
+				"DIRECTORY_SERVER_TRACING_SAMPLE_RATIO":                       "0.5",
+				"DIRECTORY_SERVER_TRACING_SERVICE_NAME":                       "dir-test",
+				"DIRECTORY_SERVER_LOGGING_LOG_LEVEL":                          "warn",
+				"DIRECTORY_SERVER_LOGGING_LOG_FORMAT":                         "json",
+				"DIRECTORY_SERVER_LOGGING_LOG_LEVELS":                         "routing/remote=debug,sync=error",
 			},
 			ExpectedConfig: &Config{
-				ListenAddress:      "example.com:8889",
-				HealthCheckAddress: "example.com:18888",
+				ListenAddress:              "example.com:8889",
+				HealthCheckAddress:         "example.com:18888",
+				EnableReflection:           true,
+				AllowedSignatureAlgorithms: []string{"ED25519", "RSA_SHA256"},
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
@@ -73,14 +146,40 @@ func TestConfig(t *testing.T) {
 						LocalDir:        "local-dir",
 						RegistryAddress: "example.com:5001",
 						RepositoryName:  "test-dir",
+						Compression:     "zstd",
+						MaxRecordBytes:  1048576,
+						CanonicalMode:   "jcs",
+						DigestAlgorithm: "sha512",
 						AuthConfig: oci.AuthConfig{
 							Insecure:     true,
 							Username:     "username",
 							Password:     "password",
 							RefreshToken: "refresh-token",
 							AccessToken:  "access-token",
+							OAuth2: oci.OAuth2Config{
+								TokenURL:     "https://auth.example.com/token",
+								ClientID:     "client-id",
+								ClientSecret: "client-secret",
+								Scope:        "registry:catalog:*",
+							},
 						},
 					},
+					Multi: store.MultiConfig{
+						Quorum: 2,
+					},
+					Webhook: webhook.Config{
+						URL:            "https://hooks.example.com/dir",
+						Timeout:        5 * time.Second,
+						QueueSize:      10,
+						MaxRetries:     3,
+						InitialBackoff: 2 * time.Second,
+						MaxBackoff:     time.Minute,
+					},
+					Upstream: upstream.Config{
+						Address: "central.example.com:8888",
+						Timeout: 3 * time.Second,
+						TLS:     true,
+					},
 				},
 				Routing: routing.Config{
 					ListenAddress: "/ip4/1.1.1.1/tcp/1",
@@ -88,10 +187,40 @@ func TestConfig(t *testing.T) {
 						"/ip4/1.1.1.1/tcp/1",
 						"/ip4/1.1.1.1/tcp/2",
 					},
-					KeyPath: "/path/to/key",
+					KeyPath:                "/path/to/key",
+					Rendezvous:             "dir/staging",
+					ProtocolPrefix:         "dir-staging",
+					DatastoreBackend:       "leveldb",
+					DatastoreNamespace:     "dir/routing",
+					RepublishInterval:      12 * time.Hour,
+					RepublishJitter:        10 * time.Minute,
+					RepublishRate:          2,
+					PeerAddressTTL:         24 * time.Hour,
+					LabelMaxAges:           []string{"locators=12h", "skills=168h"},
+					AllowedPeers:           []string{"peer-a", "peer-b"},
+					DeniedPeers:            []string{"peer-c"},
+					LabelNamespaces:        []string{"features", "tags"},
+					MaxConcurrentPulls:     4,
+					SearchTimeout:          10 * time.Second,
+					LabelResolutionTimeout: time.Second,
+					MaxSearchLimit:         250,
+					LabelNormalization: routing.LabelNormalizationConfig{
+						Unicode:  true,
+						CaseFold: true,
+					},
 					GossipSub: routing.GossipSubConfig{
 						Enabled: true, // Default value
 					},
+					VerifyAnnouncements: routing.VerifyAnnouncementsConfig{
+						Enabled:    true,
+						SampleRate: 0.5,
+					},
+					AnnouncementLimits: routing.AnnouncementLimitsConfig{
+						MaxLabels: 20,
+						Rate:      2.5,
+						Burst:     5,
+					},
+					MaxLabelsPerRecord: 50,
 				},
 				Database: database.Config{
 					DBType: "sqlite",
@@ -100,9 +229,12 @@ func TestConfig(t *testing.T) {
 					},
 				},
 				Sync: sync.Config{
-					SchedulerInterval: 1 * time.Second,
-					WorkerCount:       1,
-					WorkerTimeout:     10 * time.Second,
+					SchedulerInterval:     1 * time.Second,
+					WorkerCount:           1,
+					WorkerTimeout:         10 * time.Second,
+					CredentialsStore:      "secret",
+					CredentialsDir:        "/var/lib/zot",
+					CredentialsSecretPath: "/run/secrets/zot-credentials.json",
 					RegistryMonitor: monitor.Config{
 						CheckInterval: 10 * time.Second,
 					},
@@ -115,11 +247,40 @@ func TestConfig(t *testing.T) {
 					Enabled:     true,
 					TrustDomain: "dir.com",
 				},
+				RateLimit: ratelimit.Config{
+					Enabled: true,
+					Read: ratelimit.LimitConfig{
+						RequestsPerSecond: 50,
+						Burst:             60,
+					},
+					Write: ratelimit.LimitConfig{
+						RequestsPerSecond: 0.5,
+						Burst:             2,
+					},
+				},
 				Publication: publication.Config{
 					SchedulerInterval: 10 * time.Second,
 					WorkerCount:       1,
 					WorkerTimeout:     10 * time.Second,
 				},
+				Expiry: expiry.Config{
+					SweepInterval: 30 * time.Second,
+					HideExpired:   true,
+				},
+				Metrics: metrics.Config{
+					Enabled:       true,
+					ListenAddress: "0.0.0.0:9191",
+				},
+				Tracing: tracing.Config{
+					Exporter:    "log",
+					SampleRatio: 0.5,
+					ServiceName: "dir-test",
+				},
+				Logging: logging.Config{
+					LogLevel:  "warn",
+					LogFormat: "json",
+					LogLevels: []string{"routing/remote=debug", "sync=error"},
+				},
 			},
 		},
 		{
@@ -128,6 +289,7 @@ func TestConfig(t *testing.T) {
 			ExpectedConfig: &Config{
 				ListenAddress:      DefaultListenAddress,
 				HealthCheckAddress: DefaultHealthCheckAddress,
+				EnableReflection:   DefaultEnableReflection,
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
@@ -138,17 +300,63 @@ func TestConfig(t *testing.T) {
 					OCI: oci.Config{
 						RegistryAddress: oci.DefaultRegistryAddress,
 						RepositoryName:  oci.DefaultRepositoryName,
+						Compression:     oci.DefaultCompression,
+						MaxRecordBytes:  oci.DefaultMaxRecordBytes,
+						CanonicalMode:   oci.DefaultCanonicalMode,
+						DigestAlgorithm: oci.DefaultDigestAlgorithm,
 						AuthConfig: oci.AuthConfig{
 							Insecure: oci.DefaultAuthConfigInsecure,
 						},
 					},
+					Multi: store.MultiConfig{
+						Quorum: store.DefaultMultiQuorum,
+					},
+					Webhook: webhook.Config{
+						Timeout:        webhook.DefaultTimeout,
+						QueueSize:      webhook.DefaultQueueSize,
+						MaxRetries:     webhook.DefaultMaxRetries,
+						InitialBackoff: webhook.DefaultInitialBackoff,
+						MaxBackoff:     webhook.DefaultMaxBackoff,
+					},
+					Upstream: upstream.Config{
+						Timeout: upstream.DefaultTimeout,
+						TLS:     upstream.DefaultTLS,
+					},
 				},
 				Routing: routing.Config{
-					ListenAddress:  routing.DefaultListenAddress,
-					BootstrapPeers: routing.DefaultBootstrapPeers,
+					ListenAddress:          routing.DefaultListenAddress,
+					BootstrapPeers:         routing.DefaultBootstrapPeers,
+					Rendezvous:             routing.DefaultRendezvous,
+					ProtocolPrefix:         routing.DefaultProtocolPrefix,
+					RepublishInterval:      routing.DefaultRepublishInterval,
+					RepublishJitter:        routing.DefaultRepublishJitter,
+					RepublishRate:          routing.DefaultRepublishRate,
+					PeerAddressTTL:         routing.DefaultPeerAddressTTL,
+					LabelMaxAges:           []string{},
+					AllowedPeers:           []string{},
+					DeniedPeers:            []string{},
+					LabelNamespaces:        []string{},
+					MaxConcurrentPulls:     routing.DefaultMaxConcurrentPulls,
+					SearchTimeout:          routing.DefaultSearchTimeout,
+					LabelResolutionTimeout: routing.DefaultLabelResolutionTimeout,
+					MaxSearchLimit:         routing.DefaultMaxSearchLimit,
+					LabelNormalization: routing.LabelNormalizationConfig{
+						Unicode:  routing.DefaultLabelNormalizationUnicode,
+						CaseFold: routing.DefaultLabelNormalizationCaseFold,
+					},
 					GossipSub: routing.GossipSubConfig{
 						Enabled: routing.DefaultGossipSubEnabled,
 					},
+					VerifyAnnouncements: routing.VerifyAnnouncementsConfig{
+						Enabled:    routing.DefaultVerifyAnnouncementsEnabled,
+						SampleRate: routing.DefaultVerifyAnnouncementsSampleRate,
+					},
+					AnnouncementLimits: routing.AnnouncementLimitsConfig{
+						MaxLabels: routing.DefaultAnnouncementMaxLabels,
+						Rate:      routing.DefaultAnnouncementRate,
+						Burst:     routing.DefaultAnnouncementBurst,
+					},
+					MaxLabelsPerRecord: routing.DefaultMaxLabelsPerRecord,
 				},
 				Database: database.Config{
 					DBType: database.DefaultDBType,
@@ -160,16 +368,41 @@ func TestConfig(t *testing.T) {
 					SchedulerInterval: sync.DefaultSyncSchedulerInterval,
 					WorkerCount:       sync.DefaultSyncWorkerCount,
 					WorkerTimeout:     sync.DefaultSyncWorkerTimeout,
+					CredentialsStore:  sync.DefaultCredentialsStore,
 					RegistryMonitor: monitor.Config{
 						CheckInterval: monitor.DefaultCheckInterval,
 					},
 				},
 				Authz: authz.Config{},
+				RateLimit: ratelimit.Config{
+					Enabled: ratelimit.DefaultRateLimitEnabled,
+					Read: ratelimit.LimitConfig{
+						RequestsPerSecond: ratelimit.DefaultReadRequestsPerSecond,
+						Burst:             ratelimit.DefaultReadBurst,
+					},
+					Write: ratelimit.LimitConfig{
+						RequestsPerSecond: ratelimit.DefaultWriteRequestsPerSecond,
+						Burst:             ratelimit.DefaultWriteBurst,
+					},
+				},
 				Publication: publication.Config{
 					SchedulerInterval: publication.DefaultPublicationSchedulerInterval,
 					WorkerCount:       publication.DefaultPublicationWorkerCount,
 					WorkerTimeout:     publication.DefaultPublicationWorkerTimeout,
 				},
+				Expiry: expiry.Config{
+					SweepInterval: expiry.DefaultExpirySweepInterval,
+					HideExpired:   expiry.DefaultExpiryHideExpired,
+				},
+				Metrics: metrics.Config{
+					Enabled:       metrics.DefaultMetricsEnabled,
+					ListenAddress: metrics.DefaultMetricsListenAddress,
+				},
+				Tracing: tracing.Config{
+					Exporter:    tracing.DefaultTracingExporter,
+					SampleRatio: tracing.DefaultTracingSampleRatio,
+					ServiceName: tracing.DefaultTracingServiceName,
+				},
 			},
 		},
 	}
@@ -186,3 +419,25 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.config.yml")
+	content := "listen_address: example.com:8889\nrouting:\n  bootstrap_peers:\n    - /ip4/1.1.1.1/tcp/1\n" +
+		"sync:\n  registry_tls:\n    - registry_url: registry.example.com\n      tls_verify: true\n      ca_cert_dir: /etc/zot/certs\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	config, err := LoadConfigFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com:8889", config.ListenAddress)
+	assert.Equal(t, []string{"/ip4/1.1.1.1/tcp/1"}, config.Routing.BootstrapPeers)
+	assert.Equal(t, DefaultHealthCheckAddress, config.HealthCheckAddress)
+
+	// TLS settings only apply to the registry they are configured for
+	assert.Equal(t, sync.RegistryTLS{RegistryURL: "registry.example.com", TLSVerify: true, CACertDir: "/etc/zot/certs"},
+		config.Sync.RegistryTLSFor("registry.example.com"))
+	assert.Equal(t, sync.RegistryTLS{RegistryURL: "other.example.com"}, config.Sync.RegistryTLSFor("other.example.com"))
+
+	// An explicitly requested config file must exist
+	_, err = LoadConfigFile(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
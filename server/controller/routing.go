@@ -5,9 +5,13 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"math"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
@@ -23,13 +27,31 @@ type routingCtlr struct {
 	routing     types.RoutingAPI
 	store       types.StoreAPI
 	publication types.PublicationAPI
+
+	// Overall deadline of a search, excluding the watch phase
+	searchTimeout time.Duration
+
+	// Maximum number of records returned by a search, excluding the watch phase
+	maxSearchLimit uint32
 }
 
-func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, publication types.PublicationAPI) routingv1.RoutingServiceServer {
+func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, publication types.PublicationAPI, opts types.APIOptions) routingv1.RoutingServiceServer {
+	searchTimeout := routingconfig.DefaultSearchTimeout
+	if opts.Config().Routing.SearchTimeout > 0 {
+		searchTimeout = opts.Config().Routing.SearchTimeout
+	}
+
+	maxSearchLimit := uint32(routingconfig.DefaultMaxSearchLimit)
+	if opts.Config().Routing.MaxSearchLimit > 0 {
+		maxSearchLimit = uint32(min(int64(opts.Config().Routing.MaxSearchLimit), math.MaxUint32)) //nolint:gosec // bounded by min
+	}
+
 	return &routingCtlr{
 		routing:                           routing,
 		store:                             store,
 		publication:                       publication,
+		searchTimeout:                     searchTimeout,
+		maxSearchLimit:                    maxSearchLimit,
 		UnimplementedRoutingServiceServer: routingv1.UnimplementedRoutingServiceServer{},
 	}
 }
@@ -73,7 +95,23 @@ func (c *routingCtlr) List(req *routingv1.ListRequest, srv routingv1.RoutingServ
 func (c *routingCtlr) Search(req *routingv1.SearchRequest, srv routingv1.RoutingService_SearchServer) error {
 	routingLogger.Debug("Called routing controller's Search method", "req", req)
 
-	itemChan, err := c.routing.Search(srv.Context(), req)
+	// Bound the search so that a slow datastore cannot stall the client.
+	// Watch searches run until the client goes away.
+	ctx := srv.Context()
+
+	if !req.GetWatch() {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.searchTimeout)
+		defer cancel()
+	}
+
+	// Bound the number of results sent to the client.
+	// Searches made by the server itself, such as sync queries, are not limited.
+	limit := c.searchLimit(req.GetLimit())
+	req.Limit = &limit
+
+	itemChan, err := c.routing.Search(ctx, req)
 	if err != nil {
 		st := status.Convert(err)
 
@@ -81,15 +119,102 @@ func (c *routingCtlr) Search(req *routingv1.SearchRequest, srv routingv1.Routing
 	}
 
 	// Stream SearchResponse items directly to the client
+	sent := 0
+
 	for item := range itemChan {
 		if err := srv.Send(item); err != nil {
 			return status.Errorf(codes.Internal, "failed to send search response: %v", err)
 		}
+
+		sent++
+	}
+
+	// The results found in time have been sent, report that the search is incomplete
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && srv.Context().Err() == nil {
+		return status.Errorf(codes.DeadlineExceeded, "search timed out after %s, returned %d partial results", c.searchTimeout, sent)
 	}
 
 	return nil
 }
 
+// searchLimit returns the effective limit of a search requesting the given limit.
+// Searches without a limit or with a limit above the maximum are limited to the maximum.
+func (c *routingCtlr) searchLimit(requested uint32) uint32 {
+	if requested > 0 && requested <= c.maxSearchLimit {
+		return requested
+	}
+
+	if requested > c.maxSearchLimit {
+		routingLogger.Info("Clamped search limit to the maximum", "requested", requested, "applied", c.maxSearchLimit)
+	}
+
+	return c.maxSearchLimit
+}
+
+func (c *routingCtlr) ListPeers(ctx context.Context, req *routingv1.ListPeersRequest) (*routingv1.ListPeersResponse, error) {
+	routingLogger.Debug("Called routing controller's ListPeers method", "req", req)
+
+	resp, err := c.routing.ListPeers(ctx, req)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to list peers: %s", st.Message())
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) ListProvided(ctx context.Context, req *routingv1.ListProvidedRequest) (*routingv1.ListProvidedResponse, error) {
+	routingLogger.Debug("Called routing controller's ListProvided method", "req", req)
+
+	resp, err := c.routing.ListProvided(ctx, req)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to list provided records: %s", st.Message())
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) PullFromPeer(ctx context.Context, req *routingv1.PullFromPeerRequest) (*corev1.Record, error) {
+	routingLogger.Debug("Called routing controller's PullFromPeer method", "req", req)
+
+	if req.GetRecordRef().GetCid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "record reference must have a CID") //nolint:wrapcheck // gRPC status errors should not be wrapped
+	}
+
+	if req.GetMultiaddr() == "" {
+		return nil, status.Error(codes.InvalidArgument, "multiaddr is required") //nolint:wrapcheck // gRPC status errors should not be wrapped
+	}
+
+	record, err := c.routing.PullFromPeer(ctx, req)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull from peer: %s", st.Message())
+	}
+
+	return record, nil
+}
+
+func (c *routingCtlr) PullFromNetwork(ctx context.Context, req *routingv1.PullFromNetworkRequest) (*corev1.Record, error) {
+	routingLogger.Debug("Called routing controller's PullFromNetwork method", "req", req)
+
+	if req.GetRecordRef().GetCid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "record reference must have a CID") //nolint:wrapcheck // gRPC status errors should not be wrapped
+	}
+
+	record, err := c.routing.PullFromNetwork(ctx, req)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull from network: %s", st.Message())
+	}
+
+	return record, nil
+}
+
 func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) (*emptypb.Empty, error) {
 	routingLogger.Debug("Called routing controller's Unpublish method", "req", req)
 
@@ -109,7 +234,7 @@ func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishReq
 		}
 
 		// Wrap record with adapter for interface-based unpublishing
-		adapter := adapters.NewRecordAdapter(record)
+		adapter := adapters.NewRecordAdapterWithCid(record, ref.GetCid())
 
 		err = c.routing.Unpublish(ctx, adapter)
 		if err != nil {
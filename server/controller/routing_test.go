@@ -0,0 +1,31 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutingSearchLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLimit  uint32
+		requested uint32
+		expected  uint32
+	}{
+		{name: "limit below maximum", maxLimit: 100, requested: 10, expected: 10},
+		{name: "limit at maximum", maxLimit: 100, requested: 100, expected: 100},
+		{name: "limit above maximum is clamped", maxLimit: 100, requested: 5000, expected: 100},
+		{name: "no limit uses maximum", maxLimit: 100, requested: 0, expected: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &routingCtlr{maxSearchLimit: tt.maxLimit}
+			assert.Equal(t, tt.expected, c.searchLimit(tt.requested))
+		})
+	}
+}
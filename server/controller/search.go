@@ -4,25 +4,35 @@
 package controller
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	searchv1 "github.com/agntcy/dir/api/search/v1"
 	databaseutils "github.com/agntcy/dir/server/database/utils"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var searchLogger = logging.Logger("controller/search")
 
 type searchCtlr struct {
 	searchv1.UnimplementedSearchServiceServer
-	db types.DatabaseAPI
+	db          types.DatabaseAPI
+	hideExpired bool
 }
 
-func NewSearchController(db types.DatabaseAPI) searchv1.SearchServiceServer {
+// NewSearchController creates the search service.
+// Expired records are excluded from results if hidden by the server config.
+func NewSearchController(db types.DatabaseAPI, opts types.APIOptions) searchv1.SearchServiceServer {
 	return &searchCtlr{
 		UnimplementedSearchServiceServer: searchv1.UnimplementedSearchServiceServer{},
 		db:                               db,
+		hideExpired:                      opts.Config().Expiry.HideExpired,
 	}
 }
 
@@ -34,21 +44,93 @@ func (c *searchCtlr) Search(req *searchv1.SearchRequest, srv searchv1.SearchServ
 		return fmt.Errorf("failed to create filter options: %w", err)
 	}
 
+	if req.GetQueryExpression() != "" {
+		expr, err := searchv1.ParseQueryExpression(req.GetQueryExpression())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		filterOptions = append(filterOptions, types.WithQueryExpression(expr))
+	}
+
 	filterOptions = append(filterOptions,
 		types.WithLimit(int(req.GetLimit())),
 		types.WithOffset(int(req.GetOffset())),
 	)
 
-	recordCIDs, err := c.db.GetRecordCIDs(filterOptions...)
+	if c.hideExpired {
+		filterOptions = append(filterOptions, types.WithExcludeExpired(time.Now()))
+	}
+
+	summaries, err := c.db.GetRecordSummaries(filterOptions...)
 	if err != nil {
 		return fmt.Errorf("failed to get record CIDs: %w", err)
 	}
 
-	for _, cid := range recordCIDs {
-		if err := srv.Send(&searchv1.SearchResponse{RecordCid: cid}); err != nil {
+	for _, summary := range summaries {
+		if err := srv.Send(&searchv1.SearchResponse{RecordCid: summary.CID, SchemaVersion: summary.SchemaVersion}); err != nil {
 			return fmt.Errorf("failed to send record: %w", err)
 		}
 	}
 
+	if req.GetIncludeFacets() {
+		facets, err := c.db.Facets(filterOptions...)
+		if err != nil {
+			return fmt.Errorf("failed to get facets: %w", err)
+		}
+
+		if err := srv.Send(&searchv1.SearchResponse{Facets: toFacets(facets)}); err != nil {
+			return fmt.Errorf("failed to send facets: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// toFacets converts facet counts to API facets sorted by namespace and value.
+func toFacets(facets map[string]map[string]int) []*searchv1.Facet {
+	result := make([]*searchv1.Facet, 0, len(facets))
+
+	for _, namespace := range slices.Sorted(maps.Keys(facets)) {
+		counts := facets[namespace]
+
+		facet := &searchv1.Facet{
+			Namespace: namespace,
+			Values:    make([]*searchv1.FacetValue, 0, len(counts)),
+		}
+
+		for _, value := range slices.Sorted(maps.Keys(counts)) {
+			facet.Values = append(facet.Values, &searchv1.FacetValue{
+				Value: value,
+				Count: uint64(counts[value]), //nolint:gosec // counts are never negative
+			})
+		}
+
+		result = append(result, facet)
+	}
+
+	return result
+}
+
+func (c *searchCtlr) ListSkills(_ context.Context, _ *searchv1.ListSkillsRequest) (*searchv1.ListSkillsResponse, error) {
+	searchLogger.Debug("Called search controller's ListSkills method")
+
+	skills, err := c.db.ListSkills()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list skills: %v", err)
+	}
+
+	resp := &searchv1.ListSkillsResponse{
+		Skills: make([]*searchv1.SkillInfo, 0, len(skills)),
+	}
+
+	for _, skill := range skills {
+		resp.Skills = append(resp.Skills, &searchv1.SkillInfo{
+			Id:          skill.ID,
+			Name:        skill.Name,
+			RecordCount: uint64(skill.RecordCount), //nolint:gosec // counts are never negative
+		})
+	}
+
+	return resp, nil
+}
@@ -5,10 +5,12 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	signv1 "github.com/agntcy/dir/api/sign/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/agntcy/dir/utils/zot"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -17,13 +19,16 @@ var signLogger = logging.Logger("controller/sign")
 
 type signCtrl struct {
 	signv1.UnimplementedSignServiceServer
-	store types.StoreAPI
+	store  types.StoreAPI
+	policy signaturePolicy
 }
 
 // NewSignController creates a new sign service controller.
-func NewSignController(store types.StoreAPI) signv1.SignServiceServer {
+// Only signatures using the allowed signature algorithms of the server config are trusted.
+func NewSignController(store types.StoreAPI, opts types.APIOptions) signv1.SignServiceServer {
 	return &signCtrl{
-		store: store,
+		store:  store,
+		policy: newSignaturePolicy(opts.Config().AllowedSignatureAlgorithms),
 	}
 }
 
@@ -48,10 +53,11 @@ func (s *signCtrl) Verify(ctx context.Context, req *signv1.VerifyRequest) (*sign
 }
 
 // verify attempts zot verification if the store supports it.
+// A record is verified if it has a trusted signature using an allowed algorithm.
 func (s *signCtrl) verify(ctx context.Context, recordCID string) (*signv1.VerifyResponse, error) {
 	// Check if the store supports zot verification
 	zotStore, ok := s.store.(interface {
-		VerifyWithZot(ctx context.Context, recordCID string) (bool, error)
+		VerifyWithZot(ctx context.Context, recordCID string) (*zot.VerificationResult, error)
 	})
 	if !ok {
 		return nil, status.Error(codes.Unimplemented, "zot verification not available in this store configuration") //nolint:wrapcheck
@@ -59,16 +65,33 @@ func (s *signCtrl) verify(ctx context.Context, recordCID string) (*signv1.Verify
 
 	signLogger.Debug("Attempting zot verification", "recordCID", recordCID)
 
-	verified, err := zotStore.VerifyWithZot(ctx, recordCID)
+	result, err := zotStore.VerifyWithZot(ctx, recordCID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "zot verification failed: %v", err)
 	}
 
+	verified := false
+	errMsg := "Signature verification failed"
+
+	for _, signature := range result.Signatures {
+		trusted, algorithm := s.policy.trusts(signature)
+		if trusted {
+			verified = true
+
+			break
+		}
+
+		if signature.IsTrusted {
+			signLogger.Warn("Signature uses a disallowed algorithm", "recordCID", recordCID, "algorithm", algorithm)
+
+			errMsg = fmt.Sprintf("Signature algorithm %q is not allowed", algorithm)
+		}
+	}
+
 	signLogger.Debug("Zot verification completed", "recordCID", recordCID, "verified", verified)
 
-	var errMsg string
-	if !verified {
-		errMsg = "Signature verification failed"
+	if verified {
+		errMsg = ""
 	}
 
 	return &signv1.VerifyResponse{
@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	"github.com/agntcy/dir/utils/cosign"
+	"github.com/agntcy/dir/utils/zot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signaturePolicy restricts the algorithms of signatures accepted on push and trusted on verification.
+// The algorithm is always derived from the signing key or certificate, since the algorithm
+// declared by a signature is chosen by its uploader. An empty policy allows all algorithms.
+type signaturePolicy struct {
+	allowed map[string]bool
+}
+
+func newSignaturePolicy(algorithms []string) signaturePolicy {
+	allowed := make(map[string]bool, len(algorithms))
+	for _, algorithm := range algorithms {
+		allowed[strings.ToUpper(algorithm)] = true
+	}
+
+	return signaturePolicy{allowed: allowed}
+}
+
+// allows reports whether the signature algorithm is allowed, ignoring case.
+func (p signaturePolicy) allows(algorithm string) bool {
+	return len(p.allowed) == 0 || p.allowed[strings.ToUpper(algorithm)]
+}
+
+// checkReferrer rejects public keys and certificate-based signatures whose algorithm is not allowed.
+// The algorithm of key-based signatures is unknown until their key is known, so they are
+// accepted and checked against the signing key on verification. Other referrers are accepted.
+func (p signaturePolicy) checkReferrer(referrer *corev1.RecordReferrer) error {
+	if len(p.allowed) == 0 {
+		return nil
+	}
+
+	var (
+		algorithm string
+		err       error
+	)
+
+	switch referrer.GetType() {
+	case corev1.PublicKeyReferrerType:
+		publicKey := &signv1.PublicKey{}
+		if err := publicKey.UnmarshalReferrer(referrer); err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to decode public key: %v", err)
+		}
+
+		algorithm, err = cosign.PublicKeySignatureAlgorithm([]byte(publicKey.GetKey()))
+
+	case corev1.SignatureReferrerType:
+		signature := &signv1.Signature{}
+		if err := signature.UnmarshalReferrer(referrer); err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to decode signature: %v", err)
+		}
+
+		if signature.GetCertificate() == "" {
+			return nil
+		}
+
+		algorithm, err = certificateAlgorithm(signature.GetCertificate())
+
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to determine signature algorithm: %v", err)
+	}
+
+	if !p.allows(algorithm) {
+		return status.Errorf(codes.InvalidArgument, "signature algorithm %q is not allowed", algorithm)
+	}
+
+	return nil
+}
+
+// certificateAlgorithm returns the signature algorithm of a base64-encoded signing certificate.
+func certificateAlgorithm(certificate string) (string, error) {
+	der, err := base64.StdEncoding.DecodeString(certificate)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode certificate: %w", err)
+	}
+
+	return cosign.CertificateSignatureAlgorithm(der) //nolint:wrapcheck
+}
+
+// trusts reports whether a signature verified by zot uses an allowed algorithm.
+// The algorithm of key-based signatures is derived from the signing key.
+// Zot only reports the identity of keyless signatures, so their algorithm
+// is checked against their signing certificate when they are pushed.
+func (p signaturePolicy) trusts(signature zot.SignatureDetail) (bool, string) {
+	if !signature.IsTrusted {
+		return false, ""
+	}
+
+	if len(p.allowed) == 0 || signature.KeyID == "" {
+		return true, ""
+	}
+
+	algorithm, err := cosign.PublicKeySignatureAlgorithm([]byte(signature.Author))
+	if err != nil {
+		return false, ""
+	}
+
+	return p.allows(algorithm), algorithm
+}
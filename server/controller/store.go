@@ -9,6 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
@@ -16,29 +20,55 @@ import (
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// pullStreamConcurrency is the maximum number of records pulled concurrently by PullStream.
+const pullStreamConcurrency = 8
+
+// verifyConcurrency is the maximum number of records verified concurrently by VerifyRecords.
+const verifyConcurrency = 8
+
 var storeLogger = logging.Logger("controller/store")
 
 type storeCtrl struct {
 	storev1.UnimplementedStoreServiceServer
-	store types.StoreAPI
-	db    types.DatabaseAPI
+	store       types.StoreAPI
+	db          types.DatabaseAPI
+	rateLimiter types.RateLimiterAPI
+	policy      signaturePolicy
+	hideExpired bool
 }
 
-func NewStoreController(store types.StoreAPI, db types.DatabaseAPI) storev1.StoreServiceServer {
+// NewStoreController creates the store service.
+// The rate limiter is optional and only used to report rate limits in the stats.
+// Signatures are only accepted if they use the allowed signature algorithms of the server config.
+func NewStoreController(store types.StoreAPI, db types.DatabaseAPI, rateLimiter types.RateLimiterAPI, opts types.APIOptions) storev1.StoreServiceServer {
 	return &storeCtrl{
 		UnimplementedStoreServiceServer: storev1.UnimplementedStoreServiceServer{},
 		store:                           store,
 		db:                              db,
+		rateLimiter:                     rateLimiter,
+		policy:                          newSignaturePolicy(opts.Config().AllowedSignatureAlgorithms),
+		hideExpired:                     opts.Config().Expiry.HideExpired,
 	}
 }
 
 func (s storeCtrl) Push(stream storev1.StoreService_PushServer) error {
 	storeLogger.Debug("Called store controller's Push method")
 
+	ctx, err := withPushAnnotations(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	ctx, err = withPushExpiry(ctx)
+	if err != nil {
+		return err
+	}
+
 	for {
 		// Receive complete Record from stream
 		record, err := stream.Recv()
@@ -61,7 +91,7 @@ func (s storeCtrl) Push(stream storev1.StoreService_PushServer) error {
 			return status.Errorf(codes.InvalidArgument, "record validation failed: %v", validationErrors)
 		}
 
-		pushedRef, err := s.pushRecordToStore(stream.Context(), record)
+		pushedRef, err := s.pushRecordToStore(ctx, record)
 		if err != nil {
 			return err
 		}
@@ -76,6 +106,8 @@ func (s storeCtrl) Push(stream storev1.StoreService_PushServer) error {
 func (s storeCtrl) Pull(stream storev1.StoreService_PullServer) error {
 	storeLogger.Debug("Called store controller's Pull method")
 
+	ctx := withCacheBypass(stream.Context())
+
 	for {
 		// Receive RecordRef from stream
 		recordRef, err := stream.Recv()
@@ -96,8 +128,13 @@ func (s storeCtrl) Pull(stream storev1.StoreService_PullServer) error {
 			return err
 		}
 
+		recordRef, err = s.resolveRecordRef(ctx, recordRef)
+		if err != nil {
+			return err
+		}
+
 		// Pull record from store
-		record, err := s.pullRecordFromStore(stream.Context(), recordRef)
+		record, err := s.pullRecordFromStore(ctx, recordRef)
 		if err != nil {
 			return err
 		}
@@ -109,9 +146,93 @@ func (s storeCtrl) Pull(stream storev1.StoreService_PullServer) error {
 	}
 }
 
+// PullStream pulls a batch of records concurrently and streams each record back as soon as it is fetched.
+// Records that cannot be pulled are reported in the response for their reference.
+func (s storeCtrl) PullStream(req *storev1.PullStreamRequest, stream storev1.StoreService_PullStreamServer) error {
+	storeLogger.Debug("Called store controller's PullStream method", "count", len(req.GetRecordRefs()))
+
+	ctx := withCacheBypass(stream.Context())
+	results := make(chan *storev1.PullStreamResponse)
+	sem := make(chan struct{}, pullStreamConcurrency)
+
+	go func() {
+		defer close(results)
+
+		var wg sync.WaitGroup
+
+		for _, recordRef := range req.GetRecordRefs() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+
+				return
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				select {
+				case results <- s.pullStreamRecord(ctx, recordRef):
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		wg.Wait()
+	}()
+
+	for result := range results {
+		if err := stream.Send(result); err != nil {
+			// Workers exit once the stream context is cancelled on return
+			return status.Errorf(codes.Internal, "failed to send record: %v", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	storeLogger.Debug("PullStream completed")
+
+	return nil
+}
+
+// pullStreamRecord pulls one record of a batch, reporting failures in the response.
+func (s storeCtrl) pullStreamRecord(ctx context.Context, recordRef *corev1.RecordRef) *storev1.PullStreamResponse {
+	response := &storev1.PullStreamResponse{
+		RecordRef: recordRef,
+	}
+
+	err := s.validateRecordRef(recordRef)
+	if err == nil {
+		var resolvedRef *corev1.RecordRef
+
+		resolvedRef, err = s.resolveRecordRef(ctx, recordRef)
+		if err == nil {
+			response.Record, err = s.pullRecordFromStore(ctx, resolvedRef)
+		}
+	}
+
+	if err != nil {
+		st := status.Convert(err)
+		errMsg := st.Message()
+
+		response.Code = uint32(st.Code())
+		response.ErrorMessage = &errMsg
+	}
+
+	return response
+}
+
 func (s storeCtrl) Lookup(stream storev1.StoreService_LookupServer) error {
 	storeLogger.Debug("Called store controller's Lookup method")
 
+	ctx := withCacheBypass(stream.Context())
+
 	for {
 		// Receive RecordRef from stream
 		recordRef, err := stream.Recv()
@@ -132,14 +253,24 @@ func (s storeCtrl) Lookup(stream storev1.StoreService_LookupServer) error {
 			return status.Error(codes.InvalidArgument, "record cid is required")
 		}
 
+		recordRef, err = s.resolveRecordRef(ctx, recordRef)
+		if err != nil {
+			return err
+		}
+
 		// Lookup record metadata
-		recordMeta, err := s.store.Lookup(stream.Context(), recordRef)
+		recordMeta, err := s.store.Lookup(ctx, recordRef)
 		if err != nil {
 			st := status.Convert(err)
 
 			return status.Errorf(st.Code(), "failed to lookup record: %s", st.Message())
 		}
 
+		// Expired records are hidden until the sweeper deletes them
+		if s.hideExpired && recordMeta.IsExpired(time.Now()) {
+			return status.Errorf(codes.NotFound, "record %s has expired", recordRef.GetCid())
+		}
+
 		storeLogger.Debug("Record metadata retrieved successfully", "cid", recordRef.GetCid())
 
 		// Send RecordMeta back via stream
@@ -176,6 +307,11 @@ func (s storeCtrl) Delete(stream storev1.StoreService_DeleteServer) error {
 			return status.Error(codes.InvalidArgument, "record cid is required")
 		}
 
+		recordRef, err = s.resolveRecordRef(stream.Context(), recordRef)
+		if err != nil {
+			return err
+		}
+
 		// Delete record from store
 		err = s.store.Delete(stream.Context(), recordRef)
 		if err != nil {
@@ -217,6 +353,11 @@ func (s storeCtrl) PushReferrer(stream storev1.StoreService_PushReferrerServer)
 			return err
 		}
 
+		// Reject signatures using disallowed algorithms
+		if err := s.policy.checkReferrer(request.GetReferrer()); err != nil {
+			return err
+		}
+
 		// Handle the referrer directly since we only have one type now
 		response := s.pushReferrer(stream.Context(), request)
 
@@ -322,6 +463,421 @@ func (s storeCtrl) PullReferrer(stream storev1.StoreService_PullReferrerServer)
 	}
 }
 
+// GarbageCollect removes unreferenced blobs from the store.
+func (s storeCtrl) GarbageCollect(ctx context.Context, req *storev1.GarbageCollectRequest) (*storev1.GarbageCollectResponse, error) {
+	storeLogger.Debug("Called store controller's GarbageCollect method", "dryRun", req.GetDryRun())
+
+	gcStore, ok := s.store.(types.GarbageCollectorAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "garbage collection not supported by current store implementation")
+	}
+
+	result, err := gcStore.GarbageCollect(ctx, req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+
+	return &storev1.GarbageCollectResponse{
+		DeletedBlobs:   result.DeletedBlobs,
+		ReclaimedBytes: uint64(result.ReclaimedBytes), //nolint:gosec // sizes are never negative
+	}, nil
+}
+
+// GetStats reports the statistics and limits of the store, and the rate limits of the API if enabled.
+func (s storeCtrl) GetStats(ctx context.Context, req *storev1.GetStatsRequest) (*storev1.GetStatsResponse, error) {
+	storeLogger.Debug("Called store controller's GetStats method", "includeUsage", req.GetIncludeUsage())
+
+	statsStore, ok := s.store.(types.StoreStatsAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "stats not supported by current store implementation")
+	}
+
+	stats, err := statsStore.Stats(ctx, req.GetIncludeUsage())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &storev1.GetStatsResponse{
+		MaxRecordBytes: uint64(stats.MaxRecordBytes), //nolint:gosec // limits are never negative
+	}
+
+	if usage := stats.Usage; usage != nil {
+		resp.TotalBlobBytes = uint64(usage.TotalBlobBytes)       //nolint:gosec // sizes are never negative
+		resp.ManifestCount = uint64(usage.ManifestCount)         //nolint:gosec // counts are never negative
+		resp.RecordCount = uint64(usage.RecordCount)             //nolint:gosec // counts are never negative
+		resp.SignedRecordCount = uint64(usage.SignedRecordCount) //nolint:gosec // counts are never negative
+		resp.ReferrersApiSupported = usage.ReferrersAPISupported
+	}
+
+	if s.rateLimiter != nil {
+		limits := s.rateLimiter.RateLimitStats(ctx)
+		resp.RateLimits = &storev1.RateLimitStats{
+			ReadRequestsPerSecond:  limits.ReadRequestsPerSecond,
+			ReadBurst:              uint32(limits.ReadBurst), //nolint:gosec // bursts are validated to be positive
+			WriteRequestsPerSecond: limits.WriteRequestsPerSecond,
+			WriteBurst:             uint32(limits.WriteBurst),    //nolint:gosec // bursts are validated to be positive
+			ActiveClients:          uint32(limits.ActiveClients), //nolint:gosec // counts are never negative
+			RejectedReadRequests:   limits.RejectedReadRequests,
+			RejectedWriteRequests:  limits.RejectedWriteRequests,
+			CallerReadTokens:       limits.CallerReadTokens,
+			CallerWriteTokens:      limits.CallerWriteTokens,
+		}
+	}
+
+	return resp, nil
+}
+
+// Exists reports whether a record is in the store.
+// Abbreviated CIDs are not resolved, as existence checks are meant for full CIDs.
+func (s storeCtrl) Exists(ctx context.Context, req *storev1.ExistsRequest) (*storev1.ExistsResponse, error) {
+	storeLogger.Debug("Called store controller's Exists method", "cid", req.GetRecordRef().GetCid())
+
+	if err := s.validateRecordRef(req.GetRecordRef()); err != nil {
+		return nil, err
+	}
+
+	exists, err := types.RecordExists(ctx, s.store, req.GetRecordRef())
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to check record existence: %s", st.Message())
+	}
+
+	return &storev1.ExistsResponse{Exists: exists}, nil
+}
+
+// CheckConsistency compares the records in the store with the records indexed in the search database.
+// With repair enabled, records missing from the database are re-indexed and dangling database entries are removed.
+func (s storeCtrl) CheckConsistency(ctx context.Context, req *storev1.CheckConsistencyRequest) (*storev1.CheckConsistencyResponse, error) {
+	storeLogger.Debug("Called store controller's CheckConsistency method", "repair", req.GetRepair())
+
+	lister, ok := s.store.(types.RecordListerAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "listing records not supported by current store implementation")
+	}
+
+	storeCIDs, err := lister.ListRecordCIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCIDs, err := s.db.GetRecordCIDs()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get record CIDs from database: %v", err)
+	}
+
+	inStore := make(map[string]bool, len(storeCIDs))
+	for _, cid := range storeCIDs {
+		inStore[cid] = true
+	}
+
+	inDB := make(map[string]bool, len(dbCIDs))
+	for _, cid := range dbCIDs {
+		inDB[cid] = true
+	}
+
+	resp := &storev1.CheckConsistencyResponse{}
+
+	for _, cid := range storeCIDs {
+		if !inDB[cid] {
+			resp.MissingInDatabase = append(resp.MissingInDatabase, cid)
+		}
+	}
+
+	for _, cid := range dbCIDs {
+		if !inStore[cid] {
+			resp.MissingInStore = append(resp.MissingInStore, cid)
+		}
+	}
+
+	slices.Sort(resp.MissingInStore)
+
+	storeLogger.Info("Consistency check completed",
+		"storeRecords", len(storeCIDs),
+		"databaseRecords", len(dbCIDs),
+		"missingInDatabase", len(resp.MissingInDatabase),
+		"missingInStore", len(resp.MissingInStore))
+
+	if !req.GetRepair() {
+		return resp, nil
+	}
+
+	// Re-index records that are only present in the store
+	for _, cid := range resp.GetMissingInDatabase() {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		record, err := s.store.Pull(ctx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			resp.RepairErrors = append(resp.RepairErrors, fmt.Sprintf("%s: failed to pull record: %v", cid, err))
+
+			continue
+		}
+
+		if err := s.db.AddRecord(adapters.NewRecordAdapterWithCid(record, cid)); err != nil {
+			resp.RepairErrors = append(resp.RepairErrors, fmt.Sprintf("%s: failed to add record to database: %v", cid, err))
+
+			continue
+		}
+
+		storeLogger.Info("Re-indexed record missing from database", "cid", cid)
+	}
+
+	// Remove database entries without a record in the store
+	for _, cid := range resp.GetMissingInStore() {
+		if err := s.db.RemoveRecord(cid); err != nil {
+			resp.RepairErrors = append(resp.RepairErrors, fmt.Sprintf("%s: failed to remove record from database: %v", cid, err))
+
+			continue
+		}
+
+		storeLogger.Info("Removed dangling record from database", "cid", cid)
+	}
+
+	return resp, nil
+}
+
+// VerifyRecords recomputes the CID of every stored record concurrently and streams the outcome of each check.
+// With repair, records whose stored bytes no longer match their CID are removed from the store and the database.
+// Records that cannot be pulled, or whose stored bytes cannot be read, are reported but never removed,
+// since the failure may be transient or a mismatch may only stem from how the record is re-marshaled.
+func (s storeCtrl) VerifyRecords(req *storev1.VerifyRecordsRequest, stream storev1.StoreService_VerifyRecordsServer) error {
+	storeLogger.Debug("Called store controller's VerifyRecords method", "repair", req.GetRepair())
+
+	lister, ok := s.store.(types.RecordListerAPI)
+	if !ok {
+		return status.Error(codes.Unimplemented, "listing records not supported by current store implementation")
+	}
+
+	ctx := stream.Context()
+
+	cids, err := lister.ListRecordCIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := make(chan *storev1.VerifyRecordsResponse)
+	sem := make(chan struct{}, verifyConcurrency)
+
+	go func() {
+		defer close(results)
+
+		var wg sync.WaitGroup
+
+		for _, cid := range cids {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+
+				return
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				select {
+				case results <- s.verifyRecord(ctx, cid, req.GetRepair()):
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		wg.Wait()
+	}()
+
+	var mismatched, failed, removed int
+
+	for result := range results {
+		result.Total = uint64(len(cids))
+
+		switch {
+		case result.GetErrorMessage() != "" && result.GetComputedCid() == "":
+			failed++
+		case !result.GetValid():
+			mismatched++
+		}
+
+		if result.GetRemoved() {
+			removed++
+		}
+
+		if err := stream.Send(result); err != nil {
+			// Workers exit once the stream context is cancelled on return
+			return status.Errorf(codes.Internal, "failed to send verification result: %v", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	storeLogger.Info("Record verification completed",
+		"records", len(cids),
+		"mismatched", mismatched,
+		"failed", failed,
+		"removed", removed)
+
+	return nil
+}
+
+// verifyRecord recomputes the CID of a stored record.
+// If no canonical form reproduces the CID, the digest of the stored bytes decides,
+// and the record is removed if repair is set and the stored bytes do not match either.
+func (s storeCtrl) verifyRecord(ctx context.Context, cid string, repair bool) *storev1.VerifyRecordsResponse {
+	response := &storev1.VerifyRecordsResponse{
+		Cid: cid,
+	}
+
+	recordRef := &corev1.RecordRef{Cid: cid}
+
+	record, err := s.store.Pull(ctx, recordRef)
+	if err != nil {
+		errMsg := "failed to pull record: " + status.Convert(err).Message()
+		response.ErrorMessage = &errMsg
+
+		return response
+	}
+
+	response.ComputedCid, response.Valid = recomputeCID(record, cid)
+	if response.GetValid() {
+		return response
+	}
+
+	// The record may re-marshal differently than it was stored, e.g. after a change of
+	// the canonical form, so only the stored bytes prove that its content is corrupted
+	blobCID, err := s.storedBlobCID(ctx, recordRef)
+	if err != nil {
+		errMsg := "failed to verify stored record bytes: " + status.Convert(err).Message()
+		response.ErrorMessage = &errMsg
+
+		return response
+	}
+
+	if blobCID == cid {
+		response.ComputedCid, response.Valid = blobCID, true
+
+		return response
+	}
+
+	response.ComputedCid = blobCID
+
+	if !repair {
+		return response
+	}
+
+	if err := s.store.Delete(ctx, recordRef); err != nil {
+		errMsg := "failed to remove record: " + status.Convert(err).Message()
+		response.ErrorMessage = &errMsg
+
+		return response
+	}
+
+	// Clean up search database (secondary operation - don't fail on errors)
+	if err := s.db.RemoveRecord(cid); err != nil {
+		storeLogger.Error("Failed to remove record from search index", "error", err, "cid", cid)
+	}
+
+	response.Removed = true
+
+	storeLogger.Warn("Removed record whose content does not match its CID", "cid", cid, "computedCid", response.GetComputedCid())
+
+	return response
+}
+
+// recomputeCID recomputes the CID of a record and reports whether it matches the expected CID.
+// Records may be stored in any canonical form, so the expected CID matches if any form reproduces it.
+func recomputeCID(record *corev1.Record, expected string) (string, bool) {
+	if record.HasCid(expected) {
+		return expected, true
+	}
+
+	return record.GetCid(), false
+}
+
+// storedBlobCID computes the CID of the bytes a record is stored as.
+func (s storeCtrl) storedBlobCID(ctx context.Context, ref *corev1.RecordRef) (string, error) {
+	blobStore, ok := s.store.(types.RecordBlobAPI)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "reading record blobs not supported by current store implementation")
+	}
+
+	data, err := blobStore.PullRecordBlob(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := corev1.CalculateDigest(data)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to calculate digest of stored bytes: %v", err)
+	}
+
+	cid, err := corev1.ConvertDigestToCID(digest)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to convert digest to CID: %v", err)
+	}
+
+	return cid, nil
+}
+
+// withPushAnnotations attaches the push annotations sent in the request metadata to the context.
+// Each annotation is sent as a "key=value" value of storev1.PushAnnotationMetadataKey.
+func withPushAnnotations(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	values := md.Get(storev1.PushAnnotationMetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	annotations := make(map[string]string, len(values))
+
+	for _, value := range values {
+		key, val, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid push annotation %q: expected key=value", value)
+		}
+
+		annotations[key] = val
+	}
+
+	return types.WithPushAnnotations(ctx, annotations), nil
+}
+
+// withPushExpiry attaches the expiry of the pushed records to the context.
+// The time to live is sent as a Go duration string in storev1.PushTTLMetadataKey.
+func withPushExpiry(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, storev1.PushTTLMetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	ttl, err := time.ParseDuration(values[len(values)-1])
+	if err != nil || ttl <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid push ttl %q: expected a positive duration", values[len(values)-1])
+	}
+
+	return types.WithPushExpiry(ctx, time.Now().Add(ttl)), nil
+}
+
+// withCacheBypass makes the record reads of the request skip the store cache
+// if requested with storev1.NoCacheMetadataKey.
+func withCacheBypass(ctx context.Context) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, storev1.NoCacheMetadataKey)
+	if len(values) == 0 || values[len(values)-1] != "true" {
+		return ctx
+	}
+
+	return types.WithCacheBypass(ctx)
+}
+
 // pushRecordToStore pushes a record to the store and adds it to the search index.
 func (s storeCtrl) pushRecordToStore(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
 	// Push the record to store
@@ -329,14 +885,22 @@ func (s storeCtrl) pushRecordToStore(ctx context.Context, record *corev1.Record)
 	if err != nil {
 		storeLogger.Error("Failed to push record to store", "error", err)
 
-		return nil, status.Errorf(codes.Internal, "failed to push record to store: %v", err)
+		// Keep the status of rejected pushes, e.g. for records that already exist with other annotations
+		st := status.Convert(err)
+
+		code := st.Code()
+		if code == codes.Unknown {
+			code = codes.Internal
+		}
+
+		return nil, status.Errorf(code, "failed to push record to store: %s", st.Message())
 	}
 
 	storeLogger.Info("Record pushed to store successfully", "cid", pushedRef.GetCid())
 
 	// Add record to search index for discoverability
-	// Use the adapter pattern to convert corev1.Record to types.Record
-	recordAdapter := adapters.NewRecordAdapter(record)
+	// Use the adapter pattern to convert corev1.Record to types.Record, indexed under the CID it was stored with
+	recordAdapter := adapters.NewRecordAdapterWithCid(record, pushedRef.GetCid())
 	if err := s.db.AddRecord(recordAdapter); err != nil {
 		// Log error but don't fail the push operation
 		storeLogger.Error("Failed to add record to search index", "error", err, "cid", pushedRef.GetCid())
@@ -344,9 +908,32 @@ func (s storeCtrl) pushRecordToStore(ctx context.Context, record *corev1.Record)
 		storeLogger.Debug("Record added to search index successfully", "cid", pushedRef.GetCid())
 	}
 
+	if _, ok := types.PushExpiryFromContext(ctx); ok {
+		s.indexRecordExpiry(ctx, pushedRef)
+	}
+
 	return pushedRef, nil
 }
 
+// indexRecordExpiry copies the stored expiry of a record to the search index.
+func (s storeCtrl) indexRecordExpiry(ctx context.Context, ref *corev1.RecordRef) {
+	meta, err := s.store.Lookup(ctx, ref)
+	if err != nil {
+		storeLogger.Error("Failed to lookup record expiry", "error", err, "cid", ref.GetCid())
+
+		return
+	}
+
+	expiresAt, ok := meta.ExpiryTime()
+	if !ok {
+		return
+	}
+
+	if err := s.db.SetRecordExpiry(ref.GetCid(), expiresAt); err != nil {
+		storeLogger.Error("Failed to add record expiry to search index", "error", err, "cid", ref.GetCid())
+	}
+}
+
 // validateRecordRef validates a record reference.
 func (s storeCtrl) validateRecordRef(recordRef *corev1.RecordRef) error {
 	if recordRef.GetCid() == "" {
@@ -356,8 +943,51 @@ func (s storeCtrl) validateRecordRef(recordRef *corev1.RecordRef) error {
 	return nil
 }
 
+// resolveRecordRef expands an abbreviated CID to the full CID of the only matching record.
+// Full CIDs are passed through, as are all references if the store cannot resolve prefixes.
+func (s storeCtrl) resolveRecordRef(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.RecordRef, error) {
+	if corev1.IsValidCID(recordRef.GetCid()) {
+		return recordRef, nil
+	}
+
+	resolver, ok := s.store.(types.PrefixResolverAPI)
+	if !ok {
+		return recordRef, nil
+	}
+
+	cid, err := resolver.ResolvePrefix(ctx, recordRef.GetCid())
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return recordRef, nil
+		}
+
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to resolve CID prefix: %s", st.Message())
+	}
+
+	storeLogger.Debug("Resolved CID prefix", "prefix", recordRef.GetCid(), "cid", cid)
+
+	return &corev1.RecordRef{Cid: cid}, nil
+}
+
 // pullRecordFromStore pulls a record from the store with validation.
+// Expired records are reported as not found if they are hidden.
 func (s storeCtrl) pullRecordFromStore(ctx context.Context, recordRef *corev1.RecordRef) (*corev1.Record, error) {
+	// Expired records are hidden until the sweeper deletes them
+	if s.hideExpired {
+		recordMeta, err := s.store.Lookup(ctx, recordRef)
+		if err != nil {
+			st := status.Convert(err)
+
+			return nil, status.Errorf(st.Code(), "failed to lookup record: %s", st.Message())
+		}
+
+		if recordMeta.IsExpired(time.Now()) {
+			return nil, status.Errorf(codes.NotFound, "record %s has expired", recordRef.GetCid())
+		}
+	}
+
 	// Pull record from store
 	record, err := s.store.Pull(ctx, recordRef)
 	if err != nil {
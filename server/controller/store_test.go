@@ -0,0 +1,220 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pullStore pulls records by CID, blocking pulls of CIDs with a gate until the gate is closed.
+type pullStore struct {
+	types.StoreAPI
+	records map[string]*corev1.Record
+	gates   map[string]chan struct{}
+}
+
+func (s *pullStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	if gate, ok := s.gates[ref.GetCid()]; ok {
+		select {
+		case <-gate:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+// pullStreamServer collects the responses sent by PullStream.
+type pullStreamServer struct {
+	grpc.ServerStream
+	ctx       context.Context //nolint:containedctx
+	responses []*storev1.PullStreamResponse
+	onSend    func(*storev1.PullStreamResponse) error
+}
+
+func (s *pullStreamServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *pullStreamServer) Send(resp *storev1.PullStreamResponse) error {
+	s.responses = append(s.responses, resp)
+
+	if s.onSend != nil {
+		return s.onSend(resp)
+	}
+
+	return nil
+}
+
+func newPullStreamRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+func TestPullStream(t *testing.T) {
+	first := newPullStreamRecord("first-agent")
+	second := newPullStreamRecord("second-agent")
+
+	newStore := func() *pullStore {
+		return &pullStore{
+			records: map[string]*corev1.Record{
+				"cid-first":  first,
+				"cid-second": second,
+			},
+			gates: map[string]chan struct{}{},
+		}
+	}
+
+	refs := func(cids ...string) []*corev1.RecordRef {
+		refs := make([]*corev1.RecordRef, 0, len(cids))
+		for _, cid := range cids {
+			refs = append(refs, &corev1.RecordRef{Cid: cid})
+		}
+
+		return refs
+	}
+
+	t.Run("reports every reference, including failures", func(t *testing.T) {
+		ctrl := storeCtrl{store: newStore()}
+		stream := &pullStreamServer{ctx: t.Context()}
+
+		err := ctrl.PullStream(&storev1.PullStreamRequest{RecordRefs: refs("cid-first", "cid-missing", "", "cid-second")}, stream)
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 4)
+
+		byCID := map[string]*storev1.PullStreamResponse{}
+		for _, resp := range stream.responses {
+			byCID[resp.GetRecordRef().GetCid()] = resp
+		}
+
+		assert.Equal(t, first, byCID["cid-first"].GetRecord())
+		assert.Equal(t, uint32(codes.OK), byCID["cid-first"].GetCode())
+		assert.Equal(t, second, byCID["cid-second"].GetRecord())
+
+		assert.Nil(t, byCID["cid-missing"].GetRecord())
+		assert.Equal(t, uint32(codes.NotFound), byCID["cid-missing"].GetCode())
+		assert.NotEmpty(t, byCID["cid-missing"].GetErrorMessage())
+
+		assert.Equal(t, uint32(codes.InvalidArgument), byCID[""].GetCode())
+	})
+
+	t.Run("streams records as soon as they are pulled", func(t *testing.T) {
+		store := newStore()
+		gate := make(chan struct{})
+		store.gates["cid-first"] = gate
+
+		ctrl := storeCtrl{store: store}
+		stream := &pullStreamServer{
+			ctx: t.Context(),
+			// The first record is only pulled once the second one has been sent
+			onSend: func(resp *storev1.PullStreamResponse) error {
+				if resp.GetRecordRef().GetCid() == "cid-second" {
+					close(gate)
+				}
+
+				return nil
+			},
+		}
+
+		err := ctrl.PullStream(&storev1.PullStreamRequest{RecordRefs: refs("cid-first", "cid-second")}, stream)
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+		assert.Equal(t, "cid-second", stream.responses[0].GetRecordRef().GetCid())
+		assert.Equal(t, "cid-first", stream.responses[1].GetRecordRef().GetCid())
+	})
+
+	t.Run("stops when the stream is cancelled", func(t *testing.T) {
+		store := newStore()
+		store.gates["cid-first"] = make(chan struct{})
+
+		ctx, cancel := context.WithCancel(t.Context())
+		ctrl := storeCtrl{store: store}
+		stream := &pullStreamServer{
+			ctx: ctx,
+			onSend: func(*storev1.PullStreamResponse) error {
+				cancel()
+
+				return nil
+			},
+		}
+
+		done := make(chan error)
+
+		go func() {
+			done <- ctrl.PullStream(&storev1.PullStreamRequest{RecordRefs: refs("cid-first", "cid-second")}, stream)
+		}()
+
+		select {
+		case err := <-done:
+			assert.Equal(t, codes.Canceled, status.Code(err))
+		case <-time.After(5 * time.Second):
+			t.Fatal("PullStream did not stop after cancellation")
+		}
+	})
+
+	t.Run("fails if a response cannot be sent", func(t *testing.T) {
+		ctrl := storeCtrl{store: newStore()}
+		stream := &pullStreamServer{
+			ctx: t.Context(),
+			onSend: func(*storev1.PullStreamResponse) error {
+				return errors.New("connection closed")
+			},
+		}
+
+		err := ctrl.PullStream(&storev1.PullStreamRequest{RecordRefs: refs("cid-first", "cid-second")}, stream)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+// failingPushStore fails every push with err.
+type failingPushStore struct {
+	types.StoreAPI
+	err error
+}
+
+func (s *failingPushStore) Push(context.Context, *corev1.Record) (*corev1.RecordRef, error) {
+	return nil, s.err
+}
+
+func TestPushRecordToStore(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "rejected push", err: status.Error(codes.FailedPrecondition, "record already exists"), code: codes.FailedPrecondition},
+		{name: "store failure", err: errors.New("registry unavailable"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := storeCtrl{store: &failingPushStore{err: tt.err}}
+
+			_, err := ctrl.pushRecordToStore(t.Context(), newPullStreamRecord("test-agent"))
+			require.Error(t, err)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"sort"
+	"time"
+)
+
+type Annotation struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	RecordCID string `gorm:"column:record_cid;not null;index"`
+	Key       string `gorm:"not null;index"`
+	Value     string `gorm:"not null"`
+}
+
+// convertAnnotations transforms record annotations to SQLite structs.
+// Annotations are sorted by key so that rows are inserted in a stable order.
+func convertAnnotations(annotations map[string]string, recordCID string) []Annotation {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	result := make([]Annotation, len(keys))
+	for i, key := range keys {
+		result[i] = Annotation{
+			RecordCID: recordCID,
+			Key:       key,
+			Value:     annotations[key],
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAnnotationTestData(t *testing.T, db *DB) []string {
+	t.Helper()
+
+	records := []*typesv1alpha0.Record{
+		{
+			Name:          "roundtrip-agent",
+			Version:       "1.0.0",
+			SchemaVersion: "v0.3.1",
+			Annotations: map[string]string{
+				"custom": "value",
+				"team":   "platform",
+			},
+		},
+		{
+			Name:          "other-agent",
+			Version:       "1.0.0",
+			SchemaVersion: "v0.3.1",
+			Annotations: map[string]string{
+				"custom": "other-value",
+				"team":   "platform",
+			},
+		},
+		{
+			Name:          "plain-agent",
+			Version:       "1.0.0",
+			SchemaVersion: "v0.3.1",
+		},
+	}
+
+	cids := make([]string, len(records))
+
+	for i, data := range records {
+		record := corev1.New(data)
+		require.NoError(t, db.AddRecord(adapters.NewRecordAdapter(record)))
+
+		cids[i] = record.GetCid()
+	}
+
+	return cids
+}
+
+func TestAddRecord_StoresAnnotations(t *testing.T) {
+	db := setupTestDB(t)
+	cids := createAnnotationTestData(t, db)
+
+	records, err := db.GetRecords(types.WithName("roundtrip-agent"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, cids[0], records[0].GetCid())
+
+	data := mustGetRecordData(t, records[0])
+	assert.Equal(t, map[string]string{"custom": "value", "team": "platform"}, data.GetAnnotations())
+}
+
+func TestGetRecords_AnnotationOption(t *testing.T) {
+	db := setupTestDB(t)
+	cids := createAnnotationTestData(t, db)
+
+	tests := []struct {
+		name     string
+		options  []types.FilterOption
+		expected []string
+	}{
+		{
+			name:     "exact value",
+			options:  []types.FilterOption{types.WithAnnotation("custom", "value")},
+			expected: []string{cids[0]},
+		},
+		{
+			name:     "value is case-insensitive",
+			options:  []types.FilterOption{types.WithAnnotation("custom", "VALUE")},
+			expected: []string{cids[0]},
+		},
+		{
+			name:     "wildcard value",
+			options:  []types.FilterOption{types.WithAnnotation("custom", "*value")},
+			expected: []string{cids[0], cids[1]},
+		},
+		{
+			name:     "key must match exactly",
+			options:  []types.FilterOption{types.WithAnnotation("CUSTOM", "value")},
+			expected: []string{},
+		},
+		{
+			name: "multiple filters are combined with AND",
+			options: []types.FilterOption{
+				types.WithAnnotation("team", "platform"),
+				types.WithAnnotation("custom", "other-*"),
+			},
+			expected: []string{cids[1]},
+		},
+		{
+			name: "no record matches all filters",
+			options: []types.FilterOption{
+				types.WithAnnotation("custom", "value"),
+				types.WithAnnotation("team", "research"),
+			},
+			expected: []string{},
+		},
+		{
+			name: "combined with other filters",
+			options: []types.FilterOption{
+				types.WithAnnotation("team", "platform"),
+				types.WithName("roundtrip-*"),
+			},
+			expected: []string{cids[0]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := db.GetRecords(tt.options...)
+			require.NoError(t, err)
+
+			actual := make([]string, 0, len(records))
+			for _, record := range records {
+				actual = append(actual, record.GetCid())
+			}
+
+			assert.ElementsMatch(t, tt.expected, actual)
+
+			recordCIDs, err := db.GetRecordCIDs(tt.options...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, recordCIDs)
+		})
+	}
+}
+
+func TestRemoveRecord_AnnotationSearch(t *testing.T) {
+	db := setupTestDB(t)
+	cids := createAnnotationTestData(t, db)
+
+	require.NoError(t, db.RemoveRecord(cids[0]))
+
+	recordCIDs, err := db.GetRecordCIDs(types.WithAnnotation("team", "platform"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{cids[1]}, recordCIDs)
+}
@@ -0,0 +1,58 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// explainQuery logs the SQL generated for a search query along with its SQLite query plan.
+// The finisher applies the operation that would execute the query, e.g. Find or Pluck.
+// Failures are logged and never fail the search.
+func (d *DB) explainQuery(query *gorm.DB, finisher func(tx *gorm.DB) *gorm.DB) {
+	sql, args, plan, err := d.queryPlan(query, finisher)
+	if err != nil {
+		logger.Warn("Failed to explain search query", "sql", sql, "args", args, "error", err)
+
+		return
+	}
+
+	logger.Info("Search query plan", "sql", sql, "args", args, "plan", plan)
+}
+
+// queryPlan builds the SQL of a query without executing it and returns it with its arguments
+// and the steps of its query plan, e.g. "SEARCH skills USING INDEX idx_skills_record_cid (record_cid=?)".
+func (d *DB) queryPlan(query *gorm.DB, finisher func(tx *gorm.DB) *gorm.DB) (string, []any, []string, error) {
+	stmt := finisher(query.Session(&gorm.Session{DryRun: true})).Statement
+	sql := stmt.SQL.String()
+
+	rows, err := d.gormDB.Raw("EXPLAIN QUERY PLAN "+sql, stmt.Vars...).Rows()
+	if err != nil {
+		return sql, stmt.Vars, nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+	defer rows.Close()
+
+	var plan []string
+
+	for rows.Next() {
+		var (
+			id, parent, notUsed int
+			detail              string
+		)
+
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return sql, stmt.Vars, nil, fmt.Errorf("failed to read query plan: %w", err)
+		}
+
+		plan = append(plan, detail)
+	}
+
+	if err := rows.Err(); err != nil {
+		return sql, stmt.Vars, nil, fmt.Errorf("failed to read query plan: %w", err)
+	}
+
+	return sql, stmt.Vars, plan, nil
+}
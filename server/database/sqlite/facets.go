@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// facetColumns maps facet namespaces to the table and column holding the facet values.
+// Domains are not stored in the search database and are therefore not available as facets.
+var facetColumns = map[types.LabelType]struct{ table, column string }{
+	types.LabelTypeSkill:   {table: "skills", column: "name"},
+	types.LabelTypeLocator: {table: "locators", column: "type"},
+	types.LabelTypeModule:  {table: "modules", column: "name"},
+}
+
+// Facets returns, per namespace, the number of records matching the filters for each value.
+// Pagination options are ignored as facets describe the complete result set.
+func (d *DB) Facets(opts ...types.FilterOption) (map[string]map[string]int, error) {
+	cfg := &types.RecordFilters{}
+
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, errors.New("nil option provided")
+		}
+
+		opt(cfg)
+	}
+
+	// Select the CIDs of all matching records.
+	query := d.handleFilterOptions(d.gormDB.Model(&Record{}).Select("records.record_cid"), cfg)
+	if query.Error != nil {
+		return nil, fmt.Errorf("failed to filter records: %w", query.Error)
+	}
+
+	var matching any = query
+
+	if cfg.NameFuzzy != nil {
+		cfg.Limit, cfg.Offset = 0, 0
+
+		cids, err := d.getFuzzyNameCIDs(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		matching = cids
+	}
+
+	facets := make(map[string]map[string]int, len(facetColumns))
+
+	for namespace, col := range facetColumns {
+		var rows []struct {
+			Value string
+			Count int
+		}
+
+		err := d.gormDB.Table(col.table).
+			Select(col.column+" AS value, COUNT(DISTINCT record_cid) AS count").
+			Where("record_cid IN (?)", matching).
+			Group(col.column).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s facets: %w", namespace, err)
+		}
+
+		counts := make(map[string]int, len(rows))
+		for _, row := range rows {
+			counts[row.Value] = row.Count
+		}
+
+		facets[namespace.String()] = counts
+	}
+
+	return facets, nil
+}
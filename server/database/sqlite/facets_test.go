@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacets_NoOptions(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	facets, err := db.Facets()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[string]int{
+		"skills":   {"skill1": 1, "skill2": 1, "skill3": 1, "skill4": 1},
+		"locators": {"grpc": 2, "http": 1},
+		"modules":  {"module1": 1, "module2": 1, "module3": 1},
+	}, facets)
+}
+
+func TestFacets_WithFilters(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	// Pagination must not affect facet counts.
+	facets, err := db.Facets(types.WithLocatorTypes("grpc"), types.WithLimit(1))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[string]int{
+		"skills":   {"skill1": 1, "skill2": 1, "skill4": 1},
+		"locators": {"grpc": 2},
+		"modules":  {"module1": 1},
+	}, facets)
+
+	facets, err = db.Facets(types.WithName("no-such-record"))
+	require.NoError(t, err)
+
+	for namespace, counts := range facets {
+		assert.Empty(t, counts, "namespace %s should have no facets", namespace)
+	}
+}
+
+func TestFacets_NilOption(t *testing.T) {
+	db := setupTestDB(t)
+
+	_, err := db.Facets(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agntcy/dir/server/database/utils"
+	"github.com/agntcy/dir/server/types"
+	"gorm.io/gorm"
+)
+
+// fuzzyMatch is a record whose name matched the fuzzy name filter.
+type fuzzyMatch struct {
+	RecordCID     string `gorm:"column:record_cid"`
+	Name          string
+	SchemaVersion string
+	distance      int
+}
+
+// getFuzzyNameCIDs returns the CIDs of records matching the filters and the fuzzy name filter,
+// ordered by ascending distance, with pagination applied.
+func (d *DB) getFuzzyNameCIDs(cfg *types.RecordFilters) ([]string, error) {
+	matches, err := d.getFuzzyNameMatches(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cids := make([]string, len(matches))
+	for i, match := range matches {
+		cids[i] = match.RecordCID
+	}
+
+	return cids, nil
+}
+
+// getFuzzyNameSummaries returns the summaries of records matching the filters and the fuzzy name filter,
+// ordered by ascending distance, with pagination applied.
+func (d *DB) getFuzzyNameSummaries(cfg *types.RecordFilters) ([]types.RecordSummary, error) {
+	matches, err := d.getFuzzyNameMatches(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]types.RecordSummary, len(matches))
+	for i, match := range matches {
+		summaries[i] = types.RecordSummary{CID: match.RecordCID, SchemaVersion: match.SchemaVersion}
+	}
+
+	return summaries, nil
+}
+
+// getFuzzyNameMatches returns the records matching the filters and the fuzzy name filter,
+// ordered by ascending distance, with pagination applied.
+func (d *DB) getFuzzyNameMatches(cfg *types.RecordFilters) ([]fuzzyMatch, error) {
+	fuzzy := cfg.NameFuzzy
+	term := strings.ToLower(fuzzy.Term)
+	termLen := utf8.RuneCountInString(term)
+
+	// Select candidates matching all other filters.
+	// Names whose length differs by more than the maximum distance can never match.
+	query := d.gormDB.Model(&Record{}).Select("records.record_cid, records.name, records.schema_version").Distinct()
+	query = d.handleFilterOptions(query, cfg)
+	query = query.Where("LENGTH(records.name) BETWEEN ? AND ?", termLen-fuzzy.MaxDistance, termLen+fuzzy.MaxDistance)
+
+	if cfg.Explain {
+		d.explainQuery(query, func(tx *gorm.DB) *gorm.DB {
+			return tx.Scan(&[]fuzzyMatch{})
+		})
+	}
+
+	var candidates []fuzzyMatch
+	if err := query.Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to query fuzzy name candidates: %w", err)
+	}
+
+	matches := make([]fuzzyMatch, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		candidate.distance = utils.LevenshteinDistance(term, strings.ToLower(candidate.Name))
+		if candidate.distance <= fuzzy.MaxDistance {
+			matches = append(matches, candidate)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b fuzzyMatch) int {
+		return cmp.Or(
+			cmp.Compare(a.distance, b.distance),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.RecordCID, b.RecordCID),
+		)
+	})
+
+	// Apply pagination.
+	if cfg.Offset > 0 {
+		matches = matches[min(cfg.Offset, len(matches)):]
+	}
+
+	if cfg.Limit > 0 {
+		matches = matches[:min(cfg.Limit, len(matches))]
+	}
+
+	return matches, nil
+}
+
+// getFuzzyNameRecords returns the records matching the filters and the fuzzy name filter,
+// ordered by ascending distance, with pagination applied.
+func (d *DB) getFuzzyNameRecords(cfg *types.RecordFilters) ([]types.Record, error) {
+	cids, err := d.getFuzzyNameCIDs(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cids) == 0 {
+		return []types.Record{}, nil
+	}
+
+	var dbRecords []Record
+	if err := d.gormDB.Where("record_cid IN ?", cids).Preload("Skills").Preload("Locators").Preload("Modules").Preload("Annotations").Find(&dbRecords).Error; err != nil {
+		return nil, fmt.Errorf("failed to query records: %w", err)
+	}
+
+	byCID := make(map[string]*Record, len(dbRecords))
+	for i := range dbRecords {
+		byCID[dbRecords[i].RecordCID] = &dbRecords[i]
+	}
+
+	// Preserve the distance ordering.
+	result := make([]types.Record, 0, len(cids))
+
+	for _, cid := range cids {
+		if record, ok := byCID[cid]; ok {
+			result = append(result, record)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecords_NameFuzzy(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	// Transposed characters count as two edits.
+	records, err := db.GetRecords(types.WithNameFuzzy("agnet1", 2))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent1"}, recordNames(t, records))
+
+	// Matching is case-insensitive.
+	records, err = db.GetRecords(types.WithNameFuzzy("AGENT2", 0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent2"}, recordNames(t, records))
+
+	// Results are ordered by ascending distance.
+	records, err = db.GetRecords(types.WithNameFuzzy("tst-agent", 3))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-agent"}, recordNames(t, records))
+
+	records, err = db.GetRecords(types.WithNameFuzzy("agen1", 3))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent1", "agent2"}, recordNames(t, records))
+
+	// Other filters are applied as usual.
+	records, err = db.GetRecords(types.WithNameFuzzy("agent", 1), types.WithVersion("2.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent2"}, recordNames(t, records))
+}
+
+func TestGetRecordCIDs_NameFuzzyPagination(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	all, err := db.GetRecordCIDs(types.WithNameFuzzy("agent", 1))
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	page, err := db.GetRecordCIDs(types.WithNameFuzzy("agent", 1), types.WithLimit(1), types.WithOffset(1))
+	require.NoError(t, err)
+	assert.Equal(t, all[1:], page)
+
+	page, err = db.GetRecordCIDs(types.WithNameFuzzy("agent", 1), types.WithOffset(5))
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
@@ -14,8 +14,10 @@ type Locator struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	RecordCID string `gorm:"column:record_cid;not null;index"`
-	Type      string `gorm:"not null"`
-	URL       string `gorm:"not null"`
+	Type      string `gorm:"not null;index:idx_locators_type_lower,expression:LOWER(type)"`
+	URL       string `gorm:"not null;index:idx_locators_url_lower,expression:LOWER(url)"`
+	Size      uint64 `gorm:"not null;default:0"`
+	Digest    string `gorm:"not null;default:'';index"`
 }
 
 func (locator *Locator) GetAnnotations() map[string]string {
@@ -32,13 +34,11 @@ func (locator *Locator) GetURL() string {
 }
 
 func (locator *Locator) GetSize() uint64 {
-	// SQLite locators don't store size information
-	return 0
+	return locator.Size
 }
 
 func (locator *Locator) GetDigest() string {
-	// SQLite locators don't store digest information
-	return ""
+	return locator.Digest
 }
 
 // convertLocators transforms interface types to SQLite structs.
@@ -49,6 +49,8 @@ func convertLocators(locators []types.Locator, recordCID string) []Locator {
 			RecordCID: recordCID,
 			Type:      locator.GetType(),
 			URL:       locator.GetURL(),
+			Size:      locator.GetSize(),
+			Digest:    locator.GetDigest(),
 		}
 	}
 
@@ -4,6 +4,8 @@
 package sqlite
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/agntcy/dir/server/types"
@@ -14,16 +16,29 @@ type Module struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	RecordCID string `gorm:"column:record_cid;not null;index"`
-	Name      string `gorm:"not null"`
+	Name      string `gorm:"not null;index:idx_modules_name_lower,expression:LOWER(name)"`
+	Version   string `gorm:"not null;default:''"`
+	Data      string `gorm:"type:text;not null;default:'{}'"` // JSON-encoded module data
 }
 
 func (module *Module) GetName() string {
 	return module.Name
 }
 
+func (module *Module) GetVersion() string {
+	return module.Version
+}
+
 func (module *Module) GetData() map[string]any {
-	// SQLite modules don't store data, return empty map
-	return make(map[string]any)
+	data := make(map[string]any)
+
+	if module.Data != "" {
+		if err := json.Unmarshal([]byte(module.Data), &data); err != nil {
+			logger.Warn("Failed to decode module data", "error", err, "cid", module.RecordCID, "module", module.Name)
+		}
+	}
+
+	return data
 }
 
 // convertModules transforms interface types to SQLite structs.
@@ -33,8 +48,37 @@ func convertModules(modules []types.Module, recordCID string) []Module {
 		result[i] = Module{
 			RecordCID: recordCID,
 			Name:      module.GetName(),
+			Version:   module.GetVersion(),
+			Data:      encodeModuleData(module),
 		}
 	}
 
 	return result
 }
+
+// encodeModuleData returns the JSON encoding of the module data, or an empty object if it has none.
+func encodeModuleData(module types.Module) string {
+	data := module.GetData()
+	if len(data) == 0 {
+		return "{}"
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		logger.Warn("Failed to encode module data", "error", err, "module", module.GetName())
+
+		return "{}"
+	}
+
+	return string(encoded)
+}
+
+// moduleDataPath converts a module data path to a SQLite JSON path.
+// Both "$.framework.version" and "framework.version" are accepted.
+func moduleDataPath(path string) string {
+	if strings.HasPrefix(path, "$") {
+		return path
+	}
+
+	return "$." + path
+}
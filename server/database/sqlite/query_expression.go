@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"github.com/agntcy/dir/server/database/utils"
+)
+
+// queryExpressionCondition builds a WHERE condition matching records by a query expression.
+// Terms on associations become EXISTS subqueries, so that NOT and OR apply to the record
+// as a whole rather than to a single joined row. Returns the condition and its arguments.
+func queryExpressionCondition(expr *searchv1.QueryExpression) (string, []any, error) {
+	switch expr.Op {
+	case searchv1.QueryExpressionMatch:
+		return queryTermCondition(expr.Query)
+
+	case searchv1.QueryExpressionNot:
+		if len(expr.Operands) != 1 {
+			return "", nil, fmt.Errorf("NOT expects a single operand, got %d", len(expr.Operands))
+		}
+
+		condition, args, err := queryExpressionCondition(expr.Operands[0])
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "NOT (" + condition + ")", args, nil
+
+	case searchv1.QueryExpressionAnd, searchv1.QueryExpressionOr:
+		if len(expr.Operands) == 0 {
+			return "", nil, fmt.Errorf("%s expects operands", queryOperatorKeyword(expr.Op))
+		}
+
+		conditions := make([]string, 0, len(expr.Operands))
+
+		var args []any
+
+		for _, operand := range expr.Operands {
+			condition, operandArgs, err := queryExpressionCondition(operand)
+			if err != nil {
+				return "", nil, err
+			}
+
+			conditions = append(conditions, "("+condition+")")
+			args = append(args, operandArgs...)
+		}
+
+		return strings.Join(conditions, " "+queryOperatorKeyword(expr.Op)+" "), args, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown query expression operator %d", expr.Op)
+}
+
+func queryOperatorKeyword(op searchv1.QueryExpressionOp) string {
+	if op == searchv1.QueryExpressionOr {
+		return "OR"
+	}
+
+	return "AND"
+}
+
+// queryTermCondition builds a WHERE condition matching records by a single record query.
+//
+//nolint:cyclop
+func queryTermCondition(query *searchv1.RecordQuery) (string, []any, error) {
+	switch query.GetType() { //nolint:exhaustive
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME:
+		condition, arg := utils.BuildSingleWildcardCondition("records.name", query.GetValue())
+
+		return condition, []any{arg}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_VERSION:
+		condition, arg := utils.BuildSingleWildcardCondition("records.version", query.GetValue())
+
+		return condition, []any{arg}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL_ID:
+		id, err := strconv.ParseUint(query.GetValue(), 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse skill ID %q: %w", query.GetValue(), err)
+		}
+
+		return existsCondition("skills", "skills.skill_id = ?"), []any{id}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL_NAME:
+		condition, arg := utils.BuildSingleWildcardCondition("skills.name", query.GetValue())
+
+		return existsCondition("skills", condition), []any{arg}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		// Type and URL must be matched by the same locator
+		locatorType, locatorURL := utils.ParseLocatorQuery(query.GetValue())
+
+		var (
+			conditions []string
+			args       []any
+		)
+
+		if locatorType != "" {
+			condition, arg := utils.BuildSingleWildcardCondition("locators.type", locatorType)
+			conditions = append(conditions, condition)
+			args = append(args, arg)
+		}
+
+		if locatorURL != "" {
+			condition, arg := utils.BuildSingleWildcardCondition("locators.url", locatorURL)
+			conditions = append(conditions, condition)
+			args = append(args, arg)
+		}
+
+		return existsCondition("locators", conditions...), args, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST:
+		return existsCondition("locators", "locators.digest = ?"), []any{query.GetValue()}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		condition, arg := utils.BuildSingleWildcardCondition("modules.name", query.GetValue())
+
+		return existsCondition("modules", condition), []any{arg}, nil
+
+	case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA:
+		path, value, ok := strings.Cut(query.GetValue(), "=")
+		if !ok || strings.TrimSpace(path) == "" {
+			return "", nil, fmt.Errorf("invalid module data query %q: expected <path>=<value>", query.GetValue())
+		}
+
+		condition, args := moduleDataCondition(strings.TrimSpace(path), value)
+
+		return condition, args, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported query type %s in query expression", query.GetType())
+}
+
+// existsCondition builds a condition matching records with a row in the association
+// table that satisfies all conditions.
+func existsCondition(table string, conditions ...string) string {
+	condition := "EXISTS (SELECT 1 FROM " + table + " WHERE " + table + ".record_cid = records.record_cid"
+
+	for _, c := range conditions {
+		condition += " AND " + c
+	}
+
+	return condition + ")"
+}
@@ -0,0 +1,144 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecords_QueryExpressionOption(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	const (
+		agent1    = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		agent2    = "bafybeihkoviema7g3gxyt6la7b7kbblo2hm7zgi3f6d67dqd7wy3yqhqxu"
+		testAgent = "bafybeihdwdcefgh4dqkjv67uzcmw7ojzge6uyuvma5kw7bzydb56wxfao"
+	)
+
+	tests := []struct {
+		name       string
+		expression string
+		options    []types.FilterOption
+		expected   []string
+	}{
+		{
+			name:       "single term",
+			expression: "skill-name:skill3",
+			expected:   []string{agent2},
+		},
+		{
+			name:       "and with not",
+			expression: "skill-name:skill* AND version:1.* AND NOT name:*test*",
+			expected:   []string{agent1},
+		},
+		{
+			name:       "or across fields",
+			expression: "name:agent2 OR skill-id:104",
+			expected:   []string{agent2, testAgent},
+		},
+		{
+			name:       "not applies to the whole record",
+			expression: "NOT skill-name:skill2",
+			expected:   []string{agent2, testAgent},
+		},
+		{
+			name:       "parentheses",
+			expression: "(module:module1 OR module:module3) AND NOT locator:http",
+			expected:   []string{agent1},
+		},
+		{
+			name:       "locator type and url match the same locator",
+			expression: "locator:grpc:localhost:8082",
+			expected:   []string{testAgent},
+		},
+		{
+			name:       "locator digest",
+			expression: "locator-digest:sha256:abc123 OR name:agent1",
+			expected:   []string{agent1, agent2},
+		},
+		{
+			name:       "combined with other filters",
+			expression: "locator:grpc",
+			options:    []types.FilterOption{types.WithVersion("1.0.0"), types.WithSkillNames("skill4")},
+			expected:   []string{testAgent},
+		},
+		{
+			name:       "no match",
+			expression: "name:agent1 AND NOT skill-id:101",
+			expected:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := searchv1.ParseQueryExpression(tt.expression)
+			require.NoError(t, err)
+
+			options := append([]types.FilterOption{types.WithQueryExpression(expr)}, tt.options...)
+
+			cids, err := db.GetRecordCIDs(options...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, cids)
+
+			records, err := db.GetRecords(options...)
+			require.NoError(t, err)
+			assert.Len(t, records, len(tt.expected))
+		})
+	}
+}
+
+func TestGetRecords_QueryExpressionModuleData(t *testing.T) {
+	db := setupTestDB(t)
+
+	records := []types.Record{
+		&TestRecord{
+			cid: "cid-langgraph",
+			data: &TestRecordData{
+				name: "langgraph-agent",
+				modules: []types.Module{
+					&TestModule{name: "runtime/framework", data: map[string]any{
+						"framework": map[string]any{"name": "langgraph"},
+						"streaming": true,
+					}},
+				},
+			},
+		},
+		&TestRecord{
+			cid: "cid-crewai",
+			data: &TestRecordData{
+				name: "crewai-agent",
+				modules: []types.Module{
+					&TestModule{name: "runtime/framework", data: map[string]any{
+						"framework": map[string]any{"name": "crewai"},
+						"streaming": false,
+					}},
+				},
+			},
+		},
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	expr, err := searchv1.ParseQueryExpression("module-data:streaming=true OR module-data:framework.name=crew*")
+	require.NoError(t, err)
+
+	cids, err := db.GetRecordCIDs(types.WithQueryExpression(expr))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cid-langgraph", "cid-crewai"}, cids)
+
+	expr, err = searchv1.ParseQueryExpression("NOT module-data:streaming=true")
+	require.NoError(t, err)
+
+	cids, err = db.GetRecordCIDs(types.WithQueryExpression(expr))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid-crewai"}, cids)
+}
@@ -6,23 +6,43 @@ package sqlite
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/agntcy/dir/server/database/utils"
 	"github.com/agntcy/dir/server/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// sortColumns maps the sort fields accepted by types.WithSortBy to SQL expressions.
+// Only fields listed here can be used for ordering to prevent SQL injection.
+var sortColumns = map[string]string{
+	"name":        "records.name",
+	"version":     "records.version",
+	"created_at":  "records.created_at",
+	"skill_count": "(SELECT COUNT(*) FROM skills WHERE skills.record_cid = records.record_cid)",
+}
+
+// Record is the indexed form of a record.
+// Filtered columns of records and their children are indexed on their lowercase value,
+// as filters match case-insensitively.
 type Record struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	RecordCID string `gorm:"column:record_cid;primarykey;not null"`
-	Name      string `gorm:"not null"`
-	Version   string `gorm:"not null"`
-
-	Skills   []Skill   `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
-	Locators []Locator `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
-	Modules  []Module  `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	RecordCID string     `gorm:"column:record_cid;primarykey;not null"`
+	Name      string     `gorm:"not null;index:idx_records_name_lower,expression:LOWER(name)"`
+	Version   string     `gorm:"not null;index:idx_records_version_lower,expression:LOWER(version)"`
+	ExpiresAt *time.Time `gorm:"index"`
+
+	// SchemaVersion is the OASF schema version of the record.
+	// It is empty for records indexed before schema versions were stored.
+	SchemaVersion string `gorm:"not null;default:''"`
+
+	Skills      []Skill      `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Locators    []Locator    `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Modules     []Module     `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
+	Annotations []Annotation `gorm:"foreignKey:RecordCID;references:RecordCID;constraint:OnDelete:CASCADE"`
 }
 
 // Implement central Record interface.
@@ -40,8 +60,12 @@ type RecordDataAdapter struct {
 }
 
 func (r *RecordDataAdapter) GetAnnotations() map[string]string {
-	// SQLite records don't store annotations, return empty map
-	return make(map[string]string)
+	annotations := make(map[string]string, len(r.record.Annotations))
+	for _, annotation := range r.record.Annotations {
+		annotations[annotation.Key] = annotation.Value
+	}
+
+	return annotations
 }
 
 func (r *RecordDataAdapter) GetDomains() []types.Domain {
@@ -51,8 +75,7 @@ func (r *RecordDataAdapter) GetDomains() []types.Domain {
 }
 
 func (r *RecordDataAdapter) GetSchemaVersion() string {
-	// Default schema version for search records
-	return "v1"
+	return r.record.SchemaVersion
 }
 
 func (r *RecordDataAdapter) GetName() string {
@@ -124,39 +147,184 @@ func (d *DB) AddRecord(record types.Record) error {
 	// Get CID
 	cid := record.GetCid()
 
-	// Check if record already exists
-	var existingRecord Record
+	// Build complete Record with all associations
+	sqliteRecord := &Record{
+		RecordCID:     cid,
+		Name:          recordData.GetName(),
+		Version:       recordData.GetVersion(),
+		SchemaVersion: recordData.GetSchemaVersion(),
+		Skills:        convertSkills(recordData.GetSkills(), cid),
+		Locators:      convertLocators(recordData.GetLocators(), cid),
+		Modules:       convertModules(recordData.GetModules(), cid),
+		Annotations:   convertAnnotations(recordData.GetAnnotations(), cid),
+	}
+
+	inserted := false
 
-	err = d.gormDB.Where("record_cid = ?", cid).First(&existingRecord).Error
-	if err == nil {
-		// Record exists, skip insert
-		logger.Debug("Record already exists in search database, skipping insert", "record_cid", existingRecord.RecordCID, "cid", cid)
+	// The record and its associations are inserted atomically, and only by the writer
+	// that inserted the record row, so concurrent adds of the same CID cannot duplicate them.
+	err = d.gormDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(sqliteRecord)
+		if result.Error != nil {
+			return fmt.Errorf("failed to insert record: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		inserted = true
+
+		return createAssociations(tx, sqliteRecord)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add record to SQLite database: %w", err)
+	}
+
+	if !inserted {
+		logger.Debug("Record already exists in search database, skipping insert", "cid", cid)
 
 		return nil
 	}
 
-	// If error is not "record not found", return the error
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("failed to check existing record: %w", err)
+	logger.Debug("Added new record with associations to SQLite database", "record_cid", sqliteRecord.RecordCID, "cid", cid,
+		"skills", len(sqliteRecord.Skills), "locators", len(sqliteRecord.Locators), "modules", len(sqliteRecord.Modules),
+		"annotations", len(sqliteRecord.Annotations))
+
+	return nil
+}
+
+// addRecordsBatchSize is the number of association rows inserted per statement by AddRecords.
+// It keeps the number of bound parameters well below the SQLite limit.
+const addRecordsBatchSize = 500
+
+// AddRecords adds many records in a single transaction, inserting associations in batches.
+// Duplicate CIDs are added once, and records that already exist are skipped.
+func (d *DB) AddRecords(records []types.Record) error {
+	sqliteRecords := make([]Record, 0, len(records))
+	seen := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		cid := record.GetCid()
+		if _, ok := seen[cid]; ok {
+			continue
+		}
+
+		seen[cid] = struct{}{}
+
+		recordData, err := record.GetRecordData()
+		if err != nil {
+			return fmt.Errorf("failed to get record data for %s: %w", cid, err)
+		}
+
+		sqliteRecords = append(sqliteRecords, Record{
+			RecordCID:     cid,
+			Name:          recordData.GetName(),
+			Version:       recordData.GetVersion(),
+			SchemaVersion: recordData.GetSchemaVersion(),
+			Skills:        convertSkills(recordData.GetSkills(), cid),
+			Locators:      convertLocators(recordData.GetLocators(), cid),
+			Modules:       convertModules(recordData.GetModules(), cid),
+			Annotations:   convertAnnotations(recordData.GetAnnotations(), cid),
+		})
 	}
 
-	// Build complete Record with all associations
-	sqliteRecord := &Record{
-		RecordCID: cid,
-		Name:      recordData.GetName(),
-		Version:   recordData.GetVersion(),
-		Skills:    convertSkills(recordData.GetSkills(), cid),
-		Locators:  convertLocators(recordData.GetLocators(), cid),
-		Modules:   convertModules(recordData.GetModules(), cid),
+	var newRecords []Record
+
+	// As in AddRecord, the associations of a record are only inserted by the writer
+	// that inserted the record row, so concurrent adds of the same CID cannot duplicate them.
+	err := d.gormDB.Transaction(func(tx *gorm.DB) error {
+		var (
+			skills      []Skill
+			locators    []Locator
+			modules     []Module
+			annotations []Annotation
+		)
+
+		for i := range sqliteRecords {
+			record := &sqliteRecords[i]
+
+			result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+			if result.Error != nil {
+				return fmt.Errorf("failed to insert record %s: %w", record.RecordCID, result.Error)
+			}
+
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			newRecords = append(newRecords, *record)
+			skills = append(skills, record.Skills...)
+			locators = append(locators, record.Locators...)
+			modules = append(modules, record.Modules...)
+			annotations = append(annotations, record.Annotations...)
+		}
+
+		if len(newRecords) == 0 {
+			return nil
+		}
+
+		if len(skills) > 0 {
+			if err := tx.CreateInBatches(skills, addRecordsBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert skills: %w", err)
+			}
+		}
+
+		if len(locators) > 0 {
+			if err := tx.CreateInBatches(locators, addRecordsBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert locators: %w", err)
+			}
+		}
+
+		if len(modules) > 0 {
+			if err := tx.CreateInBatches(modules, addRecordsBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert modules: %w", err)
+			}
+		}
+
+		if len(annotations) > 0 {
+			if err := tx.CreateInBatches(annotations, addRecordsBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert annotations: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add records to SQLite database: %w", err)
 	}
 
-	// Let GORM handle the entire creation with associations
-	if err := d.gormDB.Create(sqliteRecord).Error; err != nil {
-		return fmt.Errorf("failed to add record to SQLite database: %w", err)
+	logger.Debug("Added records to SQLite database", "requested", len(records), "added", len(newRecords),
+		"skipped", len(sqliteRecords)-len(newRecords))
+
+	return nil
+}
+
+// createAssociations inserts the child rows of a record.
+func createAssociations(tx *gorm.DB, record *Record) error {
+	if len(record.Skills) > 0 {
+		if err := tx.Create(&record.Skills).Error; err != nil {
+			return fmt.Errorf("failed to insert skills: %w", err)
+		}
 	}
 
-	logger.Debug("Added new record with associations to SQLite database", "record_cid", sqliteRecord.RecordCID, "cid", cid,
-		"skills", len(sqliteRecord.Skills), "locators", len(sqliteRecord.Locators), "modules", len(sqliteRecord.Modules))
+	if len(record.Locators) > 0 {
+		if err := tx.Create(&record.Locators).Error; err != nil {
+			return fmt.Errorf("failed to insert locators: %w", err)
+		}
+	}
+
+	if len(record.Modules) > 0 {
+		if err := tx.Create(&record.Modules).Error; err != nil {
+			return fmt.Errorf("failed to insert modules: %w", err)
+		}
+	}
+
+	if len(record.Annotations) > 0 {
+		if err := tx.Create(&record.Annotations).Error; err != nil {
+			return fmt.Errorf("failed to insert annotations: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -175,6 +343,11 @@ func (d *DB) GetRecords(opts ...types.FilterOption) ([]types.Record, error) {
 		opt(cfg)
 	}
 
+	// Fuzzy name matching is ordered by distance, which is computed outside the database.
+	if cfg.NameFuzzy != nil {
+		return d.getFuzzyNameRecords(cfg)
+	}
+
 	// Start with the base query for records.
 	query := d.gormDB.Model(&Record{}).Distinct()
 
@@ -190,9 +363,21 @@ func (d *DB) GetRecords(opts ...types.FilterOption) ([]types.Record, error) {
 	// Apply all filters.
 	query = d.handleFilterOptions(query, cfg)
 
+	// Apply ordering.
+	query, err := d.handleSortOptions(query, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Explain {
+		d.explainQuery(query, func(tx *gorm.DB) *gorm.DB {
+			return tx.Find(&[]Record{})
+		})
+	}
+
 	// Execute the query to get records.
 	var dbRecords []Record
-	if err := query.Preload("Skills").Preload("Locators").Preload("Modules").Find(&dbRecords).Error; err != nil {
+	if err := query.Preload("Skills").Preload("Locators").Preload("Modules").Preload("Annotations").Find(&dbRecords).Error; err != nil {
 		return nil, fmt.Errorf("failed to query records: %w", err)
 	}
 
@@ -220,6 +405,11 @@ func (d *DB) GetRecordCIDs(opts ...types.FilterOption) ([]string, error) {
 		opt(cfg)
 	}
 
+	// Fuzzy name matching is ordered by distance, which is computed outside the database.
+	if cfg.NameFuzzy != nil {
+		return d.getFuzzyNameCIDs(cfg)
+	}
+
 	// Start with the base query for records - only select CID for efficiency.
 	query := d.gormDB.Model(&Record{}).Select("records.record_cid").Distinct()
 
@@ -235,6 +425,18 @@ func (d *DB) GetRecordCIDs(opts ...types.FilterOption) ([]string, error) {
 	// Apply all filters.
 	query = d.handleFilterOptions(query, cfg)
 
+	// Apply ordering.
+	query, err := d.handleSortOptions(query, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Explain {
+		d.explainQuery(query, func(tx *gorm.DB) *gorm.DB {
+			return tx.Pluck("record_cid", &[]string{})
+		})
+	}
+
 	// Execute the query to get only CIDs (no preloading needed).
 	var cids []string
 	if err := query.Pluck("record_cid", &cids).Error; err != nil {
@@ -245,27 +447,145 @@ func (d *DB) GetRecordCIDs(opts ...types.FilterOption) ([]string, error) {
 	return cids, nil
 }
 
+// GetRecordSummaries retrieves the CIDs and schema versions of the records matching the options.
+// Like GetRecordCIDs, only the selected columns are queried, without preloading associations.
+func (d *DB) GetRecordSummaries(opts ...types.FilterOption) ([]types.RecordSummary, error) {
+	// Create default configuration.
+	cfg := &types.RecordFilters{}
+
+	// Apply all options.
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, errors.New("nil option provided")
+		}
+
+		opt(cfg)
+	}
+
+	// Fuzzy name matching is ordered by distance, which is computed outside the database.
+	if cfg.NameFuzzy != nil {
+		return d.getFuzzyNameSummaries(cfg)
+	}
+
+	query := d.gormDB.Model(&Record{}).Select("records.record_cid, records.schema_version").Distinct()
+
+	// Apply pagination.
+	if cfg.Limit > 0 {
+		query = query.Limit(cfg.Limit)
+	}
+
+	if cfg.Offset > 0 {
+		query = query.Offset(cfg.Offset)
+	}
+
+	// Apply all filters.
+	query = d.handleFilterOptions(query, cfg)
+
+	// Apply ordering.
+	query, err := d.handleSortOptions(query, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Explain {
+		d.explainQuery(query, func(tx *gorm.DB) *gorm.DB {
+			return tx.Scan(&[]recordSummary{})
+		})
+	}
+
+	var rows []recordSummary
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query record summaries: %w", err)
+	}
+
+	summaries := make([]types.RecordSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = types.RecordSummary{CID: row.RecordCID, SchemaVersion: row.SchemaVersion}
+	}
+
+	return summaries, nil
+}
+
+// recordSummary is the row scanned by GetRecordSummaries.
+type recordSummary struct {
+	RecordCID     string `gorm:"column:record_cid"`
+	SchemaVersion string
+}
+
 // RemoveRecord removes a record from the search database by CID.
-// Uses CASCADE DELETE to automatically remove related Skills, Locators, and Modules.
+// Related Skills, Locators, Modules, and Annotations are removed along with it, atomically.
 func (d *DB) RemoveRecord(cid string) error {
-	result := d.gormDB.Where("record_cid = ?", cid).Delete(&Record{})
+	var rowsAffected int64
+
+	// Foreign key constraints are not enforced by SQLite unless enabled per connection,
+	// so child rows are deleted explicitly, in the same transaction as the record.
+	err := d.gormDB.Transaction(func(tx *gorm.DB) error {
+		for _, child := range []any{&Skill{}, &Locator{}, &Module{}, &Annotation{}} {
+			if err := tx.Where("record_cid = ?", cid).Delete(child).Error; err != nil {
+				return fmt.Errorf("failed to remove %T rows: %w", child, err)
+			}
+		}
 
-	if result.Error != nil {
-		return fmt.Errorf("failed to remove record from search database: %w", result.Error)
+		result := tx.Where("record_cid = ?", cid).Delete(&Record{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove record from search database: %w", err)
 	}
 
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		// Record not found in search database (might not have been indexed)
 		logger.Debug("No record found in search database", "cid", cid)
 
 		return nil // Not an error - might be a storage-only record
 	}
 
-	logger.Debug("Removed record from search database", "cid", cid, "rows_affected", result.RowsAffected)
+	logger.Debug("Removed record from search database", "cid", cid, "rows_affected", rowsAffected)
+
+	return nil
+}
+
+// SetRecordExpiry sets the expiry of an indexed record.
+// Records that are not indexed are ignored.
+func (d *DB) SetRecordExpiry(cid string, expiresAt time.Time) error {
+	result := d.gormDB.Model(&Record{}).Where("record_cid = ?", cid).Update("expires_at", expiresAt.UTC())
+	if result.Error != nil {
+		return fmt.Errorf("failed to set record expiry: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Debug("No record found in search database", "cid", cid)
+	}
 
 	return nil
 }
 
+// handleSortOptions applies the requested ordering to the query.
+// Record CID is used as a tie-breaker so that paginated results are stable.
+func (d *DB) handleSortOptions(query *gorm.DB, cfg *types.RecordFilters) (*gorm.DB, error) {
+	if cfg.SortBy == "" {
+		return query, nil
+	}
+
+	column, ok := sortColumns[cfg.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field: %q", cfg.SortBy)
+	}
+
+	direction := "ASC"
+	if cfg.SortDesc {
+		direction = "DESC"
+	}
+
+	return query.Order(column + " " + direction).Order("records.record_cid ASC"), nil
+}
+
 // handleFilterOptions applies the provided filters to the query.
 //
 //nolint:gocognit,cyclop,nestif
@@ -298,7 +618,7 @@ func (d *DB) handleFilterOptions(query *gorm.DB, cfg *types.RecordFilters) *gorm
 	}
 
 	// Handle locator filters with wildcard support.
-	if len(cfg.LocatorTypes) > 0 || len(cfg.LocatorURLs) > 0 {
+	if len(cfg.LocatorTypes) > 0 || len(cfg.LocatorURLs) > 0 || len(cfg.LocatorDigests) > 0 {
 		query = query.Joins("JOIN locators ON locators.record_cid = records.record_cid")
 
 		if len(cfg.LocatorTypes) > 0 {
@@ -314,6 +634,10 @@ func (d *DB) handleFilterOptions(query *gorm.DB, cfg *types.RecordFilters) *gorm
 				query = query.Where(condition, args...)
 			}
 		}
+
+		if len(cfg.LocatorDigests) > 0 {
+			query = query.Where("locators.digest IN ?", cfg.LocatorDigests)
+		}
 	}
 
 	// Handle module filters with wildcard support.
@@ -328,5 +652,77 @@ func (d *DB) handleFilterOptions(query *gorm.DB, cfg *types.RecordFilters) *gorm
 		}
 	}
 
+	// Handle module data filters with wildcard support.
+	// Each filter must be matched by at least one module of the record.
+	for _, filter := range cfg.ModuleData {
+		condition, args := moduleDataCondition(filter.Path, filter.Value)
+		query = query.Where(condition, args...)
+	}
+
+	// Handle extension version range filters.
+	// Versions are compared outside the database, so the matching records are resolved first.
+	// An invalid constraint fails the query.
+	for _, filter := range cfg.ExtensionVersionRanges {
+		cids, err := d.getExtensionVersionRangeCIDs(filter)
+		if err != nil {
+			_ = query.AddError(err)
+
+			return query
+		}
+
+		query = query.Where("records.record_cid IN ?", cids)
+	}
+
+	// Handle annotation filters with wildcard support.
+	// Each filter must be matched by an annotation of the record.
+	for _, filter := range cfg.Annotations {
+		condition, arg := utils.BuildSingleWildcardCondition("annotations.value", filter.Value)
+		query = query.Where("EXISTS (SELECT 1 FROM annotations WHERE annotations.record_cid = records.record_cid"+
+			" AND annotations.key = ? AND "+condition+")", filter.Key, arg)
+	}
+
+	// Handle the query expression, combined with the other filters by AND.
+	if cfg.QueryExpression != nil {
+		condition, args, err := queryExpressionCondition(cfg.QueryExpression)
+		if err != nil {
+			_ = query.AddError(err)
+
+			return query
+		}
+
+		query = query.Where(condition, args...)
+	}
+
+	// Handle expiry filters. Records without an expiry never expire.
+	if cfg.ExcludeExpiredAt != nil {
+		query = query.Where("(records.expires_at IS NULL OR records.expires_at > ?)", cfg.ExcludeExpiredAt.UTC())
+	}
+
+	if cfg.ExpiredAt != nil {
+		query = query.Where("records.expires_at IS NOT NULL AND records.expires_at <= ?", cfg.ExpiredAt.UTC())
+	}
+
 	return query
 }
+
+// moduleDataCondition builds a WHERE condition matching records with a module whose
+// data has the given value at a JSON path. Booleans are compared as "true" and "false",
+// other scalar values by their text representation.
+// Returns the condition and its arguments.
+func moduleDataCondition(path, value string) (string, []any) {
+	valueExpr := `CASE json_type(module_data.data, ?)
+		WHEN 'true' THEN 'true'
+		WHEN 'false' THEN 'false'
+		ELSE CAST(json_extract(module_data.data, ?) AS TEXT)
+	END`
+
+	operator := "="
+	if utils.ContainsWildcards(value) {
+		operator = "GLOB"
+	}
+
+	condition := "EXISTS (SELECT 1 FROM modules AS module_data WHERE module_data.record_cid = records.record_cid" +
+		" AND LOWER(" + valueExpr + ") " + operator + " ?)"
+
+	return condition, []any{moduleDataPath(path), moduleDataPath(path), strings.ToLower(value)}
+}
@@ -5,8 +5,13 @@ package sqlite
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	corev1 "github.com/agntcy/dir/api/core/v1"
@@ -43,15 +48,20 @@ func mustGetRecordData(t *testing.T, record types.Record) types.RecordData {
 
 // TestRecordData implements types.RecordData interface for testing.
 type TestRecordData struct {
-	name     string
-	version  string
-	skills   []types.Skill
-	locators []types.Locator
-	modules  []types.Module
+	name        string
+	version     string
+	annotations map[string]string
+	skills      []types.Skill
+	locators    []types.Locator
+	modules     []types.Module
 }
 
 func (r *TestRecordData) GetAnnotations() map[string]string {
-	return make(map[string]string)
+	if r.annotations == nil {
+		return make(map[string]string)
+	}
+
+	return r.annotations
 }
 
 func (r *TestRecordData) GetSchemaVersion() string {
@@ -123,6 +133,8 @@ func (s *TestSkill) GetID() uint64 {
 type TestLocator struct {
 	locType string
 	url     string
+	size    uint64
+	digest  string
 }
 
 func (l *TestLocator) GetAnnotations() map[string]string {
@@ -138,23 +150,33 @@ func (l *TestLocator) GetURL() string {
 }
 
 func (l *TestLocator) GetSize() uint64 {
-	return 0
+	return l.size
 }
 
 func (l *TestLocator) GetDigest() string {
-	return ""
+	return l.digest
 }
 
 type TestModule struct {
-	name string
+	name    string
+	version string
+	data    map[string]any
 }
 
 func (m *TestModule) GetName() string {
 	return m.name
 }
 
+func (m *TestModule) GetVersion() string {
+	return m.version
+}
+
 func (m *TestModule) GetData() map[string]any {
-	return make(map[string]any)
+	if m.data == nil {
+		return make(map[string]any)
+	}
+
+	return m.data
 }
 
 func setupTestDB(t *testing.T) *DB {
@@ -165,7 +187,7 @@ func setupTestDB(t *testing.T) *DB {
 	})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&Record{}, &Skill{}, &Locator{}, &Module{}, &Sync{})
+	err = db.AutoMigrate(&Record{}, &Skill{}, &Locator{}, &Module{}, &Annotation{}, &Sync{})
 	require.NoError(t, err)
 
 	return &DB{
@@ -204,7 +226,7 @@ func createTestData(t *testing.T, db *DB) {
 					&TestSkill{id: 103, name: "skill3"},
 				},
 				locators: []types.Locator{
-					&TestLocator{locType: "http", url: "http://localhost:8081"},
+					&TestLocator{locType: "http", url: "http://localhost:8081", size: 2048, digest: "sha256:abc123"},
 				},
 				modules: []types.Module{
 					&TestModule{name: "module2"},
@@ -337,6 +359,40 @@ func TestGetRecords_CombinedOptions(t *testing.T) {
 	assert.Empty(t, records) // module2 is on agent2 which has version 2.0.0, not 1.0.0
 }
 
+// TestGetRecords_Explain tests that explaining a query does not change its results.
+func TestGetRecords_Explain(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	filters := []types.FilterOption{
+		types.WithSkillNames("skill1"),
+		types.WithLocatorTypes("grpc"),
+		types.WithModuleNames("module1"),
+	}
+
+	records, err := db.GetRecords(append(filters, types.WithExplain())...)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "agent1", mustGetRecordData(t, records[0]).GetName())
+
+	cids, err := db.GetRecordCIDs(append(filters, types.WithExplain())...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{records[0].GetCid()}, cids)
+
+	t.Run("query plan", func(t *testing.T) {
+		cfg := &types.RecordFilters{SkillNames: []string{"skill1"}}
+		query := db.handleFilterOptions(db.gormDB.Model(&Record{}).Select("records.record_cid").Distinct(), cfg)
+
+		sql, args, plan, err := db.queryPlan(query, func(tx *gorm.DB) *gorm.DB {
+			return tx.Pluck("record_cid", &[]string{})
+		})
+		require.NoError(t, err)
+		assert.Contains(t, sql, "skills")
+		assert.Contains(t, args, "skill1")
+		assert.NotEmpty(t, plan)
+	})
+}
+
 // TestGetRecords_SkillIdOption tests the skill ID option.
 func TestGetRecords_SkillIdOption(t *testing.T) {
 	db := setupTestDB(t)
@@ -360,6 +416,202 @@ func TestGetRecords_LocatorUrlOption(t *testing.T) {
 	assert.Equal(t, "agent2", mustGetRecordData(t, records[0]).GetName())
 }
 
+// TestGetRecords_LocatorDigestOption tests filtering by the digest of a locator artifact.
+func TestGetRecords_LocatorDigestOption(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	records, err := db.GetRecords(types.WithLocatorDigest("sha256:abc123"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "agent2", mustGetRecordData(t, records[0]).GetName())
+
+	locators := mustGetRecordData(t, records[0]).GetLocators()
+	require.Len(t, locators, 1)
+	assert.Equal(t, uint64(2048), locators[0].GetSize())
+	assert.Equal(t, "sha256:abc123", locators[0].GetDigest())
+
+	records, err = db.GetRecords(types.WithLocatorDigest("sha256:unknown"))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+// TestGetRecords_ModuleDataPathOption tests filtering by nested module data fields.
+func TestGetRecords_ModuleDataPathOption(t *testing.T) {
+	db := setupTestDB(t)
+
+	records := []types.Record{
+		&TestRecord{
+			cid: "cid-langgraph",
+			data: &TestRecordData{
+				name: "langgraph-agent",
+				modules: []types.Module{
+					&TestModule{name: "runtime/framework", data: map[string]any{
+						"framework": map[string]any{"name": "langgraph", "version": "1.2.0"},
+						"streaming": true,
+					}},
+				},
+			},
+		},
+		&TestRecord{
+			cid: "cid-crewai",
+			data: &TestRecordData{
+				name: "crewai-agent",
+				modules: []types.Module{
+					&TestModule{name: "runtime/framework", data: map[string]any{
+						"framework": map[string]any{"name": "crewai", "version": "0.9.1"},
+						"streaming": false,
+					}},
+				},
+			},
+		},
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	// Test exact match on a nested field.
+	cids, err := db.GetRecordCIDs(types.WithModuleDataPath("framework.version", "1.2.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid-langgraph"}, cids)
+
+	// Test explicit JSON path and wildcard match.
+	cids, err = db.GetRecordCIDs(types.WithModuleDataPath("$.framework.name", "crew*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid-crewai"}, cids)
+
+	// Test boolean match.
+	cids, err = db.GetRecordCIDs(types.WithModuleDataPath("streaming", "true"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid-langgraph"}, cids)
+
+	// Test that all filters must match.
+	cids, err = db.GetRecordCIDs(
+		types.WithModuleDataPath("framework.name", "langgraph"),
+		types.WithModuleDataPath("framework.version", "0.9.1"),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+
+	// Test combination with module names and missing fields.
+	cids, err = db.GetRecordCIDs(types.WithModuleNames("runtime/framework"), types.WithModuleDataPath("framework.license", "*"))
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+
+	// Test that module data is preserved when reading records back.
+	result, err := db.GetRecords(types.WithName("langgraph-agent"))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	modules := mustGetRecordData(t, result[0]).GetModules()
+	require.Len(t, modules, 1)
+	assert.Equal(t, map[string]any{"name": "langgraph", "version": "1.2.0"}, modules[0].GetData()["framework"])
+}
+
+// TestGetRecords_ExtensionVersionRangeOption tests filtering by extension version ranges.
+func TestGetRecords_ExtensionVersionRangeOption(t *testing.T) {
+	db := setupTestDB(t)
+
+	newRecord := func(cid string, modules ...types.Module) types.Record {
+		return &TestRecord{cid: cid, data: &TestRecordData{name: cid, modules: modules}}
+	}
+
+	records := []types.Record{
+		newRecord("cid-v0", &TestModule{name: "runtime/framework", version: "v0.9.1"}),
+		newRecord("cid-v1", &TestModule{name: "runtime/framework", version: "1.2.0"}),
+		newRecord("cid-v1-minor", &TestModule{name: "runtime/framework", version: "v1.4"}),
+		newRecord("cid-v2", &TestModule{name: "runtime/framework", version: "2.0.0"}),
+		newRecord("cid-invalid", &TestModule{name: "runtime/framework", version: "latest"}),
+		newRecord("cid-unversioned", &TestModule{name: "runtime/framework"}),
+		newRecord("cid-other", &TestModule{name: "runtime/language", version: "1.0.0"}),
+		newRecord("cid-multi",
+			&TestModule{name: "runtime/framework", version: "3.0.0"},
+			&TestModule{name: "runtime/language", version: "1.5.0"},
+		),
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.AddRecord(record))
+	}
+
+	tests := []struct {
+		name    string
+		opts    []types.FilterOption
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "range",
+			opts: []types.FilterOption{types.WithExtensionVersionRange("runtime/framework", ">=1.0.0 <2.0.0")},
+			want: []string{"cid-v1", "cid-v1-minor"},
+		},
+		{
+			name: "alternatives",
+			opts: []types.FilterOption{types.WithExtensionVersionRange("runtime/framework", "<1.0.0 || >=3.0.0")},
+			want: []string{"cid-multi", "cid-v0"},
+		},
+		{
+			name: "wildcard name",
+			opts: []types.FilterOption{types.WithExtensionVersionRange("runtime/*", ">=1.0.0 <1.1.0")},
+			want: []string{"cid-other"},
+		},
+		{
+			name: "all filters must match",
+			opts: []types.FilterOption{
+				types.WithExtensionVersionRange("runtime/framework", ">=2.0.0"),
+				types.WithExtensionVersionRange("runtime/language", ">=1.0.0"),
+			},
+			want: []string{"cid-multi"},
+		},
+		{
+			name: "combined with other filters and pagination",
+			opts: []types.FilterOption{
+				types.WithExtensionVersionRange("runtime/framework", ">=1.0.0"),
+				types.WithName("cid-v*"),
+				types.WithSortBy("name", false),
+				types.WithLimit(2),
+				types.WithOffset(1),
+			},
+			want: []string{"cid-v1-minor", "cid-v2"},
+		},
+		{
+			name: "no match",
+			opts: []types.FilterOption{types.WithExtensionVersionRange("runtime/framework", ">=4.0.0")},
+			want: []string{},
+		},
+		{
+			name:    "invalid constraint",
+			opts:    []types.FilterOption{types.WithExtensionVersionRange("runtime/framework", "~> one")},
+			wantErr: "invalid version constraint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cids, err := db.GetRecordCIDs(tt.opts...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, cids)
+
+			records, err := db.GetRecords(tt.opts...)
+			require.NoError(t, err)
+			assert.Len(t, records, len(tt.want))
+		})
+	}
+
+	// Extension versions are preserved when reading records back.
+	result, err := db.GetRecords(types.WithName("cid-v1"))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "1.2.0", mustGetRecordData(t, result[0]).GetModules()[0].GetVersion())
+}
+
 // TestGetRecords_PreloadRelations ensures related data is properly loaded.
 func TestGetRecords_PreloadRelations(t *testing.T) {
 	db := setupTestDB(t)
@@ -402,6 +654,110 @@ func TestGetRecords_NilOption(t *testing.T) {
 	assert.Nil(t, records)
 }
 
+func recordNames(t *testing.T, records []types.Record) []string {
+	t.Helper()
+
+	names := make([]string, len(records))
+	for i, record := range records {
+		names[i] = mustGetRecordData(t, record).GetName()
+	}
+
+	return names
+}
+
+// TestGetRecords_SortBy tests ordering records by each supported field.
+func TestGetRecords_SortBy(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	testCases := []struct {
+		name     string
+		field    string
+		desc     bool
+		expected []string
+	}{
+		{name: "name ascending", field: "name", expected: []string{"agent1", "agent2", "test-agent"}},
+		{name: "name descending", field: "name", desc: true, expected: []string{"test-agent", "agent2", "agent1"}},
+		{name: "version ascending", field: "version", expected: []string{"agent1", "test-agent", "agent2"}},
+		{name: "version descending", field: "version", desc: true, expected: []string{"agent2", "agent1", "test-agent"}},
+		{name: "created_at ascending", field: "created_at", expected: []string{"agent1", "agent2", "test-agent"}},
+		{name: "created_at descending", field: "created_at", desc: true, expected: []string{"test-agent", "agent2", "agent1"}},
+		{name: "skill_count descending", field: "skill_count", desc: true, expected: []string{"agent1", "test-agent", "agent2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := db.GetRecords(types.WithSortBy(tc.field, tc.desc))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, recordNames(t, records))
+		})
+	}
+}
+
+// TestGetRecords_SortByPaginationStability tests that sorted pages are stable and non-overlapping.
+func TestGetRecords_SortByPaginationStability(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	for _, field := range []string{"name", "version", "created_at", "skill_count"} {
+		all, err := db.GetRecords(types.WithSortBy(field, true))
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+
+		var paged []string
+
+		for offset := range 3 {
+			page, err := db.GetRecords(types.WithSortBy(field, true), types.WithLimit(1), types.WithOffset(offset))
+			require.NoError(t, err)
+			require.Len(t, page, 1)
+
+			paged = append(paged, mustGetRecordData(t, page[0]).GetName())
+		}
+
+		assert.Equal(t, recordNames(t, all), paged, "field %s", field)
+	}
+}
+
+// TestGetRecords_SortByInvalidField ensures unknown sort fields are rejected.
+func TestGetRecords_SortByInvalidField(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	records, err := db.GetRecords(types.WithSortBy("name; DROP TABLE records", false))
+	require.Error(t, err)
+	assert.Nil(t, records)
+
+	cids, err := db.GetRecordCIDs(types.WithSortBy("description", false))
+	require.Error(t, err)
+	assert.Nil(t, cids)
+}
+
+// TestGetRecords_ExpiryOptions tests filtering records by their expiry.
+func TestGetRecords_ExpiryOptions(t *testing.T) {
+	db := setupTestDB(t)
+	createTestData(t, db)
+
+	now := time.Now()
+
+	require.NoError(t, db.SetRecordExpiry("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", now.Add(-time.Minute)))
+	require.NoError(t, db.SetRecordExpiry("bafybeihkoviema7g3gxyt6la7b7kbblo2hm7zgi3f6d67dqd7wy3yqhqxu", now.Add(time.Hour)))
+
+	// Records that are not indexed are ignored
+	require.NoError(t, db.SetRecordExpiry("bafy-unknown", now))
+
+	records, err := db.GetRecords(types.WithExcludeExpired(now), types.WithSortBy("name", false))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent2", "test-agent"}, recordNames(t, records))
+
+	cids, err := db.GetRecordCIDs(types.WithExpired(now))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"}, cids)
+
+	cids, err = db.GetRecordCIDs(types.WithExpired(now.Add(2 * time.Hour)))
+	require.NoError(t, err)
+	assert.Len(t, cids, 2)
+}
+
 // TestGetRecordRefs_CompareWithGetRecords tests that GetRecordRefs returns the same CIDs as GetRecords.
 func TestGetRecordRefs_CompareWithGetRecords(t *testing.T) {
 	db := setupTestDB(t)
@@ -439,6 +795,42 @@ func TestGetRecordRefs_CompareWithGetRecords(t *testing.T) {
 	assert.Equal(t, expectedCIDs, actualCIDs, "GetRecordRefs should return the same CIDs as GetRecords")
 }
 
+// TestGetRecordSummaries_SchemaVersions tests that summaries report the schema version of each record.
+func TestGetRecordSummaries_SchemaVersions(t *testing.T) {
+	db := setupTestDB(t)
+
+	expected := map[string]string{}
+
+	for _, recordJSON := range []string{
+		`{"name": "agent-old", "version": "1.0.0", "schema_version": "v0.3.1"}`,
+		`{"name": "agent-new", "version": "1.0.0", "schema_version": "0.7.0"}`,
+	} {
+		record, err := corev1.UnmarshalRecord([]byte(recordJSON))
+		require.NoError(t, err)
+
+		recordAdapter := adapters.NewRecordAdapter(record)
+		require.NoError(t, db.AddRecord(recordAdapter))
+
+		expected[recordAdapter.GetCid()] = record.GetSchemaVersion()
+	}
+
+	summaries, err := db.GetRecordSummaries(types.WithName("agent-*"))
+	require.NoError(t, err)
+
+	actual := map[string]string{}
+	for _, summary := range summaries {
+		actual[summary.CID] = summary.SchemaVersion
+	}
+
+	assert.Equal(t, expected, actual)
+
+	// Fuzzy name matching projects the schema version as well
+	summaries, err = db.GetRecordSummaries(types.WithNameFuzzy("agent-olf", 1))
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "v0.3.1", summaries[0].SchemaVersion)
+}
+
 // TestAddRecord_VerifyRelatedDataInsertion tests that AddRecord properly inserts all related data.
 func TestAddRecord_VerifyRelatedDataInsertion(t *testing.T) {
 	db := setupTestDB(t)
@@ -546,6 +938,63 @@ func TestRemoveRecord_VerifyRelatedDataDeletion(t *testing.T) {
 	t.Logf("✅ RemoveRecord properly deleted all related data")
 }
 
+// TestRemoveRecord_AtomicOnFailure tests that a failure partway through deletion leaves the record intact.
+func TestRemoveRecord_AtomicOnFailure(t *testing.T) {
+	db := setupTestDB(t)
+
+	testRecord := &TestRecord{
+		cid: "test-cid-atomic",
+		data: &TestRecordData{
+			name:    "atomic-delete-agent",
+			version: "1.0.0",
+			skills: []types.Skill{
+				&TestSkill{id: 10202, name: "Atomic Skill"},
+			},
+			locators: []types.Locator{
+				&TestLocator{locType: "grpc", url: "localhost:9090"},
+			},
+			modules: []types.Module{
+				&TestModule{name: "atomic-module"},
+			},
+		},
+	}
+
+	require.NoError(t, db.AddRecord(testRecord))
+
+	// Fail the deletion of the record row, after its child rows were deleted
+	err := db.gormDB.Callback().Delete().Before("gorm:delete").Register("test:fail_record_delete", func(tx *gorm.DB) {
+		if tx.Statement.Table == "records" {
+			_ = tx.AddError(errors.New("injected failure"))
+		}
+	})
+	require.NoError(t, err)
+
+	err = db.RemoveRecord(testRecord.cid)
+	require.Error(t, err, "RemoveRecord should fail")
+
+	countRows := func(model any) int64 {
+		var count int64
+		require.NoError(t, db.gormDB.Model(model).Where("record_cid = ?", testRecord.cid).Count(&count).Error)
+
+		return count
+	}
+
+	// Nothing was deleted
+	assert.Equal(t, int64(1), countRows(&Record{}), "Record should be kept")
+	assert.Equal(t, int64(1), countRows(&Skill{}), "Skills should be kept")
+	assert.Equal(t, int64(1), countRows(&Locator{}), "Locators should be kept")
+	assert.Equal(t, int64(1), countRows(&Module{}), "Modules should be kept")
+
+	// Once the failure is gone, everything is deleted
+	require.NoError(t, db.gormDB.Callback().Delete().Remove("test:fail_record_delete"))
+	require.NoError(t, db.RemoveRecord(testRecord.cid))
+
+	assert.Zero(t, countRows(&Record{}), "Record should be deleted")
+	assert.Zero(t, countRows(&Skill{}), "Skills should be deleted")
+	assert.Zero(t, countRows(&Locator{}), "Locators should be deleted")
+	assert.Zero(t, countRows(&Module{}), "Modules should be deleted")
+}
+
 // TestE2EScenario_AddSearchDeleteSearch tests the exact E2E flow that's failing.
 func TestE2EScenario_AddSearchDeleteSearch(t *testing.T) {
 	db := setupTestDB(t)
@@ -671,6 +1120,190 @@ func TestDuplicateAddRecord_VerifyIdempotency(t *testing.T) {
 	t.Logf("✅ Duplicate AddRecord is properly idempotent")
 }
 
+// TestConcurrentAddRecord_SameCID tests that concurrent adds of the same record do not duplicate rows.
+func TestConcurrentAddRecord_SameCID(t *testing.T) {
+	const writers = 20
+
+	// Use a database file, as every connection to an in-memory database opens a separate database
+	db, err := New(filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(10000)")
+	require.NoError(t, err)
+
+	testRecord := &TestRecord{
+		cid: "concurrent-cid-123",
+		data: &TestRecordData{
+			name:    "concurrent-agent",
+			version: "1.0.0",
+			skills: []types.Skill{
+				&TestSkill{id: 10201, name: "Concurrent Skill"},
+				&TestSkill{id: 10202, name: "Another Skill"},
+			},
+			locators: []types.Locator{
+				&TestLocator{locType: "http", url: "http://concurrent.example.com"},
+			},
+			modules: []types.Module{
+				&TestModule{name: "concurrent-module"},
+			},
+		},
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, writers)
+
+	for range writers {
+		wg.Go(func() {
+			errs <- db.AddRecord(testRecord)
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var count int64
+
+	require.NoError(t, db.gormDB.Model(&Record{}).Where("record_cid = ?", testRecord.cid).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "Should store exactly 1 record")
+
+	require.NoError(t, db.gormDB.Model(&Skill{}).Where("record_cid = ?", testRecord.cid).Count(&count).Error)
+	assert.Equal(t, int64(2), count, "Should store each skill once")
+
+	require.NoError(t, db.gormDB.Model(&Locator{}).Where("record_cid = ?", testRecord.cid).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "Should store each locator once")
+
+	require.NoError(t, db.gormDB.Model(&Module{}).Where("record_cid = ?", testRecord.cid).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "Should store each module once")
+}
+
+// TestAddRecords_BulkInsert tests that AddRecords inserts all related data, deduplicates CIDs and skips existing records.
+func TestAddRecords_BulkInsert(t *testing.T) {
+	db := setupTestDB(t)
+
+	existing := newBulkTestRecord(0)
+	require.NoError(t, db.AddRecord(existing))
+
+	records := []types.Record{existing}
+	for i := 1; i <= 3; i++ {
+		records = append(records, newBulkTestRecord(i))
+	}
+
+	// Duplicate CID within the same call
+	records = append(records, newBulkTestRecord(1))
+
+	require.NoError(t, db.AddRecords(records))
+
+	// Adding the same records again is idempotent
+	require.NoError(t, db.AddRecords(records))
+
+	for i := range 4 {
+		cid := fmt.Sprintf("bulk-cid-%d", i)
+
+		cids, err := db.GetRecordCIDs(types.WithName(fmt.Sprintf("bulk-agent-%d", i)))
+		require.NoError(t, err)
+		assert.Equal(t, []string{cid}, cids)
+
+		cids, err = db.GetRecordCIDs(types.WithSkillNames(fmt.Sprintf("bulk-skill-%d", i)))
+		require.NoError(t, err)
+		assert.Equal(t, []string{cid}, cids, "Should find record by skill name")
+
+		cids, err = db.GetRecordCIDs(types.WithLocatorURLs(fmt.Sprintf("http://bulk-%d.example.com", i)))
+		require.NoError(t, err)
+		assert.Equal(t, []string{cid}, cids, "Should find record by locator URL")
+
+		cids, err = db.GetRecordCIDs(types.WithModuleNames(fmt.Sprintf("bulk-module-%d", i)))
+		require.NoError(t, err)
+		assert.Equal(t, []string{cid}, cids, "Should find record by module name")
+	}
+
+	var count int64
+
+	require.NoError(t, db.gormDB.Model(&Record{}).Count(&count).Error)
+	assert.Equal(t, int64(4), count, "Should store each record once")
+
+	for model, expected := range map[any]int64{&Skill{}: 4, &Locator{}: 4, &Module{}: 4, &Annotation{}: 4} {
+		require.NoError(t, db.gormDB.Model(model).Count(&count).Error)
+		assert.Equal(t, expected, count, "Should store the children of each record once: %T", model)
+	}
+}
+
+// TestConcurrentAddRecords_OverlappingCIDs tests that concurrent bulk adds of overlapping records do not duplicate rows.
+func TestConcurrentAddRecords_OverlappingCIDs(t *testing.T) {
+	const (
+		writers = 10
+		total   = 20
+	)
+
+	// Use a database file, as every connection to an in-memory database opens a separate database
+	db, err := New(filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(10000)")
+	require.NoError(t, err)
+
+	// A record added on its own concurrently with the bulk adds
+	require.NoError(t, db.AddRecord(newBulkTestRecord(0)))
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, writers+1)
+
+	for w := range writers {
+		wg.Go(func() {
+			// Each writer adds an overlapping window of the records
+			var records []types.Record
+			for i := w; i < w+total/2; i++ {
+				records = append(records, newBulkTestRecord(i))
+			}
+
+			errs <- db.AddRecords(records)
+		})
+	}
+
+	wg.Go(func() {
+		errs <- db.AddRecord(newBulkTestRecord(total / 2))
+	})
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// Records 0 to writers+total/2-2 were added, each exactly once
+	added := int64(writers + total/2 - 1)
+
+	var count int64
+
+	require.NoError(t, db.gormDB.Model(&Record{}).Count(&count).Error)
+	assert.Equal(t, added, count, "Should store each record once")
+
+	for model := range map[any]struct{}{&Skill{}: {}, &Locator{}: {}, &Module{}: {}, &Annotation{}: {}} {
+		require.NoError(t, db.gormDB.Model(model).Count(&count).Error)
+		assert.Equal(t, added, count, "Should store the children of each record once: %T", model)
+	}
+}
+
+func newBulkTestRecord(i int) *TestRecord {
+	return &TestRecord{
+		cid: fmt.Sprintf("bulk-cid-%d", i),
+		data: &TestRecordData{
+			name:        fmt.Sprintf("bulk-agent-%d", i),
+			version:     "1.0.0",
+			annotations: map[string]string{"index": strconv.Itoa(i)},
+			skills: []types.Skill{
+				&TestSkill{id: uint64(i), name: fmt.Sprintf("bulk-skill-%d", i)},
+			},
+			locators: []types.Locator{
+				&TestLocator{locType: "http", url: fmt.Sprintf("http://bulk-%d.example.com", i)},
+			},
+			modules: []types.Module{
+				&TestModule{name: fmt.Sprintf("bulk-module-%d", i)},
+			},
+		},
+	}
+}
+
 // TestAllOASFVersions_SkillHandling tests that all OASF versions (V1, V2, V3) handle skills correctly.
 func TestAllOASFVersions_SkillHandling(t *testing.T) {
 	testCases := []struct {
@@ -896,3 +1529,91 @@ func TestSkillSearchCompatibility_AcrossVersions(t *testing.T) {
 	t.Logf("   Added CIDs: %v", addedCIDs)
 	t.Logf("   Found by name: %d agents", len(cids))
 }
+
+// BenchmarkGetRecordCIDs_Indexes compares filtered searches with and without the column indexes.
+func BenchmarkGetRecordCIDs_Indexes(b *testing.B) {
+	const recordCount = 5000
+
+	db, err := New(filepath.Join(b.TempDir(), "bench.db"))
+	require.NoError(b, err)
+
+	for i := range recordCount {
+		err := db.AddRecord(&TestRecord{
+			cid: fmt.Sprintf("bench-cid-%d", i),
+			data: &TestRecordData{
+				name:    fmt.Sprintf("bench-agent-%d", i),
+				version: "1.0.0",
+				skills: []types.Skill{
+					&TestSkill{id: uint64(i), name: fmt.Sprintf("skill-%d", i)},
+				},
+				locators: []types.Locator{
+					&TestLocator{locType: "http", url: fmt.Sprintf("http://bench-%d.example.com", i)},
+				},
+				modules: []types.Module{
+					&TestModule{name: fmt.Sprintf("module-%d", i%100)},
+				},
+			},
+		})
+		require.NoError(b, err)
+	}
+
+	filters := []types.FilterOption{
+		types.WithSkillNames("skill-4242"),
+		types.WithLocatorURLs("http://bench-4242.example.com"),
+		types.WithModuleNames("module-42"),
+	}
+
+	search := func(b *testing.B) {
+		b.Helper()
+
+		for b.Loop() {
+			cids, err := db.GetRecordCIDs(filters...)
+			require.NoError(b, err)
+			require.Len(b, cids, 1)
+		}
+	}
+
+	b.Run("indexed", search)
+
+	migrator := db.gormDB.Migrator()
+	for model, indexes := range map[any][]string{
+		&Skill{}:   {"idx_skills_name_lower", "idx_skills_skill_id"},
+		&Locator{}: {"idx_locators_type_lower", "idx_locators_url_lower"},
+		&Module{}:  {"idx_modules_name_lower"},
+	} {
+		for _, index := range indexes {
+			require.NoError(b, migrator.DropIndex(model, index))
+		}
+	}
+
+	b.Run("unindexed", search)
+}
+
+// BenchmarkAddRecords compares a bulk insert against sequential AddRecord calls.
+func BenchmarkAddRecords(b *testing.B) {
+	const recordCount = 1000
+
+	records := make([]types.Record, recordCount)
+	for i := range records {
+		records[i] = newBulkTestRecord(i)
+	}
+
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			db, err := New(filepath.Join(b.TempDir(), "bench.db"))
+			require.NoError(b, err)
+			require.NoError(b, db.AddRecords(records))
+		}
+	})
+
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			db, err := New(filepath.Join(b.TempDir(), "bench.db"))
+			require.NoError(b, err)
+
+			for _, record := range records {
+				require.NoError(b, db.AddRecord(record))
+			}
+		}
+	})
+}
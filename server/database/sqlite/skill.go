@@ -4,6 +4,8 @@
 package sqlite
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/agntcy/dir/server/types"
@@ -14,8 +16,8 @@ type Skill struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	RecordCID string `gorm:"column:record_cid;not null;index"`
-	SkillID   uint64 `gorm:"not null"`
-	Name      string `gorm:"not null"`
+	SkillID   uint64 `gorm:"not null;index"`
+	Name      string `gorm:"not null;index:idx_skills_name_lower,expression:LOWER(name)"`
 }
 
 func (skill *Skill) GetAnnotations() map[string]string {
@@ -44,3 +46,64 @@ func convertSkills(skills []types.Skill, recordCID string) []Skill {
 
 	return result
 }
+
+// GetSkillNames returns the distinct names used for the skill with the given ID.
+func (d *DB) GetSkillNames(id uint64) ([]string, error) {
+	var names []string
+
+	err := d.gormDB.Model(&Skill{}).
+		Distinct().
+		Where("skill_id = ?", id).
+		Order("name").
+		Pluck("name", &names).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill names: %w", err)
+	}
+
+	return names, nil
+}
+
+// GetSkillIDs returns the distinct IDs used for the skill with the given name (case-insensitive).
+func (d *DB) GetSkillIDs(name string) ([]uint64, error) {
+	var ids []uint64
+
+	err := d.gormDB.Model(&Skill{}).
+		Distinct().
+		Where("LOWER(name) = ?", strings.ToLower(name)).
+		Order("skill_id").
+		Pluck("skill_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ListSkills returns all distinct skills with the number of records using them, sorted by name.
+func (d *DB) ListSkills() ([]types.SkillInfo, error) {
+	var rows []struct {
+		SkillID     uint64
+		Name        string
+		RecordCount int64
+	}
+
+	err := d.gormDB.Model(&Skill{}).
+		Select("skill_id, name, COUNT(DISTINCT record_cid) AS record_count").
+		Group("skill_id, name").
+		Order("name, skill_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skills: %w", err)
+	}
+
+	skills := make([]types.SkillInfo, len(rows))
+	for i, row := range rows {
+		skills[i] = types.SkillInfo{
+			ID:          row.SkillID,
+			Name:        row.Name,
+			RecordCount: row.RecordCount,
+		}
+	}
+
+	return skills, nil
+}
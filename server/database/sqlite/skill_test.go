@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSkillTestData(t *testing.T, db *DB) {
+	t.Helper()
+
+	records := []types.Record{
+		&TestRecord{
+			cid: "cid-1",
+			data: &TestRecordData{
+				name: "agent1",
+				skills: []types.Skill{
+					&TestSkill{id: 10201, name: "natural_language_processing/text_completion"},
+					&TestSkill{id: 50201, name: "audio/speech_recognition"},
+				},
+			},
+		},
+		&TestRecord{
+			cid: "cid-2",
+			data: &TestRecordData{
+				name: "agent2",
+				skills: []types.Skill{
+					&TestSkill{id: 10201, name: "natural_language_processing/text_completion"},
+				},
+			},
+		},
+		&TestRecord{
+			cid: "cid-3",
+			data: &TestRecordData{
+				name: "agent3",
+				skills: []types.Skill{
+					// Older schema versions use a different name for the same skill
+					&TestSkill{id: 10201, name: "Natural Language Processing/Text Completion"},
+				},
+			},
+		},
+	}
+
+	for _, record := range records {
+		require.NoError(t, db.AddRecord(record))
+	}
+}
+
+func TestGetSkillNames(t *testing.T) {
+	db := setupTestDB(t)
+	createSkillTestData(t, db)
+
+	names, err := db.GetSkillNames(10201)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Natural Language Processing/Text Completion",
+		"natural_language_processing/text_completion",
+	}, names)
+
+	names, err = db.GetSkillNames(99999)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestGetSkillIDs(t *testing.T) {
+	db := setupTestDB(t)
+	createSkillTestData(t, db)
+
+	ids, err := db.GetSkillIDs("AUDIO/Speech_Recognition")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{50201}, ids)
+
+	ids, err = db.GetSkillIDs("unknown")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestListSkills(t *testing.T) {
+	db := setupTestDB(t)
+	createSkillTestData(t, db)
+
+	skills, err := db.ListSkills()
+	require.NoError(t, err)
+	assert.Equal(t, []types.SkillInfo{
+		{ID: 10201, Name: "Natural Language Processing/Text Completion", RecordCount: 1},
+		{ID: 50201, Name: "audio/speech_recognition", RecordCount: 1},
+		{ID: 10201, Name: "natural_language_processing/text_completion", RecordCount: 2},
+	}, skills)
+}
@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/agntcy/dir/server/database/utils"
+	"github.com/agntcy/dir/server/types"
+	"github.com/blang/semver"
+)
+
+// extensionVersion is the version of an extension of a record.
+type extensionVersion struct {
+	RecordCID string `gorm:"column:record_cid"`
+	Version   string
+}
+
+// getExtensionVersionRangeCIDs returns the CIDs of records with an extension matching the filter name
+// whose version satisfies the filter constraint. Versions that are not valid semver are skipped.
+func (d *DB) getExtensionVersionRangeCIDs(filter types.ExtensionVersionRangeFilter) ([]string, error) {
+	versionRange, err := semver.ParseRange(filter.Constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", filter.Constraint, err)
+	}
+
+	// Select candidates by extension name, the constraint cannot be evaluated by the database.
+	condition, arg := utils.BuildSingleWildcardCondition("name", filter.Name)
+
+	var candidates []extensionVersion
+	if err := d.gormDB.Model(&Module{}).Select("record_cid, version").Where(condition, arg).Where("version <> ''").Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to query extension versions: %w", err)
+	}
+
+	cids := make([]string, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		version, err := semver.ParseTolerant(candidate.Version)
+		if err != nil {
+			logger.Debug("Skipping extension with invalid version", "cid", candidate.RecordCID, "version", candidate.Version, "error", err)
+
+			continue
+		}
+
+		if versionRange(version) {
+			cids = append(cids, candidate.RecordCID)
+		}
+	}
+
+	return cids, nil
+}
@@ -0,0 +1,35 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"agent", "", 5},
+		{"", "agent", 5},
+		{"agent", "agent", 0},
+		{"agent", "agnet", 2},
+		{"agent", "agents", 1},
+		{"agent", "aget", 1},
+		{"agent", "agant", 1},
+		{"kitten", "sitting", 3},
+		{"müller", "muller", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LevenshteinDistance(tt.a, tt.b))
+			assert.Equal(t, tt.expected, LevenshteinDistance(tt.b, tt.a))
+		})
+	}
+}
@@ -15,6 +15,9 @@ import (
 
 var logger = logging.Logger("database/utils")
 
+// DefaultFuzzyDistance is the maximum edit distance of fuzzy queries that do not specify one.
+const DefaultFuzzyDistance = 2
+
 func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, error) { //nolint:gocognit,cyclop
 	var options []types.FilterOption
 
@@ -41,46 +44,41 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 			options = append(options, types.WithSkillNames(query.GetValue()))
 
 		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
-			l := strings.SplitN(query.GetValue(), ":", 2) //nolint:mnd
-
-			// If the type starts with a wildcard, treat it as a URL pattern
-			// Example: "*marketing-strategy"
-			if len(l) == 1 && strings.HasPrefix(l[0], "*") {
-				options = append(options, types.WithLocatorURLs(l[0]))
+			locatorType, locatorURL := ParseLocatorQuery(query.GetValue())
 
-				break
+			if locatorType != "" {
+				options = append(options, types.WithLocatorTypes(locatorType))
 			}
 
-			if len(l) == 1 && strings.TrimSpace(l[0]) != "" {
-				options = append(options, types.WithLocatorTypes(l[0]))
+			if locatorURL != "" {
+				options = append(options, types.WithLocatorURLs(locatorURL))
+			}
 
-				break
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR_DIGEST:
+			if strings.TrimSpace(query.GetValue()) != "" {
+				options = append(options, types.WithLocatorDigest(query.GetValue()))
 			}
 
-			// If the prefix is //, check if the part before : is a wildcard
-			// If it's a wildcard (like "*"), treat the whole thing as a URL pattern
-			// If it's not a wildcard (like "docker-image"), treat as type:url format
-			// Example: "*://ghcr.io/agntcy/marketing-strategy" -> pure URL pattern
-			if len(l) == 2 && strings.HasPrefix(l[1], "//") && strings.HasPrefix(l[0], "*") {
-				options = append(options, types.WithLocatorURLs(query.GetValue()))
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+			if strings.TrimSpace(query.GetValue()) != "" {
+				options = append(options, types.WithModuleNames(query.GetValue()))
+			}
 
-				break
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE_DATA:
+			path, value, ok := strings.Cut(query.GetValue(), "=")
+			if !ok || strings.TrimSpace(path) == "" {
+				return nil, fmt.Errorf("invalid module data query %q: expected <path>=<value>", query.GetValue())
 			}
 
-			if len(l) == 2 { //nolint:mnd
-				if strings.TrimSpace(l[0]) != "" {
-					options = append(options, types.WithLocatorTypes(l[0]))
-				}
+			options = append(options, types.WithModuleDataPath(strings.TrimSpace(path), value))
 
-				if strings.TrimSpace(l[1]) != "" {
-					options = append(options, types.WithLocatorURLs(l[1]))
-				}
+		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_NAME_FUZZY:
+			term, maxDistance, err := parseFuzzyQuery(query.GetValue())
+			if err != nil {
+				return nil, err
 			}
 
-		case searchv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
-			if strings.TrimSpace(query.GetValue()) != "" {
-				options = append(options, types.WithModuleNames(query.GetValue()))
-			}
+			options = append(options, types.WithNameFuzzy(term, maxDistance))
 
 		default:
 			logger.Warn("Unknown query type", "type", query.GetType())
@@ -89,3 +87,63 @@ func QueryToFilters(queries []*searchv1.RecordQuery) ([]types.FilterOption, erro
 
 	return options, nil
 }
+
+// ParseLocatorQuery splits a locator query value into a type and a URL pattern.
+// Either part is empty if the value does not constrain it.
+func ParseLocatorQuery(value string) (string, string) {
+	l := strings.SplitN(value, ":", 2) //nolint:mnd
+
+	// If the type starts with a wildcard, treat it as a URL pattern
+	// Example: "*marketing-strategy"
+	if len(l) == 1 && strings.HasPrefix(l[0], "*") {
+		return "", l[0]
+	}
+
+	if len(l) == 1 {
+		if strings.TrimSpace(l[0]) == "" {
+			return "", ""
+		}
+
+		return l[0], ""
+	}
+
+	// If the prefix is //, check if the part before : is a wildcard
+	// If it's a wildcard (like "*"), treat the whole thing as a URL pattern
+	// If it's not a wildcard (like "docker-image"), treat as type:url format
+	// Example: "*://ghcr.io/agntcy/marketing-strategy" -> pure URL pattern
+	if strings.HasPrefix(l[1], "//") && strings.HasPrefix(l[0], "*") {
+		return "", value
+	}
+
+	var locatorType, locatorURL string
+
+	if strings.TrimSpace(l[0]) != "" {
+		locatorType = l[0]
+	}
+
+	if strings.TrimSpace(l[1]) != "" {
+		locatorURL = l[1]
+	}
+
+	return locatorType, locatorURL
+}
+
+// parseFuzzyQuery parses a fuzzy query value in the "<term>[~<max-distance>]" format.
+func parseFuzzyQuery(value string) (string, int, error) {
+	term, distance := value, DefaultFuzzyDistance
+
+	if idx := strings.LastIndex(value, "~"); idx != -1 {
+		d, err := strconv.Atoi(value[idx+1:])
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid fuzzy query %q: failed to parse max distance: %w", value, err)
+		}
+
+		term, distance = value[:idx], d
+	}
+
+	if strings.TrimSpace(term) == "" {
+		return "", 0, fmt.Errorf("invalid fuzzy query %q: term is required", value)
+	}
+
+	return term, distance, nil
+}
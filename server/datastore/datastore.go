@@ -8,11 +8,30 @@ import (
 
 	"github.com/agntcy/dir/server/types"
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	badger "github.com/ipfs/go-ds-badger"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+)
+
+// Backend is the implementation used to store datastore entries.
+type Backend string
+
+const (
+	// BackendMemory keeps all entries in memory. Entries are lost on restart.
+	BackendMemory Backend = "memory"
+
+	// BackendBadger stores entries on disk using BadgerDB.
+	BackendBadger Backend = "badger"
+
+	// BackendLevelDB stores entries on disk using LevelDB.
+	BackendLevelDB Backend = "leveldb"
 )
 
 // New is shortcut to creating specific datastore.
-// For now, we use memory store.
+//
+// If no backend is set, BadgerDB is used when a local directory is provided,
+// and an in-memory datastore otherwise. If a namespace is set, the datastore is
+// wrapped so that all keys are stored under it.
 //
 // We should only create a proper datastore from options,
 // as we do not implement this interface.
@@ -25,11 +44,47 @@ func New(opts ...Option) (types.Datastore, error) {
 		}
 	}
 
-	// create local datastore if requested
-	if localDir := options.localDir; localDir != "" {
-		return badger.NewDatastore(localDir, &badger.DefaultOptions) //nolint:wrapcheck
+	dstore, err := newBackend(options)
+	if err != nil {
+		return nil, err
 	}
 
-	// create in-memory datastore
-	return datastore.NewMapDatastore(), nil
+	if options.namespace == "" {
+		return dstore, nil
+	}
+
+	return namespace.Wrap(dstore, datastore.NewKey(options.namespace)), nil
+}
+
+// newBackend creates the datastore of the configured backend.
+func newBackend(options *options) (types.Datastore, error) {
+	backend := options.backend
+	if backend == "" {
+		backend = BackendMemory
+		if options.localDir != "" {
+			backend = BackendBadger
+		}
+	}
+
+	switch backend {
+	case BackendMemory:
+		return datastore.NewMapDatastore(), nil
+
+	case BackendBadger:
+		if options.localDir == "" {
+			return nil, fmt.Errorf("backend %s requires a local directory", backend)
+		}
+
+		return badger.NewDatastore(options.localDir, &badger.DefaultOptions) //nolint:wrapcheck
+
+	case BackendLevelDB:
+		if options.localDir == "" {
+			return nil, fmt.Errorf("backend %s requires a local directory", backend)
+		}
+
+		return leveldb.NewDatastore(options.localDir, nil) //nolint:wrapcheck
+
+	default:
+		return nil, fmt.Errorf("unsupported datastore backend: %s", backend)
+	}
 }
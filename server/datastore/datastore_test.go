@@ -0,0 +1,86 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPersistentBackends(t *testing.T) {
+	key := datastore.NewKey("/skills/AI/cid/peer")
+
+	for _, backend := range []Backend{"", BackendBadger, BackendLevelDB} {
+		t.Run(string(backend), func(t *testing.T) {
+			dir := t.TempDir()
+
+			dstore, err := New(WithBackend(backend), WithFsProvider(dir))
+			require.NoError(t, err)
+			require.NoError(t, dstore.Put(t.Context(), key, []byte("value")))
+			require.NoError(t, dstore.Close())
+
+			// Entries survive reopening the datastore
+			dstore, err = New(WithBackend(backend), WithFsProvider(dir))
+			require.NoError(t, err)
+
+			defer dstore.Close()
+
+			value, err := dstore.Get(t.Context(), key)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("value"), value)
+		})
+	}
+}
+
+func TestNewNamespace(t *testing.T) {
+	dir := t.TempDir()
+	key := datastore.NewKey("/skills/AI/cid/peer")
+
+	dstore, err := New(WithFsProvider(dir), WithNamespace("dir/routing"))
+	require.NoError(t, err)
+	require.NoError(t, dstore.Put(t.Context(), key, []byte("value")))
+
+	// Keys and query results are unprefixed for users of the namespaced datastore
+	results, err := dstore.Query(t.Context(), query.Query{Prefix: "/skills/"})
+	require.NoError(t, err)
+
+	entries, err := results.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, key.String(), entries[0].Key)
+	require.NoError(t, dstore.Close())
+
+	// The underlying datastore stores the key under the namespace
+	dstore, err = New(WithFsProvider(dir))
+	require.NoError(t, err)
+
+	defer dstore.Close()
+
+	found, err := dstore.Has(t.Context(), key)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	value, err := dstore.Get(t.Context(), datastore.NewKey("/dir/routing/skills/AI/cid/peer"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestNewBackendValidation(t *testing.T) {
+	dstore, err := New()
+	require.NoError(t, err)
+	assert.IsType(t, &datastore.MapDatastore{}, dstore)
+
+	_, err = New(WithBackend(BackendBadger))
+	require.Error(t, err)
+
+	_, err = New(WithBackend(BackendLevelDB))
+	require.Error(t, err)
+
+	_, err = New(WithBackend("postgres"), WithFsProvider(t.TempDir()))
+	require.Error(t, err)
+}
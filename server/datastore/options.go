@@ -11,7 +11,9 @@ import (
 type Option func(*options) error
 
 type options struct {
-	localDir string
+	backend   Backend
+	localDir  string
+	namespace string
 }
 
 // WithFsProvider sets the filesystem as the datastore provider.
@@ -29,3 +31,24 @@ func WithFsProvider(dir string) Option {
 		return nil
 	}
 }
+
+// WithNamespace prefixes all keys of the datastore with the given namespace, e.g. "dir/routing",
+// so that several components can share the same physical datastore without key collisions.
+// The prefix is transparent to users of the datastore, whose keys and query results are unprefixed.
+func WithNamespace(namespace string) Option {
+	return func(o *options) error {
+		o.namespace = namespace
+
+		return nil
+	}
+}
+
+// WithBackend sets the datastore backend.
+// Persistent backends also require WithFsProvider.
+func WithBackend(backend Backend) Option {
+	return func(o *options) error {
+		o.backend = backend
+
+		return nil
+	}
+}
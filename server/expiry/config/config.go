@@ -0,0 +1,21 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "time"
+
+const (
+	DefaultExpirySweepInterval = 1 * time.Minute
+	DefaultExpiryHideExpired   = false
+)
+
+type Config struct {
+	// Sweep interval.
+	// The interval at which expired records are deleted and unannounced.
+	// If zero, expired records are never deleted.
+	SweepInterval time.Duration `json:"sweep_interval,omitempty" mapstructure:"sweep_interval"`
+
+	// Hide expired records from lookups and searches before they are deleted.
+	HideExpired bool `json:"hide_expired,omitempty" mapstructure:"hide_expired"`
+}
@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/expiry/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("expiry")
+
+// Service periodically deletes expired records.
+// Expired records are unpublished from routing, deleted from the store and removed from the search index.
+type Service struct {
+	db      types.DatabaseAPI
+	store   types.StoreAPI
+	routing types.RoutingAPI
+	config  config.Config
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a new expiry service.
+func New(db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, opts types.APIOptions) (*Service, error) {
+	return &Service{
+		db:      db,
+		store:   store,
+		routing: routing,
+		config:  opts.Config().Expiry,
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// Start begins sweeping expired records.
+// Nothing is started if the sweep interval is not set.
+func (s *Service) Start(ctx context.Context) error {
+	if s.config.SweepInterval <= 0 {
+		logger.Info("Expiry sweeper disabled, expired records are not deleted")
+
+		return nil
+	}
+
+	logger.Info("Starting expiry service", "interval", s.config.SweepInterval)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		s.run(ctx)
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the expiry service.
+func (s *Service) Stop() error {
+	logger.Info("Stopping expiry service")
+
+	close(s.stopCh)
+	s.wg.Wait()
+
+	logger.Info("Expiry service stopped")
+
+	return nil
+}
+
+// run sweeps expired records at every interval until stopped.
+func (s *Service) run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.SweepInterval)
+	defer ticker.Stop()
+
+	// Sweep immediately on start
+	s.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Expiry sweeper stopping due to context cancellation")
+
+			return
+		case <-s.stopCh:
+			logger.Info("Expiry sweeper stopping due to stop signal")
+
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// sweep deletes the records that have expired according to the search index.
+func (s *Service) sweep(ctx context.Context) {
+	now := time.Now()
+
+	cids, err := s.db.GetRecordCIDs(types.WithExpired(now))
+	if err != nil {
+		logger.Error("Failed to get expired records", "error", err)
+
+		return
+	}
+
+	if len(cids) == 0 {
+		return
+	}
+
+	logger.Debug("Sweeping expired records", "count", len(cids))
+
+	for _, cid := range cids {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.expireRecord(ctx, &corev1.RecordRef{Cid: cid}, now)
+	}
+}
+
+// expireRecord unpublishes and deletes an expired record.
+// The stored expiry is authoritative, so records that have not expired in the store are kept.
+func (s *Service) expireRecord(ctx context.Context, ref *corev1.RecordRef, now time.Time) {
+	meta, err := s.store.Lookup(ctx, ref)
+	if err != nil {
+		// Records deleted from the store are only removed from the search index
+		if status.Code(err) == codes.NotFound {
+			s.removeFromIndex(ref)
+
+			return
+		}
+
+		logger.Error("Failed to lookup expired record", "error", err, "cid", ref.GetCid())
+
+		return
+	}
+
+	if !meta.IsExpired(now) {
+		logger.Warn("Record has not expired in the store, skipping", "cid", ref.GetCid(), "expires_at", meta.GetExpiresAt())
+
+		return
+	}
+
+	record, err := s.store.Pull(ctx, ref)
+	if err != nil {
+		logger.Error("Failed to pull expired record", "error", err, "cid", ref.GetCid())
+
+		return
+	}
+
+	// Stop announcing the record before it is deleted, so that it is not republished
+	// Most expiring records were never published, so only unpublish the published ones
+	if err := s.routing.Unpublish(types.WithUnpublishPublishedOnly(ctx), adapters.NewRecordAdapterWithCid(record, ref.GetCid())); err != nil {
+		logger.Error("Failed to unpublish expired record", "error", err, "cid", ref.GetCid())
+
+		return
+	}
+
+	if err := s.store.Delete(ctx, ref); err != nil {
+		logger.Error("Failed to delete expired record", "error", err, "cid", ref.GetCid())
+
+		return
+	}
+
+	s.removeFromIndex(ref)
+
+	logger.Info("Expired record deleted", "cid", ref.GetCid(), "expires_at", meta.GetExpiresAt())
+}
+
+// removeFromIndex removes a record from the search index.
+func (s *Service) removeFromIndex(ref *corev1.RecordRef) {
+	if err := s.db.RemoveRecord(ref.GetCid()); err != nil {
+		logger.Error("Failed to remove expired record from search index", "error", err, "cid", ref.GetCid())
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expiryStore holds records with their stored expiry.
+type expiryStore struct {
+	types.StoreAPI
+	records   map[string]*corev1.Record
+	expiresAt map[string]time.Time
+	deleted   []string
+}
+
+func (s *expiryStore) Lookup(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if _, ok := s.records[ref.GetCid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	meta := &corev1.RecordMeta{Cid: ref.GetCid()}
+	if expiresAt, ok := s.expiresAt[ref.GetCid()]; ok {
+		meta.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return meta, nil
+}
+
+func (s *expiryStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+func (s *expiryStore) Delete(_ context.Context, ref *corev1.RecordRef) error {
+	delete(s.records, ref.GetCid())
+	s.deleted = append(s.deleted, ref.GetCid())
+
+	return nil
+}
+
+// expiryDatabase returns the expired CIDs of the search index.
+type expiryDatabase struct {
+	types.DatabaseAPI
+	expired []string
+	removed []string
+}
+
+func (d *expiryDatabase) GetRecordCIDs(...types.FilterOption) ([]string, error) {
+	return d.expired, nil
+}
+
+func (d *expiryDatabase) RemoveRecord(cid string) error {
+	d.removed = append(d.removed, cid)
+
+	return nil
+}
+
+// expiryRouting records the unpublished CIDs.
+type expiryRouting struct {
+	types.RoutingAPI
+	err         error
+	unpublished []string
+}
+
+func (r *expiryRouting) Unpublish(ctx context.Context, record types.Record) error {
+	if !types.UnpublishPublishedOnlyFromContext(ctx) {
+		return errors.New("expired records must only be unpublished if published")
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.unpublished = append(r.unpublished, record.GetCid())
+
+	return nil
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Now()
+
+	newService := func(routingErr error) (*Service, *expiryStore, *expiryDatabase, *expiryRouting) {
+		store := &expiryStore{
+			records: map[string]*corev1.Record{
+				"cid-expired":     corev1.New(&typesv1alpha0.Record{Name: "expired-agent", SchemaVersion: "v0.3.1"}),
+				"cid-not-expired": corev1.New(&typesv1alpha0.Record{Name: "live-agent", SchemaVersion: "v0.3.1"}),
+			},
+			expiresAt: map[string]time.Time{
+				"cid-expired":     now.Add(-time.Hour),
+				"cid-not-expired": now.Add(time.Hour),
+			},
+		}
+		db := &expiryDatabase{}
+		routing := &expiryRouting{err: routingErr}
+
+		return &Service{db: db, store: store, routing: routing}, store, db, routing
+	}
+
+	t.Run("expired record", func(t *testing.T) {
+		service, store, db, routing := newService(nil)
+		db.expired = []string{"cid-expired"}
+
+		service.sweep(t.Context())
+
+		// The record is unpublished and deleted, under the CID it was stored with
+		assert.Equal(t, []string{"cid-expired"}, routing.unpublished)
+		assert.Equal(t, []string{"cid-expired"}, store.deleted)
+		assert.Equal(t, []string{"cid-expired"}, db.removed)
+	})
+
+	t.Run("not expired in the store", func(t *testing.T) {
+		service, store, db, routing := newService(nil)
+		db.expired = []string{"cid-not-expired"}
+
+		service.sweep(t.Context())
+
+		// The stored expiry is authoritative, so the record is kept
+		assert.Empty(t, routing.unpublished)
+		assert.Empty(t, store.deleted)
+		assert.Empty(t, db.removed)
+		assert.Contains(t, store.records, "cid-not-expired")
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		service, store, db, routing := newService(nil)
+		db.expired = []string{"cid-deleted"}
+
+		service.sweep(t.Context())
+
+		// The record is only removed from the search index
+		assert.Empty(t, routing.unpublished)
+		assert.Empty(t, store.deleted)
+		assert.Equal(t, []string{"cid-deleted"}, db.removed)
+	})
+
+	t.Run("unpublish failure", func(t *testing.T) {
+		service, store, db, _ := newService(errors.New("routing unavailable"))
+		db.expired = []string{"cid-expired"}
+
+		service.sweep(t.Context())
+
+		// The record is kept to be retried on the next sweep, so that it is not announced once deleted
+		assert.Empty(t, store.deleted)
+		assert.Empty(t, db.removed)
+		assert.Contains(t, store.records, "cid-expired")
+	})
+}
+
+func TestStartDisabled(t *testing.T) {
+	service := &Service{stopCh: make(chan struct{})}
+
+	// Nothing is swept without an interval
+	require.NoError(t, service.Start(t.Context()))
+	require.NoError(t, service.Stop())
+}
@@ -0,0 +1,17 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+const (
+	DefaultMetricsEnabled       = false
+	DefaultMetricsListenAddress = "0.0.0.0:9090"
+)
+
+type Config struct {
+	// Enabled exposes Prometheus metrics.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Address of the HTTP server serving the /metrics endpoint.
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+}
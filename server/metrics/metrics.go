@@ -0,0 +1,186 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics/config"
+	"github.com/agntcy/dir/utils/logging"
+	utilsmetrics "github.com/agntcy/dir/utils/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var logger = logging.Logger("metrics")
+
+const (
+	namespace = "dir"
+
+	shutdownTimeout   = 5 * time.Second
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Service serves Prometheus metrics over HTTP.
+type Service struct {
+	config   config.Config
+	registry *prometheus.Registry
+	server   *http.Server
+}
+
+// New creates the metrics service and installs the Prometheus recorder.
+func New(cfg config.Config) (*Service, error) {
+	registry := prometheus.NewRegistry()
+
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, fmt.Errorf("failed to register go collector: %w", err)
+	}
+
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, fmt.Errorf("failed to register process collector: %w", err)
+	}
+
+	recorder, err := newPrometheusRecorder(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	utilsmetrics.SetRecorder(recorder)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+
+	return &Service{
+		config:   cfg,
+		registry: registry,
+		server: &http.Server{
+			Addr:              cfg.ListenAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}, nil
+}
+
+// Start starts serving metrics in the background.
+func (s *Service) Start() error {
+	listener, err := net.Listen("tcp", s.config.ListenAddress) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.ListenAddress, err)
+	}
+
+	go func() {
+		logger.Info("Metrics server starting", "address", s.config.ListenAddress)
+
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the metrics server and restores the no-op recorder.
+func (s *Service) Stop() error {
+	utilsmetrics.SetRecorder(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop metrics server: %w", err)
+	}
+
+	return nil
+}
+
+// prometheusRecorder implements the metrics recorder with Prometheus collectors.
+type prometheusRecorder struct {
+	storeDuration    *prometheus.HistogramVec
+	storeErrors      *prometheus.CounterVec
+	searchDuration   *prometheus.HistogramVec
+	searchResults    *prometheus.HistogramVec
+	gossipSubMsgs    *prometheus.CounterVec
+	datastoreQueries *prometheus.CounterVec
+}
+
+func newPrometheusRecorder(registry prometheus.Registerer) (*prometheusRecorder, error) {
+	r := &prometheusRecorder{
+		storeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of store operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		storeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "operation_errors_total",
+			Help:      "Number of failed store operations.",
+		}, []string{"operation"}),
+		searchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "routing",
+			Name:      "search_duration_seconds",
+			Help:      "Duration of routing searches.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"source"}),
+		searchResults: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "routing",
+			Name:      "search_results",
+			Help:      "Number of results returned by routing searches.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10), //nolint:mnd
+		}, []string{"source"}),
+		gossipSubMsgs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "routing",
+			Name:      "gossipsub_messages_total",
+			Help:      "Number of GossipSub label announcements published and received.",
+		}, []string{"direction"}),
+		datastoreQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "routing",
+			Name:      "datastore_queries_total",
+			Help:      "Number of routing datastore queries.",
+		}, []string{"namespace"}),
+	}
+
+	for _, c := range []prometheus.Collector{
+		r.storeDuration, r.storeErrors, r.searchDuration, r.searchResults, r.gossipSubMsgs, r.datastoreQueries,
+	} {
+		if err := registry.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register metrics collector: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *prometheusRecorder) ObserveStoreOperation(op string, duration time.Duration, err error) {
+	r.storeDuration.WithLabelValues(op).Observe(duration.Seconds())
+
+	if err != nil {
+		r.storeErrors.WithLabelValues(op).Inc()
+	}
+}
+
+func (r *prometheusRecorder) ObserveSearch(source string, results int, duration time.Duration) {
+	r.searchDuration.WithLabelValues(source).Observe(duration.Seconds())
+	r.searchResults.WithLabelValues(source).Observe(float64(results))
+}
+
+func (r *prometheusRecorder) IncGossipSubMessages(direction string) {
+	r.gossipSubMsgs.WithLabelValues(direction).Inc()
+}
+
+func (r *prometheusRecorder) IncDatastoreQueries(namespace string) {
+	r.datastoreQueries.WithLabelValues(namespace).Inc()
+}
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics/config"
+	utilsmetrics "github.com/agntcy/dir/utils/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherMetric returns the metric of the family with the given label value, or nil if it was not recorded.
+func gatherMetric(t *testing.T, registry *prometheus.Registry, name, labelValue string) *dto.Metric {
+	t.Helper()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetValue() == labelValue {
+					return metric
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestPrometheusRecorder(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	recorder, err := newPrometheusRecorder(registry)
+	require.NoError(t, err)
+
+	t.Run("store operations", func(t *testing.T) {
+		recorder.ObserveStoreOperation(utilsmetrics.OpPush, time.Millisecond, nil)
+		recorder.ObserveStoreOperation(utilsmetrics.OpPush, time.Millisecond, errors.New("push failed"))
+		recorder.ObserveStoreOperation(utilsmetrics.OpPull, time.Millisecond, nil)
+
+		push := gatherMetric(t, registry, "dir_store_operation_duration_seconds", utilsmetrics.OpPush)
+		require.NotNil(t, push)
+		assert.Equal(t, uint64(2), push.GetHistogram().GetSampleCount())
+
+		pushErrors := gatherMetric(t, registry, "dir_store_operation_errors_total", utilsmetrics.OpPush)
+		require.NotNil(t, pushErrors)
+		assert.InDelta(t, 1, pushErrors.GetCounter().GetValue(), 0)
+
+		// Successful operations are not counted as errors
+		assert.Nil(t, gatherMetric(t, registry, "dir_store_operation_errors_total", utilsmetrics.OpPull))
+	})
+
+	t.Run("searches", func(t *testing.T) {
+		recorder.ObserveSearch("remote", 3, 2*time.Second)
+
+		duration := gatherMetric(t, registry, "dir_routing_search_duration_seconds", "remote")
+		require.NotNil(t, duration)
+		assert.InDelta(t, 2, duration.GetHistogram().GetSampleSum(), 0)
+
+		results := gatherMetric(t, registry, "dir_routing_search_results", "remote")
+		require.NotNil(t, results)
+		assert.InDelta(t, 3, results.GetHistogram().GetSampleSum(), 0)
+	})
+
+	t.Run("counters", func(t *testing.T) {
+		recorder.IncGossipSubMessages(utilsmetrics.DirectionPublished)
+		recorder.IncGossipSubMessages(utilsmetrics.DirectionReceived)
+		recorder.IncGossipSubMessages(utilsmetrics.DirectionReceived)
+		recorder.IncDatastoreQueries("skills")
+
+		published := gatherMetric(t, registry, "dir_routing_gossipsub_messages_total", utilsmetrics.DirectionPublished)
+		require.NotNil(t, published)
+		assert.InDelta(t, 1, published.GetCounter().GetValue(), 0)
+
+		received := gatherMetric(t, registry, "dir_routing_gossipsub_messages_total", utilsmetrics.DirectionReceived)
+		require.NotNil(t, received)
+		assert.InDelta(t, 2, received.GetCounter().GetValue(), 0)
+
+		queries := gatherMetric(t, registry, "dir_routing_datastore_queries_total", "skills")
+		require.NotNil(t, queries)
+		assert.InDelta(t, 1, queries.GetCounter().GetValue(), 0)
+	})
+}
+
+func TestService(t *testing.T) {
+	service, err := New(config.Config{Enabled: true, ListenAddress: "127.0.0.1:0"})
+	require.NoError(t, err)
+
+	// The service installs its recorder globally, so that instrumented components report to it
+	utilsmetrics.Default().ObserveStoreOperation(utilsmetrics.OpLookup, time.Millisecond, errors.New("not found"))
+	utilsmetrics.Default().IncDatastoreQueries("locators")
+
+	rec := httptest.NewRecorder()
+	service.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `dir_store_operation_errors_total{operation="lookup"} 1`)
+	assert.Contains(t, rec.Body.String(), `dir_routing_datastore_queries_total{namespace="locators"} 1`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+
+	require.NoError(t, service.Start())
+	require.NoError(t, service.Stop())
+
+	// Stopping the service restores the no-op recorder
+	assert.Equal(t, utilsmetrics.Noop{}, utilsmetrics.Default())
+}
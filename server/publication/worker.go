@@ -165,7 +165,7 @@ func (w *Worker) announceToDHT(ctx context.Context, cid string) error {
 	}
 
 	// Wrap record with adapter for interface-based publishing
-	adapter := adapters.NewRecordAdapter(record)
+	adapter := adapters.NewRecordAdapterWithCid(record, cid)
 
 	// Publish the record to the network
 	err = w.routing.Publish(ctx, adapter)
@@ -0,0 +1,71 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultRateLimitEnabled = false
+
+	DefaultReadRequestsPerSecond = 100.0
+	DefaultReadBurst             = 200
+
+	DefaultWriteRequestsPerSecond = 10.0
+	DefaultWriteBurst             = 20
+)
+
+// Config contains configuration for per-client rate limiting of the API.
+// Clients are identified by their authenticated SPIFFE ID, or by their address otherwise.
+type Config struct {
+	// Indicates if rate limiting is enabled
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Limits of read operations, such as pull, lookup and search
+	Read LimitConfig `json:"read,omitempty" mapstructure:"read"`
+
+	// Limits of write operations, such as push and delete
+	Write LimitConfig `json:"write,omitempty" mapstructure:"write"`
+}
+
+// LimitConfig configures a token bucket.
+type LimitConfig struct {
+	// Sustained number of requests per second
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty" mapstructure:"requests_per_second"`
+
+	// Number of requests that can be made at once above the sustained rate
+	Burst int `json:"burst,omitempty" mapstructure:"burst"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if err := c.Read.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("read: %w", err))
+	}
+
+	if err := c.Write.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("write: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *LimitConfig) validate() error {
+	if c.RequestsPerSecond <= 0 {
+		return fmt.Errorf("requests_per_second must be positive (%v)", c.RequestsPerSecond)
+	}
+
+	if c.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1 (%d)", c.Burst)
+	}
+
+	return nil
+}
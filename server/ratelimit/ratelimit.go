@@ -0,0 +1,300 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the rate of API requests per client with token buckets.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var logger = logging.Logger("ratelimit")
+
+const (
+	// Clients without requests for this long are forgotten.
+	clientIdleTimeout = 10 * time.Minute
+
+	// Minimum interval between sweeps of idle clients.
+	sweepInterval = time.Minute
+
+	// Key of requests without a known client.
+	unknownClient = "unknown"
+)
+
+// writeMethods are the API methods that modify state, by method name.
+// All other methods are limited as reads.
+var writeMethods = map[string]bool{
+	"Push":              true,
+	"PushReferrer":      true,
+	"Delete":            true,
+	"PullFromPeer":      true,
+	"PullFromNetwork":   true,
+	"GarbageCollect":    true,
+	"VerifyRecords":     true,
+	"Publish":           true,
+	"Unpublish":         true,
+	"CreatePublication": true,
+	"CreateSync":        true,
+	"DeleteSync":        true,
+}
+
+// exemptServices are the services that are never rate limited.
+var exemptServices = map[string]bool{
+	"grpc.health.v1.Health": true,
+}
+
+type clientLimiters struct {
+	read     *rate.Limiter
+	write    *rate.Limiter
+	lastSeen time.Time
+}
+
+// Service limits the rate of read and write requests of each client.
+// Clients are identified by their SPIFFE ID when authentication is enabled,
+// and by their address otherwise.
+type Service struct {
+	cfg config.Config
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiters
+	lastSweep time.Time
+
+	rejectedReads  atomic.Uint64
+	rejectedWrites atomic.Uint64
+}
+
+// New creates a new rate limiting service.
+func New(cfg config.Config) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	logger.Info("Rate limiting service initialized",
+		"read_rps", cfg.Read.RequestsPerSecond, "read_burst", cfg.Read.Burst,
+		"write_rps", cfg.Write.RequestsPerSecond, "write_burst", cfg.Write.Burst,
+	)
+
+	return &Service{
+		cfg:       cfg,
+		clients:   make(map[string]*clientLimiters),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// GetServerOptions returns gRPC server options for rate limiting.
+// They must be applied after authentication so that clients are keyed by identity.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.allow(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	}
+}
+
+// streamInterceptor consumes a token when a stream is opened.
+// Streams of client messages, such as those of Push, Pull, Lookup, Delete and PullStream,
+// carry one request per message, so every message after the first consumes a token as well.
+func (s *Service) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.allow(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	if info.IsClientStream {
+		ss = &limitedServerStream{ServerStream: ss, service: s, fullMethod: info.FullMethod}
+	}
+
+	return handler(srv, ss)
+}
+
+// limitedServerStream consumes a token for every received message after the first,
+// whose token is consumed when the stream is opened.
+type limitedServerStream struct {
+	grpc.ServerStream
+
+	service    *Service
+	fullMethod string
+	received   int
+}
+
+func (l *limitedServerStream) RecvMsg(m any) error {
+	if err := l.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	l.received++
+	if l.received == 1 {
+		return nil
+	}
+
+	return l.service.allow(l.Context(), l.fullMethod)
+}
+
+// allow consumes a token of the calling client for the method,
+// or returns a ResourceExhausted error with a retry hint if none is available.
+func (s *Service) allow(ctx context.Context, fullMethod string) error {
+	service, method := splitMethod(fullMethod)
+	if exemptServices[service] {
+		return nil
+	}
+
+	now := time.Now()
+	client := clientKey(ctx)
+	limiters := s.limitersFor(client, now)
+
+	write := writeMethods[method]
+
+	limiter := limiters.read
+	if write {
+		limiter = limiters.write
+	}
+
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return s.reject(client, fullMethod, write, 0)
+	}
+
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+
+	// Give the token back, the request is rejected rather than delayed.
+	reservation.CancelAt(now)
+
+	return s.reject(client, fullMethod, write, delay)
+}
+
+func (s *Service) reject(client, fullMethod string, write bool, retryAfter time.Duration) error {
+	kind := "read"
+	if write {
+		kind = "write"
+
+		s.rejectedWrites.Add(1)
+	} else {
+		s.rejectedReads.Add(1)
+	}
+
+	// Round up so that retrying after the hint succeeds.
+	retryAfter = time.Duration(math.Ceil(float64(retryAfter)/float64(time.Millisecond))) * time.Millisecond
+
+	logger.Debug("Rate limit exceeded", "client", client, "method", fullMethod, "kind", kind, "retry_after", retryAfter)
+
+	st := status.Newf(codes.ResourceExhausted, "%s rate limit exceeded, retry after %s", kind, retryAfter)
+
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err() //nolint:wrapcheck
+	}
+
+	return detailed.Err() //nolint:wrapcheck
+}
+
+// limitersFor returns the limiters of a client, creating them on first use.
+// Idle clients are swept at most once per sweep interval.
+func (s *Service) limitersFor(client string, now time.Time) *clientLimiters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for key, limiters := range s.clients {
+			if now.Sub(limiters.lastSeen) >= clientIdleTimeout {
+				delete(s.clients, key)
+			}
+		}
+
+		s.lastSweep = now
+	}
+
+	limiters, ok := s.clients[client]
+	if !ok {
+		limiters = &clientLimiters{
+			read:  rate.NewLimiter(rate.Limit(s.cfg.Read.RequestsPerSecond), s.cfg.Read.Burst),
+			write: rate.NewLimiter(rate.Limit(s.cfg.Write.RequestsPerSecond), s.cfg.Write.Burst),
+		}
+		s.clients[client] = limiters
+	}
+
+	limiters.lastSeen = now
+
+	return limiters
+}
+
+// RateLimitStats implements types.RateLimiterAPI.
+func (s *Service) RateLimitStats(ctx context.Context) *types.RateLimitStats {
+	stats := &types.RateLimitStats{
+		ReadRequestsPerSecond:  s.cfg.Read.RequestsPerSecond,
+		ReadBurst:              s.cfg.Read.Burst,
+		WriteRequestsPerSecond: s.cfg.Write.RequestsPerSecond,
+		WriteBurst:             s.cfg.Write.Burst,
+		RejectedReadRequests:   s.rejectedReads.Load(),
+		RejectedWriteRequests:  s.rejectedWrites.Load(),
+		CallerReadTokens:       float64(s.cfg.Read.Burst),
+		CallerWriteTokens:      float64(s.cfg.Write.Burst),
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats.ActiveClients = len(s.clients)
+
+	if limiters, ok := s.clients[clientKey(ctx)]; ok {
+		stats.CallerReadTokens = limiters.read.TokensAt(now)
+		stats.CallerWriteTokens = limiters.write.TokensAt(now)
+	}
+
+	return stats
+}
+
+// clientKey identifies the client of a request by its SPIFFE ID,
+// or by the host of its address if the request is not authenticated.
+func clientKey(ctx context.Context) string {
+	if sid, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return sid.String()
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return unknownClient
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+// splitMethod splits a full gRPC method name into its service and method names.
+func splitMethod(fullMethod string) (string, string) {
+	service, method := path.Split(fullMethod)
+
+	return strings.Trim(service, "/"), method
+}
@@ -0,0 +1,166 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/ratelimit/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func clientContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	service, err := New(config.Config{
+		Enabled: true,
+		Read:    config.LimitConfig{RequestsPerSecond: 0.001, Burst: 2},
+		Write:   config.LimitConfig{RequestsPerSecond: 0.001, Burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	alice := clientContext("10.0.0.1")
+	bob := clientContext("10.0.0.2")
+
+	// Burst of reads is allowed, then rejected with a retry hint
+	for range 2 {
+		if err := service.allow(alice, storev1.StoreService_Pull_FullMethodName); err != nil {
+			t.Fatalf("read within burst rejected: %v", err)
+		}
+	}
+
+	err = service.allow(alice, storev1.StoreService_Lookup_FullMethodName)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("read above burst: got %v, want ResourceExhausted", err)
+	}
+
+	var retryInfo *errdetails.RetryInfo
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+
+	if retryInfo == nil || retryInfo.GetRetryDelay().AsDuration() <= 0 {
+		t.Errorf("read above burst: missing retry hint in %v", err)
+	}
+
+	// Writes are limited separately from reads
+	if err := service.allow(alice, storev1.StoreService_Push_FullMethodName); err != nil {
+		t.Fatalf("write within burst rejected: %v", err)
+	}
+
+	if err := service.allow(alice, storev1.StoreService_Delete_FullMethodName); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("write above burst: got %v, want ResourceExhausted", err)
+	}
+
+	// Other clients are not affected
+	if err := service.allow(bob, storev1.StoreService_Pull_FullMethodName); err != nil {
+		t.Fatalf("read of other client rejected: %v", err)
+	}
+
+	// Health checks are never limited
+	if err := service.allow(alice, "/grpc.health.v1.Health/Check"); err != nil {
+		t.Fatalf("health check rejected: %v", err)
+	}
+
+	stats := service.RateLimitStats(alice)
+	if stats.ActiveClients != 2 {
+		t.Errorf("ActiveClients = %d, want 2", stats.ActiveClients)
+	}
+
+	if stats.RejectedReadRequests != 1 || stats.RejectedWriteRequests != 1 {
+		t.Errorf("rejected requests = %d reads, %d writes, want 1 and 1", stats.RejectedReadRequests, stats.RejectedWriteRequests)
+	}
+
+	if stats.CallerReadTokens >= 1 || stats.CallerWriteTokens >= 1 {
+		t.Errorf("caller tokens = %v reads, %v writes, want less than 1", stats.CallerReadTokens, stats.CallerWriteTokens)
+	}
+}
+
+// messageStream is a server stream receiving a fixed number of messages.
+type messageStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context //nolint:containedctx
+	messages int
+}
+
+func (m *messageStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *messageStream) RecvMsg(any) error {
+	if m.messages == 0 {
+		return io.EOF
+	}
+
+	m.messages--
+
+	return nil
+}
+
+func TestRateLimitStreamMessages(t *testing.T) {
+	service, err := New(config.Config{
+		Enabled: true,
+		Read:    config.LimitConfig{RequestsPerSecond: 0.001, Burst: 3},
+		Write:   config.LimitConfig{RequestsPerSecond: 0.001, Burst: 3},
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	// receive drains the stream and returns the first error other than io.EOF
+	receive := func(_ any, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(nil); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+
+				return err
+			}
+		}
+	}
+
+	pullStream := &grpc.StreamServerInfo{FullMethod: storev1.StoreService_Pull_FullMethodName, IsClientStream: true}
+
+	// The first message is covered by the token of the stream itself
+	err = service.streamInterceptor(nil, &messageStream{ctx: clientContext("10.0.0.1"), messages: 3}, pullStream, receive)
+	if err != nil {
+		t.Fatalf("messages within burst rejected: %v", err)
+	}
+
+	// Every further message consumes a token
+	err = service.streamInterceptor(nil, &messageStream{ctx: clientContext("10.0.0.2"), messages: 4}, pullStream, receive)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("messages above burst: got %v, want ResourceExhausted", err)
+	}
+
+	// Server streams with a single request only consume the token of the stream
+	listStream := &grpc.StreamServerInfo{FullMethod: "/agntcy.dir.routing.v1.RoutingService/List", IsServerStream: true}
+
+	for range 3 {
+		err = service.streamInterceptor(nil, &messageStream{ctx: clientContext("10.0.0.3"), messages: 5}, listStream, receive)
+		if err != nil {
+			t.Fatalf("server stream within burst rejected: %v", err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Peers without announcements for this long have their rate limiter and rejection count dropped.
+	announcementPeerIdleTimeout = 10 * time.Minute
+
+	// Minimum interval between sweeps of idle peers.
+	announcementSweepInterval = time.Minute
+)
+
+// peerAnnouncementState is the rate limiter and rejection count of a peer.
+// The limiter is nil if rate limiting is disabled.
+type peerAnnouncementState struct {
+	limiter  *rate.Limiter
+	rejected uint64
+	lastSeen time.Time
+}
+
+// announcementGuard rejects GossipSub announcements that are malformed, carry too many
+// labels, or come from peers exceeding the announcement rate, before they are cached.
+// Rejected announcements are counted per peer for abuse detection.
+type announcementGuard struct {
+	maxLabels int
+	rate      rate.Limit
+	burst     int
+
+	mu        sync.Mutex
+	peers     map[string]*peerAnnouncementState
+	lastSweep time.Time
+}
+
+// newAnnouncementGuard creates a guard enforcing the given limits.
+// A zero label limit uses the default, a zero rate disables rate limiting.
+func newAnnouncementGuard(cfg routingconfig.AnnouncementLimitsConfig) *announcementGuard {
+	maxLabels := routingconfig.DefaultAnnouncementMaxLabels
+	if cfg.MaxLabels > 0 {
+		maxLabels = cfg.MaxLabels
+	}
+
+	return &announcementGuard{
+		maxLabels: maxLabels,
+		rate:      rate.Limit(cfg.Rate),
+		burst:     cfg.Burst,
+		peers:     make(map[string]*peerAnnouncementState),
+		lastSweep: time.Now(),
+	}
+}
+
+// Check validates an announcement from a peer and consumes one of its rate tokens.
+// It returns the reason of the rejection, or nil if the announcement is accepted.
+// A nil guard accepts all announcements.
+func (g *announcementGuard) Check(peerID string, event *pubsub.RecordPublishEvent, now time.Time) error {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	peer := g.peerState(peerID, now)
+
+	err := g.check(peer, event, now)
+	if err != nil {
+		peer.rejected++
+	}
+
+	return err
+}
+
+func (g *announcementGuard) check(peer *peerAnnouncementState, event *pubsub.RecordPublishEvent, now time.Time) error {
+	if peer.limiter != nil && !peer.limiter.AllowN(now, 1) {
+		return fmt.Errorf("announcement rate of %v/s exceeded", float64(g.rate))
+	}
+
+	if !corev1.IsValidCID(event.CID) {
+		return fmt.Errorf("malformed CID %q", event.CID)
+	}
+
+	if len(event.Labels) > g.maxLabels {
+		return fmt.Errorf("too many labels: %d, at most %d accepted", len(event.Labels), g.maxLabels)
+	}
+
+	return nil
+}
+
+// peerState returns the state of a peer, creating it on first use.
+// Idle peers are swept at most once per sweep interval, so neither their limiters
+// nor their rejection counts accumulate. Must be called with the lock held.
+func (g *announcementGuard) peerState(peerID string, now time.Time) *peerAnnouncementState {
+	if now.Sub(g.lastSweep) >= announcementSweepInterval {
+		for key, peer := range g.peers {
+			if now.Sub(peer.lastSeen) >= announcementPeerIdleTimeout {
+				delete(g.peers, key)
+			}
+		}
+
+		g.lastSweep = now
+	}
+
+	peer, ok := g.peers[peerID]
+	if !ok {
+		peer = &peerAnnouncementState{}
+		if g.rate > 0 {
+			peer.limiter = rate.NewLimiter(g.rate, g.burst)
+		}
+
+		g.peers[peerID] = peer
+	}
+
+	peer.lastSeen = now
+
+	return peer
+}
+
+// Rejected returns the number of rejected announcements of each peer with rejections.
+// Counts of peers idle for longer than the idle timeout are dropped. A nil guard has no rejections.
+func (g *announcementGuard) Rejected() map[string]uint64 {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rejected := make(map[string]uint64)
+
+	for peerID, peer := range g.peers {
+		if peer.rejected > 0 {
+			rejected[peerID] = peer.rejected
+		}
+	}
+
+	return rejected
+}
@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnouncementGuard(t *testing.T) {
+	const (
+		validCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		peerA    = "peer-a"
+		peerB    = "peer-b"
+	)
+
+	newEvent := func(cid string, labelCount int) *pubsub.RecordPublishEvent {
+		labels := make([]string, labelCount)
+		for i := range labels {
+			labels[i] = fmt.Sprintf("/skills/skill-%d", i)
+		}
+
+		return &pubsub.RecordPublishEvent{CID: cid, Labels: labels, Timestamp: time.Now()}
+	}
+
+	t.Run("accepts_valid_announcement", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{})
+
+		assert.NoError(t, guard.Check(peerA, newEvent(validCID, 3), time.Now()))
+		assert.Empty(t, guard.Rejected())
+	})
+
+	t.Run("rejects_malformed_cid", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{})
+
+		assert.ErrorContains(t, guard.Check(peerA, newEvent("not-a-cid", 1), time.Now()), "malformed CID")
+		assert.Equal(t, map[string]uint64{peerA: 1}, guard.Rejected())
+	})
+
+	t.Run("rejects_too_many_labels", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{MaxLabels: 2})
+
+		assert.NoError(t, guard.Check(peerA, newEvent(validCID, 2), time.Now()))
+		assert.ErrorContains(t, guard.Check(peerA, newEvent(validCID, 3), time.Now()), "too many labels")
+	})
+
+	t.Run("limits_rate_per_peer", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{Rate: 1, Burst: 2})
+		now := time.Now()
+
+		assert.NoError(t, guard.Check(peerA, newEvent(validCID, 1), now))
+		assert.NoError(t, guard.Check(peerA, newEvent(validCID, 1), now))
+		assert.ErrorContains(t, guard.Check(peerA, newEvent(validCID, 1), now), "rate")
+
+		// Other peers have their own budget
+		assert.NoError(t, guard.Check(peerB, newEvent(validCID, 1), now))
+
+		// Tokens are refilled over time
+		assert.NoError(t, guard.Check(peerA, newEvent(validCID, 1), now.Add(time.Second)))
+
+		assert.Equal(t, map[string]uint64{peerA: 1}, guard.Rejected())
+	})
+
+	t.Run("zero_rate_disables_rate_limiting", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{})
+		now := time.Now()
+
+		for range 1000 {
+			assert.NoError(t, guard.Check(peerA, newEvent(validCID, 1), now))
+		}
+	})
+
+	t.Run("prunes_idle_peers", func(t *testing.T) {
+		guard := newAnnouncementGuard(routingconfig.AnnouncementLimitsConfig{Rate: 1, Burst: 1})
+		now := time.Now()
+
+		assert.ErrorContains(t, guard.Check(peerA, newEvent("not-a-cid", 1), now), "malformed CID")
+		assert.Equal(t, map[string]uint64{peerA: 1}, guard.Rejected())
+
+		// An announcement of another peer after the idle timeout sweeps the idle peer
+		assert.NoError(t, guard.Check(peerB, newEvent(validCID, 1), now.Add(announcementPeerIdleTimeout)))
+		assert.Empty(t, guard.Rejected())
+		assert.Len(t, guard.peers, 1)
+	})
+
+	t.Run("nil_guard_accepts_all", func(t *testing.T) {
+		var guard *announcementGuard
+
+		assert.NoError(t, guard.Check(peerA, newEvent("not-a-cid", 1), time.Now()))
+		assert.Nil(t, guard.Rejected())
+	})
+}
@@ -5,10 +5,13 @@ package routing
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 	"time"
 
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/datastore"
 	"github.com/agntcy/dir/server/types"
 	ipfsdatastore "github.com/ipfs/go-datastore"
@@ -45,7 +48,7 @@ func TestCleanup_CoreLogic(t *testing.T) {
 		}
 
 		for _, tl := range testLabels {
-			enhancedKey := BuildEnhancedLabelKey(types.Label(tl.label), testCID, tl.peerID)
+			enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(tl.label), testCID, tl.peerID)
 			err = dstore.Put(ctx, ipfsdatastore.NewKey(enhancedKey), []byte("metadata"))
 			require.NoError(t, err)
 		}
@@ -61,7 +64,7 @@ func TestCleanup_CoreLogic(t *testing.T) {
 
 		// Verify label cleanup
 		for _, tl := range testLabels {
-			enhancedKey := BuildEnhancedLabelKey(types.Label(tl.label), testCID, tl.peerID)
+			enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(tl.label), testCID, tl.peerID)
 			exists, err := dstore.Has(ctx, ipfsdatastore.NewKey(enhancedKey))
 			require.NoError(t, err)
 
@@ -179,7 +182,7 @@ func TestCleanup_CoreLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		for _, label := range labelsToDelete {
-			enhancedKey := BuildEnhancedLabelKey(types.Label(label), testCID, localPeerID)
+			enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(label), testCID, localPeerID)
 			err = dstore.Put(ctx, ipfsdatastore.NewKey(enhancedKey), []byte("metadata"))
 			require.NoError(t, err)
 		}
@@ -267,6 +270,195 @@ func simulateCleanupLabelsForCID(ctx context.Context, dstore types.Datastore, ci
 	return keysDeleted > 0
 }
 
+func TestValidateRepublishSchedule(t *testing.T) {
+	testCases := []struct {
+		name      string
+		interval  time.Duration
+		jitter    time.Duration
+		expectErr bool
+	}{
+		{name: "defaults", interval: 0, jitter: 0, expectErr: false},
+		{name: "default_interval_with_jitter", interval: RepublishInterval, jitter: time.Hour, expectErr: false},
+		{name: "short_interval_large_jitter", interval: 12 * time.Hour, jitter: 12 * time.Hour, expectErr: false},
+		{name: "interval_equal_to_ttl", interval: RecordTTL, jitter: 0, expectErr: true},
+		{name: "jitter_pushes_past_limit", interval: 30 * time.Hour, jitter: 12 * time.Hour, expectErr: true},
+		{name: "negative_jitter", interval: time.Hour, jitter: -time.Minute, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRepublishSchedule(tc.interval, tc.jitter)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCleanupManager_NextRepublishDelay(t *testing.T) {
+	t.Run("no_jitter", func(t *testing.T) {
+		manager := NewCleanupManager(nil, nil, nil, nil, WithRepublishInterval(time.Hour))
+		assert.Equal(t, time.Hour, manager.nextRepublishDelay())
+	})
+
+	t.Run("default_interval", func(t *testing.T) {
+		manager := NewCleanupManager(nil, nil, nil, nil, WithRepublishInterval(0))
+		assert.Equal(t, RepublishInterval, manager.nextRepublishDelay())
+	})
+
+	t.Run("with_jitter", func(t *testing.T) {
+		manager := NewCleanupManager(nil, nil, nil, nil,
+			WithRepublishInterval(time.Hour),
+			WithRepublishJitter(10*time.Minute),
+		)
+
+		for range 100 {
+			delay := manager.nextRepublishDelay()
+			assert.GreaterOrEqual(t, delay, time.Hour)
+			assert.Less(t, delay, time.Hour+10*time.Minute)
+		}
+	})
+}
+
+func TestCleanupManager_RepublishPacing(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	store := newMockStore()
+
+	for _, name := range []string{"agent-1", "agent-2", "agent-3"} {
+		ref, err := store.Push(ctx, corev1.New(&typesv1alpha0.Record{Name: name, SchemaVersion: "v0.3.1"}))
+		require.NoError(t, err)
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/records/"+ref.GetCid()), nil))
+	}
+
+	var published []time.Time
+
+	publish := func(_ context.Context, _ types.Record) error {
+		published = append(published, time.Now())
+
+		return nil
+	}
+
+	manager := NewCleanupManager(dstore, store, nil, publish, WithRepublishRate(20))
+	manager.republishLocalProviders(ctx)
+
+	// One record every 50ms after the first
+	require.Len(t, published, 3)
+	assert.GreaterOrEqual(t, published[2].Sub(published[0]), 90*time.Millisecond)
+}
+
+func TestCleanupStalePeerAddresses(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	putEntry := func(peerID string, lastSeen time.Time) {
+		value, err := json.Marshal(&peerAddrsEntry{LastSeen: lastSeen})
+		require.NoError(t, err)
+		require.NoError(t, dstore.Put(ctx, peerAddrsKey(peerID), value))
+	}
+
+	putEntry("fresh-peer", time.Now())
+	putEntry("stale-peer", time.Now().Add(-2*time.Hour))
+
+	// Legacy entry without timestamp
+	require.NoError(t, dstore.Put(ctx, peerAddrsKey("legacy-peer"), []byte("[]")))
+
+	manager := NewCleanupManager(dstore, nil, nil, nil, WithPeerAddrsTTL(time.Hour))
+	require.NoError(t, manager.cleanupStalePeerAddresses(ctx))
+
+	exists, err := dstore.Has(ctx, peerAddrsKey("fresh-peer"))
+	require.NoError(t, err)
+	assert.True(t, exists, "fresh peer addresses should be kept")
+
+	exists, err = dstore.Has(ctx, peerAddrsKey("stale-peer"))
+	require.NoError(t, err)
+	assert.False(t, exists, "stale peer addresses should be removed")
+
+	exists, err = dstore.Has(ctx, peerAddrsKey("legacy-peer"))
+	require.NoError(t, err)
+	assert.False(t, exists, "legacy peer addresses should be removed")
+}
+
+func TestRemoveStaleRemoteLabelsPerNamespace(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	putLabel := func(label types.Label, peerID string, lastSeen time.Time) ipfsdatastore.Key {
+		metadata := &types.LabelMetadata{Timestamp: lastSeen, LastSeen: lastSeen}
+
+		value, err := metadata.Marshal()
+		require.NoError(t, err)
+
+		key := ipfsdatastore.NewKey(BuildEnhancedLabelKey(types.LabelNormalization{}, label, "test-cid", peerID))
+		require.NoError(t, dstore.Put(ctx, key, value))
+
+		return key
+	}
+
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+
+	remoteLocator := putLabel("/locators/docker-image", "remote-peer", twoHoursAgo)
+	remoteSkill := putLabel("/skills/AI", "remote-peer", twoHoursAgo)
+	localLocator := putLabel("/locators/docker-image", testLocalPeerID, twoHoursAgo)
+
+	manager := NewCleanupManager(dstore, nil, nil, nil,
+		WithLabelMaxAges(map[types.LabelType]time.Duration{types.LabelTypeLocator: time.Hour}),
+	)
+	require.NoError(t, manager.removeStaleRemoteLabels(ctx, testLocalPeerID))
+
+	exists, err := dstore.Has(ctx, remoteLocator)
+	require.NoError(t, err)
+	assert.False(t, exists, "remote locators older than their namespace max age should be removed")
+
+	exists, err = dstore.Has(ctx, remoteSkill)
+	require.NoError(t, err)
+	assert.True(t, exists, "remote skills within the default max age should be kept")
+
+	exists, err = dstore.Has(ctx, localLocator)
+	require.NoError(t, err)
+	assert.True(t, exists, "local labels should never be removed by the remote cleanup")
+}
+
+func TestCleanupOrphanedLocalLabelsRemovesProvided(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	for _, cid := range []string{"orphaned-cid", "stored-cid"} {
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/records/"+cid), []byte{}))
+	}
+
+	labelKey := ipfsdatastore.NewKey(BuildEnhancedLabelKey(types.LabelNormalization{}, "/skills/AI", "orphaned-cid", testLocalPeerID))
+	require.NoError(t, dstore.Put(ctx, labelKey, []byte{}))
+
+	provided := newProvidedSet()
+	provided.Provided("orphaned-cid", time.Now())
+	provided.Provided("stored-cid", time.Now())
+
+	manager := NewCleanupManager(dstore, nil, nil, nil, WithProvidedSet(provided))
+	assert.Equal(t, 1, manager.cleanupOrphanedLocalLabels(ctx, testLocalPeerID, []string{"orphaned-cid"}))
+
+	for _, key := range []ipfsdatastore.Key{ipfsdatastore.NewKey("/records/orphaned-cid"), labelKey} {
+		exists, err := dstore.Has(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, exists, "key %s of the orphaned record should be removed", key)
+	}
+
+	records := provided.List()
+	require.Len(t, records, 1, "orphaned records should no longer be listed as provided")
+	assert.Equal(t, "stored-cid", records[0].GetRecordRef().GetCid())
+}
+
 // Helper function for cleanup testing.
 func setupCleanupCoreTestDatastore(t *testing.T) (types.Datastore, func()) {
 	t.Helper()
@@ -5,8 +5,8 @@ package routing
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"path"
 	"sync"
 	"time"
@@ -19,6 +19,7 @@ import (
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
+	"golang.org/x/time/rate"
 )
 
 var cleanupLogger = logging.Logger("routing/cleanup")
@@ -53,6 +54,97 @@ type CleanupManager struct {
 	storeAPI    types.StoreAPI
 	server      *p2p.Server
 	publishFunc pubsub.PublishEventHandler // Publishing callback (captures routeRemote state)
+
+	republishInterval time.Duration // Base interval between republishing cycles
+	republishJitter   time.Duration // Maximum random delay added to each republishing cycle
+	republishRate     rate.Limit    // Maximum number of records republished per second, zero for no pacing
+	peerAddrsTTL      time.Duration // How long cached peer addresses remain valid
+
+	labelMaxAges map[types.LabelType]time.Duration // Per-namespace maximum age of cached remote labels
+
+	provided *providedSet // CIDs announced by this node, updated when orphaned records are removed
+}
+
+// CleanupOption configures optional CleanupManager settings.
+type CleanupOption func(*CleanupManager)
+
+// WithRepublishInterval sets the base interval between republishing cycles.
+// Non-positive values are ignored and RepublishInterval is used.
+func WithRepublishInterval(interval time.Duration) CleanupOption {
+	return func(c *CleanupManager) {
+		if interval > 0 {
+			c.republishInterval = interval
+		}
+	}
+}
+
+// WithRepublishRate sets the maximum number of records republished per second.
+// Pacing keeps the announcements of a cycle below the rate remote peers accept.
+// Non-positive values disable pacing.
+func WithRepublishRate(perSecond float64) CleanupOption {
+	return func(c *CleanupManager) {
+		if perSecond > 0 {
+			c.republishRate = rate.Limit(perSecond)
+		}
+	}
+}
+
+// WithRepublishJitter sets the maximum random delay added to each republishing cycle.
+// Jitter spreads republishing of many nodes over a window to avoid synchronized DHT churn.
+func WithRepublishJitter(jitter time.Duration) CleanupOption {
+	return func(c *CleanupManager) {
+		if jitter > 0 {
+			c.republishJitter = jitter
+		}
+	}
+}
+
+// WithPeerAddrsTTL sets how long cached peer addresses remain valid without re-announcement.
+// Non-positive values are ignored and MaxPeerAddrsAge is used.
+func WithPeerAddrsTTL(ttl time.Duration) CleanupOption {
+	return func(c *CleanupManager) {
+		if ttl > 0 {
+			c.peerAddrsTTL = ttl
+		}
+	}
+}
+
+// WithLabelMaxAges sets the maximum age of cached remote labels per namespace.
+// Labels of namespaces without an entry are considered stale after MaxLabelAge.
+// These ages only apply to the local label cache and are independent of the DHT record TTL.
+func WithLabelMaxAges(maxAges map[types.LabelType]time.Duration) CleanupOption {
+	return func(c *CleanupManager) {
+		c.labelMaxAges = maxAges
+	}
+}
+
+// WithProvidedSet sets the CIDs announced by this node,
+// from which orphaned records are removed along with their labels.
+func WithProvidedSet(provided *providedSet) CleanupOption {
+	return func(c *CleanupManager) {
+		c.provided = provided
+	}
+}
+
+// ValidateRepublishSchedule checks that the republishing schedule keeps records alive.
+// The longest possible republish delay (interval + jitter) must stay within
+// MaxRepublishDelayRatio of RecordTTL so that records never expire between cycles.
+func ValidateRepublishSchedule(interval, jitter time.Duration) error {
+	if interval < 0 || jitter < 0 {
+		return fmt.Errorf("republish interval and jitter must not be negative (interval=%s, jitter=%s)", interval, jitter)
+	}
+
+	if interval == 0 {
+		interval = RepublishInterval
+	}
+
+	maxDelay := time.Duration(float64(RecordTTL) * MaxRepublishDelayRatio)
+	if interval+jitter > maxDelay {
+		return fmt.Errorf("republish interval plus jitter (%s) must not exceed %s (%.0f%% of record TTL %s)",
+			interval+jitter, maxDelay, MaxRepublishDelayRatio*100, RecordTTL) //nolint:mnd
+	}
+
+	return nil
 }
 
 // NewCleanupManager creates a new cleanup manager with the required dependencies.
@@ -64,30 +156,60 @@ type CleanupManager struct {
 //   - storeAPI: Store API for record operations
 //   - server: P2P server for DHT operations
 //   - publishFunc: Callback for publishing (from routeRemote.Publish, see pubsub.PublishEventHandler)
+//   - opts: Optional settings such as the republishing schedule
 func NewCleanupManager(
 	dstore types.Datastore,
 	storeAPI types.StoreAPI,
 	server *p2p.Server,
 	publishFunc pubsub.PublishEventHandler,
+	opts ...CleanupOption,
 ) *CleanupManager {
-	return &CleanupManager{
-		dstore:      dstore,
-		storeAPI:    storeAPI,
-		server:      server,
-		publishFunc: publishFunc,
+	manager := &CleanupManager{
+		dstore:            dstore,
+		storeAPI:          storeAPI,
+		server:            server,
+		publishFunc:       publishFunc,
+		republishInterval: RepublishInterval,
+		peerAddrsTTL:      MaxPeerAddrsAge,
+	}
+
+	for _, opt := range opts {
+		opt(manager)
+	}
+
+	return manager
+}
+
+// labelMaxAge returns how long cached remote labels of a namespace remain valid without being seen again.
+func (c *CleanupManager) labelMaxAge(labelType types.LabelType) time.Duration {
+	if maxAge, ok := c.labelMaxAges[labelType]; ok && maxAge > 0 {
+		return maxAge
+	}
+
+	return MaxLabelAge
+}
+
+// nextRepublishDelay returns the delay until the next republishing cycle,
+// which is the base interval plus a random jitter.
+func (c *CleanupManager) nextRepublishDelay() time.Duration {
+	if c.republishJitter <= 0 {
+		return c.republishInterval
 	}
+
+	return c.republishInterval + rand.N(c.republishJitter) //nolint:gosec // Jitter does not require a secure random source
 }
 
 // StartLabelRepublishTask starts a background task that periodically republishes local
 // CID provider announcements to keep content discoverable (provider records expire after ProviderRecordTTL).
+// Each cycle is scheduled after the republish interval plus a random jitter.
 // The wg parameter is used to track this goroutine in the parent's WaitGroup.
 func (c *CleanupManager) StartLabelRepublishTask(ctx context.Context, wg *sync.WaitGroup) {
-	ticker := time.NewTicker(RepublishInterval)
+	timer := time.NewTimer(c.nextRepublishDelay())
 
-	cleanupLogger.Info("Started CID provider republishing task", "interval", RepublishInterval)
+	cleanupLogger.Info("Started CID provider republishing task", "interval", c.republishInterval, "jitter", c.republishJitter)
 
 	defer func() {
-		ticker.Stop()
+		timer.Stop()
 		wg.Done()
 		cleanupLogger.Debug("CID provider republishing task stopped")
 	}()
@@ -98,13 +220,15 @@ func (c *CleanupManager) StartLabelRepublishTask(ctx context.Context, wg *sync.W
 			cleanupLogger.Info("CID provider republishing task stopping (context cancelled)")
 
 			return
-		case <-ticker.C:
+		case <-timer.C:
 			c.republishLocalProviders(ctx)
+			timer.Reset(c.nextRepublishDelay())
 		}
 	}
 }
 
-// StartRemoteLabelCleanupTask starts a background task that periodically cleans up stale remote labels.
+// StartRemoteLabelCleanupTask starts a background task that periodically cleans up stale remote labels
+// and cached addresses of peers that have not re-announced within the peer address TTL.
 // This is critical for the pull-based architecture to remove cached labels from offline or deleted remote content.
 // The wg parameter is used to track this goroutine in the parent's WaitGroup.
 func (c *CleanupManager) StartRemoteLabelCleanupTask(ctx context.Context, wg *sync.WaitGroup) {
@@ -128,6 +252,10 @@ func (c *CleanupManager) StartRemoteLabelCleanupTask(ctx context.Context, wg *sy
 			if err := c.cleanupStaleRemoteLabels(ctx); err != nil {
 				cleanupLogger.Error("Failed to cleanup stale remote labels", "error", err)
 			}
+
+			if err := c.cleanupStalePeerAddresses(ctx); err != nil {
+				cleanupLogger.Error("Failed to cleanup stale peer addresses", "error", err)
+			}
 		}
 	}
 }
@@ -155,6 +283,12 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 
 	var orphanedCIDs []string
 
+	// Pace republishing so that remote peers do not rate limit the announcements
+	var limiter *rate.Limiter
+	if c.republishRate > 0 {
+		limiter = rate.NewLimiter(c.republishRate, 1)
+	}
+
 	for result := range results.Next() {
 		if result.Error != nil {
 			cleanupLogger.Warn("Error reading local record for republishing", "error", result.Error)
@@ -193,7 +327,15 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 		}
 
 		// Wrap record with adapter for interface-based publishing
-		adapter := adapters.NewRecordAdapter(record)
+		adapter := adapters.NewRecordAdapterWithCid(record, cidStr)
+
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				cleanupLogger.Info("Republishing cycle interrupted", "republished", republishedCount, "error", err)
+
+				return
+			}
+		}
 
 		// Use injected publishing function (handles both DHT and GossipSub)
 		// This reuses routeRemote.Publish logic without circular dependency
@@ -215,7 +357,7 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 
 	// Clean up orphaned local records and their labels
 	if len(orphanedCIDs) > 0 {
-		cleanedCount := c.cleanupOrphanedLocalLabels(ctx, orphanedCIDs)
+		cleanedCount := c.cleanupOrphanedLocalLabels(ctx, c.server.Host().ID().String(), orphanedCIDs)
 		cleanupLogger.Info("Cleaned up orphaned local records", "count", cleanedCount)
 	}
 
@@ -228,13 +370,23 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 
 // cleanupStaleRemoteLabels removes remote labels that haven't been seen recently.
 func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
-	localPeerID := c.server.Host().ID().String()
+	return c.removeStaleRemoteLabels(ctx, c.server.Host().ID().String())
+}
 
+// remoteLabelEntry is a cached remote label along with the maximum age of its namespace.
+type remoteLabelEntry struct {
+	result query.Result
+	maxAge time.Duration
+}
+
+// removeStaleRemoteLabels removes labels of peers other than localPeerID
+// that have not been seen within the maximum age of their namespace.
+func (c *CleanupManager) removeStaleRemoteLabels(ctx context.Context, localPeerID string) error {
 	cleanupLogger.Debug("Starting stale remote label cleanup")
 
 	// Query all label keys with remote filter
 	// We'll query each namespace separately and combine results
-	var allResults []query.Result
+	var allResults []remoteLabelEntry
 
 	for _, namespace := range types.AllLabelTypes() {
 		nsResults, err := c.dstore.Query(ctx, query.Query{
@@ -254,8 +406,9 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 		}
 
 		// Collect results from this namespace
+		maxAge := c.labelMaxAge(namespace)
 		for result := range nsResults.Next() {
-			allResults = append(allResults, result)
+			allResults = append(allResults, remoteLabelEntry{result: result, maxAge: maxAge})
 		}
 
 		nsResults.Close()
@@ -264,7 +417,9 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 	var staleKeys []datastore.Key
 
 	// Check each remote label for staleness
-	for _, result := range allResults {
+	for _, entry := range allResults {
+		result := entry.result
+
 		if result.Error != nil {
 			cleanupLogger.Warn("Error reading label entry", "key", result.Key, "error", result.Error)
 
@@ -282,8 +437,8 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 			continue
 		}
 
-		var metadata types.LabelMetadata
-		if err := json.Unmarshal(result.Value, &metadata); err != nil {
+		metadata, upgraded, err := types.UnmarshalLabelMetadata(result.Value)
+		if err != nil {
 			cleanupLogger.Warn("Failed to parse label metadata, marking for deletion",
 				"key", result.Key, "error", err)
 
@@ -302,12 +457,19 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 			continue
 		}
 
-		// Check if label is stale using the IsStale method
-		if metadata.IsStale(MaxLabelAge) {
+		// Check if label is stale against the maximum age of its namespace
+		if metadata.IsStale(entry.maxAge) {
 			cleanupLogger.Debug("Found stale remote label",
-				"key", result.Key, "age", metadata.Age(), "peer", keyPeerID)
+				"key", result.Key, "age", metadata.Age(), "maxAge", entry.maxAge, "peer", keyPeerID)
 
 			staleKeys = append(staleKeys, datastore.NewKey(result.Key))
+
+			continue
+		}
+
+		// Write entries of an older format back upgraded, so that they are only migrated once
+		if upgraded {
+			c.writeUpgradedLabelMetadata(ctx, result.Key, metadata)
 		}
 	}
 
@@ -336,12 +498,93 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 	return nil
 }
 
+// writeUpgradedLabelMetadata stores label metadata that was upgraded from an older format.
+// Failures are logged, the entry is upgraded again on the next read.
+func (c *CleanupManager) writeUpgradedLabelMetadata(ctx context.Context, key string, metadata *types.LabelMetadata) {
+	data, err := metadata.Marshal()
+	if err != nil {
+		cleanupLogger.Warn("Failed to marshal upgraded label metadata", "key", key, "error", err)
+
+		return
+	}
+
+	if err := c.dstore.Put(ctx, datastore.NewKey(key), data); err != nil {
+		cleanupLogger.Warn("Failed to store upgraded label metadata", "key", key, "error", err)
+
+		return
+	}
+
+	cleanupLogger.Debug("Upgraded label metadata", "key", key, "version", metadata.Version)
+}
+
+// cleanupStalePeerAddresses removes cached peer addresses that have not been refreshed within the TTL.
+// Entries that cannot be decoded (e.g. written by older versions without timestamps) are removed as well.
+func (c *CleanupManager) cleanupStalePeerAddresses(ctx context.Context) error {
+	results, err := c.dstore.Query(ctx, query.Query{Prefix: PeerAddrsPrefix})
+	if err != nil {
+		return fmt.Errorf("failed to query peer addresses: %w", err)
+	}
+
+	var staleKeys []datastore.Key
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading peer addresses entry", "error", result.Error)
+
+			continue
+		}
+
+		entry, err := decodePeerAddrsEntry(result.Value)
+		if err != nil {
+			cleanupLogger.Debug("Failed to decode peer addresses, marking for deletion", "key", result.Key, "error", err)
+
+			staleKeys = append(staleKeys, datastore.NewKey(result.Key))
+
+			continue
+		}
+
+		if entry.IsExpired(c.peerAddrsTTL) {
+			cleanupLogger.Debug("Found stale peer addresses", "key", result.Key, "lastSeen", entry.LastSeen)
+
+			staleKeys = append(staleKeys, datastore.NewKey(result.Key))
+		}
+	}
+
+	results.Close()
+
+	if len(staleKeys) == 0 {
+		cleanupLogger.Debug("No stale peer addresses found")
+
+		return nil
+	}
+
+	batch, err := c.dstore.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch for peer address cleanup: %w", err)
+	}
+
+	for _, key := range staleKeys {
+		if err := batch.Delete(ctx, key); err != nil {
+			cleanupLogger.Warn("Failed to delete stale peer addresses", "key", key.String(), "error", err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit peer address cleanup: %w", err)
+	}
+
+	cleanupLogger.Info("Cleaned up stale peer addresses", "count", len(staleKeys))
+
+	return nil
+}
+
 // cleanupOrphanedLocalLabels removes local records and labels for CIDs that no longer exist in storage.
-func (c *CleanupManager) cleanupOrphanedLocalLabels(ctx context.Context, orphanedCIDs []string) int {
+// The CIDs are no longer listed as provided by this node.
+func (c *CleanupManager) cleanupOrphanedLocalLabels(ctx context.Context, localPeerID string, orphanedCIDs []string) int {
 	cleanedCount := 0
 
 	for _, cid := range orphanedCIDs {
-		if c.cleanupLabelsForCID(ctx, cid) {
+		if c.cleanupLabelsForCID(ctx, localPeerID, cid) {
 			cleanedCount++
 		}
 	}
@@ -350,7 +593,7 @@ func (c *CleanupManager) cleanupOrphanedLocalLabels(ctx context.Context, orphane
 }
 
 // cleanupLabelsForCID removes all local records and labels associated with a specific CID.
-func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bool {
+func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, localPeerID, cid string) bool {
 	batch, err := c.dstore.Batch(ctx)
 	if err != nil {
 		cleanupLogger.Error("Failed to create cleanup batch", "cid", cid, "error", err)
@@ -369,8 +612,6 @@ func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bo
 	}
 
 	// Find and remove all label keys for this CID across all namespaces
-	localPeerID := c.server.Host().ID().String()
-
 	for _, namespace := range types.AllLabelTypes() {
 		// Query labels in this namespace that match our CID
 		labelResults, err := c.dstore.Query(ctx, query.Query{
@@ -420,6 +661,11 @@ func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bo
 		return false
 	}
 
+	// The record is gone, so it is no longer provided either
+	if c.provided != nil {
+		c.provided.Remove(cid)
+	}
+
 	if keysDeleted > 0 {
 		cleanupLogger.Debug("Successfully cleaned up orphaned labels", "cid", cid, "keysDeleted", keysDeleted)
 	}
@@ -11,8 +11,49 @@ var (
 		// TODO: once we deploy our bootstrap nodes, we should update this
 	}
 
+	// Network identity defaults, shared by all peers of the public network.
+	DefaultRendezvous     = "dir/connect"
+	DefaultProtocolPrefix = "dir"
+
 	// GossipSub default (only enable/disable is configurable).
 	DefaultGossipSubEnabled = true
+
+	// Republishing defaults.
+	DefaultRepublishInterval = 36 * time.Hour
+	DefaultRepublishJitter   = 1 * time.Hour
+
+	// Republished records per second by default, half the default announcement rate
+	// remote peers accept, so that republishing is not rejected by their announcement limits.
+	DefaultRepublishRate = DefaultAnnouncementRate / 2
+
+	// Peer address cache default.
+	DefaultPeerAddressTTL = 72 * time.Hour
+
+	// Announcement verification defaults.
+	DefaultVerifyAnnouncementsEnabled    = false
+	DefaultVerifyAnnouncementsSampleRate = 0.1
+
+	// GossipSub announcement limits defaults.
+	DefaultAnnouncementMaxLabels = 100
+	DefaultAnnouncementRate      = 10.0
+	DefaultAnnouncementBurst     = 100
+
+	// Label announcement cap default for local records.
+	DefaultMaxLabelsPerRecord = 100
+
+	// Fallback pull concurrency default.
+	DefaultMaxConcurrentPulls = 16
+
+	// Label normalization defaults, disabled to keep the key space of existing deployments.
+	DefaultLabelNormalizationUnicode  = false
+	DefaultLabelNormalizationCaseFold = false
+
+	// Remote search time budget defaults.
+	DefaultSearchTimeout          = 30 * time.Second
+	DefaultLabelResolutionTimeout = 5 * time.Second
+
+	// Remote search result limit default.
+	DefaultMaxSearchLimit = 1000
 )
 
 type Config struct {
@@ -29,18 +70,117 @@ type Config struct {
 	// Path to asymmetric private key
 	KeyPath string `json:"key_path,omitempty" mapstructure:"key_path"`
 
+	// Rendezvous string advertised for libp2p peer discovery.
+	// Peers only discover each other through the rendezvous when they use the same string,
+	// so isolated networks (e.g. staging) can share bootstrap infrastructure without mixing.
+	// If empty, uses DefaultRendezvous.
+	Rendezvous string `json:"rendezvous,omitempty" mapstructure:"rendezvous"`
+
+	// Prefix of the DHT protocol IDs, e.g. "dir" for "dir/kad/1.0.0".
+	// Peers with different prefixes do not speak the same DHT protocol and never share routing data,
+	// so changing it splits a node off its current network.
+	// If empty, uses DefaultProtocolPrefix.
+	ProtocolPrefix string `json:"protocol_prefix,omitempty" mapstructure:"protocol_prefix"`
+
 	// Path to the routing datastore.
 	// If empty, the routing data will be stored in memory.
 	// If not empty, this dir will be used to store the routing data on disk.
 	DatastoreDir string `json:"datastore_dir,omitempty" mapstructure:"datastore_dir"`
 
+	// Backend of the routing datastore: "memory", "badger" or "leveldb".
+	// If empty, BadgerDB is used when DatastoreDir is set, and memory otherwise.
+	// Persistent backends keep cached labels and peer addresses across restarts.
+	DatastoreBackend string `json:"datastore_backend,omitempty" mapstructure:"datastore_backend"`
+
+	// Top-level namespace of all routing datastore keys, such as peer addresses,
+	// cached labels and DHT provider records, e.g. "dir/routing".
+	// Allows sharing the physical datastore with other components without key collisions.
+	// Changing it hides the data stored under the previous namespace.
+	// If empty, keys are stored without a prefix.
+	DatastoreNamespace string `json:"datastore_namespace,omitempty" mapstructure:"datastore_namespace"`
+
 	// Refresh interval for DHT routing tables.
 	// If not set or zero, uses the default RefreshInterval constant.
 	// This is primarily used for testing with faster intervals.
 	RefreshInterval time.Duration `json:"refresh_interval,omitempty" mapstructure:"refresh_interval"`
 
+	// Interval between republishing cycles of local records.
+	// If not set or zero, uses the default RepublishInterval constant.
+	// Interval plus jitter must stay comfortably below the DHT record TTL.
+	RepublishInterval time.Duration `json:"republish_interval,omitempty" mapstructure:"republish_interval"`
+
+	// Maximum random delay added to each republishing cycle.
+	// Spreads republishing of many nodes over time to avoid synchronized DHT churn.
+	RepublishJitter time.Duration `json:"republish_jitter,omitempty" mapstructure:"republish_jitter"`
+
+	// Maximum number of local records republished per second.
+	// Keep it below the announcement rate accepted by remote peers (announcement_limits.rate),
+	// or they reject the GossipSub announcements of a republishing cycle. Zero disables pacing.
+	RepublishRate float64 `json:"republish_rate,omitempty" mapstructure:"republish_rate"`
+
 	// GossipSub configuration for label announcements
 	GossipSub GossipSubConfig `json:"gossipsub,omitempty" mapstructure:"gossipsub"`
+
+	// How long cached peer addresses remain valid without the peer re-announcing.
+	// If not set or zero, uses the default MaxPeerAddrsAge constant.
+	PeerAddressTTL time.Duration `json:"peer_address_ttl,omitempty" mapstructure:"peer_address_ttl"`
+
+	// Maximum age of cached remote labels per namespace, as "namespace=duration" entries,
+	// e.g. "locators=12h" to expire volatile locators sooner than stable skills.
+	// Labels not seen again within this age are removed by the periodic cleanup,
+	// independently of the DHT record TTL. Namespaces without an entry use MaxLabelAge.
+	LabelMaxAges []string `json:"label_max_ages,omitempty" mapstructure:"label_max_ages"`
+
+	// Peer IDs allowed to provide labels and records.
+	// If not empty, only announcements from these peers are processed.
+	AllowedPeers []string `json:"allowed_peers,omitempty" mapstructure:"allowed_peers"`
+
+	// Peer IDs whose announcements are always ignored.
+	// Denied peers are also excluded from search results.
+	DeniedPeers []string `json:"denied_peers,omitempty" mapstructure:"denied_peers"`
+
+	// Additional label namespaces beyond the built-in skills, domains, modules and locators.
+	// For example, "features" enables labels under "/features/".
+	LabelNamespaces []string `json:"label_namespaces,omitempty" mapstructure:"label_namespaces"`
+
+	// Normalization of label values applied when building label keys and matching queries
+	LabelNormalization LabelNormalizationConfig `json:"label_normalization,omitempty" mapstructure:"label_normalization"`
+
+	// Maximum number of records pulled concurrently when labels for a provider
+	// announcement are not cached yet (DHT+Pull fallback).
+	// If not set or zero, uses DefaultMaxConcurrentPulls.
+	MaxConcurrentPulls int `json:"max_concurrent_pulls,omitempty" mapstructure:"max_concurrent_pulls"`
+
+	// Overall deadline of a remote search. Results found until then are returned,
+	// and the search ends with a DeadlineExceeded status.
+	// Does not apply to newly announced records streamed by watch searches.
+	// If not set or zero, uses DefaultSearchTimeout.
+	SearchTimeout time.Duration `json:"search_timeout,omitempty" mapstructure:"search_timeout"`
+
+	// Maximum time spent resolving the cached labels of a single record during a remote search.
+	// Records whose labels cannot be resolved in time are skipped.
+	// If not set or zero, uses DefaultLabelResolutionTimeout.
+	LabelResolutionTimeout time.Duration `json:"label_resolution_timeout,omitempty" mapstructure:"label_resolution_timeout"`
+
+	// Maximum number of records returned by a remote search of the routing API.
+	// Larger client limits are clamped to it, and searches without a limit return at most this many records.
+	// Does not apply to newly announced records streamed by watch searches,
+	// nor to searches made by the server itself, such as sync queries.
+	// If not set or zero, uses DefaultMaxSearchLimit. There is no unlimited setting.
+	MaxSearchLimit int `json:"max_search_limit,omitempty" mapstructure:"max_search_limit"`
+
+	// Verification of labels received via GossipSub announcements
+	VerifyAnnouncements VerifyAnnouncementsConfig `json:"verify_announcements,omitempty" mapstructure:"verify_announcements"`
+
+	// Limits applied to GossipSub announcements received from remote peers
+	AnnouncementLimits AnnouncementLimitsConfig `json:"announcement_limits,omitempty" mapstructure:"announcement_limits"`
+
+	// Maximum number of labels announced via GossipSub for a local record.
+	// Records with more labels are still stored and indexed locally with all labels,
+	// but only a subset taken across label types is announced, so remote peers
+	// cannot find them by the labels left out.
+	// If not set or zero, uses DefaultMaxLabelsPerRecord.
+	MaxLabelsPerRecord int `json:"max_labels_per_record,omitempty" mapstructure:"max_labels_per_record"`
 }
 
 // GossipSubConfig configures GossipSub-based label announcements.
@@ -63,3 +203,53 @@ type GossipSubConfig struct {
 	// server/routing/pubsub/constants.go for network compatibility.
 	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
 }
+
+// VerifyAnnouncementsConfig configures verification of GossipSub label announcements.
+// When enabled, a sample of announcements is checked by pulling the announced record
+// from the announcing peer and comparing the announced labels against the labels
+// derived from the record itself. Announcements that fail verification are not cached.
+type VerifyAnnouncementsConfig struct {
+	// Enabled controls whether announced labels are verified before caching.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// SampleRate is the fraction of announcements to verify, between 0.0 and 1.0.
+	// A value of 1.0 verifies every announcement.
+	// Default: 0.1
+	SampleRate float64 `json:"sample_rate,omitempty" mapstructure:"sample_rate"`
+}
+
+// AnnouncementLimitsConfig configures the checks applied to GossipSub announcements
+// before their labels are cached. Announcements with malformed CIDs, too many labels,
+// or from peers exceeding the announcement rate are rejected and counted per peer.
+// These limits are local policy and may differ between peers of a network.
+type AnnouncementLimitsConfig struct {
+	// MaxLabels is the maximum number of labels accepted per announcement.
+	// It cannot exceed the protocol limit of the labels topic.
+	// If not set or zero, uses DefaultAnnouncementMaxLabels.
+	// Default: 100
+	MaxLabels int `json:"max_labels,omitempty" mapstructure:"max_labels"`
+
+	// Rate is the number of announcements accepted per second from each peer.
+	// Zero disables rate limiting.
+	// Default: 10
+	Rate float64 `json:"rate,omitempty" mapstructure:"rate"`
+
+	// Burst is the number of announcements accepted from a peer at once above the rate.
+	// Default: 100
+	Burst int `json:"burst,omitempty" mapstructure:"burst"`
+}
+
+// LabelNormalizationConfig configures normalization of label values, so that near-duplicate
+// labels such as "/skills/AI" and "/skills/ai" share the same key and match the same queries.
+// Enabling it changes the keys of newly stored labels, so all peers of a network
+// should use the same settings and local records should be republished.
+type LabelNormalizationConfig struct {
+	// Unicode enables Unicode NFC normalization.
+	// Default: false
+	Unicode bool `json:"unicode,omitempty" mapstructure:"unicode"`
+
+	// CaseFold enables case folding, making label matching case-insensitive.
+	// Default: false
+	CaseFold bool `json:"case_fold,omitempty" mapstructure:"case_fold"`
+}
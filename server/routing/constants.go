@@ -25,15 +25,6 @@ const (
 	RefreshInterval = 30 * time.Second
 )
 
-// Protocol constants for libp2p DHT and discovery.
-const (
-	// ProtocolPrefix is the prefix used for DHT protocol identification.
-	ProtocolPrefix = "dir"
-
-	// ProtocolRendezvous is the rendezvous string used for peer discovery.
-	ProtocolRendezvous = "dir/connect"
-)
-
 // Validation rules and limits.
 const (
 	// MaxHops defines the maximum number of hops allowed in distributed queries.
@@ -42,14 +33,38 @@ const (
 	// NotificationChannelSize defines the buffer size for announcement notifications.
 	NotificationChannelSize = 1000
 
+	// PullSlotTimeout defines how long a provider notification waits for a free
+	// fallback pull slot before it is dropped. The record is retried on the next announcement.
+	PullSlotTimeout = 30 * time.Second
+
+	// MaxConcurrentNotifications bounds the number of provider notifications handled concurrently.
+	// Further notifications wait in the notification channel.
+	MaxConcurrentNotifications = 64
+
+	// MaxConcurrentVerifications bounds the number of GossipSub announcements verified concurrently.
+	// Sampled announcements arriving while all verification slots are busy are dropped.
+	MaxConcurrentVerifications = 16
+
 	// MaxLabelAge defines when remote label announcements are considered stale.
 	// Labels older than this will be cleaned up during periodic cleanup cycles.
 	MaxLabelAge = 72 * time.Hour
 
+	// MaxPeerAddrsAge defines when cached peer addresses are considered stale.
+	// Addresses are refreshed whenever the peer re-announces content.
+	MaxPeerAddrsAge = 72 * time.Hour
+
+	// MaxRepublishDelayRatio defines the maximum fraction of RecordTTL that a
+	// republishing cycle (interval + jitter) may take, leaving headroom before records expire.
+	MaxRepublishDelayRatio = 0.8
+
 	// DefaultMinMatchScore defines the minimum allowed match score for production safety.
 	// Per proto specification: "If not set, it will return records that match at least one query".
 	// Any value below this threshold is automatically corrected to this value.
 	DefaultMinMatchScore = 1
+
+	// MaxNetworkPullProviders defines how many providers discovered via DHT are
+	// tried in turn when pulling a record that is not stored locally.
+	MaxNetworkPullProviders = 5
 )
 
 const ResultChannelBufferSize = 100
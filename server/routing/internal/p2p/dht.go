@@ -41,6 +41,13 @@ func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo,
 	}
 
 	// Sync with bootstrap nodes
+	connectBootstrapPeers(ctx, host, bootstrapPeers)
+
+	return kdht, nil
+}
+
+// connectBootstrapPeers connects to the bootstrap peers and protects them from pruning.
+func connectBootstrapPeers(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo) {
 	var wg sync.WaitGroup
 	for _, p := range bootstrapPeers {
 		wg.Add(1)
@@ -79,6 +86,17 @@ func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo,
 			}
 		}
 	}
+}
 
-	return kdht, nil
+// releaseBootstrapPeers removes the protection of former bootstrap peers.
+// Existing connections are kept, and managed by the Connection Manager as any other.
+func releaseBootstrapPeers(host host.Host, peerIDs []peer.ID) {
+	if host.ConnManager() == nil {
+		return
+	}
+
+	for _, id := range peerIDs {
+		host.ConnManager().Unprotect(id, "bootstrap")
+		host.ConnManager().UntagPeer(id, "bootstrap")
+	}
 }
@@ -6,6 +6,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/agntcy/dir/utils/logging"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
@@ -24,6 +25,9 @@ type Server struct {
 	host    host.Host
 	dht     *dht.IpfsDHT
 	closeFn func()
+
+	bootstrapMu    sync.Mutex
+	bootstrapPeers []peer.AddrInfo
 }
 
 // New constructs a new p2p server.
@@ -50,6 +54,8 @@ func New(ctx context.Context, opts ...Option) (*Server, error) {
 		host:    status.Host,
 		dht:     status.DHT,
 		closeFn: status.Close,
+
+		bootstrapPeers: options.BootstrapPeers,
 	}
 
 	logger.Debug("P2P server created", "host", server.host.ID(), "addresses", server.P2pAddrs())
@@ -99,6 +105,50 @@ func (s *Server) Key() crypto.PrivKey {
 	return s.host.Peerstore().PrivKey(s.host.ID())
 }
 
+// UpdateBootstrapPeers replaces the bootstrap peers of a running server.
+// Added peers are connected to and protected from pruning. Removed peers lose
+// their protection, but existing connections to them are not closed.
+// It returns the IDs of the added and removed peers.
+func (s *Server) UpdateBootstrapPeers(ctx context.Context, peers []peer.AddrInfo) ([]peer.ID, []peer.ID) {
+	s.bootstrapMu.Lock()
+	defer s.bootstrapMu.Unlock()
+
+	current := make(map[peer.ID]struct{}, len(s.bootstrapPeers))
+	for _, p := range s.bootstrapPeers {
+		current[p.ID] = struct{}{}
+	}
+
+	updated := make(map[peer.ID]struct{}, len(peers))
+
+	var (
+		addedPeers []peer.AddrInfo
+		added      []peer.ID
+		removed    []peer.ID
+	)
+
+	for _, p := range peers {
+		updated[p.ID] = struct{}{}
+
+		if _, ok := current[p.ID]; !ok {
+			addedPeers = append(addedPeers, p)
+			added = append(added, p.ID)
+		}
+	}
+
+	for _, p := range s.bootstrapPeers {
+		if _, ok := updated[p.ID]; !ok {
+			removed = append(removed, p.ID)
+		}
+	}
+
+	releaseBootstrapPeers(s.host, removed)
+	connectBootstrapPeers(ctx, s.host, addedPeers)
+
+	s.bootstrapPeers = peers
+
+	return added, removed
+}
+
 // Close stops running services.
 func (s *Server) Close() {
 	s.closeFn()
@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHost creates a host listening on a random local port.
+func newTestHost(t *testing.T) host.Host {
+	t.Helper()
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	h, err := newHost("/ip4/127.0.0.1/tcp/0", "", key)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = h.Close() })
+
+	return h
+}
+
+func addrInfo(h host.Host) peer.AddrInfo {
+	return peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}
+}
+
+func TestUpdateBootstrapPeers(t *testing.T) {
+	server := &Server{host: newTestHost(t)}
+	first := newTestHost(t)
+	second := newTestHost(t)
+
+	isProtected := func(id peer.ID) bool {
+		return server.host.ConnManager().IsProtected(id, "bootstrap")
+	}
+
+	// Adding a peer connects to it and protects it
+	added, removed := server.UpdateBootstrapPeers(t.Context(), []peer.AddrInfo{addrInfo(first)})
+	assert.Equal(t, []peer.ID{first.ID()}, added)
+	assert.Empty(t, removed)
+	assert.Equal(t, network.Connected, server.host.Network().Connectedness(first.ID()))
+	assert.True(t, isProtected(first.ID()))
+
+	// Replacing a peer releases the old one but keeps its connection
+	added, removed = server.UpdateBootstrapPeers(t.Context(), []peer.AddrInfo{addrInfo(second)})
+	assert.Equal(t, []peer.ID{second.ID()}, added)
+	assert.Equal(t, []peer.ID{first.ID()}, removed)
+	assert.Equal(t, network.Connected, server.host.Network().Connectedness(first.ID()))
+	assert.False(t, isProtected(first.ID()))
+	assert.True(t, isProtected(second.ID()))
+
+	// An unchanged list is a no-op
+	added, removed = server.UpdateBootstrapPeers(t.Context(), []peer.AddrInfo{addrInfo(second)})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+	assert.True(t, isProtected(second.ID()))
+
+	// An unreachable peer is tracked but not protected
+	unreachable := newTestHost(t)
+	unreachableInfo := addrInfo(unreachable)
+	require.NoError(t, unreachable.Close())
+
+	added, _ = server.UpdateBootstrapPeers(t.Context(), []peer.AddrInfo{addrInfo(second), unreachableInfo})
+	assert.Equal(t, []peer.ID{unreachable.ID()}, added)
+	assert.False(t, isProtected(unreachable.ID()))
+}
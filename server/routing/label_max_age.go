@@ -0,0 +1,44 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// ParseLabelMaxAges parses per-namespace maximum ages of cached remote labels,
+// given as "namespace=duration" entries such as "locators=12h".
+// Namespaces are not checked against the registered label types.
+func ParseLabelMaxAges(entries []string) (map[types.LabelType]time.Duration, error) {
+	maxAges := make(map[types.LabelType]time.Duration, len(entries))
+
+	for _, entry := range entries {
+		namespace, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || namespace == "" {
+			return nil, fmt.Errorf("invalid label max age %q: expected namespace=duration", entry)
+		}
+
+		labelType := types.LabelType(strings.Trim(namespace, "/"))
+		if _, exists := maxAges[labelType]; exists {
+			return nil, fmt.Errorf("duplicate label max age for namespace %q", labelType)
+		}
+
+		maxAge, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label max age %q: %w", entry, err)
+		}
+
+		if maxAge <= 0 {
+			return nil, fmt.Errorf("invalid label max age %q: must be positive", entry)
+		}
+
+		maxAges[labelType] = maxAge
+	}
+
+	return maxAges, nil
+}
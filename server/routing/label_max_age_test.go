@@ -0,0 +1,37 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelMaxAges(t *testing.T) {
+	maxAges, err := ParseLabelMaxAges([]string{"locators=12h", " /skills/=168h"})
+	require.NoError(t, err)
+	assert.Equal(t, map[types.LabelType]time.Duration{
+		types.LabelTypeLocator: 12 * time.Hour,
+		types.LabelTypeSkill:   168 * time.Hour,
+	}, maxAges)
+
+	maxAges, err = ParseLabelMaxAges(nil)
+	require.NoError(t, err)
+	assert.Empty(t, maxAges)
+
+	for _, entries := range [][]string{
+		{"locators"},
+		{"=12h"},
+		{"locators=soon"},
+		{"locators=0s"},
+		{"locators=12h", "locators=1h"},
+	} {
+		_, err := ParseLabelMaxAges(entries)
+		assert.Error(t, err, "entries %v", entries)
+	}
+}
@@ -14,9 +14,10 @@ import (
 // Key manipulation utilities for routing operations.
 // These functions handle the enhanced label key format: /namespace/value/CID/PeerID
 
+// The label value is normalized with the given normalization.
 // Example: Label("/skills/AI/ML") → "/skills/AI/ML/CID123/Peer1".
-func BuildEnhancedLabelKey(label types.Label, cid, peerID string) string {
-	return fmt.Sprintf("%s/%s/%s", label.String(), cid, peerID)
+func BuildEnhancedLabelKey(normalization types.LabelNormalization, label types.Label, cid, peerID string) string {
+	return fmt.Sprintf("%s/%s/%s", normalization.Label(label).String(), cid, peerID)
 }
 
 // Example: "/skills/AI/ML/CID123/Peer1" → (Label("/skills/AI/ML"), "CID123", "Peer1", nil).
@@ -51,7 +51,7 @@ func TestBuildEnhancedLabelKey(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := BuildEnhancedLabelKey(tc.label, tc.cid, tc.peerID)
+			result := BuildEnhancedLabelKey(types.LabelNormalization{}, tc.label, tc.cid, tc.peerID)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -388,7 +388,7 @@ func TestParseEnhancedLabelKey_RoundTrip(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.label.String(), func(t *testing.T) {
 			// Build key
-			key := BuildEnhancedLabelKey(tc.label, tc.cid, tc.peerID)
+			key := BuildEnhancedLabelKey(types.LabelNormalization{}, tc.label, tc.cid, tc.peerID)
 
 			// Parse it back
 			parsedLabel, parsedCID, parsedPeer, err := ParseEnhancedLabelKey(key)
@@ -409,7 +409,7 @@ func BenchmarkBuildEnhancedLabelKey(b *testing.B) {
 	b.ResetTimer()
 
 	for range b.N {
-		_ = BuildEnhancedLabelKey(label, cid, peerID)
+		_ = BuildEnhancedLabelKey(types.LabelNormalization{}, label, cid, peerID)
 	}
 }
 
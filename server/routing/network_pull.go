@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/tracing"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// providerPullFunc pulls a record from a provider over the peer-to-peer RPC.
+type providerPullFunc func(ctx context.Context, providerID peer.ID, ref *corev1.RecordRef) (*corev1.Record, error)
+
+// PullFromNetwork pulls a record from a provider discovered via DHT and caches it in the local store.
+// Up to MaxNetworkPullProviders providers are tried in turn, skipping the local peer and peers
+// rejected by the allow/deny lists. Cached records are added to the search index.
+// Failing to cache the record does not fail the pull.
+func (r *routeRemote) PullFromNetwork(ctx context.Context, req *routingv1.PullFromNetworkRequest) (*corev1.Record, error) {
+	ref := req.GetRecordRef()
+
+	decodedCID, err := cid.Decode(ref.GetCid())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CID %q: %v", ref.GetCid(), err)
+	}
+
+	ctx, span := tracing.Start(ctx, "routing.PullFromNetwork")
+	defer span.End()
+
+	providers := r.server.DHT().FindProvidersAsync(ctx, decodedCID, MaxNetworkPullProviders)
+
+	record, err := r.pullFromProviders(ctx, ref, providers, r.server.Host().ID(), r.service.Pull)
+	if err != nil {
+		tracing.SetError(span, err)
+
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// pullFromProviders pulls a record from the first of the providers that returns it, and caches it.
+func (r *routeRemote) pullFromProviders(ctx context.Context, ref *corev1.RecordRef, providers <-chan peer.AddrInfo, localID peer.ID, pull providerPullFunc) (*corev1.Record, error) {
+	var errs []error
+
+	for provider := range providers {
+		if provider.ID == localID || !r.peerFilter.IsAllowed(provider.ID.String()) {
+			continue
+		}
+
+		record, err := pullFromProvider(ctx, pull, provider.ID, ref)
+		if err != nil {
+			remoteLogger.Warn("Failed to pull record from provider", "cid", ref.GetCid(), "peer", provider.ID.String(), "error", err)
+			errs = append(errs, fmt.Errorf("peer %s: %w", provider.ID, err))
+
+			continue
+		}
+
+		if pushedRef, err := r.storeAPI.Push(ctx, record); err != nil {
+			remoteLogger.Warn("Failed to cache record pulled from the network", "cid", ref.GetCid(), "error", err)
+		} else {
+			r.indexRecord(adapters.NewRecordAdapterWithCid(record, pushedRef.GetCid()))
+		}
+
+		remoteLogger.Info("Pulled record from the network", "cid", ref.GetCid(), "peer", provider.ID.String())
+
+		return record, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err() //nolint:wrapcheck
+	}
+
+	if len(errs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no providers found for record %s", ref.GetCid())
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "failed to pull record %s from %d providers: %v", ref.GetCid(), len(errs), errors.Join(errs...))
+}
+
+// pullFromProvider pulls a record from a provider and checks that it matches the requested CID.
+func pullFromProvider(ctx context.Context, pull providerPullFunc, providerID peer.ID, ref *corev1.RecordRef) (*corev1.Record, error) {
+	record, err := pull(ctx, providerID, ref)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // already a gRPC status error
+	}
+
+	if !record.HasCid(ref.GetCid()) {
+		return nil, status.Errorf(codes.DataLoss, "provider returned record with CID %s, expected %s", record.GetCid(), ref.GetCid())
+	}
+
+	return record, nil
+}
+
+// setIndexFunc sets the function indexing records pulled from the network.
+func (r *routeRemote) setIndexFunc(fn types.IndexFunc) {
+	r.indexMu.Lock()
+	defer r.indexMu.Unlock()
+
+	r.index = fn
+}
+
+// indexRecord adds a record pulled from the network to the search index, if an index function is set.
+func (r *routeRemote) indexRecord(record types.Record) {
+	r.indexMu.RLock()
+	index := r.index
+	r.indexMu.RUnlock()
+
+	if index == nil {
+		return
+	}
+
+	if err := index(record); err != nil {
+		remoteLogger.Error("Failed to add record pulled from the network to search index", "cid", record.GetCid(), "error", err)
+	}
+}
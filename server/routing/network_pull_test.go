@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPullFromProviders(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{Name: "network-agent", SchemaVersion: "v0.3.1"})
+	other := corev1.New(&typesv1alpha0.Record{Name: "other-agent", SchemaVersion: "v0.3.1"})
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	const (
+		localPeer    = peer.ID("local")
+		deniedPeer   = peer.ID("denied")
+		failingPeer  = peer.ID("failing")
+		alteringPeer = peer.ID("altering")
+		servingPeer  = peer.ID("serving")
+	)
+
+	providersOf := func(ids ...peer.ID) <-chan peer.AddrInfo {
+		providers := make(chan peer.AddrInfo, len(ids))
+		for _, id := range ids {
+			providers <- peer.AddrInfo{ID: id}
+		}
+
+		close(providers)
+
+		return providers
+	}
+
+	// pull serves the record from the serving peer, another record from the altering peer
+	// and fails for all other peers, recording the peers it was called for
+	newPull := func(pulled *[]peer.ID) providerPullFunc {
+		return func(_ context.Context, providerID peer.ID, _ *corev1.RecordRef) (*corev1.Record, error) {
+			*pulled = append(*pulled, providerID)
+
+			switch providerID {
+			case servingPeer:
+				return record, nil
+			case alteringPeer:
+				return other, nil
+			default:
+				return nil, status.Error(codes.Unavailable, "peer unreachable")
+			}
+		}
+	}
+
+	newRemote := func() (*routeRemote, *mockStore, *[]string) {
+		store := newMockStore()
+		indexed := &[]string{}
+
+		remote := &routeRemote{
+			storeAPI:   store,
+			peerFilter: newPeerFilter(nil, []string{deniedPeer.String()}),
+		}
+		remote.setIndexFunc(func(record types.Record) error {
+			*indexed = append(*indexed, record.GetCid())
+
+			return nil
+		})
+
+		return remote, store, indexed
+	}
+
+	t.Run("tries providers in turn and caches the record", func(t *testing.T) {
+		remote, store, indexed := newRemote()
+
+		var pulled []peer.ID
+
+		got, err := remote.pullFromProviders(t.Context(), ref,
+			providersOf(localPeer, deniedPeer, failingPeer, alteringPeer, servingPeer, failingPeer),
+			localPeer, newPull(&pulled))
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), got.GetCid())
+
+		// The local and denied peers are skipped, and no provider is tried after the first success
+		assert.Equal(t, []peer.ID{failingPeer, alteringPeer, servingPeer}, pulled)
+
+		// The record is cached and indexed, the altered record is not
+		assert.Contains(t, store.data, record.GetCid())
+		assert.NotContains(t, store.data, other.GetCid())
+		assert.Equal(t, []string{record.GetCid()}, *indexed)
+	})
+
+	t.Run("rejects records not matching the CID", func(t *testing.T) {
+		remote, store, indexed := newRemote()
+
+		var pulled []peer.ID
+
+		_, err := remote.pullFromProviders(t.Context(), ref, providersOf(alteringPeer, failingPeer), localPeer, newPull(&pulled))
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Contains(t, err.Error(), "expected "+record.GetCid())
+		assert.Empty(t, store.data)
+		assert.Empty(t, *indexed)
+	})
+
+	t.Run("no providers", func(t *testing.T) {
+		remote, _, _ := newRemote()
+
+		var pulled []peer.ID
+
+		_, err := remote.pullFromProviders(t.Context(), ref, providersOf(localPeer, deniedPeer), localPeer, newPull(&pulled))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, pulled)
+	})
+
+	t.Run("failed caching does not fail the pull", func(t *testing.T) {
+		remote, _, indexed := newRemote()
+		remote.storeAPI = &failingPushStore{mockStore: newMockStore()}
+
+		var pulled []peer.ID
+
+		got, err := remote.pullFromProviders(t.Context(), ref, providersOf(servingPeer), localPeer, newPull(&pulled))
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), got.GetCid())
+		assert.Empty(t, *indexed)
+	})
+}
+
+// failingPushStore is a store whose pushes fail.
+type failingPushStore struct {
+	*mockStore
+}
+
+func (f *failingPushStore) Push(context.Context, *corev1.Record) (*corev1.RecordRef, error) {
+	return nil, status.Error(codes.Unavailable, "store unavailable")
+}
@@ -0,0 +1,49 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// PeerAddrsPrefix is the datastore prefix for cached peer addresses.
+const PeerAddrsPrefix = "/peer_addrs/"
+
+// peerAddrsEntry is the datastore value for cached peer addresses.
+// LastSeen is refreshed every time the peer re-announces content.
+type peerAddrsEntry struct {
+	Addrs    []ma.Multiaddr `json:"addrs"`
+	LastSeen time.Time      `json:"last_seen"`
+}
+
+// peerAddrsKey returns the datastore key for the cached addresses of a peer.
+func peerAddrsKey(peerID string) datastore.Key {
+	return datastore.NewKey(PeerAddrsPrefix + peerID)
+}
+
+// decodePeerAddrsEntry parses a cached peer addresses value.
+// Values written before timestamps were introduced cannot be decoded and are treated as expired.
+func decodePeerAddrsEntry(value []byte) (*peerAddrsEntry, error) {
+	var entry peerAddrsEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal peer addresses: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// IsExpired reports whether the entry has not been refreshed within the given TTL.
+// A non-positive TTL disables expiry.
+func (e *peerAddrsEntry) IsExpired(ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+
+	return time.Since(e.LastSeen) > ttl
+}
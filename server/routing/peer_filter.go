@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import "strings"
+
+// peerFilter decides which remote peers are trusted to provide labels and records.
+// Denied peers are always rejected. If an allow-list is configured,
+// only peers on that list are accepted.
+type peerFilter struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+// newPeerFilter creates a peer filter from allow and deny lists of peer IDs.
+// Empty entries are ignored.
+func newPeerFilter(allowedPeers, deniedPeers []string) *peerFilter {
+	return &peerFilter{
+		allowed: toPeerSet(allowedPeers),
+		denied:  toPeerSet(deniedPeers),
+	}
+}
+
+// IsAllowed reports whether the given peer ID passes the allow/deny lists.
+// A nil filter allows all peers.
+func (f *peerFilter) IsAllowed(peerID string) bool {
+	if f == nil {
+		return true
+	}
+
+	if _, denied := f.denied[peerID]; denied {
+		return false
+	}
+
+	if len(f.allowed) == 0 {
+		return true
+	}
+
+	_, allowed := f.allowed[peerID]
+
+	return allowed
+}
+
+func toPeerSet(peerIDs []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(peerIDs))
+
+	for _, peerID := range peerIDs {
+		if peerID = strings.TrimSpace(peerID); peerID != "" {
+			set[peerID] = struct{}{}
+		}
+	}
+
+	return set
+}
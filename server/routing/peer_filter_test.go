@@ -0,0 +1,57 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPeerFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		allowed  []string
+		denied   []string
+		peerID   string
+		expected bool
+	}{
+		{name: "no_lists", peerID: "peer-a", expected: true},
+		{name: "denied_peer", denied: []string{"peer-a"}, peerID: "peer-a", expected: false},
+		{name: "not_denied_peer", denied: []string{"peer-a"}, peerID: "peer-b", expected: true},
+		{name: "allowed_peer", allowed: []string{"peer-a"}, peerID: "peer-a", expected: true},
+		{name: "not_allowed_peer", allowed: []string{"peer-a"}, peerID: "peer-b", expected: false},
+		{name: "deny_wins_over_allow", allowed: []string{"peer-a"}, denied: []string{"peer-a"}, peerID: "peer-a", expected: false},
+		{name: "empty_entries_ignored", allowed: []string{"", " "}, peerID: "peer-a", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := newPeerFilter(tc.allowed, tc.denied)
+			assert.Equal(t, tc.expected, filter.IsAllowed(tc.peerID))
+		})
+	}
+
+	t.Run("nil_filter", func(t *testing.T) {
+		var filter *peerFilter
+		assert.True(t, filter.IsAllowed("peer-a"))
+	})
+}
+
+func TestPullFromPeer_PeerFilter(t *testing.T) {
+	const deniedPeer = "QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC"
+
+	r := &routeRemote{peerFilter: newPeerFilter(nil, []string{deniedPeer})}
+
+	// Denied peers are rejected before they are contacted
+	_, err := r.PullFromPeer(t.Context(), &routingv1.PullFromPeerRequest{
+		Multiaddr: "/ip4/127.0.0.1/tcp/8999/p2p/" + deniedPeer,
+		RecordRef: &corev1.RecordRef{Cid: "test-cid"},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,162 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListPeers returns the peers known to the routing layer.
+// The result is the union of currently connected peers, peers in the
+// DHT routing table and peers with rejected announcements, sorted by peer ID. This operation is read-only and
+// does not interact with the network.
+func (r *routeRemote) ListPeers(ctx context.Context, _ *routingv1.ListPeersRequest) (*routingv1.ListPeersResponse, error) {
+	host := r.server.Host()
+
+	// Collect peers from the DHT routing table
+	inRoutingTable := make(map[peer.ID]bool)
+	for _, pid := range r.server.DHT().RoutingTable().ListPeers() {
+		inRoutingTable[pid] = true
+	}
+
+	// Collect peers in the GossipSub mesh of the labels topic, a subset of the topic subscribers
+	inMesh := make(map[string]bool)
+	if r.pubsubManager != nil {
+		for _, pid := range r.pubsubManager.GetMeshPeers() {
+			inMesh[pid] = true
+		}
+	}
+
+	// Union of connected peers and routing table peers
+	known := make(map[peer.ID]struct{})
+	for _, pid := range host.Network().Peers() {
+		known[pid] = struct{}{}
+	}
+
+	for pid := range inRoutingTable {
+		known[pid] = struct{}{}
+	}
+
+	// Include peers with rejected announcements for abuse detection
+	rejected := make(map[peer.ID]uint64)
+
+	for peerID, count := range r.announcementGuard.Rejected() {
+		pid, err := peer.Decode(peerID)
+		if err != nil {
+			continue
+		}
+
+		rejected[pid] = count
+		known[pid] = struct{}{}
+	}
+
+	delete(known, host.ID())
+
+	peers := make([]*routingv1.PeerStatus, 0, len(known))
+
+	for pid := range known {
+		if ctx.Err() != nil {
+			return nil, ctx.Err() //nolint:wrapcheck
+		}
+
+		addrs := host.Peerstore().Addrs(pid)
+
+		addrStrs := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addrStrs = append(addrStrs, addr.String())
+		}
+
+		peers = append(peers, &routingv1.PeerStatus{
+			Peer: &routingv1.Peer{
+				Id:         pid.String(),
+				Addrs:      addrStrs,
+				Connection: toPeerConnectionType(host.Network().Connectedness(pid)),
+			},
+			DirectoryAddress:      extractDirProtocol(addrs, pid.String()),
+			InRoutingTable:        inRoutingTable[pid],
+			InGossipsubMesh:       inMesh[pid.String()],
+			RejectedAnnouncements: rejected[pid],
+		})
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].GetPeer().GetId() < peers[j].GetPeer().GetId()
+	})
+
+	return &routingv1.ListPeersResponse{Peers: peers}, nil
+}
+
+// PullFromPeer pulls a record directly from the peer at the requested multiaddr.
+// Peers rejected by the allow/deny lists are not contacted.
+func (r *routeRemote) PullFromPeer(ctx context.Context, req *routingv1.PullFromPeerRequest) (*corev1.Record, error) {
+	addr, err := ma.NewMultiaddr(req.GetMultiaddr())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid multiaddr %q: %v", req.GetMultiaddr(), err)
+	}
+
+	// Multiaddrs without a peer ID are rejected when pulling
+	if info, err := peer.AddrInfoFromP2pAddr(addr); err == nil && !r.peerFilter.IsAllowed(info.ID.String()) {
+		return nil, status.Errorf(codes.PermissionDenied, "peer %s is not allowed", info.ID)
+	}
+
+	record, err := r.service.PullFromAddr(ctx, addr, req.GetRecordRef())
+	if err != nil {
+		return nil, err //nolint:wrapcheck // already a gRPC status error
+	}
+
+	return record, nil
+}
+
+// toPeerConnectionType maps libp2p connectedness to the API connection type.
+func toPeerConnectionType(c network.Connectedness) routingv1.PeerConnectionType {
+	switch c { //nolint:exhaustive
+	case network.Connected, network.Limited:
+		return routingv1.PeerConnectionType_PEER_CONNECTION_TYPE_CONNECTED
+	case network.CannotConnect:
+		return routingv1.PeerConnectionType_PEER_CONNECTION_TYPE_CANNOT_CONNECT
+	default:
+		return routingv1.PeerConnectionType_PEER_CONNECTION_TYPE_NOT_CONNECTED
+	}
+}
+
+// UpdateBootstrapPeers replaces the bootstrap peers with the given multiaddrs.
+// All addresses are validated before any change is applied.
+// Existing connections are not disrupted.
+func (r *routeRemote) UpdateBootstrapPeers(ctx context.Context, addrs []string) error {
+	peers := make([]peer.AddrInfo, 0, len(addrs))
+
+	var errs []error
+
+	for _, addr := range addrs {
+		peerInfo, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid bootstrap peer %q: %w", addr, err))
+
+			continue
+		}
+
+		peers = append(peers, *peerInfo)
+	}
+
+	if len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "failed to update bootstrap peers: %v", errors.Join(errs...))
+	}
+
+	added, removed := r.server.UpdateBootstrapPeers(ctx, peers)
+
+	remoteLogger.Info("Updated bootstrap peers", "added", added, "removed", removed, "total", len(peers))
+
+	return nil
+}
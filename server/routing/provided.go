@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// providedEntry holds the announcement times of a provided CID.
+type providedEntry struct {
+	firstProvided time.Time
+	lastProvided  time.Time
+}
+
+// providedSet tracks the CIDs this node has announced to the DHT.
+// Entries are added or refreshed on each successful announcement, including
+// periodic republishing, and removed when the record is unpublished.
+// The set is kept in memory and rebuilt by publishing after a restart.
+type providedSet struct {
+	mu      sync.RWMutex
+	entries map[string]providedEntry
+}
+
+// newProvidedSet creates an empty set of provided CIDs.
+func newProvidedSet() *providedSet {
+	return &providedSet{
+		entries: make(map[string]providedEntry),
+	}
+}
+
+// Provided records a successful announcement of the CID at the given time.
+func (s *providedSet) Provided(cid string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[cid]
+	if !ok {
+		entry.firstProvided = at
+	}
+
+	entry.lastProvided = at
+	s.entries[cid] = entry
+}
+
+// Remove stops tracking the CID.
+func (s *providedSet) Remove(cid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, cid)
+}
+
+// List returns the provided records sorted by CID.
+func (s *providedSet) List() []*routingv1.ProvidedRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]*routingv1.ProvidedRecord, 0, len(s.entries))
+	for cid, entry := range s.entries {
+		records = append(records, &routingv1.ProvidedRecord{
+			RecordRef:       &corev1.RecordRef{Cid: cid},
+			FirstProvidedAt: entry.firstProvided.UTC().Format(time.RFC3339),
+			LastProvidedAt:  entry.lastProvided.UTC().Format(time.RFC3339),
+		})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].GetRecordRef().GetCid() < records[j].GetRecordRef().GetCid()
+	})
+
+	return records
+}
+
+// ListProvided returns the records this node has announced to the DHT.
+// This operation is read-only and does not interact with the network.
+func (r *routeRemote) ListProvided(_ context.Context, _ *routingv1.ListProvidedRequest) (*routingv1.ListProvidedResponse, error) {
+	return &routingv1.ListProvidedResponse{
+		Records: r.provided.List(),
+	}, nil
+}
@@ -0,0 +1,53 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvidedSet(t *testing.T) {
+	first := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, newProvidedSet().List())
+	})
+
+	t.Run("republish_keeps_first_provided", func(t *testing.T) {
+		set := newProvidedSet()
+		set.Provided("cid-a", first)
+		set.Provided("cid-a", second)
+
+		records := set.List()
+		require.Len(t, records, 1)
+		assert.Equal(t, "cid-a", records[0].GetRecordRef().GetCid())
+		assert.Equal(t, "2025-01-01T10:00:00Z", records[0].GetFirstProvidedAt())
+		assert.Equal(t, "2025-01-01T11:00:00Z", records[0].GetLastProvidedAt())
+	})
+
+	t.Run("sorted_by_cid", func(t *testing.T) {
+		set := newProvidedSet()
+		set.Provided("cid-b", first)
+		set.Provided("cid-a", first)
+
+		records := set.List()
+		require.Len(t, records, 2)
+		assert.Equal(t, "cid-a", records[0].GetRecordRef().GetCid())
+		assert.Equal(t, "cid-b", records[1].GetRecordRef().GetCid())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		set := newProvidedSet()
+		set.Provided("cid-a", first)
+		set.Remove("cid-a")
+		set.Remove("cid-unknown")
+
+		assert.Empty(t, set.List())
+	})
+}
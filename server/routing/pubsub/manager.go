@@ -12,6 +12,7 @@ import (
 	"github.com/agntcy/dir/server/routing/internal/p2p"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/agntcy/dir/utils/metrics"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 )
@@ -37,9 +38,13 @@ type Manager struct {
 	pubsub      *pubsub.PubSub
 	topic       *pubsub.Topic
 	sub         *pubsub.Subscription
+	mesh        *meshTracer
 	localPeerID string
 	topicName   string // Topic name (protocol constant)
 
+	// Maximum number of labels announced per record
+	maxLabels int
+
 	// Callback invoked when record publish event is received.
 	// Parameters:
 	//   - context.Context: Operation context
@@ -58,11 +63,19 @@ type Manager struct {
 // Parameters:
 //   - ctx: Context for lifecycle management
 //   - h: libp2p host for network operations
+//   - maxLabels: Maximum number of labels announced per record, at most MaxLabelsPerAnnouncement
+//     (zero uses MaxLabelsPerAnnouncement)
 //
 // Returns:
 //   - *Manager: Initialized manager ready for use
 //   - error: If GossipSub setup fails
-func New(ctx context.Context, h host.Host) (*Manager, error) {
+func New(ctx context.Context, h host.Host, maxLabels int) (*Manager, error) {
+	if maxLabels <= 0 || maxLabels > MaxLabelsPerAnnouncement {
+		maxLabels = MaxLabelsPerAnnouncement
+	}
+
+	mesh := newMeshTracer()
+
 	// Create GossipSub with protocol-defined settings
 	ps, err := pubsub.NewGossipSub(
 		ctx,
@@ -71,6 +84,8 @@ func New(ctx context.Context, h host.Host) (*Manager, error) {
 		pubsub.WithPeerExchange(true),
 		// Limit message size to protocol-defined maximum
 		pubsub.WithMaxMessageSize(MaxMessageSize),
+		// Track the mesh peers for monitoring
+		pubsub.WithRawTracer(mesh),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gossipsub: %w", err)
@@ -94,8 +109,10 @@ func New(ctx context.Context, h host.Host) (*Manager, error) {
 		pubsub:      ps,
 		topic:       topic,
 		sub:         sub,
+		mesh:        mesh,
 		localPeerID: h.ID().String(),
 		topicName:   TopicLabels,
+		maxLabels:   maxLabels,
 	}
 
 	// Start message handler goroutine
@@ -104,6 +121,7 @@ func New(ctx context.Context, h host.Host) (*Manager, error) {
 	logger.Info("GossipSub manager initialized",
 		"topic", TopicLabels,
 		"maxMessageSize", MaxMessageSize,
+		"maxLabels", maxLabels,
 		"peerID", manager.localPeerID)
 
 	return manager, nil
@@ -115,7 +133,7 @@ func New(ctx context.Context, h host.Host) (*Manager, error) {
 //
 // Flow:
 //  1. Extract CID and labels from record
-//  2. Convert types.Label to wire format ([]string)
+//  2. Cap the labels to the per-record limit and convert them to wire format ([]string)
 //  3. Create and validate RecordPublishEvent
 //  4. Publish to GossipSub topic
 //  5. GossipSub mesh propagates to all subscribed peers
@@ -148,6 +166,18 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 		return nil
 	}
 
+	// Announce only a subset of the labels of records exceeding the limit,
+	// so a single record cannot flood the network
+	if total := len(labelList); total > m.maxLabels {
+		labelList = types.LimitLabels(labelList, m.maxLabels)
+
+		logger.Warn("Record exceeds the label announcement limit, announcing a subset of its labels",
+			"cid", cid,
+			"labels", total,
+			"announced", len(labelList),
+			"limit", m.maxLabels)
+	}
+
 	// Convert types.Label to strings for wire format
 	labelStrings := make([]string, len(labelList))
 	for i, label := range labelList {
@@ -179,6 +209,8 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 		return fmt.Errorf("failed to publish announcement: %w", err)
 	}
 
+	metrics.Default().IncGossipSubMessages(metrics.DirectionPublished)
+
 	logger.Info("Published record announcement",
 		"cid", cid,
 		"labels", len(labelList),
@@ -209,7 +241,7 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 //	manager.SetOnRecordPublishEvent(func(ctx context.Context, authenticatedPeerID string, event *RecordPublishEvent) {
 //	    for _, labelStr := range event.Labels {
 //	        label := labels.Label(labelStr)
-//	        key := BuildEnhancedLabelKey(label, event.CID, authenticatedPeerID)
+//	        key := BuildEnhancedLabelKey(normalization, label, event.CID, authenticatedPeerID)
 //	        // ... store in datastore ...
 //	    }
 //	})
@@ -254,6 +286,8 @@ func (m *Manager) handleMessages() {
 			continue
 		}
 
+		metrics.Default().IncGossipSubMessages(metrics.DirectionReceived)
+
 		// Parse and validate announcement
 		announcement, err := UnmarshalRecordPublishEvent(msg.Data)
 		if err != nil {
@@ -298,6 +332,23 @@ func (m *Manager) GetTopicPeers() []string {
 	return peerIDs
 }
 
+// GetMeshPeers returns the list of peers in the local mesh of the labels topic.
+// Unlike the topic subscribers, these are the peers that announcements are exchanged with in full,
+// the other subscribers only receiving gossip about them.
+//
+// Returns:
+//   - []string: List of peer IDs (as strings)
+func (m *Manager) GetMeshPeers() []string {
+	peers := m.mesh.peers(m.topicName)
+	peerIDs := make([]string, len(peers))
+
+	for i, p := range peers {
+		peerIDs[i] = p.String()
+	}
+
+	return peerIDs
+}
+
 // Close stops the GossipSub manager and releases resources.
 // This should be called during shutdown to clean up gracefully.
 //
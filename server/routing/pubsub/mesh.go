@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// meshTracer tracks the peers in the local GossipSub mesh of each topic,
+// i.e. the peers that full messages are exchanged with, as opposed to all the topic subscribers.
+// GossipSub does not expose its mesh, so it is rebuilt from the graft and prune events of the router.
+type meshTracer struct {
+	mu   sync.RWMutex
+	mesh map[string]map[peer.ID]struct{}
+}
+
+var _ pubsub.RawTracer = (*meshTracer)(nil)
+
+func newMeshTracer() *meshTracer {
+	return &meshTracer{
+		mesh: make(map[string]map[peer.ID]struct{}),
+	}
+}
+
+// peers returns the peers in the mesh of the topic.
+func (t *meshTracer) peers(topic string) []peer.ID {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	peers := make([]peer.ID, 0, len(t.mesh[topic]))
+	for p := range t.mesh[topic] {
+		peers = append(peers, p)
+	}
+
+	return peers
+}
+
+func (t *meshTracer) Graft(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.mesh[topic] == nil {
+		t.mesh[topic] = make(map[peer.ID]struct{})
+	}
+
+	t.mesh[topic][p] = struct{}{}
+}
+
+func (t *meshTracer) Prune(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.mesh[topic], p)
+}
+
+// RemovePeer removes a disconnected peer from all meshes, as the router does without pruning it.
+func (t *meshTracer) RemovePeer(p peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, peers := range t.mesh {
+		delete(peers, p)
+	}
+}
+
+func (t *meshTracer) Leave(topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.mesh, topic)
+}
+
+func (t *meshTracer) AddPeer(peer.ID, protocol.ID)          {}
+func (t *meshTracer) Join(string)                           {}
+func (t *meshTracer) ValidateMessage(*pubsub.Message)       {}
+func (t *meshTracer) DeliverMessage(*pubsub.Message)        {}
+func (t *meshTracer) RejectMessage(*pubsub.Message, string) {}
+func (t *meshTracer) DuplicateMessage(*pubsub.Message)      {}
+func (t *meshTracer) ThrottlePeer(peer.ID)                  {}
+func (t *meshTracer) RecvRPC(*pubsub.RPC)                   {}
+func (t *meshTracer) SendRPC(*pubsub.RPC, peer.ID)          {}
+func (t *meshTracer) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (t *meshTracer) UndeliverableMessage(*pubsub.Message)  {}
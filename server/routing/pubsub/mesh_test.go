@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshTracer(t *testing.T) {
+	const (
+		alice = peer.ID("alice")
+		bob   = peer.ID("bob")
+	)
+
+	tracer := newMeshTracer()
+	assert.Empty(t, tracer.peers(TopicLabels))
+
+	tracer.Graft(alice, TopicLabels)
+	tracer.Graft(bob, TopicLabels)
+	tracer.Graft(bob, "other-topic")
+	assert.ElementsMatch(t, []peer.ID{alice, bob}, tracer.peers(TopicLabels))
+
+	// Pruned peers leave the mesh of the topic only
+	tracer.Prune(alice, TopicLabels)
+	assert.ElementsMatch(t, []peer.ID{bob}, tracer.peers(TopicLabels))
+
+	// Disconnected peers leave all meshes
+	tracer.RemovePeer(bob)
+	assert.Empty(t, tracer.peers(TopicLabels))
+	assert.Empty(t, tracer.peers("other-topic"))
+
+	// Leaving a topic clears its mesh
+	tracer.Graft(alice, TopicLabels)
+	tracer.Leave(TopicLabels)
+	assert.Empty(t, tracer.peers(TopicLabels))
+}
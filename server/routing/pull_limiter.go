@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"time"
+)
+
+// pullLimiter bounds the number of concurrent record pulls.
+// Callers that cannot acquire a slot within the timeout give up,
+// so a burst of announcements cannot exhaust connections or memory.
+type pullLimiter struct {
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// newPullLimiter creates a limiter allowing up to maxConcurrent pulls.
+func newPullLimiter(maxConcurrent int, timeout time.Duration) *pullLimiter {
+	return &pullLimiter{
+		slots:   make(chan struct{}, maxConcurrent),
+		timeout: timeout,
+	}
+}
+
+// Acquire waits for a free pull slot.
+// It returns false if no slot became available within the timeout or the context is done.
+// A nil limiter always succeeds.
+func (l *pullLimiter) Acquire(ctx context.Context) bool {
+	if l == nil {
+		return true
+	}
+
+	timer := time.NewTimer(l.timeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Release frees a slot obtained with Acquire.
+func (l *pullLimiter) Release() {
+	if l == nil {
+		return
+	}
+
+	<-l.slots
+}
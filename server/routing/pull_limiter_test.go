@@ -0,0 +1,53 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullLimiter(t *testing.T) {
+	t.Run("acquire_within_limit", func(t *testing.T) {
+		limiter := newPullLimiter(2, 10*time.Millisecond)
+
+		assert.True(t, limiter.Acquire(t.Context()))
+		assert.True(t, limiter.Acquire(t.Context()))
+	})
+
+	t.Run("acquire_times_out_when_full", func(t *testing.T) {
+		limiter := newPullLimiter(1, 10*time.Millisecond)
+
+		assert.True(t, limiter.Acquire(t.Context()))
+		assert.False(t, limiter.Acquire(t.Context()))
+	})
+
+	t.Run("release_frees_slot", func(t *testing.T) {
+		limiter := newPullLimiter(1, 10*time.Millisecond)
+
+		assert.True(t, limiter.Acquire(t.Context()))
+		limiter.Release()
+		assert.True(t, limiter.Acquire(t.Context()))
+	})
+
+	t.Run("acquire_fails_on_cancelled_context", func(t *testing.T) {
+		limiter := newPullLimiter(1, time.Minute)
+		assert.True(t, limiter.Acquire(t.Context()))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		assert.False(t, limiter.Acquire(ctx))
+	})
+
+	t.Run("nil_limiter", func(t *testing.T) {
+		var limiter *pullLimiter
+
+		assert.True(t, limiter.Acquire(t.Context()))
+		limiter.Release()
+	})
+}
@@ -24,6 +24,7 @@ type LabelRetriever func(ctx context.Context, cid string) []types.Label
 //
 // Parameters:
 //   - ctx: Context for the operation
+//   - normalization: Normalization applied to labels and query values
 //   - cid: The CID of the record to check
 //   - queries: List of queries that must ALL match (AND relationship)
 //   - labelRetriever: Function to retrieve labels for the given CID
@@ -31,6 +32,7 @@ type LabelRetriever func(ctx context.Context, cid string) []types.Label
 // Returns true if all queries match, false otherwise.
 func MatchesAllQueries(
 	ctx context.Context,
+	normalization types.LabelNormalization,
 	cid string,
 	queries []*routingv1.RecordQuery,
 	labelRetriever LabelRetriever,
@@ -44,7 +46,7 @@ func MatchesAllQueries(
 
 	// ALL queries must match (AND relationship)
 	for _, query := range queries {
-		if !QueryMatchesLabels(query, labels) {
+		if !QueryMatchesLabels(normalization, query, labels) {
 			return false
 		}
 	}
@@ -55,9 +57,10 @@ func MatchesAllQueries(
 // QueryMatchesLabels checks if a single query matches against a list of labels.
 // This function contains the unified logic for all query types, resolving the
 // differences between local and remote implementations.
+// Both the query value and the labels are normalized with the given normalization.
 //
 //nolint:gocognit,cyclop // Complex but necessary logic for handling all query types with exact and prefix matching
-func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) bool {
+func QueryMatchesLabels(normalization types.LabelNormalization, query *routingv1.RecordQuery, labelList []types.Label) bool {
 	if query == nil {
 		return false
 	}
@@ -65,7 +68,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 	switch query.GetType() {
 	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
 		// Check if any skill label matches the query
-		targetSkill := types.LabelTypeSkill.Prefix() + query.GetValue()
+		targetSkill := types.LabelTypeSkill.Prefix() + normalization.Value(query.GetValue())
 
 		for _, label := range labelList {
 			// Type-safe filtering: only check skill labels
@@ -73,7 +76,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 				continue
 			}
 
-			labelStr := label.String()
+			labelStr := normalization.Label(label).String()
 			// Exact match: /skills/category1/class1 matches "category1/class1"
 			if labelStr == targetSkill {
 				return true
@@ -82,13 +85,17 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 			if strings.HasPrefix(labelStr, targetSkill+"/") {
 				return true
 			}
+			// Parent match (opt-in): /skills/category3 matches "category3/class3"
+			if query.GetExpandParents() && strings.HasPrefix(targetSkill, labelStr+"/") {
+				return true
+			}
 		}
 
 		return false
 
 	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
 		// Unified locator handling - use proper namespace prefix (fixing remote implementation)
-		targetLocator := types.LabelTypeLocator.Prefix() + query.GetValue()
+		targetLocator := types.LabelTypeLocator.Prefix() + normalization.Value(query.GetValue())
 
 		for _, label := range labelList {
 			// Type-safe filtering: only check locator labels
@@ -97,7 +104,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 			}
 
 			// Exact match: /locators/docker-image matches "docker-image"
-			if label.String() == targetLocator {
+			if normalization.Label(label).String() == targetLocator {
 				return true
 			}
 		}
@@ -106,7 +113,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 
 	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
 		// Check if any domain label matches the query
-		targetDomain := types.LabelTypeDomain.Prefix() + query.GetValue()
+		targetDomain := types.LabelTypeDomain.Prefix() + normalization.Value(query.GetValue())
 
 		for _, label := range labelList {
 			// Type-safe filtering: only check domain labels
@@ -114,7 +121,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 				continue
 			}
 
-			labelStr := label.String()
+			labelStr := normalization.Label(label).String()
 			// Exact match: /domains/research matches "research"
 			if labelStr == targetDomain {
 				return true
@@ -129,7 +136,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 
 	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
 		// Check if any module label matches the query
-		targetModule := types.LabelTypeModule.Prefix() + query.GetValue()
+		targetModule := types.LabelTypeModule.Prefix() + normalization.Value(query.GetValue())
 
 		for _, label := range labelList {
 			// Type-safe filtering: only check module labels
@@ -137,7 +144,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 				continue
 			}
 
-			labelStr := label.String()
+			labelStr := normalization.Label(label).String()
 			// Exact match: /modules/runtime/language matches "runtime/language"
 			if labelStr == targetModule {
 				return true
@@ -163,7 +170,7 @@ func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) b
 
 // GetMatchingQueries returns the queries that match against a specific label key.
 // This is used primarily for calculating match scores in Search operations.
-func GetMatchingQueries(labelKey string, queries []*routingv1.RecordQuery) []*routingv1.RecordQuery {
+func GetMatchingQueries(normalization types.LabelNormalization, labelKey string, queries []*routingv1.RecordQuery) []*routingv1.RecordQuery {
 	var matchingQueries []*routingv1.RecordQuery
 
 	// Extract label from the enhanced key
@@ -176,7 +183,7 @@ func GetMatchingQueries(labelKey string, queries []*routingv1.RecordQuery) []*ro
 
 	// Check which queries this label satisfies
 	for _, query := range queries {
-		if QueryMatchesLabels(query, []types.Label{label}) {
+		if QueryMatchesLabels(normalization, query, []types.Label{label}) {
 			matchingQueries = append(matchingQueries, query)
 		}
 	}
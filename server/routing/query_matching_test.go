@@ -56,6 +56,66 @@ func TestQueryMatchesLabels(t *testing.T) {
 			labels:   []types.Label{types.Label("/skills/AI/ML"), types.Label("/skills/web-development")},
 			expected: false,
 		},
+		{
+			name: "skill_expand_parents_match",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+				Value:         "AI/ML/deep-learning",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/skills/AI/ML"), types.Label("/skills/web-development")},
+			expected: true,
+		},
+		{
+			name: "skill_expand_parents_root_match",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+				Value:         "AI/ML",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/skills/AI")},
+			expected: true,
+		},
+		{
+			name: "skill_expand_parents_keeps_prefix_match",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+				Value:         "AI",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/skills/AI/ML")},
+			expected: true,
+		},
+		{
+			name: "skill_expand_parents_sibling_no_match",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+				Value:         "AI/ML",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/skills/AI/NLP"), types.Label("/skills/web-development")},
+			expected: false,
+		},
+		{
+			name: "skill_expand_parents_segment_boundary_no_match",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+				Value:         "AIR/ML",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/skills/AI")},
+			expected: false,
+		},
+		{
+			name: "skill_expand_parents_ignored_for_domains",
+			query: &routingv1.RecordQuery{
+				Type:          routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
+				Value:         "healthcare/diagnostics/radiology",
+				ExpandParents: true,
+			},
+			labels:   []types.Label{types.Label("/domains/healthcare/diagnostics")},
+			expected: false,
+		},
 
 		// Locator queries
 		{
@@ -196,12 +256,37 @@ func TestQueryMatchesLabels(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := QueryMatchesLabels(tc.query, tc.labels)
+			result := QueryMatchesLabels(types.LabelNormalization{}, tc.query, tc.labels)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
 
+func TestQueryMatchesLabelsNormalized(t *testing.T) {
+	normalization := types.LabelNormalization{Unicode: true, CaseFold: true}
+
+	skillQuery := &routingv1.RecordQuery{
+		Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+		Value: "ai", // lowercase
+	}
+
+	// Case differences match once case folding is enabled
+	assert.True(t, QueryMatchesLabels(normalization, skillQuery, []types.Label{types.Label("/skills/AI")}))
+	assert.True(t, QueryMatchesLabels(normalization, skillQuery, []types.Label{types.Label("/skills/AI/ML")}))
+	assert.False(t, QueryMatchesLabels(types.LabelNormalization{}, skillQuery, []types.Label{types.Label("/skills/AI")}))
+
+	// Composed and decomposed forms match
+	localeQuery := &routingv1.RecordQuery{
+		Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
+		Value: "caf\u00e9",
+	}
+	assert.True(t, QueryMatchesLabels(normalization, localeQuery, []types.Label{types.Label("/domains/Cafe\u0301")}))
+
+	// Keys are built from the normalized label
+	assert.Equal(t, "/skills/ai/ml/CID123/Peer1", BuildEnhancedLabelKey(normalization, types.Label("/skills/AI/ML"), "CID123", "Peer1"))
+	assert.Equal(t, "/skills/AI/ML/CID123/Peer1", BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label("/skills/AI/ML"), "CID123", "Peer1"))
+}
+
 func TestMatchesAllQueries(t *testing.T) {
 	ctx := t.Context()
 	testCID := "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
@@ -345,7 +430,7 @@ func TestMatchesAllQueries(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := MatchesAllQueries(ctx, tc.cid, tc.queries, mockLabelRetriever)
+			result := MatchesAllQueries(ctx, types.LabelNormalization{}, tc.cid, tc.queries, mockLabelRetriever)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -425,7 +510,7 @@ func TestGetMatchingQueries(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			matches := GetMatchingQueries(tc.labelKey, testQueries)
+			matches := GetMatchingQueries(types.LabelNormalization{}, tc.labelKey, testQueries)
 			assert.Len(t, matches, tc.expectedMatches)
 
 			if tc.expectedMatches > 0 {
@@ -438,7 +523,7 @@ func TestGetMatchingQueries(t *testing.T) {
 func TestQueryMatchingEdgeCases(t *testing.T) {
 	t.Run("nil_query", func(t *testing.T) {
 		// This should not panic
-		result := QueryMatchesLabels(nil, []types.Label{types.Label("/skills/AI")})
+		result := QueryMatchesLabels(types.LabelNormalization{}, nil, []types.Label{types.Label("/skills/AI")})
 		assert.False(t, result)
 	})
 
@@ -447,7 +532,7 @@ func TestQueryMatchingEdgeCases(t *testing.T) {
 			Type:  routingv1.RecordQueryType(999), // Unknown type
 			Value: "test",
 		}
-		result := QueryMatchesLabels(query, []types.Label{types.Label("/skills/AI")})
+		result := QueryMatchesLabels(types.LabelNormalization{}, query, []types.Label{types.Label("/skills/AI")})
 		assert.False(t, result)
 	})
 
@@ -456,7 +541,7 @@ func TestQueryMatchingEdgeCases(t *testing.T) {
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
 			Value: "",
 		}
-		result := QueryMatchesLabels(query, []types.Label{types.Label("/skills/")})
+		result := QueryMatchesLabels(types.LabelNormalization{}, query, []types.Label{types.Label("/skills/")})
 		assert.True(t, result) // Empty value matches "/skills/" prefix
 	})
 
@@ -465,7 +550,7 @@ func TestQueryMatchingEdgeCases(t *testing.T) {
 			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
 			Value: "AI",
 		}
-		result := QueryMatchesLabels(query, nil)
+		result := QueryMatchesLabels(types.LabelNormalization{}, query, nil)
 		assert.False(t, result)
 	})
 }
@@ -515,9 +600,9 @@ func TestQueryMatchingIntegration(t *testing.T) {
 		}
 
 		// Only mixed-record should match both queries
-		assert.True(t, MatchesAllQueries(ctx, "mixed-record", queries, complexLabelRetriever))
-		assert.False(t, MatchesAllQueries(ctx, "ai-record", queries, complexLabelRetriever))
-		assert.False(t, MatchesAllQueries(ctx, "web-record", queries, complexLabelRetriever))
+		assert.True(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "mixed-record", queries, complexLabelRetriever))
+		assert.False(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "ai-record", queries, complexLabelRetriever))
+		assert.False(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "web-record", queries, complexLabelRetriever))
 	})
 
 	t.Run("hierarchical_skill_matching", func(t *testing.T) {
@@ -529,8 +614,8 @@ func TestQueryMatchingIntegration(t *testing.T) {
 		}
 
 		// Should match records with AI/ML or more specific skills
-		assert.True(t, MatchesAllQueries(ctx, "ai-record", queries, complexLabelRetriever))
-		assert.False(t, MatchesAllQueries(ctx, "web-record", queries, complexLabelRetriever))
-		assert.False(t, MatchesAllQueries(ctx, "mixed-record", queries, complexLabelRetriever)) // Only has /skills/AI, not AI/ML
+		assert.True(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "ai-record", queries, complexLabelRetriever))
+		assert.False(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "web-record", queries, complexLabelRetriever))
+		assert.False(t, MatchesAllQueries(ctx, types.LabelNormalization{}, "mixed-record", queries, complexLabelRetriever)) // Only has /skills/AI, not AI/ML
 	})
 }
@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/datastore"
 	"github.com/agntcy/dir/server/types"
@@ -38,15 +39,29 @@ func (r *route) hasPeersInRoutingTable() bool {
 }
 
 func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (types.RoutingAPI, error) {
+	// Register custom label namespaces before validators and queries are set up
+	for _, namespace := range opts.Config().Routing.LabelNamespaces {
+		if _, err := types.RegisterLabelType(namespace); err != nil {
+			return nil, fmt.Errorf("failed to register label namespace: %w", err)
+		}
+	}
+
 	// Create main router
 	mainRounter := &route{}
 
 	// Create routing datastore
-	var dsOpts []datastore.Option
+	dsOpts := []datastore.Option{
+		datastore.WithBackend(datastore.Backend(opts.Config().Routing.DatastoreBackend)),
+	}
 	if dstoreDir := opts.Config().Routing.DatastoreDir; dstoreDir != "" {
 		dsOpts = append(dsOpts, datastore.WithFsProvider(dstoreDir))
 	}
 
+	// The namespace is applied by the datastore, so key builders, parsers and queries use unprefixed keys
+	if namespace := opts.Config().Routing.DatastoreNamespace; namespace != "" {
+		dsOpts = append(dsOpts, datastore.WithNamespace(namespace))
+	}
+
 	dstore, err := datastore.New(dsOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create routing datastore: %w", err)
@@ -62,11 +77,20 @@ func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (type
 	localPeerID := mainRounter.remote.server.Host().ID().String()
 
 	// Create local router with peer ID
-	mainRounter.local = newLocal(store, dstore, localPeerID)
+	mainRounter.local = newLocal(store, dstore, localPeerID, labelNormalization(opts))
 
 	return mainRounter, nil
 }
 
+// labelNormalization returns the label normalization configured for routing.
+// Local and remote routing must use the same one, so that keys and query matching agree.
+func labelNormalization(opts types.APIOptions) types.LabelNormalization {
+	return types.LabelNormalization{
+		Unicode:  opts.Config().Routing.LabelNormalization.Unicode,
+		CaseFold: opts.Config().Routing.LabelNormalization.CaseFold,
+	}
+}
+
 func (r *route) Publish(ctx context.Context, record types.Record) error {
 	// Always publish data locally for archival/querying
 	err := r.local.Publish(ctx, record)
@@ -101,6 +125,32 @@ func (r *route) Search(ctx context.Context, req *routingv1.SearchRequest) (<-cha
 	return r.remote.Search(ctx, req)
 }
 
+func (r *route) ListPeers(ctx context.Context, req *routingv1.ListPeersRequest) (*routingv1.ListPeersResponse, error) {
+	// ListPeers inspects the local peerstore, DHT routing table and GossipSub topic
+	// This operation does not interact with the network
+	return r.remote.ListPeers(ctx, req)
+}
+
+func (r *route) ListProvided(ctx context.Context, req *routingv1.ListProvidedRequest) (*routingv1.ListProvidedResponse, error) {
+	// ListProvided reports the CIDs announced by this node without querying the DHT
+	return r.remote.ListProvided(ctx, req)
+}
+
+func (r *route) PullFromPeer(ctx context.Context, req *routingv1.PullFromPeerRequest) (*corev1.Record, error) {
+	// Direct pull does not require DHT discovery, only a reachable peer address
+	return r.remote.PullFromPeer(ctx, req)
+}
+
+func (r *route) PullFromNetwork(ctx context.Context, req *routingv1.PullFromNetworkRequest) (*corev1.Record, error) {
+	// Providers are discovered via DHT and the record is pulled over the peer-to-peer RPC
+	return r.remote.PullFromNetwork(ctx, req)
+}
+
+// SetIndexFunc sets the function indexing records pulled from the network.
+func (r *route) SetIndexFunc(fn types.IndexFunc) {
+	r.remote.setIndexFunc(fn)
+}
+
 func (r *route) Unpublish(ctx context.Context, record types.Record) error {
 	err := r.local.Unpublish(ctx, record)
 	if err != nil {
@@ -109,11 +159,20 @@ func (r *route) Unpublish(ctx context.Context, record types.Record) error {
 		return status.Errorf(st.Code(), "failed to unpublish locally: %s", st.Message())
 	}
 
+	// Stop reporting the record as provided, its DHT provider record expires
+	// since it is no longer republished
+	r.remote.provided.Remove(record.GetCid())
+
 	// no need to explicitly handle unpublishing from the network
 	// TODO clarify if network sync trigger is needed here
 	return nil
 }
 
+// UpdateBootstrapPeers replaces the bootstrap peers of the running routing services.
+func (r *route) UpdateBootstrapPeers(ctx context.Context, addrs []string) error {
+	return r.remote.UpdateBootstrapPeers(ctx, addrs)
+}
+
 // Stop stops the routing services and releases resources.
 // This should be called during server shutdown to clean up gracefully.
 func (r *route) Stop() error {
@@ -5,7 +5,6 @@ package routing
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/agntcy/dir/utils/metrics"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	"google.golang.org/grpc/codes"
@@ -23,16 +23,18 @@ var localLogger = logging.Logger("routing/local")
 
 // operations performed locally.
 type routeLocal struct {
-	store       types.StoreAPI
-	dstore      types.Datastore
-	localPeerID string // Cached local peer ID for efficient filtering
+	store         types.StoreAPI
+	dstore        types.Datastore
+	localPeerID   string                   // Cached local peer ID for efficient filtering
+	normalization types.LabelNormalization // Applied to label keys and query matching
 }
 
-func newLocal(store types.StoreAPI, dstore types.Datastore, localPeerID string) *routeLocal {
+func newLocal(store types.StoreAPI, dstore types.Datastore, localPeerID string, normalization types.LabelNormalization) *routeLocal {
 	return &routeLocal{
-		store:       store,
-		dstore:      dstore,
-		localPeerID: localPeerID,
+		store:         store,
+		dstore:        dstore,
+		localPeerID:   localPeerID,
+		normalization: normalization,
 	}
 }
 
@@ -91,13 +93,13 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 		}
 
 		// Serialize metadata to JSON
-		metadataBytes, err := json.Marshal(metadata)
+		metadataBytes, err := metadata.Marshal()
 		if err != nil {
 			return status.Errorf(codes.Internal, "failed to serialize label metadata: %v", err)
 		}
 
 		// Store with enhanced self-descriptive key: /skills/AI/CID123/Peer1
-		enhancedKey := BuildEnhancedLabelKey(label, cid, r.localPeerID)
+		enhancedKey := BuildEnhancedLabelKey(r.normalization, label, cid, r.localPeerID)
 
 		labelKey := datastore.NewKey(enhancedKey)
 		if err := batch.Put(ctx, labelKey, metadataBytes); err != nil {
@@ -143,7 +145,10 @@ func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest) (<-ch
 	go func() {
 		defer close(outCh)
 
-		r.listLocalRecords(ctx, deduplicatedQueries, req.GetLimit(), outCh)
+		start := time.Now()
+		count := r.listLocalRecords(ctx, deduplicatedQueries, req.GetLimit(), outCh)
+
+		metrics.Default().ObserveSearch("local", count, time.Since(start))
 	}()
 
 	return outCh, nil
@@ -151,7 +156,8 @@ func (r *routeLocal) List(ctx context.Context, req *routingv1.ListRequest) (<-ch
 
 // listLocalRecords lists all local records with optional query filtering.
 // Uses the simple and efficient approach: start with /records/ index, then filter by queries.
-func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, outCh chan<- *routingv1.ListResponse) {
+// It returns the number of records sent to outCh.
+func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, outCh chan<- *routingv1.ListResponse) int {
 	processedCount := 0
 	limitInt := int(limit)
 
@@ -162,7 +168,7 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 	if err != nil {
 		localLogger.Error("Failed to query local records", "error", err)
 
-		return
+		return 0
 	}
 	defer recordResults.Close()
 
@@ -205,13 +211,15 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 	}
 
 	localLogger.Debug("Completed List operation", "processed", processedCount, "queries", len(queries))
+
+	return processedCount
 }
 
 // matchesAllQueries checks if a record matches ALL provided queries (AND relationship).
 // Uses shared query matching logic with local label retrieval strategy.
 func (r *routeLocal) matchesAllQueries(ctx context.Context, cid string, queries []*routingv1.RecordQuery) bool {
 	// Inject local label retrieval strategy into shared query matching logic
-	return MatchesAllQueries(ctx, cid, queries, r.getRecordLabelsEfficiently)
+	return MatchesAllQueries(ctx, r.normalization, cid, queries, r.getRecordLabelsEfficiently)
 }
 
 // getRecordLabelsEfficiently gets labels for a record by extracting them from datastore keys.
@@ -259,6 +267,22 @@ func (r *routeLocal) Unpublish(ctx context.Context, record types.Record) error {
 
 	localLogger.Debug("Called local routing's Unpublish method", "cid", cid)
 
+	recordKey := datastore.NewKey("/records/" + cid)
+
+	// on expiry, skip records that were never published to avoid updating their metrics
+	if types.UnpublishPublishedOnlyFromContext(ctx) {
+		recordExists, err := r.dstore.Has(ctx, recordKey)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check if record exists: %v", err)
+		}
+
+		if !recordExists {
+			localLogger.Debug("Skipping unpublish as record is not published", "cid", cid)
+
+			return nil
+		}
+	}
+
 	// load metrics for the client
 	metrics, err := loadMetrics(ctx, r.dstore)
 	if err != nil {
@@ -270,8 +294,7 @@ func (r *routeLocal) Unpublish(ctx context.Context, record types.Record) error {
 		return status.Errorf(codes.Internal, "failed to create batch: %v", err)
 	}
 
-	// get record key and remove record
-	recordKey := datastore.NewKey("/records/" + cid)
+	// remove record
 	if err := batch.Delete(ctx, recordKey); err != nil {
 		return status.Errorf(codes.Internal, "failed to delete record key: %v", err)
 	}
@@ -281,7 +304,7 @@ func (r *routeLocal) Unpublish(ctx context.Context, record types.Record) error {
 
 	for _, label := range labelList {
 		// Delete enhanced key with CID and PeerID
-		enhancedKey := BuildEnhancedLabelKey(label, cid, r.localPeerID)
+		enhancedKey := BuildEnhancedLabelKey(r.normalization, label, cid, r.localPeerID)
 
 		labelKey := datastore.NewKey(enhancedKey)
 		if err := batch.Delete(ctx, labelKey); err != nil {
@@ -77,6 +77,15 @@ func (m *mockStore) Lookup(_ context.Context, ref *corev1.RecordRef) (*corev1.Re
 	return nil, errors.New("test object not found")
 }
 
+func (m *mockStore) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	metas := make([]*corev1.RecordMeta, len(refs))
+	for i, ref := range refs {
+		metas[i], _ = m.Lookup(ctx, ref)
+	}
+
+	return metas, nil
+}
+
 func (m *mockStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
 	if record, exists := m.data[ref.GetCid()]; exists {
 		return record, nil
@@ -203,6 +212,10 @@ func TestPublishList_ValidSingleSkillQuery(t *testing.T) {
 	err = r.Unpublish(t.Context(), adapterUnpub)
 	assert.NoError(t, err)
 
+	// Unpublishing a record that is not published is a no-op on expiry
+	err = r.Unpublish(types.WithUnpublishPublishedOnly(t.Context()), adapterUnpub)
+	assert.NoError(t, err)
+
 	// Try to list second record using RecordQuery
 	refsChan, err := r.List(t.Context(), &routingv1.ListRequest{
 		Queries: []*routingv1.RecordQuery{
@@ -325,8 +338,8 @@ func Benchmark_RouteLocal(b *testing.B) {
 	inMemoryDatastore := newInMemoryDatastore(b)
 	localLogger = slog.New(slog.DiscardHandler)
 
-	badgerRouter := newLocal(store, badgerDatastore, testPeerID)
-	inMemoryRouter := newLocal(store, inMemoryDatastore, testPeerID)
+	badgerRouter := newLocal(store, badgerDatastore, testPeerID, types.LabelNormalization{})
+	inMemoryRouter := newLocal(store, inMemoryDatastore, testPeerID, types.LabelNormalization{})
 
 	record := corev1.New(&typesv1alpha0.Record{
 		Name:          "bench-agent",
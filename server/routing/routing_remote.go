@@ -6,19 +6,22 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
 	"github.com/agntcy/dir/server/routing/pubsub"
 	"github.com/agntcy/dir/server/routing/rpc"
 	validators "github.com/agntcy/dir/server/routing/validators"
+	"github.com/agntcy/dir/server/tracing"
 	"github.com/agntcy/dir/server/types"
-	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/agntcy/dir/utils/metrics"
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
@@ -29,6 +32,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	ma "github.com/multiformats/go-multiaddr"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -43,54 +47,92 @@ type NamespaceEntry struct {
 	Value     []byte
 }
 
+// maxConcurrentNamespaceQueries bounds the number of namespaces scanned in parallel.
+const maxConcurrentNamespaceQueries = 4
+
 // QueryAllNamespaces queries all supported label namespaces and returns processed entries.
 // This centralizes namespace iteration and datastore querying, eliminating code duplication
 // between local and remote routing operations. All resource management is handled internally.
+//
+// Namespaces are scanned in parallel. Entries are returned grouped by namespace,
+// in the order of types.AllLabelTypes, regardless of which scan finishes first.
+// A namespace that fails to be queried is logged and skipped.
 func QueryAllNamespaces(ctx context.Context, dstore types.Datastore) ([]NamespaceEntry, error) {
+	labelTypes := types.AllLabelTypes()
+	namespaceEntries := make([][]NamespaceEntry, len(labelTypes))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxConcurrentNamespaceQueries)
+
+	// Query all label namespaces, including registered custom ones
+	for i, labelType := range labelTypes {
+		group.Go(func() error {
+			namespace := labelType.Prefix()
+
+			// Check for context cancellation
+			select {
+			case <-groupCtx.Done():
+				return fmt.Errorf("namespace query canceled: %w", groupCtx.Err())
+			default:
+			}
+
+			namespaceEntries[i] = queryNamespace(groupCtx, dstore, namespace)
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
 	var entries []NamespaceEntry
+	for _, nsEntries := range namespaceEntries {
+		entries = append(entries, nsEntries...)
+	}
+
+	return entries, nil
+}
+
+// queryNamespace scans a single namespace.
+// Query errors are logged and result in no entries.
+func queryNamespace(ctx context.Context, dstore types.Datastore, namespace string) []NamespaceEntry {
+	metrics.Default().IncDatastoreQueries(namespace)
+
+	_, span := tracing.Start(ctx, "routing.QueryNamespace", tracing.AttrNamespace.String(namespace))
 
-	// Query all label namespaces
-	namespaces := []string{
-		types.LabelTypeSkill.Prefix(),
-		types.LabelTypeDomain.Prefix(),
-		types.LabelTypeModule.Prefix(),
-		types.LabelTypeLocator.Prefix(),
+	results, err := dstore.Query(ctx, query.Query{Prefix: namespace})
+	if err != nil {
+		remoteLogger.Warn("Failed to query namespace", "namespace", namespace, "error", err)
+		tracing.End(span, err)
+
+		return nil
 	}
+	defer results.Close()
 
-	for _, namespace := range namespaces {
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("namespace query canceled: %w", ctx.Err())
-		default:
-		}
+	var entries []NamespaceEntry
 
-		results, err := dstore.Query(ctx, query.Query{Prefix: namespace})
-		if err != nil {
-			remoteLogger.Warn("Failed to query namespace", "namespace", namespace, "error", err)
+	for result := range results.Next() {
+		// Stop scanning a slow datastore once the caller gave up
+		if ctx.Err() != nil {
+			break
+		}
 
+		if result.Error != nil {
 			continue
 		}
 
-		// Process results and handle cleanup
-		func() {
-			defer results.Close()
-
-			for result := range results.Next() {
-				if result.Error != nil {
-					continue
-				}
-
-				entries = append(entries, NamespaceEntry{
-					Namespace: namespace,
-					Key:       result.Key,
-					Value:     result.Value,
-				})
-			}
-		}()
+		entries = append(entries, NamespaceEntry{
+			Namespace: namespace,
+			Key:       result.Key,
+			Value:     result.Value,
+		})
 	}
 
-	return entries, nil
+	span.SetAttributes(tracing.AttrResults.Int(len(entries)))
+	tracing.End(span, nil)
+
+	return entries
 }
 
 // routeRemote handles routing across the network with hybrid label discovery.
@@ -104,6 +146,42 @@ type routeRemote struct {
 	cleanupManager *CleanupManager
 	pubsubManager  *pubsub.Manager // GossipSub manager for label announcements (nil if disabled)
 
+	// Allow/deny lists for remote peers
+	peerFilter *peerFilter
+
+	// Validation and per-peer rate limiting of GossipSub announcements
+	announcementGuard *announcementGuard
+
+	// How long cached peer addresses remain valid without re-announcement
+	peerAddrsTTL time.Duration
+
+	// Bounds concurrent record pulls in the DHT+Pull fallback
+	pullLimiter *pullLimiter
+
+	// Bound the provider notifications handled and the announcements verified concurrently
+	notifySlots chan struct{}
+	verifySlots chan struct{}
+
+	// Maximum time spent resolving the labels of a single record in a search
+	labelResolutionTimeout time.Duration
+
+	// Normalization applied to label keys and query matching
+	normalization types.LabelNormalization
+
+	// Notifies search watchers about newly cached remote records
+	announcements *announcementBroker
+
+	// CIDs announced to the DHT by this node
+	provided *providedSet
+
+	// Adds records pulled from the network to the search index
+	indexMu sync.RWMutex
+	index   types.IndexFunc
+
+	// Announcement verification settings
+	verifyAnnouncements bool
+	verifySampleRate    float64
+
 	// Lifecycle management
 	//nolint:containedctx // Context needed for managing lifecycle of multiple long-running goroutines (handleNotify, cleanup tasks)
 	ctx    context.Context    // Routing subsystem context
@@ -116,16 +194,56 @@ func newRemote(parentCtx context.Context,
 	dstore types.Datastore,
 	opts types.APIOptions,
 ) (*routeRemote, error) {
+	// Validate republishing schedule against record TTL
+	republishInterval := opts.Config().Routing.RepublishInterval
+	republishJitter := opts.Config().Routing.RepublishJitter
+
+	if err := ValidateRepublishSchedule(republishInterval, republishJitter); err != nil {
+		return nil, fmt.Errorf("invalid republish configuration: %w", err)
+	}
+
+	peerAddrsTTL := MaxPeerAddrsAge
+	if opts.Config().Routing.PeerAddressTTL > 0 {
+		peerAddrsTTL = opts.Config().Routing.PeerAddressTTL
+	}
+
+	labelMaxAges, err := ParseLabelMaxAges(opts.Config().Routing.LabelMaxAges)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label max ages: %w", err)
+	}
+
+	maxConcurrentPulls := routingconfig.DefaultMaxConcurrentPulls
+	if opts.Config().Routing.MaxConcurrentPulls > 0 {
+		maxConcurrentPulls = opts.Config().Routing.MaxConcurrentPulls
+	}
+
+	labelResolutionTimeout := routingconfig.DefaultLabelResolutionTimeout
+	if opts.Config().Routing.LabelResolutionTimeout > 0 {
+		labelResolutionTimeout = opts.Config().Routing.LabelResolutionTimeout
+	}
+
 	// Create routing subsystem context for lifecycle management of background tasks
 	routingCtx, cancel := context.WithCancel(parentCtx)
 
 	// Create routing
 	routeAPI := &routeRemote{
-		storeAPI: storeAPI,
-		notifyCh: make(chan *handlerSync, NotificationChannelSize),
-		dstore:   dstore,
-		ctx:      routingCtx,
-		cancel:   cancel,
+		storeAPI:               storeAPI,
+		notifyCh:               make(chan *handlerSync, NotificationChannelSize),
+		dstore:                 dstore,
+		peerFilter:             newPeerFilter(opts.Config().Routing.AllowedPeers, opts.Config().Routing.DeniedPeers),
+		announcementGuard:      newAnnouncementGuard(opts.Config().Routing.AnnouncementLimits),
+		peerAddrsTTL:           peerAddrsTTL,
+		pullLimiter:            newPullLimiter(maxConcurrentPulls, PullSlotTimeout),
+		notifySlots:            make(chan struct{}, MaxConcurrentNotifications),
+		verifySlots:            make(chan struct{}, MaxConcurrentVerifications),
+		labelResolutionTimeout: labelResolutionTimeout,
+		normalization:          labelNormalization(opts),
+		announcements:          newAnnouncementBroker(),
+		provided:               newProvidedSet(),
+		verifyAnnouncements:    opts.Config().Routing.VerifyAnnouncements.Enabled,
+		verifySampleRate:       opts.Config().Routing.VerifyAnnouncements.SampleRate,
+		ctx:                    routingCtx,
+		cancel:                 cancel,
 	}
 
 	refreshInterval := RefreshInterval
@@ -133,13 +251,25 @@ func newRemote(parentCtx context.Context,
 		refreshInterval = opts.Config().Routing.RefreshInterval
 	}
 
+	rendezvous := routingconfig.DefaultRendezvous
+	if opts.Config().Routing.Rendezvous != "" {
+		rendezvous = opts.Config().Routing.Rendezvous
+	}
+
+	protocolPrefix := routingconfig.DefaultProtocolPrefix
+	if opts.Config().Routing.ProtocolPrefix != "" {
+		protocolPrefix = opts.Config().Routing.ProtocolPrefix
+	}
+
+	remoteLogger.Info("Joining routing network", "rendezvous", rendezvous, "protocol_prefix", protocolPrefix)
+
 	// Use parent context for p2p server (should live as long as the server)
 	server, err := p2p.New(parentCtx,
 		p2p.WithListenAddress(opts.Config().Routing.ListenAddress),
 		p2p.WithDirectoryAPIAddress(opts.Config().Routing.DirectoryAPIAddress),
 		p2p.WithBootstrapAddrs(opts.Config().Routing.BootstrapPeers),
 		p2p.WithRefreshInterval(refreshInterval),
-		p2p.WithRandevous(ProtocolRendezvous), // enable libp2p auto-discovery
+		p2p.WithRandevous(rendezvous), // enable libp2p auto-discovery
 		p2p.WithIdentityKeyPath(opts.Config().Routing.KeyPath),
 		p2p.WithCustomDHTOpts(
 			func(h host.Host) ([]dht.Option, error) {
@@ -148,16 +278,11 @@ func newRemote(parentCtx context.Context,
 					return nil, fmt.Errorf("failed to create provider manager: %w", err)
 				}
 
-				labelValidators := validators.CreateLabelValidators()
-				validator := record.NamespacedValidator{
-					types.LabelTypeSkill.String():  labelValidators[types.LabelTypeSkill.String()],
-					types.LabelTypeDomain.String(): labelValidators[types.LabelTypeDomain.String()],
-					types.LabelTypeModule.String(): labelValidators[types.LabelTypeModule.String()],
-				}
+				validator := record.NamespacedValidator(validators.CreateLabelValidators())
 
 				return []dht.Option{
 					dht.Datastore(dstore),                           // custom DHT datastore
-					dht.ProtocolPrefix(protocol.ID(ProtocolPrefix)), // custom DHT protocol prefix
+					dht.ProtocolPrefix(protocol.ID(protocolPrefix)), // custom DHT protocol prefix
 					dht.Validator(validator),                        // custom validators for label namespaces
 					dht.MaxRecordAge(RecordTTL),                     // set consistent TTL for all DHT records
 					dht.Mode(dht.ModeServer),
@@ -190,7 +315,7 @@ func newRemote(parentCtx context.Context,
 	// and are NOT configurable to ensure network-wide compatibility
 	if opts.Config().Routing.GossipSub.Enabled {
 		// Use parent context for GossipSub (should live as long as the server)
-		pubsubManager, err := pubsub.New(parentCtx, server.Host())
+		pubsubManager, err := pubsub.New(parentCtx, server.Host(), opts.Config().Routing.MaxLabelsPerRecord)
 		if err != nil {
 			defer server.Close()
 
@@ -212,7 +337,14 @@ func newRemote(parentCtx context.Context,
 
 	// Pass Publish as callback to avoid circular dependency
 	// The method value captures routeAPI's state (server, pubsubManager)
-	routeAPI.cleanupManager = NewCleanupManager(dstore, storeAPI, server, routeAPI.Publish)
+	routeAPI.cleanupManager = NewCleanupManager(dstore, storeAPI, server, routeAPI.Publish,
+		WithRepublishInterval(republishInterval),
+		WithRepublishJitter(republishJitter),
+		WithRepublishRate(opts.Config().Routing.RepublishRate),
+		WithPeerAddrsTTL(peerAddrsTTL),
+		WithLabelMaxAges(labelMaxAges),
+		WithProvidedSet(routeAPI.provided),
+	)
 
 	// Start all background goroutines with routing context
 	routeAPI.wg.Add(1)
@@ -266,11 +398,17 @@ func (r *routeRemote) Publish(ctx context.Context, record types.Record) error {
 	}
 
 	// 1. Announce CID to DHT network (content discovery)
-	err = r.server.DHT().Provide(ctx, decodedCID, true)
+	provideCtx, span := tracing.Start(ctx, "routing.Provide", tracing.AttrCID.String(cidStr))
+	err = r.server.DHT().Provide(provideCtx, decodedCID, true)
+
+	tracing.End(span, err)
+
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to announce CID to DHT: %v", err)
 	}
 
+	r.provided.Provided(cidStr, time.Now())
+
 	// 2. Publish record via GossipSub (if enabled)
 	// This provides efficient label propagation to ALL subscribed peers
 	if r.pubsubManager != nil {
@@ -318,12 +456,45 @@ func (r *routeRemote) Search(ctx context.Context, req *routingv1.SearchRequest)
 		remoteLogger.Debug("Applied minimum match score for production safety", "original", req.GetMinMatchScore(), "applied", minMatchScore)
 	}
 
+	limit := req.GetLimit()
+
+	// Reject malformed peer IDs instead of silently returning no results
+	if peerID := req.GetPeerId(); peerID != "" {
+		if _, err := peer.Decode(peerID); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid peer ID %q: %v", peerID, err)
+		}
+	}
+
 	outCh := make(chan *routingv1.SearchResponse)
 
+	// Subscribe before the initial search so that no announcement is missed in between
+	var announcements <-chan cachedAnnouncement
+
+	unsubscribe := func() {}
+	if req.GetWatch() {
+		announcements, unsubscribe = r.announcements.Subscribe()
+	}
+
 	go func() {
 		defer close(outCh)
+		defer unsubscribe()
+
+		ctx, span := tracing.Start(ctx, "routing.Search", tracing.AttrQueries.Int(len(deduplicatedQueries)))
+		defer span.End()
 
-		r.searchRemoteRecords(ctx, deduplicatedQueries, req.GetLimit(), minMatchScore, outCh)
+		start := time.Now()
+		maxAge := time.Duration(req.GetMaxAgeSeconds()) * time.Second
+		sent := make(map[string]bool)
+		count := r.searchRemoteRecords(ctx, deduplicatedQueries, limit, minMatchScore, maxAge, req.GetRank(), req.GetPeerId(), sent, outCh)
+
+		metrics.Default().ObserveSearch("remote", count, time.Since(start))
+		span.SetAttributes(tracing.AttrResults.Int(count))
+
+		// In watch mode, keep streaming newly announced records until the client goes away.
+		// The limit only applies to the initial results.
+		if announcements != nil {
+			r.watchRemoteRecords(ctx, announcements, deduplicatedQueries, minMatchScore, maxAge, req.GetPeerId(), sent, outCh)
+		}
 	}()
 
 	return outCh, nil
@@ -331,26 +502,56 @@ func (r *routeRemote) Search(ctx context.Context, req *routingv1.SearchRequest)
 
 // searchRemoteRecords searches for remote records using cached labels with OR logic.
 // Records are returned if they match at least minMatchScore queries.
+// If maxAge is positive, labels not seen within maxAge are ignored.
+// If peerID is set, only records announced by that peer are considered.
+// The CIDs sent to outCh are recorded in sent.
+// The search stops early when ctx is done, keeping the records already sent.
+// Records whose labels cannot be resolved within the label resolution timeout are skipped.
+// If rank is set, matching records are buffered and sent best first once all are scored, see rankSearchResults.
+// It returns the number of records sent to outCh.
 //
-//nolint:gocognit // Core search algorithm requires complex logic for namespace iteration, filtering, and scoring
-func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, minMatchScore uint32, outCh chan<- *routingv1.SearchResponse) {
+//nolint:gocognit,cyclop // Core search algorithm requires complex logic for namespace iteration, filtering, and scoring
+func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, minMatchScore uint32, maxAge time.Duration, rank bool, peerID string, sent map[string]bool, outCh chan<- *routingv1.SearchResponse) int {
+	ctx, span := tracing.Start(ctx, "routing.searchRemoteRecords")
+	defer span.End()
+
 	localPeerID := r.server.Host().ID().String()
-	processedCIDs := make(map[string]bool) // Avoid duplicates
+	processedCIDs := sent // Avoid duplicates
 	processedCount := 0
 	limitInt := int(limit)
 
-	remoteLogger.Debug("Starting remote search with OR logic and minimum threshold", "queries", len(queries), "minMatchScore", minMatchScore, "localPeerID", localPeerID)
+	remoteLogger.Debug("Starting remote search with OR logic and minimum threshold", "queries", len(queries), "minMatchScore", minMatchScore, "localPeerID", localPeerID, "peerID", peerID)
 
 	// Query all namespaces to find remote records
 	entries, err := QueryAllNamespaces(ctx, r.dstore)
 	if err != nil {
 		remoteLogger.Error("Failed to get namespace entries for search", "error", err)
+		tracing.SetError(span, err)
 
-		return
+		return 0
+	}
+
+	entries = filterEntriesByPeer(entries, peerID)
+
+	// Ranking needs the last time each record was seen, and all candidates before sending any
+	var (
+		lastSeen      map[string]time.Time
+		candidates    []rankedResult
+		candidateCIDs = make(map[string]bool)
+	)
+
+	if rank {
+		lastSeen = remoteRecordsLastSeen(entries)
 	}
 
 	for _, entry := range entries {
-		if limitInt > 0 && processedCount >= limitInt {
+		if !rank && limitInt > 0 && processedCount >= limitInt {
+			break
+		}
+
+		if ctx.Err() != nil {
+			remoteLogger.Warn("Remote search interrupted, returning partial results", "results", processedCount, "error", ctx.Err())
+
 			break
 		}
 
@@ -366,25 +567,60 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 			continue // Skip local records
 		}
 
+		// Exclude records from peers rejected by the allow/deny lists
+		if !r.peerFilter.IsAllowed(keyPeerID) {
+			continue
+		}
+
 		// Avoid duplicate CIDs (same record might have multiple matching labels)
-		if processedCIDs[keyCID] {
+		if processedCIDs[keyCID] || candidateCIDs[keyCID] {
 			continue
 		}
 
 		// Calculate match score using OR logic (how many queries match this record)
-		matchQueries, score := r.calculateMatchScore(ctx, keyCID, queries, keyPeerID)
+		matchQueries, score, resolved := r.calculateMatchScoreWithTimeout(ctx, keyCID, queries, keyPeerID, maxAge)
+		if !resolved {
+			remoteLogger.Warn("Timed out resolving labels of remote record, skipping", "cid", keyCID, "peer", keyPeerID, "timeout", r.labelResolutionTimeout)
+
+			continue
+		}
 
 		remoteLogger.Debug("Calculated match score for remote record", "cid", keyCID, "score", score, "minMatchScore", minMatchScore, "matchingQueries", len(matchQueries))
 
+		// Buffer matching records for ranking, their peer info is only resolved for the records sent
+		if rank && score >= minMatchScore {
+			candidateCIDs[keyCID] = true
+
+			candidates = append(candidates, rankedResult{
+				cid:          keyCID,
+				peerID:       keyPeerID,
+				matchQueries: matchQueries,
+				score:        score,
+				lastSeen:     lastSeen[keyCID+"/"+keyPeerID],
+			})
+
+			continue
+		}
+
 		// Apply minimum match score filter (record included if score ≥ threshold)
 		if score >= minMatchScore {
 			peer := r.createPeerInfo(ctx, keyPeerID)
+			if peer == nil {
+				continue
+			}
 
-			outCh <- &routingv1.SearchResponse{
+			select {
+			case outCh <- &routingv1.SearchResponse{
 				RecordRef:    &corev1.RecordRef{Cid: keyCID},
 				Peer:         peer,
 				MatchQueries: matchQueries,
 				MatchScore:   score,
+			}:
+			case <-ctx.Done():
+				remoteLogger.Warn("Remote search interrupted, returning partial results", "results", processedCount, "error", ctx.Err())
+				span.SetAttributes(tracing.AttrResults.Int(processedCount))
+
+				return processedCount
 			}
 
 			processedCIDs[keyCID] = true
@@ -400,17 +636,48 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 		}
 	}
 
+	if rank {
+		processedCount = r.sendRankedResults(ctx, candidates, limitInt, sent, outCh)
+	}
+
 	remoteLogger.Debug("Completed Search operation", "processed", processedCount, "queries", len(queries))
+	span.SetAttributes(tracing.AttrResults.Int(processedCount))
+
+	return processedCount
+}
+
+// calculateMatchScoreWithTimeout calculates the match score like calculateMatchScore,
+// bounding the label resolution by the label resolution timeout.
+// It reports false if the labels could not be resolved in time.
+func (r *routeRemote) calculateMatchScoreWithTimeout(ctx context.Context, cid string, queries []*routingv1.RecordQuery, peerID string, maxAge time.Duration) ([]*routingv1.RecordQuery, uint32, bool) {
+	if r.labelResolutionTimeout <= 0 {
+		matchQueries, score := r.calculateMatchScore(ctx, cid, queries, peerID, maxAge)
+
+		return matchQueries, score, true
+	}
+
+	labelCtx, cancel := context.WithTimeout(ctx, r.labelResolutionTimeout)
+	defer cancel()
+
+	matchQueries, score := r.calculateMatchScore(labelCtx, cid, queries, peerID, maxAge)
+
+	// Only the per-record timeout counts here, the end of the whole search is handled by the caller
+	if errors.Is(labelCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, 0, false
+	}
+
+	return matchQueries, score, true
 }
 
 // calculateMatchScore calculates how many queries match a remote record (OR logic).
+// If maxAge is positive, only labels seen within maxAge are considered.
 // Returns the matching queries and the match score for minimum threshold filtering.
-func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queries []*routingv1.RecordQuery, peerID string) ([]*routingv1.RecordQuery, uint32) {
+func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queries []*routingv1.RecordQuery, peerID string, maxAge time.Duration) ([]*routingv1.RecordQuery, uint32) {
 	if len(queries) == 0 {
 		return nil, 0
 	}
 
-	labels := r.getRemoteRecordLabels(ctx, cid, peerID)
+	labels := r.getRemoteRecordLabels(ctx, cid, peerID, maxAge)
 	if len(labels) == 0 {
 		return nil, 0
 	}
@@ -419,7 +686,7 @@ func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queri
 
 	// Check each query against all labels - any match counts toward the score (OR logic)
 	for _, query := range queries {
-		if QueryMatchesLabels(query, labels) {
+		if QueryMatchesLabels(r.normalization, query, labels) {
 			matchingQueries = append(matchingQueries, query)
 		}
 	}
@@ -432,7 +699,8 @@ func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queri
 }
 
 // getRemoteRecordLabels gets labels for a remote record by finding all enhanced keys for this CID/PeerID.
-func (r *routeRemote) getRemoteRecordLabels(ctx context.Context, cid, peerID string) []types.Label {
+// If maxAge is positive, labels that were not seen within maxAge are skipped.
+func (r *routeRemote) getRemoteRecordLabels(ctx context.Context, cid, peerID string, maxAge time.Duration) []types.Label {
 	var labelList []types.Label
 
 	entries, err := QueryAllNamespaces(ctx, r.dstore)
@@ -448,16 +716,38 @@ func (r *routeRemote) getRemoteRecordLabels(ctx context.Context, cid, peerID str
 			continue
 		}
 
-		if keyCID == cid && keyPeerID == peerID {
-			labelList = append(labelList, label)
+		if keyCID != cid || keyPeerID != peerID {
+			continue
+		}
+
+		if maxAge > 0 && isStaleLabelEntry(entry.Value, maxAge) {
+			continue
 		}
+
+		labelList = append(labelList, label)
 	}
 
 	return labelList
 }
 
+// isStaleLabelEntry reports whether the label metadata was last seen longer than maxAge ago.
+// Entries with unreadable metadata are treated as stale.
+func isStaleLabelEntry(value []byte, maxAge time.Duration) bool {
+	metadata, _, err := types.UnmarshalLabelMetadata(value)
+	if err != nil {
+		return true
+	}
+
+	return metadata.IsStale(maxAge)
+}
+
 // createPeerInfo creates a Peer message from a PeerID string.
+// Returns nil for peers rejected by the allow/deny lists.
 func (r *routeRemote) createPeerInfo(ctx context.Context, peerID string) *routingv1.Peer {
+	if !r.peerFilter.IsAllowed(peerID) {
+		return nil
+	}
+
 	dirAPIAddr := r.getDirectoryAPIAddress(ctx, peerID)
 
 	return &routingv1.Peer{
@@ -504,28 +794,34 @@ func (r *routeRemote) getDirectoryAPIAddress(ctx context.Context, peerID string)
 }
 
 // getDirectoryAPIAddressFromDatastore checks datastore cache for peer addresses.
+// Expired entries are ignored.
 func (r *routeRemote) getDirectoryAPIAddressFromDatastore(ctx context.Context, peerID string) string {
-	key := datastore.NewKey("peer_addrs/" + peerID)
-
-	addresses, err := r.dstore.Get(ctx, key)
+	value, err := r.dstore.Get(ctx, peerAddrsKey(peerID))
 	if err != nil {
 		remoteLogger.Debug("No cached peer addresses in datastore", "peerID", peerID)
 
 		return ""
 	}
 
-	var multiaddrs []ma.Multiaddr
-	if err := json.Unmarshal(addresses, &multiaddrs); err != nil {
-		remoteLogger.Error("Failed to unmarshal peer addresses", "error", err)
+	entry, err := decodePeerAddrsEntry(value)
+	if err != nil {
+		remoteLogger.Error("Failed to decode peer addresses", "peerID", peerID, "error", err)
+
+		return ""
+	}
+
+	if entry.IsExpired(r.peerAddrsTTL) {
+		remoteLogger.Debug("Cached peer addresses expired", "peerID", peerID, "lastSeen", entry.LastSeen)
 
 		return ""
 	}
 
-	return extractDirProtocol(multiaddrs, peerID)
+	return extractDirProtocol(entry.Addrs, peerID)
 }
 
 // storePeerAddresses stores peer addresses in datastore for later retrieval.
 // Tries DHT notification addresses first, falls back to peerstore if empty.
+// Re-announcements refresh the lastSeen timestamp of the cached entry.
 func (r *routeRemote) storePeerAddresses(ctx context.Context, peerIDStr string, peerID peer.ID, notifAddrs []ma.Multiaddr, cid string) {
 	// Try DHT notification addresses first
 	peerAddrs := notifAddrs
@@ -545,21 +841,18 @@ func (r *routeRemote) storePeerAddresses(ctx context.Context, peerIDStr string,
 		return
 	}
 
-	// Check if already stored
-	key := datastore.NewKey("peer_addrs/" + peerIDStr)
-	if _, err := r.dstore.Get(ctx, key); err == nil {
-		return // Already have addresses
-	}
-
-	// Marshal and store
-	addresses, err := json.Marshal(peerAddrs)
+	// Marshal and store with a fresh timestamp
+	addresses, err := json.Marshal(&peerAddrsEntry{
+		Addrs:    peerAddrs,
+		LastSeen: time.Now(),
+	})
 	if err != nil {
 		remoteLogger.Error("Failed to marshal peer addresses", "error", err)
 
 		return
 	}
 
-	if err := r.dstore.Put(ctx, key, addresses); err != nil {
+	if err := r.dstore.Put(ctx, peerAddrsKey(peerIDStr), addresses); err != nil {
 		remoteLogger.Error("Failed to store peer addresses", "error", err)
 
 		return
@@ -609,8 +902,25 @@ func (r *routeRemote) handleNotify() {
 			return
 		case notif := <-r.notifyCh:
 			// All announcements are now CID provider announcements
-			// Labels are discovered via pull-based mechanism
-			r.handleCIDProviderNotification(r.ctx, notif)
+			// Labels are discovered via pull-based mechanism.
+			// Notifications are handled concurrently up to MaxConcurrentNotifications,
+			// and fallback pulls are further bounded by the pull limiter.
+			select {
+			case r.notifySlots <- struct{}{}:
+			case <-r.ctx.Done():
+				cleanupLogger.Debug("DHT provider notification handler stopped")
+
+				return
+			}
+
+			r.wg.Add(1)
+
+			go func() {
+				defer r.wg.Done()
+				defer func() { <-r.notifySlots }()
+
+				r.handleCIDProviderNotification(r.ctx, notif)
+			}()
 		}
 	}
 }
@@ -677,12 +987,23 @@ func (r *routeRemote) startMeshPeerTagging() {
 func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *handlerSync) {
 	peerIDStr := notif.Peer.ID.String()
 
+	ctx, span := tracing.Start(ctx, "routing.handleCIDProviderNotification",
+		tracing.AttrCID.String(notif.Ref.GetCid()),
+		tracing.AttrPeerID.String(peerIDStr))
+	defer span.End()
+
 	if peerIDStr == r.server.Host().ID().String() {
 		remoteLogger.Debug("Ignoring self announcement", "cid", notif.Ref.GetCid())
 
 		return
 	}
 
+	if !r.peerFilter.IsAllowed(peerIDStr) {
+		remoteLogger.Debug("Ignoring announcement from filtered peer", "cid", notif.Ref.GetCid(), "peer", peerIDStr)
+
+		return
+	}
+
 	// Store peer addresses for later use
 	r.storePeerAddresses(ctx, peerIDStr, notif.Peer.ID, notif.Peer.Addrs, notif.Ref.GetCid())
 
@@ -711,8 +1032,25 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 		"peer", peerIDStr,
 		"reason", "gossipsub_not_received")
 
-	record, err := r.service.Pull(ctx, notif.Peer.ID, notif.Ref)
+	if !r.pullLimiter.Acquire(ctx) {
+		remoteLogger.Warn("Dropping provider notification, too many concurrent pulls",
+			"cid", notif.Ref.GetCid(),
+			"peer", peerIDStr,
+			"fallback", "next announcement will retry")
+
+		return
+	}
+
+	pullCtx, pullSpan := tracing.Start(ctx, "routing.PullFallback",
+		tracing.AttrCID.String(notif.Ref.GetCid()),
+		tracing.AttrPeerID.String(peerIDStr))
+
+	labelList, err := r.service.PullLabels(pullCtx, notif.Peer.ID, notif.Ref)
+	tracing.End(pullSpan, err)
+	r.pullLimiter.Release()
+
 	if err != nil {
+		tracing.SetError(span, err)
 		remoteLogger.Error("Failed to pull remote content for label caching",
 			"cid", notif.Ref.GetCid(),
 			"peer", peerIDStr,
@@ -721,9 +1059,6 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 		return
 	}
 
-	adapter := adapters.NewRecordAdapter(record)
-
-	labelList := types.GetLabelsFromRecord(adapter)
 	if len(labelList) == 0 {
 		remoteLogger.Warn("No labels found in remote record",
 			"cid", notif.Ref.GetCid(),
@@ -736,14 +1071,14 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 	cachedCount := 0
 
 	for _, label := range labelList {
-		enhancedKey := BuildEnhancedLabelKey(label, notif.Ref.GetCid(), peerIDStr)
+		enhancedKey := BuildEnhancedLabelKey(r.normalization, label, notif.Ref.GetCid(), peerIDStr)
 
 		metadata := &types.LabelMetadata{
 			Timestamp: now,
 			LastSeen:  now,
 		}
 
-		metadataBytes, err := json.Marshal(metadata)
+		metadataBytes, err := metadata.Marshal()
 		if err != nil {
 			remoteLogger.Warn("Failed to marshal label metadata",
 				"enhanced_key", enhancedKey,
@@ -762,12 +1097,18 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 		}
 	}
 
+	if cachedCount > 0 {
+		r.announcements.Publish(cachedAnnouncement{CID: notif.Ref.GetCid(), PeerID: peerIDStr})
+	}
+
 	remoteLogger.Info("Successfully cached labels via DHT+Pull fallback",
 		"cid", notif.Ref.GetCid(),
 		"peer", peerIDStr,
 		"totalLabels", len(labelList),
 		"cached", cachedCount,
 		"source", "pull_fallback")
+
+	span.SetAttributes(tracing.AttrResults.Int(cachedCount))
 }
 
 // hasRemoteRecordCached checks if we already have cached labels for this remote record.
@@ -806,13 +1147,17 @@ func (r *routeRemote) hasRemoteRecordCached(ctx context.Context, cid, peerID str
 //
 // Flow:
 //  1. Skip own announcements (already cached locally)
-//  2. Convert []string labels to types.Label
-//  3. Build enhanced keys: /skills/AI/CID/PeerID
-//  4. Store types.LabelMetadata in datastore
+//  2. Reject malformed announcements and peers exceeding the announcement rate
+//  3. Optionally verify a sample of announcements against the record content
+//  4. Convert []string labels to types.Label
+//  5. Build enhanced keys: /skills/AI/CID/PeerID
+//  6. Store types.LabelMetadata in datastore
 //
 // Security:
 //   - Uses authenticatedPeerID from libp2p transport (cannot be spoofed)
 //   - Prevents malicious peers from poisoning the label cache
+//   - When Routing.VerifyAnnouncements is enabled, sampled announcements are only
+//     cached if the announced labels match the labels derived from the pulled record
 //
 // This completely avoids pulling the entire record from remote peers,
 // providing ~95% bandwidth savings and ~5-20ms propagation time.
@@ -822,6 +1167,64 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 		return
 	}
 
+	// Skip announcements from peers rejected by the allow/deny lists
+	if !r.peerFilter.IsAllowed(authenticatedPeerID) {
+		remoteLogger.Debug("Ignoring GossipSub announcement from filtered peer", "cid", event.CID, "peer", authenticatedPeerID)
+
+		return
+	}
+
+	// Reject malformed announcements and spamming peers before touching the datastore
+	if err := r.announcementGuard.Check(authenticatedPeerID, event, time.Now()); err != nil {
+		remoteLogger.Debug("Rejecting GossipSub announcement",
+			"cid", event.CID,
+			"peer", authenticatedPeerID,
+			"error", err)
+
+		return
+	}
+
+	// Verify sampled announcements in the background to avoid blocking the message handler.
+	// Labels are only cached once the announcement has been confirmed.
+	if r.shouldVerifyAnnouncement() {
+		// Drop the announcement rather than queueing unbounded verifications,
+		// the record is announced again when it is republished
+		select {
+		case r.verifySlots <- struct{}{}:
+		default:
+			remoteLogger.Warn("Dropping GossipSub announcement, too many concurrent verifications",
+				"cid", event.CID,
+				"peer", authenticatedPeerID)
+
+			return
+		}
+
+		r.wg.Add(1)
+
+		go func() {
+			defer r.wg.Done()
+			defer func() { <-r.verifySlots }()
+
+			if err := r.verifyAnnouncement(r.ctx, authenticatedPeerID, event); err != nil {
+				remoteLogger.Warn("Rejecting GossipSub announcement that failed verification",
+					"cid", event.CID,
+					"peer", authenticatedPeerID,
+					"error", err)
+
+				return
+			}
+
+			r.cacheAnnouncedLabels(r.ctx, authenticatedPeerID, event)
+		}()
+
+		return
+	}
+
+	r.cacheAnnouncedLabels(ctx, authenticatedPeerID, event)
+}
+
+// cacheAnnouncedLabels stores the labels of a GossipSub announcement in the datastore.
+func (r *routeRemote) cacheAnnouncedLabels(ctx context.Context, authenticatedPeerID string, event *pubsub.RecordPublishEvent) {
 	remoteLogger.Info("Caching labels from GossipSub announcement",
 		"cid", event.CID,
 		"peer", authenticatedPeerID,
@@ -835,7 +1238,7 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 		label := types.Label(labelStr)
 
 		// Use authenticated peer ID (cryptographically verified by libp2p)
-		enhancedKey := BuildEnhancedLabelKey(label, event.CID, authenticatedPeerID)
+		enhancedKey := BuildEnhancedLabelKey(r.normalization, label, event.CID, authenticatedPeerID)
 
 		// Use existing types.LabelMetadata structure
 		metadata := &types.LabelMetadata{
@@ -843,7 +1246,7 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 			LastSeen:  now,             // When we received it
 		}
 
-		metadataBytes, err := json.Marshal(metadata)
+		metadataBytes, err := metadata.Marshal()
 		if err != nil {
 			remoteLogger.Warn("Failed to marshal label metadata",
 				"key", enhancedKey,
@@ -862,6 +1265,10 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 		}
 	}
 
+	if cachedCount > 0 {
+		r.announcements.Publish(cachedAnnouncement{CID: event.CID, PeerID: authenticatedPeerID})
+	}
+
 	remoteLogger.Info("Successfully cached labels from GossipSub",
 		"cid", event.CID,
 		"peer", authenticatedPeerID,
@@ -871,16 +1278,17 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 
 // updateLabelMetadataTimestamp updates the lastSeen timestamp for a single cached label entry.
 func (r *routeRemote) updateLabelMetadataTimestamp(ctx context.Context, key string, value []byte, timestamp time.Time) error {
-	var metadata types.LabelMetadata
-	if err := json.Unmarshal(value, &metadata); err != nil {
-		return fmt.Errorf("failed to unmarshal label metadata: %w", err)
+	// Entries in an older format are upgraded when written back
+	metadata, _, err := types.UnmarshalLabelMetadata(value)
+	if err != nil {
+		return err //nolint:wrapcheck
 	}
 
 	metadata.LastSeen = timestamp
 
-	metadataBytes, err := json.Marshal(metadata)
+	metadataBytes, err := metadata.Marshal()
 	if err != nil {
-		return fmt.Errorf("failed to marshal label metadata: %w", err)
+		return err //nolint:wrapcheck
 	}
 
 	err = r.dstore.Put(ctx, datastore.NewKey(key), metadataBytes)
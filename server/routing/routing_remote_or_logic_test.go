@@ -4,13 +4,19 @@
 package routing
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/routing/pubsub"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
 	ipfsdatastore "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,7 +47,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 
 	// Store enhanced label announcements in datastore (simulating DHT cache)
 	for _, label := range skillLabels {
-		enhancedKey := BuildEnhancedLabelKey(types.Label(label), testCID, testPeerID)
+		enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(label), testCID, testPeerID)
 		metadata := &types.LabelMetadata{
 			Timestamp: time.Now(),
 			LastSeen:  time.Now(),
@@ -62,7 +68,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		}
 
 		// Test calculateMatchScore directly (avoids server dependency)
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should have 2 matching queries out of 3
 		assert.Len(t, matchQueries, 2, "Should have 2 matching queries")
@@ -82,7 +88,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		}
 
 		// Test calculateMatchScore
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should have 1 matching query
 		assert.Len(t, matchQueries, 1, "Should have 1 matching query")
@@ -97,7 +103,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		}
 
 		// Test calculateMatchScore
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should have 2 matching queries out of 2
 		assert.Len(t, matchQueries, 2, "Should have 2 matching queries")
@@ -111,7 +117,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		}
 
 		// Test calculateMatchScore
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should have 0 matching queries
 		assert.Empty(t, matchQueries, "Should have 0 matching queries")
@@ -123,7 +129,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		var queries []*routingv1.RecordQuery
 
 		// Test calculateMatchScore
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should have 0 matching queries and 0 score
 		assert.Empty(t, matchQueries, "Should have 0 matching queries with empty query list")
@@ -137,7 +143,7 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 		}
 
 		// Test calculateMatchScore
-		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID)
+		matchQueries, score := r.calculateMatchScore(ctx, testCID, queries, testPeerID, 0)
 
 		// Should match at least 1 query (hierarchical matching)
 		assert.GreaterOrEqual(t, len(matchQueries), 1, "Should have at least 1 matching query with hierarchical matching")
@@ -145,6 +151,61 @@ func TestRemoteSearch_ORLogicWithMinMatchScore(t *testing.T) {
 	})
 }
 
+func TestRemoteSearch_MaxAge(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore: dstore,
+	}
+
+	testPeerID := "remote-peer-test"
+	freshCID := "fresh-record-cid"
+	staleCID := "stale-record-cid"
+
+	// Store a recently refreshed and an old announcement of the same skill
+	for cid, lastSeen := range map[string]time.Time{
+		freshCID: time.Now(),
+		staleCID: time.Now().Add(-2 * time.Hour),
+	} {
+		enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label("/skills/AI/ML"), cid, testPeerID)
+		metadataBytes, err := json.Marshal(&types.LabelMetadata{
+			Timestamp: lastSeen,
+			LastSeen:  lastSeen,
+		})
+		require.NoError(t, err)
+
+		err = dstore.Put(ctx, ipfsdatastore.NewKey(enhancedKey), metadataBytes)
+		require.NoError(t, err)
+	}
+
+	queries := []*routingv1.RecordQuery{
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+	}
+
+	testCases := []struct {
+		name          string
+		cid           string
+		maxAge        time.Duration
+		expectedScore uint32
+	}{
+		{name: "fresh record without max age", cid: freshCID, maxAge: 0, expectedScore: 1},
+		{name: "stale record without max age", cid: staleCID, maxAge: 0, expectedScore: 1},
+		{name: "fresh record within max age", cid: freshCID, maxAge: time.Hour, expectedScore: 1},
+		{name: "stale record outside max age", cid: staleCID, maxAge: time.Hour, expectedScore: 0},
+		{name: "stale record within larger max age", cid: staleCID, maxAge: 3 * time.Hour, expectedScore: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, score := r.calculateMatchScore(ctx, tc.cid, queries, testPeerID, tc.maxAge)
+			assert.Equal(t, tc.expectedScore, score)
+		})
+	}
+}
+
 // setupTestDatastore creates a test datastore for routing tests.
 func setupTestDatastore(t *testing.T) (types.Datastore, func()) {
 	t.Helper()
@@ -160,3 +221,101 @@ func setupTestDatastore(t *testing.T) (types.Datastore, func()) {
 
 	return dstore, cleanup
 }
+
+func TestRemoteSearch_LabelResolutionTimeout(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	testPeerID := "remote-peer-test"
+	testCID := "test-record-cid"
+
+	enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label("/skills/AI/ML"), testCID, testPeerID)
+	metadataBytes, err := json.Marshal(&types.LabelMetadata{
+		Timestamp: time.Now(),
+		LastSeen:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	err = dstore.Put(ctx, ipfsdatastore.NewKey(enhancedKey), metadataBytes)
+	require.NoError(t, err)
+
+	queries := []*routingv1.RecordQuery{
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+	}
+
+	t.Run("labels resolved in time", func(t *testing.T) {
+		r := &routeRemote{dstore: dstore, labelResolutionTimeout: time.Minute}
+
+		_, score, resolved := r.calculateMatchScoreWithTimeout(ctx, testCID, queries, testPeerID, 0)
+		assert.True(t, resolved)
+		assert.Equal(t, uint32(1), score)
+	})
+
+	t.Run("label resolution timed out", func(t *testing.T) {
+		r := &routeRemote{dstore: dstore, labelResolutionTimeout: time.Nanosecond}
+
+		_, score, resolved := r.calculateMatchScoreWithTimeout(ctx, testCID, queries, testPeerID, 0)
+		assert.False(t, resolved)
+		assert.Equal(t, uint32(0), score)
+	})
+
+	t.Run("search canceled", func(t *testing.T) {
+		r := &routeRemote{dstore: dstore, labelResolutionTimeout: time.Minute}
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		// The end of the whole search is not a per-record timeout
+		_, score, resolved := r.calculateMatchScoreWithTimeout(canceledCtx, testCID, queries, testPeerID, 0)
+		assert.True(t, resolved)
+		assert.Equal(t, uint32(0), score)
+	})
+}
+
+func TestRemoteSearch_CappedAnnouncement(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{dstore: dstore, announcements: newAnnouncementBroker()}
+
+	// A record with far more skills than can be announced
+	skills := make([]*typesv1alpha0.Skill, 0, 500)
+	for i := range 500 {
+		skills = append(skills, &typesv1alpha0.Skill{CategoryName: toPtr("category"), ClassName: toPtr(fmt.Sprintf("class%d", i))})
+	}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:     "label-explosion-agent",
+		Skills:   skills,
+		Locators: []*typesv1alpha0.Locator{{Type: "docker-image", Url: "url"}},
+	})
+
+	labels := types.LimitLabels(types.GetLabelsFromRecord(adapters.NewRecordAdapter(record)), pubsub.MaxLabelsPerAnnouncement)
+	require.Len(t, labels, pubsub.MaxLabelsPerAnnouncement)
+
+	event := &pubsub.RecordPublishEvent{CID: record.GetCid(), Timestamp: time.Now()}
+	for _, label := range labels {
+		event.Labels = append(event.Labels, label.String())
+	}
+
+	require.NoError(t, event.Validate())
+
+	r.cacheAnnouncedLabels(ctx, "remote-peer-test", event)
+
+	score := func(queryType routingv1.RecordQueryType, value string) uint32 {
+		_, score := r.calculateMatchScore(ctx, record.GetCid(), []*routingv1.RecordQuery{{Type: queryType, Value: value}}, "remote-peer-test", 0)
+
+		return score
+	}
+
+	// Announced labels are found, including the locator beyond the first hundred labels
+	assert.Equal(t, uint32(1), score(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "category/class0"))
+	assert.Equal(t, uint32(1), score(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, "docker-image"))
+
+	// Labels left out of the announcement are not
+	assert.Equal(t, uint32(0), score(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "category/class499"))
+}
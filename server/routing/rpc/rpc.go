@@ -6,14 +6,17 @@ package rpc
 
 import (
 	"context"
+	"strings"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	rpc "github.com/libp2p/go-libp2p-gorpc"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -28,6 +31,14 @@ const (
 	DirServiceFuncLookup = "Lookup"
 	DirServiceFuncPull   = "Pull"
 	MaxPullSize          = 4 * 1024 * 1024 // 4 MB
+
+	// DirServiceFuncPullLabels is only served over ProtocolPullLabels.
+	DirServiceFuncPullLabels = "PullLabels"
+
+	// ProtocolPullLabels is the protocol version that adds PullLabels.
+	// Peers running older versions only serve Protocol, so support for
+	// PullLabels is detected from the protocols a peer advertises.
+	ProtocolPullLabels = protocol.ID("/dir/rpc/1.1.0")
 )
 
 type RPCAPI struct {
@@ -40,6 +51,11 @@ type PullResponse struct {
 	Data        []byte
 }
 
+type PullLabelsResponse struct {
+	Cid    string
+	Labels []string
+}
+
 type LookupResponse struct {
 	Cid         string
 	Annotations map[string]string
@@ -112,23 +128,59 @@ func (r *RPCAPI) Pull(ctx context.Context, in *corev1.RecordRef, out *PullRespon
 	return nil
 }
 
+// PullLabels computes the labels of a record on the serving peer,
+// so that only the labels are transferred instead of the full record.
+func (r *RPCAPI) PullLabels(ctx context.Context, in *corev1.RecordRef, out *PullLabelsResponse) error {
+	logger.Debug("P2p RPC: Executing PullLabels request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	record, err := r.service.store.Pull(ctx, in)
+	if err != nil {
+		st := status.Convert(err)
+
+		return status.Errorf(st.Code(), "failed to pull: %s", st.Message())
+	}
+
+	labels := types.GetLabelsFromRecord(adapters.NewRecordAdapter(record))
+
+	labelStrings := make([]string, len(labels))
+	for i, label := range labels {
+		labelStrings[i] = label.String()
+	}
+
+	// set output
+	*out = PullLabelsResponse{
+		Cid:    in.GetCid(),
+		Labels: labelStrings,
+	}
+
+	return nil
+}
+
 // NOTE: List RPC method removed since List is a local-only operation
 
 type Service struct {
-	rpcServer *rpc.Server
-	rpcClient *rpc.Client
-	host      host.Host
-	store     types.StoreAPI
+	rpcServer    *rpc.Server
+	rpcClient    *rpc.Client
+	labelsServer *rpc.Server
+	labelsClient *rpc.Client
+	host         host.Host
+	store        types.StoreAPI
 }
 
 func New(host host.Host, store types.StoreAPI) (*Service, error) {
 	service := &Service{
-		rpcServer: rpc.NewServer(host, Protocol),
-		host:      host,
-		store:     store,
+		rpcServer:    rpc.NewServer(host, Protocol),
+		labelsServer: rpc.NewServer(host, ProtocolPullLabels),
+		host:         host,
+		store:        store,
 	}
 
-	// register api
+	// register api on all protocol versions
 	rpcAPI := RPCAPI{service: service}
 
 	err := service.rpcServer.Register(&rpcAPI)
@@ -136,8 +188,14 @@ func New(host host.Host, store types.StoreAPI) (*Service, error) {
 		return nil, err //nolint:wrapcheck
 	}
 
-	// update client
+	err = service.labelsServer.Register(&rpcAPI)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// update clients
 	service.rpcClient = rpc.NewClientWithServer(host, Protocol, service.rpcServer)
+	service.labelsClient = rpc.NewClientWithServer(host, ProtocolPullLabels, service.labelsServer)
 
 	return service, nil
 }
@@ -149,7 +207,7 @@ func (s *Service) Lookup(ctx context.Context, peer peer.ID, req *corev1.RecordRe
 
 	err := s.rpcClient.CallContext(ctx, peer, DirService, DirServiceFuncLookup, req, &resp)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+		return nil, remoteCallError(err)
 	}
 
 	return &corev1.RecordRef{
@@ -164,7 +222,7 @@ func (s *Service) Pull(ctx context.Context, peer peer.ID, req *corev1.RecordRef)
 
 	err := s.rpcClient.CallContext(ctx, peer, DirService, DirServiceFuncPull, req, &resp)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+		return nil, remoteCallError(err)
 	}
 
 	record, err := corev1.UnmarshalRecord(resp.Data)
@@ -175,5 +233,125 @@ func (s *Service) Pull(ctx context.Context, peer peer.ID, req *corev1.RecordRef)
 	return record, nil
 }
 
+// PullLabels pulls only the labels of a record from a peer.
+// For peers that do not serve PullLabels, the full record is pulled
+// and the labels are extracted locally.
+func (s *Service) PullLabels(ctx context.Context, peer peer.ID, req *corev1.RecordRef) ([]types.Label, error) {
+	logger.Debug("P2p RPC: Executing PullLabels request on remote peer", "peer", peer, "req", req)
+
+	if !s.supportsProtocol(peer, ProtocolPullLabels) {
+		logger.Debug("Peer does not support pulling labels, pulling full record", "peer", peer, "cid", req.GetCid())
+
+		record, err := s.Pull(ctx, peer, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !record.HasCid(req.GetCid()) {
+			return nil, status.Errorf(codes.DataLoss, "peer %s returned record with CID %s, expected %s", peer, record.GetCid(), req.GetCid())
+		}
+
+		return types.GetLabelsFromRecord(adapters.NewRecordAdapter(record)), nil
+	}
+
+	var resp PullLabelsResponse
+
+	err := s.labelsClient.CallContext(ctx, peer, DirService, DirServiceFuncPullLabels, req, &resp)
+	if err != nil {
+		return nil, remoteCallError(err)
+	}
+
+	if resp.Cid != req.GetCid() {
+		return nil, status.Errorf(codes.DataLoss, "peer %s returned labels for CID %s, expected %s", peer, resp.Cid, req.GetCid())
+	}
+
+	labels := make([]types.Label, 0, len(resp.Labels))
+
+	for _, labelStr := range resp.Labels {
+		label := types.Label(labelStr)
+		if label.Type() == types.LabelTypeUnknown {
+			logger.Debug("Skipping label of unknown type", "peer", peer, "label", labelStr)
+
+			continue
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// remoteCallError converts the error of a call to a remote peer to a gRPC status error.
+// Only the message of the errors returned by the remote handlers is sent back,
+// so their status code is recovered from it. Other errors are reported as Internal.
+func remoteCallError(err error) error {
+	if msg, ok := strings.CutPrefix(err.Error(), "rpc error: code = "); ok && !rpc.IsRPCError(err) {
+		name, desc, _ := strings.Cut(msg, " desc = ")
+
+		for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+			if code.String() == name {
+				return status.Errorf(code, "remote peer: %s", desc)
+			}
+		}
+	}
+
+	return status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+}
+
+// supportsProtocol reports whether the peer advertises the given protocol.
+// Protocols are learned when connecting to the peer; unknown peers are assumed not to support it.
+func (s *Service) supportsProtocol(peer peer.ID, proto protocol.ID) bool {
+	protocols, err := s.host.Peerstore().SupportsProtocols(peer, proto)
+
+	return err == nil && len(protocols) > 0
+}
+
+// PullFromAddr dials the peer at the given multiaddr and pulls the record directly,
+// bypassing DHT discovery. The multiaddr must include the peer ID (/p2p/<id>).
+func (s *Service) PullFromAddr(ctx context.Context, addr ma.Multiaddr, req *corev1.RecordRef) (*corev1.Record, error) {
+	logger.Debug("P2p RPC: Executing direct Pull request", "addr", addr, "req", req)
+
+	if req.GetCid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "record reference must have a CID") //nolint:wrapcheck
+	}
+
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "multiaddr must include a peer ID (e.g. /ip4/1.2.3.4/tcp/8999/p2p/<peer-id>): %v", err)
+	}
+
+	if info.ID == s.host.ID() {
+		return nil, status.Error(codes.InvalidArgument, "multiaddr points to the local peer") //nolint:wrapcheck
+	}
+
+	if err := s.host.Connect(ctx, *info); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to peer %s: %v", info.ID, err)
+	}
+
+	// Check that the remote peer has the record before pulling it
+	if _, err := s.Lookup(ctx, info.ID, req); err != nil {
+		st := status.Convert(err)
+		if st.Code() == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "record %s not found on peer %s: %s", req.GetCid(), info.ID, st.Message())
+		}
+
+		return nil, status.Errorf(st.Code(), "failed to lookup record %s on peer %s: %s", req.GetCid(), info.ID, st.Message())
+	}
+
+	record, err := s.Pull(ctx, info.ID, req)
+	if err != nil {
+		st := status.Convert(err)
+
+		return nil, status.Errorf(st.Code(), "failed to pull from peer %s: %s", info.ID, st.Message())
+	}
+
+	// Validate that the received record matches the requested CID
+	if !record.HasCid(req.GetCid()) {
+		return nil, status.Errorf(codes.DataLoss, "peer %s returned record with CID %s, expected %s", info.ID, record.GetCid(), req.GetCid())
+	}
+
+	return record, nil
+}
+
 // NOTE: List RPC client method removed since List is a local-only operation
 // Use Search for network-wide record discovery instead
@@ -0,0 +1,220 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	rpc "github.com/libp2p/go-libp2p-gorpc"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testStore serves a fixed set of records.
+type testStore struct {
+	types.StoreAPI
+	records map[string]*corev1.Record
+}
+
+func (s *testStore) Lookup(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if _, ok := s.records[ref.GetCid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func (s *testStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	record, ok := s.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+// failingStore fails every lookup with the given error.
+type failingStore struct {
+	types.StoreAPI
+	err error
+}
+
+func (s *failingStore) Lookup(context.Context, *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	return nil, s.err
+}
+
+// newLegacyService creates a service that only serves the original protocol,
+// as peers running versions without PullLabels do.
+func newLegacyService(t *testing.T, h host.Host, store types.StoreAPI) *Service {
+	t.Helper()
+
+	service := &Service{
+		rpcServer: rpc.NewServer(h, Protocol),
+		host:      h,
+		store:     store,
+	}
+	require.NoError(t, service.rpcServer.Register(&RPCAPI{service: service}))
+
+	service.rpcClient = rpc.NewClientWithServer(h, Protocol, service.rpcServer)
+
+	return service
+}
+
+func TestPullLabels(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		Description:   "Test <agent>",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	// A record stored in another canonical form, under a CID other than the default one
+	jcsRef := &corev1.RecordRef{Cid: record.GetCanonicalCid(corev1.CanonicalModeJCS)}
+	require.NotEqual(t, ref.GetCid(), jcsRef.GetCid())
+
+	store := &testStore{records: map[string]*corev1.Record{ref.GetCid(): record, jcsRef.GetCid(): record}}
+
+	wantLabels := types.GetLabelsFromRecord(adapters.NewRecordAdapter(record))
+	require.NotEmpty(t, wantLabels)
+
+	network := mocknet.New()
+	t.Cleanup(func() { _ = network.Close() })
+
+	newTestHost := func(t *testing.T) host.Host {
+		t.Helper()
+
+		h, err := network.GenPeer()
+		require.NoError(t, err)
+		require.NoError(t, network.LinkAll())
+
+		return h
+	}
+
+	localHost := newTestHost(t)
+	local, err := New(localHost, &testStore{})
+	require.NoError(t, err)
+
+	connect := func(t *testing.T, remote host.Host) {
+		t.Helper()
+
+		require.NoError(t, localHost.Connect(t.Context(), peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+	}
+
+	t.Run("peer serving PullLabels", func(t *testing.T) {
+		remoteHost := newTestHost(t)
+		_, err := New(remoteHost, store)
+		require.NoError(t, err)
+
+		connect(t, remoteHost)
+
+		// Wait for the supported protocols to be exchanged
+		require.Eventually(t, func() bool {
+			return local.supportsProtocol(remoteHost.ID(), ProtocolPullLabels)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		labels, err := local.PullLabels(t.Context(), remoteHost.ID(), ref)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantLabels, labels)
+
+		labels, err = local.PullLabels(t.Context(), remoteHost.ID(), jcsRef)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantLabels, labels)
+
+		_, err = local.PullLabels(t.Context(), remoteHost.ID(), &corev1.RecordRef{Cid: "missing"})
+		require.Error(t, err)
+	})
+
+	t.Run("older peer falls back to Pull", func(t *testing.T) {
+		remoteHost := newTestHost(t)
+		newLegacyService(t, remoteHost, store)
+
+		connect(t, remoteHost)
+
+		// Wait for the supported protocols to be exchanged
+		require.Eventually(t, func() bool {
+			return local.supportsProtocol(remoteHost.ID(), Protocol)
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.False(t, local.supportsProtocol(remoteHost.ID(), ProtocolPullLabels))
+
+		labels, err := local.PullLabels(t.Context(), remoteHost.ID(), ref)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantLabels, labels)
+
+		labels, err = local.PullLabels(t.Context(), remoteHost.ID(), jcsRef)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantLabels, labels)
+
+		_, err = local.PullLabels(t.Context(), remoteHost.ID(), &corev1.RecordRef{Cid: "missing"})
+		require.Error(t, err)
+	})
+}
+
+func TestPullFromAddr(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{Name: "test-agent", SchemaVersion: "v0.3.1"})
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	network := mocknet.New()
+	t.Cleanup(func() { _ = network.Close() })
+
+	newRemote := func(t *testing.T, store types.StoreAPI) ma.Multiaddr {
+		t.Helper()
+
+		h, err := network.GenPeer()
+		require.NoError(t, err)
+		require.NoError(t, network.LinkAll())
+
+		_, err = New(h, store)
+		require.NoError(t, err)
+
+		addrs, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
+		require.NoError(t, err)
+
+		return addrs[0]
+	}
+
+	localHost, err := network.GenPeer()
+	require.NoError(t, err)
+
+	local, err := New(localHost, &testStore{})
+	require.NoError(t, err)
+
+	serving := newRemote(t, &testStore{records: map[string]*corev1.Record{ref.GetCid(): record}})
+
+	t.Run("pulls the record", func(t *testing.T) {
+		got, err := local.PullFromAddr(t.Context(), serving, ref)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), got.GetCid())
+	})
+
+	t.Run("record missing on the peer", func(t *testing.T) {
+		_, err := local.PullFromAddr(t.Context(), serving, &corev1.RecordRef{Cid: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("lookup failure keeps its status", func(t *testing.T) {
+		failing := newRemote(t, &failingStore{err: status.Error(codes.Unavailable, "store unavailable")})
+
+		_, err := local.PullFromAddr(t.Context(), failing, ref)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
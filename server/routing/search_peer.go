@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+// filterEntriesByPeer returns the entries of labels announced by the given peer.
+// If peerID is empty, all entries are returned. Entries with unreadable keys are dropped.
+func filterEntriesByPeer(entries []NamespaceEntry, peerID string) []NamespaceEntry {
+	if peerID == "" {
+		return entries
+	}
+
+	filtered := make([]NamespaceEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		_, _, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != peerID {
+			continue
+		}
+
+		filtered = append(filtered, entry)
+	}
+
+	return filtered
+}
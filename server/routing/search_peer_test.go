@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterEntriesByPeer(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupSearchTestDatastore(t)
+	defer cleanup()
+
+	// The same record is cached from two peers, alongside records only one of them announced
+	labels := []struct {
+		label  string
+		cid    string
+		peerID string
+	}{
+		{"/skills/AI", "shared-record", "remote-peer-1"},
+		{"/skills/AI", "shared-record", "remote-peer-2"},
+		{"/skills/AI/ML", "peer-1-record", "remote-peer-1"},
+		{"/domains/research", "peer-1-record", "remote-peer-1"},
+		{"/skills/AI/NLP", "peer-2-record", "remote-peer-2"},
+	}
+
+	for _, l := range labels {
+		metadata, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+		require.NoError(t, err)
+
+		key := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(l.label), l.cid, l.peerID)
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey(key), metadata))
+	}
+
+	entries, err := QueryAllNamespaces(ctx, dstore)
+	require.NoError(t, err)
+	require.Len(t, entries, len(labels))
+
+	// Malformed keys are never returned for a peer
+	entries = append(entries, NamespaceEntry{Key: "/skills/malformed", Value: []byte("{}")})
+
+	recordsOf := func(entries []NamespaceEntry) map[string][]string {
+		records := make(map[string][]string)
+
+		for _, entry := range entries {
+			_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+			require.NoError(t, err)
+
+			records[keyPeerID] = append(records[keyPeerID], keyCID)
+		}
+
+		return records
+	}
+
+	t.Run("peer_1", func(t *testing.T) {
+		records := recordsOf(filterEntriesByPeer(entries, "remote-peer-1"))
+
+		require.Len(t, records, 1)
+		assert.ElementsMatch(t, []string{"shared-record", "peer-1-record", "peer-1-record"}, records["remote-peer-1"])
+	})
+
+	t.Run("peer_2", func(t *testing.T) {
+		records := recordsOf(filterEntriesByPeer(entries, "remote-peer-2"))
+
+		require.Len(t, records, 1)
+		assert.ElementsMatch(t, []string{"shared-record", "peer-2-record"}, records["remote-peer-2"])
+	})
+
+	t.Run("unknown_peer", func(t *testing.T) {
+		assert.Empty(t, filterEntriesByPeer(entries, "remote-peer-3"))
+	})
+
+	t.Run("no_filter", func(t *testing.T) {
+		assert.Equal(t, entries, filterEntriesByPeer(entries, ""))
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+)
+
+// rankedFlushTimeout bounds the time spent sending the ranked results of a search that timed out.
+const rankedFlushTimeout = 5 * time.Second
+
+// rankedResult is a matching remote record buffered for ranking.
+type rankedResult struct {
+	cid          string
+	peerID       string
+	matchQueries []*routingv1.RecordQuery
+	score        uint32
+	lastSeen     time.Time
+}
+
+// rankSearchResults sorts results by descending match score, then by the most recently seen.
+// Remaining ties are broken by CID so that the order is deterministic.
+func rankSearchResults(results []rankedResult) {
+	slices.SortStableFunc(results, func(a, b rankedResult) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			b.lastSeen.Compare(a.lastSeen),
+			cmp.Compare(a.cid, b.cid),
+		)
+	})
+}
+
+// remoteRecordsLastSeen returns the most recent time any label of each record was seen,
+// keyed by "<cid>/<peer ID>". Entries with unreadable keys or metadata are ignored.
+func remoteRecordsLastSeen(entries []NamespaceEntry) map[string]time.Time {
+	lastSeen := make(map[string]time.Time)
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil {
+			continue
+		}
+
+		metadata, _, err := types.UnmarshalLabelMetadata(entry.Value)
+		if err != nil {
+			continue
+		}
+
+		key := keyCID + "/" + keyPeerID
+		if metadata.LastSeen.After(lastSeen[key]) {
+			lastSeen[key] = metadata.LastSeen
+		}
+	}
+
+	return lastSeen
+}
+
+// sendRankedResults ranks the buffered results and sends the best ones, up to limit if positive.
+// If the search timed out, the results scored so far are still sent, within rankedFlushTimeout,
+// so that a slow ranked search returns partial results like an unranked one.
+// The CIDs sent to outCh are recorded in sent. It returns the number of records sent.
+func (r *routeRemote) sendRankedResults(ctx context.Context, results []rankedResult, limit int, sent map[string]bool, outCh chan<- *routingv1.SearchResponse) int {
+	rankSearchResults(results)
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		remoteLogger.Warn("Remote search timed out, returning partial ranked results", "candidates", len(results))
+
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), rankedFlushTimeout)
+		defer cancel()
+	}
+
+	count := 0
+
+	for _, result := range results {
+		if limit > 0 && count >= limit {
+			break
+		}
+
+		peer := r.createPeerInfo(ctx, result.peerID)
+		if peer == nil {
+			continue
+		}
+
+		select {
+		case outCh <- &routingv1.SearchResponse{
+			RecordRef:    &corev1.RecordRef{Cid: result.cid},
+			Peer:         peer,
+			MatchQueries: result.matchQueries,
+			MatchScore:   result.score,
+		}:
+		case <-ctx.Done():
+			remoteLogger.Warn("Remote search interrupted, returning partial results", "results", count, "error", ctx.Err())
+
+			return count
+		}
+
+		sent[result.cid] = true
+		count++
+	}
+
+	remoteLogger.Debug("Sent ranked search results", "results", count, "candidates", len(results))
+
+	return count
+}
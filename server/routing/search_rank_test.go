@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankSearchResults(t *testing.T) {
+	now := time.Now()
+
+	results := []rankedResult{
+		{cid: "low-score", score: 1, lastSeen: now},
+		{cid: "high-score-old", score: 3, lastSeen: now.Add(-time.Hour)},
+		{cid: "mid-score", score: 2, lastSeen: now},
+		{cid: "high-score-new", score: 3, lastSeen: now},
+		{cid: "high-score-new-b", score: 3, lastSeen: now},
+	}
+
+	rankSearchResults(results)
+
+	cids := make([]string, len(results))
+	for i, result := range results {
+		cids[i] = result.cid
+	}
+
+	assert.Equal(t, []string{"high-score-new", "high-score-new-b", "high-score-old", "mid-score", "low-score"}, cids)
+}
+
+func TestRemoteRecordsLastSeen(t *testing.T) {
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	newEntry := func(label, cid, peerID string, lastSeen time.Time) NamespaceEntry {
+		metadata := &types.LabelMetadata{Timestamp: older, LastSeen: lastSeen}
+
+		value, err := metadata.Marshal()
+		require.NoError(t, err)
+
+		return NamespaceEntry{Key: BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(label), cid, peerID), Value: value}
+	}
+
+	entries := []NamespaceEntry{
+		newEntry("/skills/AI", "cid-1", "peer-a", older),
+		newEntry("/skills/AI/ML", "cid-1", "peer-a", newer),
+		newEntry("/skills/AI", "cid-1", "peer-b", older),
+		newEntry("/domains/research", "cid-2", "peer-a", newer),
+		{Key: "/skills/malformed", Value: []byte("{}")},
+		{Key: BuildEnhancedLabelKey(types.LabelNormalization{}, "/skills/AI", "cid-3", "peer-a"), Value: []byte("not json")},
+	}
+
+	lastSeen := remoteRecordsLastSeen(entries)
+
+	assert.Equal(t, map[string]time.Time{
+		"cid-1/peer-a": newer,
+		"cid-1/peer-b": older,
+		"cid-2/peer-a": newer,
+	}, lastSeen)
+}
+
+func TestSendRankedResults(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	// Peer IDs that do not decode resolve to an empty directory address without a host
+	r := &routeRemote{dstore: dstore, peerFilter: newPeerFilter(nil, nil)}
+
+	newResults := func() []rankedResult {
+		return []rankedResult{
+			{cid: "low-score", peerID: "peer-a", score: 1},
+			{cid: "high-score", peerID: "peer-b", score: 2},
+		}
+	}
+
+	t.Run("search timed out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Nanosecond)
+		defer cancel()
+
+		<-ctx.Done()
+
+		outCh := make(chan *routingv1.SearchResponse, 2)
+		sent := make(map[string]bool)
+
+		count := r.sendRankedResults(ctx, newResults(), 0, sent, outCh)
+		close(outCh)
+
+		var cids []string
+		for result := range outCh {
+			cids = append(cids, result.GetRecordRef().GetCid())
+		}
+
+		assert.Equal(t, 2, count)
+		assert.Equal(t, []string{"high-score", "low-score"}, cids)
+		assert.Equal(t, map[string]bool{"high-score": true, "low-score": true}, sent)
+	})
+
+	t.Run("search canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		// Nobody reads the results of a canceled search
+		count := r.sendRankedResults(ctx, newResults(), 0, make(map[string]bool), make(chan *routingv1.SearchResponse))
+		assert.Equal(t, 0, count)
+	})
+}
@@ -6,6 +6,7 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -58,7 +59,7 @@ func TestSearch_CoreLogic(t *testing.T) {
 	// Store test label metadata
 	for _, td := range testData {
 		for _, label := range td.labels {
-			enhancedKey := BuildEnhancedLabelKey(types.Label(label), td.cid, td.peerID)
+			enhancedKey := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(label), td.cid, td.peerID)
 			metadata := &types.LabelMetadata{
 				Timestamp: time.Now(),
 				LastSeen:  time.Now(),
@@ -247,6 +248,62 @@ func TestSearch_CoreLogic(t *testing.T) {
 	})
 }
 
+func TestQueryAllNamespaces(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupSearchTestDatastore(t)
+	defer cleanup()
+
+	// Insert labels in reverse namespace order
+	labels := []string{"/locators/docker-image", "/modules/runtime", "/domains/research", "/skills/AI"}
+	for _, label := range labels {
+		key := BuildEnhancedLabelKey(types.LabelNormalization{}, types.Label(label), "record", "remote-peer")
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey(key), []byte("{}")))
+	}
+
+	t.Run("entries are grouped in namespace order", func(t *testing.T) {
+		entries, err := QueryAllNamespaces(ctx, dstore)
+		require.NoError(t, err)
+		require.Len(t, entries, len(labels))
+
+		var namespaces []string
+		for _, entry := range entries {
+			namespaces = append(namespaces, entry.Namespace)
+		}
+
+		assert.Equal(t, []string{"/skills/", "/domains/", "/modules/", "/locators/"}, namespaces)
+	})
+
+	t.Run("canceled context returns an error", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := QueryAllNamespaces(canceledCtx, dstore)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func BenchmarkQueryAllNamespaces(b *testing.B) {
+	dstore, err := datastore.New(datastore.WithFsProvider(b.TempDir()))
+	require.NoError(b, err)
+
+	b.Cleanup(func() { _ = dstore.Close() })
+
+	// Populate every namespace with announcements from remote peers
+	for _, labelType := range types.BuiltinLabelTypes() {
+		for i := range 2000 {
+			label := types.Label(fmt.Sprintf("%s%s-%d", labelType.Prefix(), labelType, i))
+			key := BuildEnhancedLabelKey(types.LabelNormalization{}, label, fmt.Sprintf("record-%d", i), "remote-peer")
+			require.NoError(b, dstore.Put(b.Context(), ipfsdatastore.NewKey(key), []byte("{}")))
+		}
+	}
+
+	for b.Loop() {
+		_, err := QueryAllNamespaces(b.Context(), dstore)
+		require.NoError(b, err)
+	}
+}
+
 // Simplified search simulation for testing.
 //
 //nolint:gocognit // Test helper function that replicates search logic - complexity is necessary
@@ -348,7 +405,7 @@ func testMatchesAllQueriesSimple(ctx context.Context, dstore types.Datastore, ci
 		return labelList
 	}
 
-	return MatchesAllQueries(ctx, cid, queries, labelRetriever)
+	return MatchesAllQueries(ctx, types.LabelNormalization{}, cid, queries, labelRetriever)
 }
 
 // Helper functions for testing
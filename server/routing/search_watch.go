@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// WatchBufferSize is the number of announcements buffered per search watcher.
+// Announcements are dropped for watchers that fall further behind.
+const WatchBufferSize = 64
+
+// cachedAnnouncement identifies a remote record whose labels were cached.
+type cachedAnnouncement struct {
+	CID    string
+	PeerID string
+}
+
+// announcementBroker fans out cached announcements to search watchers.
+type announcementBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan cachedAnnouncement]struct{}
+}
+
+func newAnnouncementBroker() *announcementBroker {
+	return &announcementBroker{
+		subscribers: make(map[chan cachedAnnouncement]struct{}),
+	}
+}
+
+// Subscribe registers a new watcher.
+// The returned function must be called to unsubscribe once the watcher is done.
+func (b *announcementBroker) Subscribe() (<-chan cachedAnnouncement, func()) {
+	ch := make(chan cachedAnnouncement, WatchBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish notifies all watchers without blocking.
+func (b *announcementBroker) Publish(announcement cachedAnnouncement) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- announcement:
+		default:
+			remoteLogger.Warn("Search watcher is falling behind, dropping announcement", "cid", announcement.CID)
+		}
+	}
+}
+
+// watchRemoteRecords sends records matching the queries as their labels are cached,
+// skipping CIDs that were already sent.
+// If peerID is set, only records announced by that peer are sent.
+// It returns when ctx is canceled or the routing subsystem stops.
+func (r *routeRemote) watchRemoteRecords(ctx context.Context, announcements <-chan cachedAnnouncement, queries []*routingv1.RecordQuery, minMatchScore uint32, maxAge time.Duration, peerID string, sent map[string]bool, outCh chan<- *routingv1.SearchResponse) int {
+	remoteLogger.Debug("Watching for new remote records", "queries", len(queries))
+
+	count := 0
+
+	for {
+		var announcement cachedAnnouncement
+
+		select {
+		case <-ctx.Done():
+			return count
+		case <-r.ctx.Done():
+			return count
+		case announcement = <-announcements:
+		}
+
+		if sent[announcement.CID] || !r.peerFilter.IsAllowed(announcement.PeerID) {
+			continue
+		}
+
+		if peerID != "" && announcement.PeerID != peerID {
+			continue
+		}
+
+		matchQueries, score := r.calculateMatchScore(ctx, announcement.CID, queries, announcement.PeerID, maxAge)
+		if score < minMatchScore {
+			continue
+		}
+
+		peer := r.createPeerInfo(ctx, announcement.PeerID)
+		if peer == nil {
+			continue
+		}
+
+		select {
+		case outCh <- &routingv1.SearchResponse{
+			RecordRef:    &corev1.RecordRef{Cid: announcement.CID},
+			Peer:         peer,
+			MatchQueries: matchQueries,
+			MatchScore:   score,
+		}:
+		case <-ctx.Done():
+			return count
+		}
+
+		sent[announcement.CID] = true
+		count++
+
+		remoteLogger.Debug("Sent newly announced record to search watcher", "cid", announcement.CID, "score", score)
+	}
+}
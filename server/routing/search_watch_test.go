@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementBroker(t *testing.T) {
+	broker := newAnnouncementBroker()
+
+	first, unsubscribeFirst := broker.Subscribe()
+	second, unsubscribeSecond := broker.Subscribe()
+
+	announcement := cachedAnnouncement{CID: "record-cid", PeerID: "remote-peer"}
+	broker.Publish(announcement)
+
+	assert.Equal(t, announcement, <-first)
+	assert.Equal(t, announcement, <-second)
+
+	t.Run("unsubscribed watchers are not notified", func(t *testing.T) {
+		unsubscribeFirst()
+
+		broker.Publish(announcement)
+
+		assert.Empty(t, first)
+		assert.Len(t, second, 1)
+		<-second
+	})
+
+	t.Run("publish does not block on slow watchers", func(t *testing.T) {
+		for range WatchBufferSize + 1 {
+			broker.Publish(announcement)
+		}
+
+		assert.Len(t, second, WatchBufferSize)
+	})
+
+	unsubscribeSecond()
+}
+
+func TestWatchRemoteRecordsStopsOnCancel(t *testing.T) {
+	routingCtx, stopRouting := context.WithCancel(t.Context())
+	defer stopRouting()
+
+	r := &routeRemote{ctx: routingCtx}
+	queries := []*routingv1.RecordQuery{
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+	}
+
+	// The routing subsystem is stopped last, as it affects all watchers
+	testCases := []struct {
+		name   string
+		cancel func(cancelRequest context.CancelFunc)
+	}{
+		{name: "request canceled", cancel: func(cancelRequest context.CancelFunc) { cancelRequest() }},
+		{name: "routing stopped", cancel: func(context.CancelFunc) { stopRouting() }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancelRequest := context.WithCancel(t.Context())
+			defer cancelRequest()
+
+			done := make(chan int)
+
+			go func() {
+				done <- r.watchRemoteRecords(ctx, make(chan cachedAnnouncement), queries, 1, 0, "", map[string]bool{}, make(chan *routingv1.SearchResponse))
+			}()
+
+			tc.cancel(cancelRequest)
+
+			select {
+			case count := <-done:
+				assert.Zero(t, count)
+			case <-time.After(time.Second):
+				require.Fail(t, "watch did not stop")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/agntcy/dir/server/datastore"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// ValidateConfig checks the routing configuration without starting the routing subsystem.
+// All problems are reported, each prefixed with the name of the offending setting.
+func ValidateConfig(cfg routingconfig.Config) error {
+	var errs []error
+
+	if _, err := ma.NewMultiaddr(cfg.ListenAddress); err != nil {
+		errs = append(errs, fmt.Errorf("listen_address: invalid multiaddr %q: %w", cfg.ListenAddress, err))
+	}
+
+	if strings.ContainsFunc(cfg.Rendezvous, unicode.IsSpace) {
+		errs = append(errs, fmt.Errorf("rendezvous: must not contain whitespace (%q)", cfg.Rendezvous))
+	}
+
+	if strings.ContainsFunc(cfg.ProtocolPrefix, unicode.IsSpace) {
+		errs = append(errs, fmt.Errorf("protocol_prefix: must not contain whitespace (%q)", cfg.ProtocolPrefix))
+	}
+
+	for i, addr := range cfg.BootstrapPeers {
+		if _, err := peer.AddrInfoFromString(addr); err != nil {
+			errs = append(errs, fmt.Errorf("bootstrap_peers[%d]: invalid peer multiaddr %q: %w", i, addr, err))
+		}
+	}
+
+	switch backend := datastore.Backend(cfg.DatastoreBackend); backend {
+	case "", datastore.BackendMemory:
+	case datastore.BackendBadger, datastore.BackendLevelDB:
+		if cfg.DatastoreDir == "" {
+			errs = append(errs, fmt.Errorf("datastore_backend: backend %s requires datastore_dir to be set", backend))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("datastore_backend: unsupported backend %q, expected one of: %s, %s, %s",
+			backend, datastore.BackendMemory, datastore.BackendBadger, datastore.BackendLevelDB))
+	}
+
+	if err := ValidateRepublishSchedule(cfg.RepublishInterval, cfg.RepublishJitter); err != nil {
+		errs = append(errs, fmt.Errorf("republish_interval: %w", err))
+	}
+
+	if cfg.RepublishRate < 0 {
+		errs = append(errs, fmt.Errorf("republish_rate: must not be negative (%v)", cfg.RepublishRate))
+	}
+
+	if cfg.RefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("refresh_interval: must not be negative (%s)", cfg.RefreshInterval))
+	}
+
+	if cfg.PeerAddressTTL < 0 {
+		errs = append(errs, fmt.Errorf("peer_address_ttl: must not be negative (%s)", cfg.PeerAddressTTL))
+	}
+
+	if maxAges, err := ParseLabelMaxAges(cfg.LabelMaxAges); err != nil {
+		errs = append(errs, fmt.Errorf("label_max_ages: %w", err))
+	} else {
+		for labelType := range maxAges {
+			if !slices.Contains(types.BuiltinLabelTypes(), labelType) && !slices.Contains(cfg.LabelNamespaces, labelType.String()) {
+				errs = append(errs, fmt.Errorf("label_max_ages: unknown label namespace %q", labelType))
+			}
+		}
+	}
+
+	if cfg.MaxConcurrentPulls < 0 {
+		errs = append(errs, fmt.Errorf("max_concurrent_pulls: must not be negative (%d)", cfg.MaxConcurrentPulls))
+	}
+
+	if cfg.SearchTimeout < 0 {
+		errs = append(errs, fmt.Errorf("search_timeout: must not be negative (%s)", cfg.SearchTimeout))
+	}
+
+	if cfg.LabelResolutionTimeout < 0 {
+		errs = append(errs, fmt.Errorf("label_resolution_timeout: must not be negative (%s)", cfg.LabelResolutionTimeout))
+	}
+
+	if cfg.MaxSearchLimit < 0 {
+		errs = append(errs, fmt.Errorf("max_search_limit: must not be negative (%d)", cfg.MaxSearchLimit))
+	}
+
+	if rate := cfg.VerifyAnnouncements.SampleRate; rate < 0 || rate > 1 {
+		errs = append(errs, fmt.Errorf("verify_announcements.sample_rate: must be between 0 and 1 (%v)", rate))
+	}
+
+	limits := cfg.AnnouncementLimits
+	if limits.MaxLabels < 0 || limits.MaxLabels > pubsub.MaxLabelsPerAnnouncement {
+		errs = append(errs, fmt.Errorf("announcement_limits.max_labels: must be between 0 and %d (%d)", pubsub.MaxLabelsPerAnnouncement, limits.MaxLabels))
+	}
+
+	if cfg.MaxLabelsPerRecord < 0 || cfg.MaxLabelsPerRecord > pubsub.MaxLabelsPerAnnouncement {
+		errs = append(errs, fmt.Errorf("max_labels_per_record: must be between 0 and %d (%d)", pubsub.MaxLabelsPerAnnouncement, cfg.MaxLabelsPerRecord))
+	}
+
+	if limits.Rate < 0 {
+		errs = append(errs, fmt.Errorf("announcement_limits.rate: must not be negative (%v)", limits.Rate))
+	}
+
+	if limits.Rate > 0 && limits.Burst <= 0 {
+		errs = append(errs, fmt.Errorf("announcement_limits.burst: must be positive when rate limiting is enabled (%d)", limits.Burst))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	validConfig := func() routingconfig.Config {
+		return routingconfig.Config{
+			ListenAddress:     routingconfig.DefaultListenAddress,
+			BootstrapPeers:    []string{"/ip4/1.1.1.1/tcp/8999/p2p/12D3KooWRdYQiQLu5mJmd5XpABTg4vz7eg9yQyHd9ExWqcsCbRhq"},
+			RepublishInterval: routingconfig.DefaultRepublishInterval,
+			RepublishJitter:   routingconfig.DefaultRepublishJitter,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func(cfg *routingconfig.Config)
+		expected []string
+	}{
+		{name: "valid", modify: func(*routingconfig.Config) {}},
+		{
+			name:     "invalid listen address",
+			modify:   func(cfg *routingconfig.Config) { cfg.ListenAddress = "0.0.0.0:8999" },
+			expected: []string{"listen_address"},
+		},
+		{
+			name:     "bootstrap peer without peer ID",
+			modify:   func(cfg *routingconfig.Config) { cfg.BootstrapPeers = append(cfg.BootstrapPeers, "/ip4/1.1.1.1/tcp/1") },
+			expected: []string{"bootstrap_peers[1]"},
+		},
+		{
+			name: "custom network identity",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Rendezvous = "dir/staging"
+				cfg.ProtocolPrefix = "dir-staging"
+			},
+		},
+		{
+			name: "network identity with whitespace",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Rendezvous = "dir staging"
+				cfg.ProtocolPrefix = "dir\t"
+			},
+			expected: []string{"rendezvous", "protocol_prefix"},
+		},
+		{
+			name:     "persistent backend without directory",
+			modify:   func(cfg *routingconfig.Config) { cfg.DatastoreBackend = "badger" },
+			expected: []string{"datastore_backend"},
+		},
+		{
+			name: "republish schedule exceeds record TTL",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RepublishInterval = RecordTTL
+				cfg.RepublishJitter = time.Hour
+			},
+			expected: []string{"republish_interval"},
+		},
+		{
+			name: "announcement limits out of range",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.AnnouncementLimits.MaxLabels = 1000
+				cfg.AnnouncementLimits.Rate = 1
+			},
+			expected: []string{"announcement_limits.max_labels", "announcement_limits.burst"},
+		},
+		{
+			name: "max labels per record out of range",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.MaxLabelsPerRecord = 1000
+			},
+			expected: []string{"max_labels_per_record"},
+		},
+		{
+			name: "label max ages of known namespaces",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.LabelNamespaces = []string{"features"}
+				cfg.LabelMaxAges = []string{"locators=12h", "features=24h"}
+			},
+		},
+		{
+			name:     "label max age of unknown namespace",
+			modify:   func(cfg *routingconfig.Config) { cfg.LabelMaxAges = []string{"features=24h"} },
+			expected: []string{"label_max_ages"},
+		},
+		{
+			name:     "invalid label max age",
+			modify:   func(cfg *routingconfig.Config) { cfg.LabelMaxAges = []string{"locators=-1h"} },
+			expected: []string{"label_max_ages"},
+		},
+		{
+			name: "all problems are reported",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.DatastoreBackend = "postgres"
+				cfg.PeerAddressTTL = -time.Hour
+				cfg.VerifyAnnouncements.SampleRate = 2
+			},
+			expected: []string{"datastore_backend", "peer_address_ttl", "verify_announcements.sample_rate"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.modify(&cfg)
+
+			err := ValidateConfig(cfg)
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			for _, key := range tc.expected {
+				assert.ErrorContains(t, err, key+":")
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package validators
 
 import (
+	"bytes"
 	"errors"
 	"strconv"
 	"strings"
@@ -86,26 +87,49 @@ func (v *BaseValidator) validateValue(value []byte) error {
 	return nil
 }
 
-// selectFirstValid provides default selection logic for all validators.
-func (v *BaseValidator) selectFirstValid(key string, values [][]byte, validateFunc func(string, []byte) error) (int, error) {
+// selectPreferred provides default selection logic for all validators.
+// Among all valid values it deterministically prefers non-empty values and then the
+// lexicographically smallest one, so that all nodes converge on the same selection
+// regardless of the order in which values were received.
+// Identical values are resolved in favor of the lowest index.
+func (v *BaseValidator) selectPreferred(key string, values [][]byte, validateFunc func(string, []byte) error) (int, error) {
 	validatorLogger.Debug("Selecting from multiple DHT record values", "key", key, "count", len(values))
 
 	if len(values) == 0 {
 		return -1, errors.New("no values to select from")
 	}
 
+	selected := -1
+
 	for i, value := range values {
-		err := validateFunc(key, value)
-		if err == nil {
-			validatorLogger.Debug("Selected DHT record value", "key", key, "index", i)
+		if err := validateFunc(key, value); err != nil {
+			continue
+		}
 
-			return i, nil
+		if selected == -1 || preferValue(value, values[selected]) {
+			selected = i
 		}
 	}
 
-	validatorLogger.Warn("No valid values found for DHT record", "key", key)
+	if selected == -1 {
+		validatorLogger.Warn("No valid values found for DHT record", "key", key)
+
+		return -1, errors.New("no valid values found")
+	}
+
+	validatorLogger.Debug("Selected DHT record value", "key", key, "index", selected)
 
-	return -1, errors.New("no valid values found")
+	return selected, nil
+}
+
+// preferValue reports whether candidate should be preferred over current.
+// Non-empty values win over empty ones, otherwise the smaller value wins.
+func preferValue(candidate, current []byte) bool {
+	if (len(candidate) == 0) != (len(current) == 0) {
+		return len(candidate) > 0
+	}
+
+	return bytes.Compare(candidate, current) < 0
 }
 
 // SkillValidator validates DHT records for skill-based content discovery.
@@ -172,7 +196,7 @@ func (v *SkillValidator) validateSkillsSpecific(parts []string) error {
 
 // Select chooses between multiple values for skills records.
 func (v *SkillValidator) Select(key string, values [][]byte) (int, error) {
-	return v.selectFirstValid(key, values, v.Validate)
+	return v.selectPreferred(key, values, v.Validate)
 }
 
 // DomainValidator validates DHT records for domain-based content discovery.
@@ -232,7 +256,7 @@ func (v *DomainValidator) validateDomainsSpecific(parts []string) error {
 
 // Select chooses between multiple values for domains records.
 func (v *DomainValidator) Select(key string, values [][]byte) (int, error) {
-	return v.selectFirstValid(key, values, v.Validate)
+	return v.selectPreferred(key, values, v.Validate)
 }
 
 // ModuleValidator validates DHT records for module-based content discovery.
@@ -292,7 +316,7 @@ func (v *ModuleValidator) validateModulesSpecific(parts []string) error {
 
 // Select chooses between multiple values for modules records.
 func (v *ModuleValidator) Select(key string, values [][]byte) (int, error) {
-	return v.selectFirstValid(key, values, v.Validate)
+	return v.selectPreferred(key, values, v.Validate)
 }
 
 // LocatorValidator validates DHT records for locator-based content discovery.
@@ -359,17 +383,71 @@ func (v *LocatorValidator) validateLocatorsSpecific(parts []string) error {
 
 // Select chooses between multiple values for locators records.
 func (v *LocatorValidator) Select(key string, values [][]byte) (int, error) {
-	return v.selectFirstValid(key, values, v.Validate)
+	return v.selectPreferred(key, values, v.Validate)
+}
+
+// CustomValidator validates DHT records for custom label namespaces
+// registered via types.RegisterLabelType.
+type CustomValidator struct {
+	BaseValidator
+
+	Namespace types.LabelType
+}
+
+// Validate validates a custom namespace DHT record.
+// Key format: /<namespace>/<label_path>/<cid>/<peer_id>
+func (v *CustomValidator) Validate(key string, value []byte) error {
+	validatorLogger.Debug("Validating custom DHT record", "namespace", v.Namespace, "key", key)
+
+	// Basic format validation
+	parts, err := v.validateKeyFormat(key, v.Namespace.String())
+	if err != nil {
+		return err
+	}
+
+	// Extract label path (everything between namespace and CID)
+	labelParts := parts[2 : len(parts)-2] // Exclude CID and PeerID
+	if len(labelParts) == 0 {
+		return errors.New(v.Namespace.String() + " path cannot be empty")
+	}
+
+	// Validate that none of the label path components are empty
+	for i, part := range labelParts {
+		if part == "" {
+			return errors.New(v.Namespace.String() + " path component cannot be empty at position " + strconv.Itoa(i+1))
+		}
+	}
+
+	// Value validation
+	if err := v.validateValue(value); err != nil {
+		return err
+	}
+
+	validatorLogger.Debug("Custom DHT record validation successful", "namespace", v.Namespace, "key", key)
+
+	return nil
+}
+
+// Select chooses between multiple values for custom namespace records.
+func (v *CustomValidator) Select(key string, values [][]byte) (int, error) {
+	return v.selectPreferred(key, values, v.Validate)
 }
 
 // CreateLabelValidators creates separate validators for each label namespace.
+// Registered custom namespaces are validated with a CustomValidator.
 func CreateLabelValidators() map[string]record.Validator {
-	return map[string]record.Validator{
+	labelValidators := map[string]record.Validator{
 		types.LabelTypeSkill.String():   &SkillValidator{},
 		types.LabelTypeDomain.String():  &DomainValidator{},
 		types.LabelTypeModule.String():  &ModuleValidator{},
 		types.LabelTypeLocator.String(): &LocatorValidator{},
 	}
+
+	for _, labelType := range types.CustomLabelTypes() {
+		labelValidators[labelType.String()] = &CustomValidator{Namespace: labelType}
+	}
+
+	return labelValidators
 }
 
 // ValidateLabelKey validates a label key format before storing in DHT.
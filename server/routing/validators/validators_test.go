@@ -411,7 +411,7 @@ func TestValidators_Select(t *testing.T) {
 		errorMsg  string
 	}{
 		{
-			name:      "skills validator - select first valid value",
+			name:      "skills validator - select only valid value",
 			validator: &SkillValidator{},
 			key:       "/skills/programming/golang/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
 			values: [][]byte{
@@ -422,7 +422,7 @@ func TestValidators_Select(t *testing.T) {
 			wantError: false,
 		},
 		{
-			name:      "domains validator - select first valid from multiple",
+			name:      "domains validator - prefer non-empty valid value",
 			validator: &DomainValidator{},
 			key:       "/domains/ai/machine-learning/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer2",
 			values: [][]byte{
@@ -446,7 +446,7 @@ func TestValidators_Select(t *testing.T) {
 			errorMsg:  "no valid values found",
 		},
 		{
-			name:      "locators validator - select first valid value",
+			name:      "locators validator - select only valid value",
 			validator: &LocatorValidator{},
 			key:       "/locators/docker-image/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
 			values: [][]byte{
@@ -456,6 +456,41 @@ func TestValidators_Select(t *testing.T) {
 			wantIndex: 0,
 			wantError: false,
 		},
+		{
+			name:      "skills validator - prefer smallest CID",
+			validator: &SkillValidator{},
+			key:       "/skills/programming/golang/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			values: [][]byte{
+				[]byte("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"),
+				[]byte("bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"),
+			},
+			wantIndex: 1,
+			wantError: false,
+		},
+		{
+			name:      "skills validator - same winner regardless of order",
+			validator: &SkillValidator{},
+			key:       "/skills/programming/golang/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			values: [][]byte{
+				[]byte("bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"),
+				[]byte("invalid-cid"),
+				[]byte("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"),
+			},
+			wantIndex: 0,
+			wantError: false,
+		},
+		{
+			name:      "modules validator - duplicate values resolve to lowest index",
+			validator: &ModuleValidator{},
+			key:       "/modules/llm/reasoning/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer3",
+			values: [][]byte{
+				[]byte(""),
+				[]byte("bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"),
+				[]byte("bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"),
+			},
+			wantIndex: 1,
+			wantError: false,
+		},
 		{
 			name:      "empty values slice",
 			validator: &SkillValidator{},
@@ -565,6 +600,59 @@ func TestCreateLabelValidators(t *testing.T) {
 	assert.IsType(t, &LocatorValidator{}, validators[types.LabelTypeLocator.String()])
 }
 
+func TestCustomValidator_Validate(t *testing.T) {
+	validator := &CustomValidator{Namespace: types.LabelType("features")}
+
+	tests := []struct {
+		name      string
+		key       string
+		value     []byte
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name:      "valid custom key",
+			key:       "/features/streaming/audio/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			value:     []byte{},
+			wantError: false,
+		},
+		{
+			name:      "invalid namespace",
+			key:       "/skills/streaming/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			value:     []byte{},
+			wantError: true,
+			errorMsg:  "invalid namespace: expected features, got skills",
+		},
+		{
+			name:      "empty path component",
+			key:       "/features/streaming//bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			value:     []byte{},
+			wantError: true,
+			errorMsg:  "features path component cannot be empty at position 2",
+		},
+		{
+			name:      "invalid value",
+			key:       "/features/streaming/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			value:     []byte("not-a-cid"),
+			wantError: true,
+			errorMsg:  "invalid CID in value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.key, tt.value)
+
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateLabelKey(t *testing.T) {
 	tests := []struct {
 		name      string
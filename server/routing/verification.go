@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// shouldVerifyAnnouncement decides whether an incoming GossipSub announcement
+// should be verified, based on the configured sample rate.
+func (r *routeRemote) shouldVerifyAnnouncement() bool {
+	if !r.verifyAnnouncements {
+		return false
+	}
+
+	if r.verifySampleRate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < r.verifySampleRate //nolint:gosec // Sampling does not require a secure random source
+}
+
+// verifyAnnouncement pulls the announced record from the announcing peer and checks
+// that every announced label is actually derived from the record content.
+func (r *routeRemote) verifyAnnouncement(ctx context.Context, authenticatedPeerID string, event *pubsub.RecordPublishEvent) error {
+	peerID, err := peer.Decode(authenticatedPeerID)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID %q: %w", authenticatedPeerID, err)
+	}
+
+	record, err := r.service.Pull(ctx, peerID, &corev1.RecordRef{Cid: event.CID})
+	if err != nil {
+		return fmt.Errorf("failed to pull announced record: %w", err)
+	}
+
+	return verifyAnnouncedLabels(event.CID, event.Labels, record)
+}
+
+// verifyAnnouncedLabels checks that the record matches the announced CID and that
+// all announced labels are present in the labels derived from the record.
+func verifyAnnouncedLabels(cid string, announced []string, record *corev1.Record) error {
+	if !record.HasCid(cid) {
+		return fmt.Errorf("record CID mismatch: announced %s, got %s", cid, record.GetCid())
+	}
+
+	actual := make(map[string]struct{})
+	for _, label := range types.GetLabelsFromRecord(adapters.NewRecordAdapter(record)) {
+		actual[label.String()] = struct{}{}
+	}
+
+	for _, label := range announced {
+		if _, ok := actual[label]; !ok {
+			return fmt.Errorf("announced label %q not found in record %s", label, cid)
+		}
+	}
+
+	return nil
+}
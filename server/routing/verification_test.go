@@ -0,0 +1,60 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAnnouncedLabels(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name: "test-verify-agent",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+		Locators: []*typesv1alpha0.Locator{
+			{Type: "docker-image", Url: "ghcr.io/agntcy/test"},
+		},
+	})
+	recordCID := record.GetCid()
+
+	var recordLabels []string
+	for _, label := range types.GetLabelsFromRecord(adapters.NewRecordAdapter(record)) {
+		recordLabels = append(recordLabels, label.String())
+	}
+
+	require.NotEmpty(t, recordLabels)
+
+	t.Run("all_labels_match", func(t *testing.T) {
+		assert.NoError(t, verifyAnnouncedLabels(recordCID, recordLabels, record))
+	})
+
+	t.Run("subset_of_labels", func(t *testing.T) {
+		assert.NoError(t, verifyAnnouncedLabels(recordCID, recordLabels[:1], record))
+	})
+
+	t.Run("forged_label", func(t *testing.T) {
+		forged := append([]string{}, recordLabels...)
+		forged = append(forged, "/skills/forged/label")
+
+		assert.Error(t, verifyAnnouncedLabels(recordCID, forged, record))
+	})
+
+	t.Run("cid_mismatch", func(t *testing.T) {
+		assert.Error(t, verifyAnnouncedLabels("bafy-other-cid", recordLabels, record))
+	})
+}
+
+func TestShouldVerifyAnnouncement(t *testing.T) {
+	assert.False(t, (&routeRemote{verifyAnnouncements: false, verifySampleRate: 1}).shouldVerifyAnnouncement())
+	assert.True(t, (&routeRemote{verifyAnnouncements: true, verifySampleRate: 1}).shouldVerifyAnnouncement())
+	assert.False(t, (&routeRemote{verifyAnnouncements: true, verifySampleRate: 0}).shouldVerifyAnnouncement())
+}
@@ -6,6 +6,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/signal"
@@ -22,10 +23,14 @@ import (
 	"github.com/agntcy/dir/server/config"
 	"github.com/agntcy/dir/server/controller"
 	"github.com/agntcy/dir/server/database"
+	"github.com/agntcy/dir/server/expiry"
+	"github.com/agntcy/dir/server/metrics"
 	"github.com/agntcy/dir/server/publication"
+	"github.com/agntcy/dir/server/ratelimit"
 	"github.com/agntcy/dir/server/routing"
 	"github.com/agntcy/dir/server/store"
 	"github.com/agntcy/dir/server/sync"
+	"github.com/agntcy/dir/server/tracing"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc"
@@ -46,6 +51,9 @@ type Server struct {
 	authnService       *authn.Service
 	authzService       *authz.Service
 	publicationService *publication.Service
+	expiryService      *expiry.Service
+	metricsService     *metrics.Service
+	tracingService     *tracing.Service
 	healthzServer      *healthz.Server
 	grpcServer         *grpc.Server
 }
@@ -53,6 +61,15 @@ type Server struct {
 func Run(ctx context.Context, cfg *config.Config) error {
 	errCh := make(chan error)
 
+	// Fail fast on misconfiguration before any service is started
+	if err := ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	if err := logging.Apply(cfg.Logging); err != nil {
+		return fmt.Errorf("failed to configure logging: %w", err)
+	}
+
 	server, err := New(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
@@ -66,7 +83,28 @@ func Run(ctx context.Context, cfg *config.Config) error {
 
 	// Wait for deactivation
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+
+	// Reload the configuration on SIGHUP.
+	// Reloads run off the signal loop so that they never delay shutdown.
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	defer signal.Stop(reloadCh)
+
+	reloadCtx, cancelReload := context.WithCancel(ctx)
+	reloadDone := make(chan struct{})
+
+	go func() {
+		defer close(reloadDone)
+
+		server.watchReloads(reloadCtx, reloadCh, cfg.ConfigFile)
+	}()
+
+	// Stop reloading before the server is closed
+	defer func() {
+		cancelReload()
+		<-reloadDone
+	}()
 
 	select {
 	case <-ctx.Done():
@@ -78,6 +116,47 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	}
 }
 
+// watchReloads reloads the configuration for every signal received on reloadCh until ctx is done.
+// Reloads run one at a time; signals received during a reload trigger at most one more.
+func (s Server) watchReloads(ctx context.Context, reloadCh <-chan os.Signal, configFile string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloadCh:
+			s.reload(ctx, configFile)
+		}
+	}
+}
+
+// reload re-reads the configuration and applies the settings that can change at runtime.
+// Currently, only the logging settings and the routing bootstrap peers are reloaded.
+func (s Server) reload(ctx context.Context, configFile string) {
+	logger.Info("Reloading configuration", "file", configFile)
+
+	cfg, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		logger.Error("Failed to reload configuration", "error", err)
+
+		return
+	}
+
+	if err := logging.Apply(cfg.Logging); err != nil {
+		logger.Error("Failed to reload logging configuration", "error", err)
+	}
+
+	bootstrapAPI, ok := s.routing.(types.BootstrapPeersAPI)
+	if !ok {
+		logger.Warn("Routing does not support reloading bootstrap peers")
+
+		return
+	}
+
+	if err := bootstrapAPI.UpdateBootstrapPeers(ctx, cfg.Routing.BootstrapPeers); err != nil {
+		logger.Error("Failed to reload bootstrap peers", "error", err)
+	}
+}
+
 func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 	logger.Debug("Creating server with config", "config", cfg, "version", version.String())
 
@@ -85,6 +164,30 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 	options := types.NewOptions(cfg)
 	serverOpts := []grpc.ServerOption{}
 
+	// Create metrics service if enabled, so that all APIs record to it
+	var metricsService *metrics.Service
+	if cfg.Metrics.Enabled {
+		var err error
+
+		metricsService, err = metrics.New(cfg.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics service: %w", err)
+		}
+	}
+
+	// Create tracing service if an exporter is configured, otherwise spans are no-ops
+	var tracingService *tracing.Service
+	if cfg.Tracing.Enabled() {
+		var err error
+
+		tracingService, err = tracing.New(cfg.Tracing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tracing service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, tracingService.GetServerOptions()...)
+	}
+
 	// Create APIs
 	storeAPI, err := store.New(options) //nolint:staticcheck
 	if err != nil {
@@ -101,8 +204,15 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create database API: %w", err)
 	}
 
+	// Index the records fetched from the upstream directory or pulled from the network so that they can be searched
+	for _, component := range []any{storeAPI, routingAPI} {
+		if indexer, ok := component.(types.RecordIndexer); ok {
+			indexer.SetIndexFunc(databaseAPI.AddRecord)
+		}
+	}
+
 	// Create services
-	syncService, err := sync.New(databaseAPI, storeAPI, options)
+	syncService, err := sync.New(databaseAPI, storeAPI, routingAPI, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync service: %w", err)
 	}
@@ -130,25 +240,45 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		serverOpts = append(serverOpts, authzService.GetServerOptions()...)
 	}
 
+	// Create rate limiting service if enabled, after authn so that clients are keyed by identity
+	var rateLimiter types.RateLimiterAPI
+	if cfg.RateLimit.Enabled {
+		rateLimitService, err := ratelimit.New(cfg.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rate limiting service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, rateLimitService.GetServerOptions()...)
+		rateLimiter = rateLimitService
+	}
+
 	// Create publication service
 	publicationService, err := publication.New(databaseAPI, storeAPI, routingAPI, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create publication service: %w", err)
 	}
 
+	// Create expiry service
+	expiryService, err := expiry.New(databaseAPI, storeAPI, routingAPI, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create expiry service: %w", err)
+	}
+
 	// Create a server
 	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Register APIs
-	storev1.RegisterStoreServiceServer(grpcServer, controller.NewStoreController(storeAPI, databaseAPI))
-	routingv1.RegisterRoutingServiceServer(grpcServer, controller.NewRoutingController(routingAPI, storeAPI, publicationService))
+	storev1.RegisterStoreServiceServer(grpcServer, controller.NewStoreController(storeAPI, databaseAPI, rateLimiter, options))
+	routingv1.RegisterRoutingServiceServer(grpcServer, controller.NewRoutingController(routingAPI, storeAPI, publicationService, options))
 	routingv1.RegisterPublicationServiceServer(grpcServer, controller.NewPublicationController(databaseAPI, options))
-	searchv1.RegisterSearchServiceServer(grpcServer, controller.NewSearchController(databaseAPI))
+	searchv1.RegisterSearchServiceServer(grpcServer, controller.NewSearchController(databaseAPI, options))
 	storev1.RegisterSyncServiceServer(grpcServer, controller.NewSyncController(databaseAPI, options))
-	signv1.RegisterSignServiceServer(grpcServer, controller.NewSignController(storeAPI))
+	signv1.RegisterSignServiceServer(grpcServer, controller.NewSignController(storeAPI, options))
 
-	// Register server
-	reflection.Register(grpcServer)
+	// Expose the API descriptors to tools such as grpcurl if enabled
+	if options.Config().EnableReflection {
+		reflection.Register(grpcServer)
+	}
 
 	return &Server{
 		options:            options,
@@ -159,6 +289,9 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		authnService:       authnService,
 		authzService:       authzService,
 		publicationService: publicationService,
+		expiryService:      expiryService,
+		metricsService:     metricsService,
+		tracingService:     tracingService,
 		healthzServer:      healthz.NewHealthServer(cfg.HealthCheckAddress),
 		grpcServer:         grpcServer,
 	}, nil
@@ -208,7 +341,35 @@ func (s Server) Close() {
 		}
 	}
 
+	// Stop expiry service if running
+	if s.expiryService != nil {
+		if err := s.expiryService.Stop(); err != nil {
+			logger.Error("Failed to stop expiry service", "error", err)
+		}
+	}
+
+	// Stop metrics service if running
+	if s.metricsService != nil {
+		if err := s.metricsService.Stop(); err != nil {
+			logger.Error("Failed to stop metrics service", "error", err)
+		}
+	}
+
 	s.grpcServer.GracefulStop()
+
+	// Stop store background workers, such as webhook notifications, once no more pushes can arrive
+	if closer, ok := s.store.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Error("Failed to close store", "error", err)
+		}
+	}
+
+	// Stop tracing service last to flush spans of in-flight requests
+	if s.tracingService != nil {
+		if err := s.tracingService.Stop(); err != nil {
+			logger.Error("Failed to stop tracing service", "error", err)
+		}
+	}
 }
 
 func (s Server) start(ctx context.Context) error {
@@ -230,6 +391,24 @@ func (s Server) start(ctx context.Context) error {
 		logger.Info("Publication service started")
 	}
 
+	// Start expiry service
+	if s.expiryService != nil {
+		if err := s.expiryService.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start expiry service: %w", err)
+		}
+
+		logger.Info("Expiry service started")
+	}
+
+	// Start metrics service
+	if s.metricsService != nil {
+		if err := s.metricsService.Start(); err != nil {
+			return fmt.Errorf("failed to start metrics service: %w", err)
+		}
+
+		logger.Info("Metrics service started")
+	}
+
 	// Create a listener on TCP port
 	listen, err := net.Listen("tcp", s.Options().Config().ListenAddress) //nolint:noctx
 	if err != nil {
@@ -0,0 +1,110 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBootstrapRouting records the bootstrap peers it is updated with.
+type fakeBootstrapRouting struct {
+	types.RoutingAPI
+	updates chan []string
+}
+
+func (f *fakeBootstrapRouting) UpdateBootstrapPeers(_ context.Context, addrs []string) error {
+	f.updates <- addrs
+
+	return nil
+}
+
+// writeConfigFile writes a server config file with the given bootstrap peers.
+func writeConfigFile(t *testing.T, path string, peers ...string) {
+	t.Helper()
+
+	content := "routing:\n  bootstrap_peers:\n"
+	for _, p := range peers {
+		content += "    - " + p + "\n"
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReload(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "server.config.yml")
+	routing := &fakeBootstrapRouting{updates: make(chan []string, 1)}
+	server := Server{routing: routing}
+
+	t.Run("applies the bootstrap peers", func(t *testing.T) {
+		writeConfigFile(t, configFile, "/ip4/1.1.1.1/tcp/1/p2p/peer-a", "/ip4/2.2.2.2/tcp/1/p2p/peer-b")
+
+		server.reload(t.Context(), configFile)
+
+		assert.Equal(t, []string{"/ip4/1.1.1.1/tcp/1/p2p/peer-a", "/ip4/2.2.2.2/tcp/1/p2p/peer-b"}, <-routing.updates)
+	})
+
+	t.Run("keeps the peers on an invalid config", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configFile, []byte("routing: ["), 0o600))
+
+		server.reload(t.Context(), configFile)
+
+		assert.Empty(t, routing.updates)
+	})
+
+	t.Run("ignores routing without reload support", func(t *testing.T) {
+		writeConfigFile(t, configFile, "/ip4/1.1.1.1/tcp/1/p2p/peer-a")
+
+		Server{}.reload(t.Context(), configFile)
+
+		assert.Empty(t, routing.updates)
+	})
+}
+
+func TestWatchReloads(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "server.config.yml")
+	writeConfigFile(t, configFile, "/ip4/1.1.1.1/tcp/1/p2p/peer-a")
+
+	routing := &fakeBootstrapRouting{updates: make(chan []string)}
+	server := Server{routing: routing}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	reloadCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		server.watchReloads(ctx, reloadCh, configFile)
+	}()
+
+	// Every signal triggers a reload
+	for range 2 {
+		reloadCh <- syscall.SIGHUP
+
+		select {
+		case peers := <-routing.updates:
+			assert.Equal(t, []string{"/ip4/1.1.1.1/tcp/1/p2p/peer-a"}, peers)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	}
+
+	// Canceling the context stops watching
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watching reloads did not stop")
+	}
+}
@@ -9,11 +9,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-datastore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -44,7 +47,7 @@ func (s *cachedStore) Push(ctx context.Context, record *corev1.Record) (*corev1.
 	}
 
 	// Cache the record after successful push
-	if err := s.cacheRecord(ctx, record); err != nil {
+	if err := s.cacheRecord(ctx, ref.GetCid(), record); err != nil {
 		logger.Debug("Failed to cache record", "cid", ref.GetCid(), "error", err)
 	}
 
@@ -52,18 +55,24 @@ func (s *cachedStore) Push(ctx context.Context, record *corev1.Record) (*corev1.
 }
 
 // Pull pulls a record from cache first, then from source store if not found.
+// With types.WithCacheBypass, the record is pulled from the source store and cached.
 func (s *cachedStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
 	cid := ref.GetCid()
-	logger.Debug("Pull: checking cache first", "cid", cid)
 
-	// Try to get from cache first
-	if record, err := s.getRecordFromCache(ctx, cid); err == nil {
-		logger.Debug("Pull: cache hit", "cid", cid)
+	if types.CacheBypassFromContext(ctx) {
+		logger.Debug("Pull: bypassing cache, forwarding to source store", "cid", cid)
+	} else {
+		logger.Debug("Pull: checking cache first", "cid", cid)
 
-		return record, nil
-	}
+		// Try to get from cache first
+		if record, err := s.getRecordFromCache(ctx, cid); err == nil {
+			logger.Debug("Pull: cache hit", "cid", cid)
+
+			return record, nil
+		}
 
-	logger.Debug("Pull: cache miss, forwarding to source store", "cid", cid)
+		logger.Debug("Pull: cache miss, forwarding to source store", "cid", cid)
+	}
 
 	// Not in cache, get from source store
 	record, err := s.source.Pull(ctx, ref)
@@ -72,7 +81,7 @@ func (s *cachedStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.
 	}
 
 	// Cache the record for future requests
-	if err := s.cacheRecord(ctx, record); err != nil {
+	if err := s.cacheRecord(ctx, cid, record); err != nil {
 		logger.Debug("Failed to cache record", "cid", cid, "error", err)
 	}
 
@@ -80,18 +89,24 @@ func (s *cachedStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.
 }
 
 // Lookup looks up record metadata from cache first, then from source store if not found.
+// With types.WithCacheBypass, the metadata is looked up in the source store and cached.
 func (s *cachedStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
 	cid := ref.GetCid()
-	logger.Debug("Lookup: checking cache first", "cid", cid)
 
-	// Try to get metadata from cache first
-	if meta, err := s.getMetaFromCache(ctx, cid); err == nil {
-		logger.Debug("Lookup: cache hit", "cid", cid)
+	if types.CacheBypassFromContext(ctx) {
+		logger.Debug("Lookup: bypassing cache, forwarding to source store", "cid", cid)
+	} else {
+		logger.Debug("Lookup: checking cache first", "cid", cid)
 
-		return meta, nil
-	}
+		// Try to get metadata from cache first
+		if meta, err := s.getMetaFromCache(ctx, cid); err == nil {
+			logger.Debug("Lookup: cache hit", "cid", cid)
 
-	logger.Debug("Lookup: cache miss, forwarding to source store", "cid", cid)
+			return meta, nil
+		}
+
+		logger.Debug("Lookup: cache miss, forwarding to source store", "cid", cid)
+	}
 
 	// Not in cache, get from source store
 	meta, err := s.source.Lookup(ctx, ref)
@@ -107,6 +122,64 @@ func (s *cachedStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev
 	return meta, nil
 }
 
+// Exists reports whether the record is in cache, then checks the source store if not found.
+func (s *cachedStore) Exists(ctx context.Context, ref *corev1.RecordRef) (bool, error) {
+	if _, err := s.getMetaFromCache(ctx, ref.GetCid()); err == nil {
+		return true, nil
+	}
+
+	return types.RecordExists(ctx, s.source, ref)
+}
+
+// LookupMany looks up metadata of multiple records from cache first,
+// then from source store for the records not found in cache.
+func (s *cachedStore) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	metas := make([]*corev1.RecordMeta, len(refs))
+
+	var (
+		missIndexes []int
+		missRefs    []*corev1.RecordRef
+	)
+
+	for i, ref := range refs {
+		if meta, err := s.getMetaFromCache(ctx, ref.GetCid()); err == nil {
+			metas[i] = meta
+
+			continue
+		}
+
+		missIndexes = append(missIndexes, i)
+		missRefs = append(missRefs, ref)
+	}
+
+	logger.Debug("LookupMany: checked cache", "hits", len(refs)-len(missRefs), "misses", len(missRefs))
+
+	if len(missRefs) == 0 {
+		return metas, nil
+	}
+
+	// Get cache misses from source store
+	sourceMetas, err := s.source.LookupMany(ctx, missRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, meta := range sourceMetas {
+		if meta == nil {
+			continue
+		}
+
+		metas[missIndexes[j]] = meta
+
+		// Cache the metadata for future requests
+		if err := s.cacheMeta(ctx, meta); err != nil {
+			logger.Debug("Failed to cache metadata", "cid", meta.GetCid(), "error", err)
+		}
+	}
+
+	return metas, nil
+}
+
 // Delete removes a record from both cache and source store.
 func (s *cachedStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
 	cid := ref.GetCid()
@@ -119,9 +192,70 @@ func (s *cachedStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
 	return s.source.Delete(ctx, ref)
 }
 
-// cacheRecord stores a record in the cache.
-func (s *cachedStore) cacheRecord(ctx context.Context, record *corev1.Record) error {
-	cid := record.GetCid()
+// ListRecordCIDs lists the records of the source store.
+// Listing always bypasses the cache as it only holds a subset of records.
+func (s *cachedStore) ListRecordCIDs(ctx context.Context) ([]string, error) {
+	lister, ok := s.source.(types.RecordListerAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "listing records not supported by source store")
+	}
+
+	return lister.ListRecordCIDs(ctx)
+}
+
+// ResolvePrefix resolves an abbreviated CID using the source store.
+// Resolution always bypasses the cache as it only holds a subset of records.
+func (s *cachedStore) ResolvePrefix(ctx context.Context, prefix string) (string, error) {
+	resolver, ok := s.source.(types.PrefixResolverAPI)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "resolving CID prefixes not supported by source store")
+	}
+
+	return resolver.ResolvePrefix(ctx, prefix)
+}
+
+// Stats reports the statistics and limits of the source store.
+func (s *cachedStore) Stats(ctx context.Context, includeUsage bool) (*types.StoreStats, error) {
+	statsStore, ok := s.source.(types.StoreStatsAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "stats not supported by source store")
+	}
+
+	return statsStore.Stats(ctx, includeUsage)
+}
+
+// PullLayers lists the record manifest layers of the source store.
+func (s *cachedStore) PullLayers(ctx context.Context, ref *corev1.RecordRef) ([]types.LayerInfo, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayers(ctx, ref)
+}
+
+// PullLayer pulls a record manifest layer from the source store.
+func (s *cachedStore) PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (io.ReadCloser, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayer(ctx, ref, digest)
+}
+
+// PullRecordBlob pulls the stored record bytes from the source store.
+func (s *cachedStore) PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) ([]byte, error) {
+	blobStore, ok := s.source.(types.RecordBlobAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record blobs not supported by source store")
+	}
+
+	return blobStore.PullRecordBlob(ctx, ref)
+}
+
+// cacheRecord stores a record in the cache under the CID it was pushed or pulled with.
+func (s *cachedStore) cacheRecord(ctx context.Context, cid string, record *corev1.Record) error {
 	if cid == "" {
 		return errors.New("record has no CID")
 	}
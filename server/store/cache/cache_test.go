@@ -9,6 +9,7 @@ import (
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,21 @@ func (m *MockStoreAPI) Lookup(ctx context.Context, ref *corev1.RecordRef) (*core
 	return meta, args.Error(1) //nolint:wrapcheck // Mock should return exact error without wrapping
 }
 
+func (m *MockStoreAPI) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	args := m.Called(ctx, refs)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:wrapcheck // Mock should return exact error without wrapping
+	}
+
+	metas, ok := args.Get(0).([]*corev1.RecordMeta)
+	if !ok {
+		panic("MockStoreAPI.LookupMany: expected []*corev1.RecordMeta, got different type")
+	}
+
+	return metas, args.Error(1) //nolint:wrapcheck // Mock should return exact error without wrapping
+}
+
 func (m *MockStoreAPI) Delete(ctx context.Context, ref *corev1.RecordRef) error {
 	args := m.Called(ctx, ref)
 
@@ -115,6 +131,38 @@ func TestCachedStore_Push(t *testing.T) {
 	mockStore.AssertExpectations(t)
 }
 
+func TestCachedStore_Push_StoredCID(t *testing.T) {
+	ctx := t.Context()
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		Description:   "Test <agent>",
+		Version:       "1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+
+	// The source store pushes the record in a canonical form other than the default
+	storedRef := &corev1.RecordRef{Cid: record.GetCanonicalCid(corev1.CanonicalModeJCS)}
+	require.NotEqual(t, record.GetCid(), storedRef.GetCid())
+
+	mockStore := &MockStoreAPI{}
+	cache := sync.MutexWrap(datastore.NewMapDatastore())
+	cachedStore, ok := Wrap(mockStore, cache).(*cachedStore)
+	require.True(t, ok, "Wrap should return *cachedStore")
+
+	mockStore.On("Push", ctx, record).Return(storedRef, nil)
+
+	_, err := cachedStore.Push(ctx, record)
+	require.NoError(t, err)
+
+	// The record is cached under the CID returned by the source store
+	_, err = cachedStore.Pull(ctx, storedRef)
+	require.NoError(t, err)
+
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "Pull")
+}
+
 func TestCachedStore_Pull_CacheHit(t *testing.T) {
 	ctx := t.Context()
 
@@ -140,7 +188,7 @@ func TestCachedStore_Pull_CacheHit(t *testing.T) {
 	require.True(t, ok, "Wrap should return *cachedStore")
 
 	// Pre-cache the record
-	err = cachedStore.cacheRecord(ctx, record)
+	err = cachedStore.cacheRecord(ctx, recordCID, record)
 	require.NoError(t, err)
 
 	// Test Pull - should hit cache and not call source store
@@ -265,6 +313,151 @@ func TestCachedStore_Lookup_CacheMiss(t *testing.T) {
 	mockStore.AssertExpectations(t)
 }
 
+// spyCache is a cache datastore recording the keys read from and written to it.
+type spyCache struct {
+	types.Datastore
+
+	gets []string
+	puts []string
+}
+
+func newSpyCache() *spyCache {
+	return &spyCache{Datastore: sync.MutexWrap(datastore.NewMapDatastore())}
+}
+
+func (s *spyCache) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	s.gets = append(s.gets, key.String())
+
+	return s.Datastore.Get(ctx, key) //nolint:wrapcheck
+}
+
+func (s *spyCache) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	s.puts = append(s.puts, key.String())
+
+	return s.Datastore.Put(ctx, key, value) //nolint:wrapcheck
+}
+
+func TestCachedStore_Pull_CacheBypass(t *testing.T) {
+	ctx := types.WithCacheBypass(t.Context())
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		Description:   "Test agent",
+		Version:       "1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+	recordKey := "/record/" + record.GetCid()
+
+	// Create mock store and spy cache
+	mockStore := &MockStoreAPI{}
+	cache := newSpyCache()
+	cachedStore, ok := Wrap(mockStore, cache).(*cachedStore)
+	require.True(t, ok, "Wrap should return *cachedStore")
+
+	// Pre-cache the record
+	err := cachedStore.cacheRecord(ctx, ref.GetCid(), record)
+	require.NoError(t, err)
+
+	cache.puts = nil
+
+	mockStore.On("Pull", ctx, ref).Return(record, nil)
+
+	// Test Pull - should skip the cache read and call source store
+	pulledRecord, err := cachedStore.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulledRecord.GetCid())
+
+	mockStore.AssertExpectations(t)
+	assert.NotContains(t, cache.gets, recordKey)
+
+	// The pulled record still refreshes the cache
+	assert.Contains(t, cache.puts, recordKey)
+}
+
+func TestCachedStore_Lookup_CacheBypass(t *testing.T) {
+	ctx := types.WithCacheBypass(t.Context())
+
+	recordCID := "test-cid-123"
+	ref := &corev1.RecordRef{Cid: recordCID}
+	metaKey := "/meta/" + recordCID
+
+	meta := &corev1.RecordMeta{
+		Cid:           recordCID,
+		Annotations:   map[string]string{"test": "value"},
+		SchemaVersion: "v0.3.1",
+		CreatedAt:     "2023-01-01T00:00:00Z",
+	}
+
+	// Create mock store and spy cache
+	mockStore := &MockStoreAPI{}
+	cache := newSpyCache()
+	cachedStore, ok := Wrap(mockStore, cache).(*cachedStore)
+	require.True(t, ok, "Wrap should return *cachedStore")
+
+	// Pre-cache stale metadata
+	err := cachedStore.cacheMeta(ctx, &corev1.RecordMeta{Cid: recordCID})
+	require.NoError(t, err)
+
+	cache.puts = nil
+
+	mockStore.On("Lookup", ctx, ref).Return(meta, nil)
+
+	// Test Lookup - should skip the cache read and call source store
+	lookedUpMeta, err := cachedStore.Lookup(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, meta.GetAnnotations(), lookedUpMeta.GetAnnotations())
+
+	mockStore.AssertExpectations(t)
+	assert.NotContains(t, cache.gets, metaKey)
+
+	// The looked up metadata still refreshes the cache
+	assert.Contains(t, cache.puts, metaKey)
+
+	// Reads without the bypass see the refreshed metadata
+	cachedMeta, err := cachedStore.Lookup(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, meta.GetAnnotations(), cachedMeta.GetAnnotations())
+	mockStore.AssertNumberOfCalls(t, "Lookup", 1)
+}
+
+func TestCachedStore_LookupMany(t *testing.T) {
+	ctx := t.Context()
+
+	cachedRef := &corev1.RecordRef{Cid: "cached-cid"}
+	sourceRef := &corev1.RecordRef{Cid: "source-cid"}
+	missingRef := &corev1.RecordRef{Cid: "missing-cid"}
+
+	cachedMeta := &corev1.RecordMeta{Cid: cachedRef.GetCid()}
+	sourceMeta := &corev1.RecordMeta{Cid: sourceRef.GetCid()}
+
+	// Create mock store and cache
+	mockStore := &MockStoreAPI{}
+	cache := sync.MutexWrap(datastore.NewMapDatastore())
+	cachedStore, ok := Wrap(mockStore, cache).(*cachedStore)
+	require.True(t, ok, "Wrap should return *cachedStore")
+
+	// Pre-cache the metadata of the first record
+	require.NoError(t, cachedStore.cacheMeta(ctx, cachedMeta))
+
+	// Only cache misses are looked up in the source store
+	mockStore.On("LookupMany", ctx, []*corev1.RecordRef{sourceRef, missingRef}).
+		Return([]*corev1.RecordMeta{sourceMeta, nil}, nil)
+
+	metas, err := cachedStore.LookupMany(ctx, []*corev1.RecordRef{cachedRef, sourceRef, missingRef})
+	require.NoError(t, err)
+	require.Len(t, metas, 3)
+	assert.Equal(t, cachedRef.GetCid(), metas[0].GetCid())
+	assert.Equal(t, sourceRef.GetCid(), metas[1].GetCid())
+	assert.Nil(t, metas[2])
+
+	mockStore.AssertExpectations(t)
+
+	// Found records are cached
+	_, err = cachedStore.getMetaFromCache(ctx, sourceRef.GetCid())
+	require.NoError(t, err)
+}
+
 func TestCachedStore_Delete(t *testing.T) {
 	ctx := t.Context()
 
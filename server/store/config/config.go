@@ -5,10 +5,15 @@ package config
 
 import (
 	oci "github.com/agntcy/dir/server/store/oci/config"
+	upstream "github.com/agntcy/dir/server/store/upstream/config"
+	webhook "github.com/agntcy/dir/server/store/webhook/config"
 )
 
 const (
 	DefaultProvider = "oci"
+
+	// DefaultMultiQuorum requires all child stores to accept a push.
+	DefaultMultiQuorum = 0
 )
 
 type Config struct {
@@ -17,4 +22,26 @@ type Config struct {
 
 	// Config for OCI database.
 	OCI oci.Config `json:"oci,omitempty" mapstructure:"oci"`
+
+	// Config for the multi store, used when provider is "multi".
+	Multi MultiConfig `json:"multi,omitempty" mapstructure:"multi"`
+
+	// Webhook notified after each successful push.
+	// Only used for the top-level store, not for child stores.
+	Webhook webhook.Config `json:"webhook,omitempty" mapstructure:"webhook"`
+
+	// Upstream directory that records missing locally are fetched from and cached.
+	// Only used for the top-level store, not for child stores.
+	Upstream upstream.Config `json:"upstream,omitempty" mapstructure:"upstream"`
+}
+
+// MultiConfig configures a store that fans out writes to multiple child stores.
+type MultiConfig struct {
+	// Stores are the child store configurations.
+	// Reads are served by the first child store that has the record.
+	Stores []Config `json:"stores,omitempty" mapstructure:"stores"`
+
+	// Quorum is the number of child stores that must accept a push for it to succeed.
+	// If zero, all child stores must accept it.
+	Quorum int `json:"quorum,omitempty" mapstructure:"quorum"`
 }
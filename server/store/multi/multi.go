@@ -0,0 +1,518 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("store/multi")
+
+// multiStore fans out writes to multiple stores and reads from the first store that has the data.
+type multiStore struct {
+	stores []types.StoreAPI
+	quorum int
+}
+
+// New creates a store that wraps the given stores.
+//
+// Push and PushReferrer succeed if at least quorum stores accept the write.
+// If the quorum is not reached, records newly written to the other stores are deleted on a best-effort basis.
+// Stores that already held the record keep it.
+// A quorum of zero requires all stores to accept the write.
+//
+// Pull, Lookup and WalkReferrers try the stores in order.
+func New(stores []types.StoreAPI, quorum int) (types.StoreAPI, error) {
+	if len(stores) == 0 {
+		return nil, errors.New("at least one store is required")
+	}
+
+	if quorum < 0 || quorum > len(stores) {
+		return nil, fmt.Errorf("invalid quorum %d for %d stores", quorum, len(stores))
+	}
+
+	if quorum == 0 {
+		quorum = len(stores)
+	}
+
+	return &multiStore{
+		stores: stores,
+		quorum: quorum,
+	}, nil
+}
+
+// fanOut calls fn for every store concurrently and returns the error of each call.
+func (s *multiStore) fanOut(fn func(i int, store types.StoreAPI) error) []error {
+	errs := make([]error, len(s.stores))
+
+	var wg sync.WaitGroup
+
+	for i, store := range s.stores {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = fn(i, store)
+		}()
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// Push pushes the record to all stores.
+func (s *multiStore) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	refs := make([]*corev1.RecordRef, len(s.stores))
+	newlyWritten := make([]bool, len(s.stores))
+
+	errs := s.fanOut(func(i int, store types.StoreAPI) error {
+		// Push is idempotent, so remember which stores did not hold the record yet.
+		// Only those are rolled back, never copies that existed before this push.
+		checkedRef := &corev1.RecordRef{Cid: record.GetCid()}
+
+		existed, checkErr := types.RecordExists(ctx, store, checkedRef)
+		if checkErr != nil {
+			logger.Warn("Failed to check record before push", "index", i, "cid", checkedRef.GetCid(), "error", checkErr)
+		}
+
+		ref, err := store.Push(ctx, record)
+		refs[i] = ref
+
+		// Stores that could not be checked or that computed a different CID are not rolled back either
+		newlyWritten[i] = err == nil && checkErr == nil && !existed && ref.GetCid() == checkedRef.GetCid()
+
+		return err
+	})
+
+	var (
+		ref       *corev1.RecordRef
+		succeeded int
+	)
+
+	for i, err := range errs {
+		if err != nil {
+			logger.Warn("Failed to push record to store", "index", i, "error", err)
+
+			continue
+		}
+
+		ref = refs[i]
+		succeeded++
+	}
+
+	if succeeded >= s.quorum {
+		return ref, nil
+	}
+
+	// Roll back the partial push
+	for i, store := range s.stores {
+		if !newlyWritten[i] {
+			continue
+		}
+
+		if err := store.Delete(ctx, refs[i]); err != nil {
+			logger.Warn("Failed to roll back partial push", "index", i, "cid", refs[i].GetCid(), "error", err)
+		}
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "push accepted by %d of %d stores, quorum is %d: %v",
+		succeeded, len(s.stores), s.quorum, errors.Join(errs...))
+}
+
+// Pull pulls the record from the first store that has it.
+func (s *multiStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	var lastErr error
+
+	for _, store := range s.stores {
+		record, err := store.Pull(ctx, ref)
+		if err == nil {
+			return record, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// Lookup looks up the record metadata in the first store that has it.
+func (s *multiStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	var lastErr error
+
+	for _, store := range s.stores {
+		meta, err := store.Lookup(ctx, ref)
+		if err == nil {
+			return meta, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// Exists reports whether any of the stores has the record.
+func (s *multiStore) Exists(ctx context.Context, ref *corev1.RecordRef) (bool, error) {
+	var lastErr error
+
+	for _, store := range s.stores {
+		exists, err := types.RecordExists(ctx, store, ref)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		if exists {
+			return true, nil
+		}
+	}
+
+	return false, lastErr
+}
+
+// LookupMany looks up the records in order, querying the next store only for records not found so far.
+func (s *multiStore) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	metas := make([]*corev1.RecordMeta, len(refs))
+
+	missIndexes := make([]int, len(refs))
+	for i := range refs {
+		missIndexes[i] = i
+	}
+
+	var lastErr error
+
+	for _, store := range s.stores {
+		if len(missIndexes) == 0 {
+			break
+		}
+
+		missRefs := make([]*corev1.RecordRef, len(missIndexes))
+		for i, idx := range missIndexes {
+			missRefs[i] = refs[idx]
+		}
+
+		found, err := store.LookupMany(ctx, missRefs)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		var stillMissing []int
+
+		for i, idx := range missIndexes {
+			if found[i] != nil {
+				metas[idx] = found[i]
+			} else {
+				stillMissing = append(stillMissing, idx)
+			}
+		}
+
+		missIndexes = stillMissing
+	}
+
+	// Only fail if no store could be queried at all
+	if lastErr != nil && len(missIndexes) == len(refs) {
+		return nil, lastErr
+	}
+
+	return metas, nil
+}
+
+// Delete deletes the record from all stores.
+// Stores that do not have the record are ignored, unless none of the stores has it.
+func (s *multiStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+	errs := s.fanOut(func(_ int, store types.StoreAPI) error {
+		return store.Delete(ctx, ref)
+	})
+
+	var (
+		failed   []error
+		notFound int
+	)
+
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case status.Code(err) == codes.NotFound:
+			notFound++
+		default:
+			failed = append(failed, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return status.Errorf(codes.Internal, "failed to delete record from %d of %d stores: %v",
+			len(failed), len(s.stores), errors.Join(failed...))
+	}
+
+	if notFound == len(s.stores) {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// PushReferrer pushes the referrer to all stores.
+// Stores that do not support referrers count as failed.
+// Referrers cannot be deleted, so a partial push is not rolled back.
+func (s *multiStore) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
+	errs := s.fanOut(func(_ int, store types.StoreAPI) error {
+		refStore, ok := store.(types.ReferrerStoreAPI)
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "store %T does not support referrers", store)
+		}
+
+		return refStore.PushReferrer(ctx, recordCID, referrer)
+	})
+
+	var succeeded int
+
+	for i, err := range errs {
+		if err != nil {
+			logger.Warn("Failed to push referrer to store", "index", i, "cid", recordCID, "error", err)
+
+			continue
+		}
+
+		succeeded++
+	}
+
+	if succeeded < s.quorum {
+		return status.Errorf(codes.Unavailable, "referrer push accepted by %d of %d stores, quorum is %d: %v",
+			succeeded, len(s.stores), s.quorum, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// WalkReferrers walks the referrers of the first store that supports them and has the record.
+func (s *multiStore) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
+	lastErr := status.Errorf(codes.Unimplemented, "no store supports referrers")
+
+	for _, store := range s.stores {
+		refStore, ok := store.(types.ReferrerStoreAPI)
+		if !ok {
+			continue
+		}
+
+		// Make sure the record is present, so that referrers are not walked on a store missing it
+		if _, err := store.Lookup(ctx, &corev1.RecordRef{Cid: recordCID}); err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		return refStore.WalkReferrers(ctx, recordCID, referrerType, walkFn)
+	}
+
+	return lastErr
+}
+
+// PullLayers lists the record manifest layers of the first store that supports layers and has the record.
+func (s *multiStore) PullLayers(ctx context.Context, ref *corev1.RecordRef) ([]types.LayerInfo, error) {
+	lastErr := status.Errorf(codes.Unimplemented, "no store supports layers")
+
+	for _, store := range s.stores {
+		layerStore, ok := store.(types.LayerStoreAPI)
+		if !ok {
+			continue
+		}
+
+		layers, err := layerStore.PullLayers(ctx, ref)
+		if err == nil {
+			return layers, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// PullLayer pulls a record manifest layer from the first store that supports layers and has it.
+func (s *multiStore) PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (io.ReadCloser, error) {
+	lastErr := status.Errorf(codes.Unimplemented, "no store supports layers")
+
+	for _, store := range s.stores {
+		layerStore, ok := store.(types.LayerStoreAPI)
+		if !ok {
+			continue
+		}
+
+		reader, err := layerStore.PullLayer(ctx, ref, digest)
+		if err == nil {
+			return reader, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// PullRecordBlob pulls the stored record bytes from the first store that supports record blobs and has the record.
+func (s *multiStore) PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) ([]byte, error) {
+	lastErr := status.Errorf(codes.Unimplemented, "no store supports record blobs")
+
+	for _, store := range s.stores {
+		blobStore, ok := store.(types.RecordBlobAPI)
+		if !ok {
+			continue
+		}
+
+		data, err := blobStore.PullRecordBlob(ctx, ref)
+		if err == nil {
+			return data, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// Stats reports the strictest limits of all stores and the usage of the first store reporting stats.
+// Stores that do not report stats are ignored.
+func (s *multiStore) Stats(ctx context.Context, includeUsage bool) (*types.StoreStats, error) {
+	result := &types.StoreStats{}
+
+	for _, store := range s.stores {
+		statsStore, ok := store.(types.StoreStatsAPI)
+		if !ok {
+			continue
+		}
+
+		// Usage is only computed once, as secondary stores mirror the first one
+		stats, err := statsStore.Stats(ctx, includeUsage && result.Usage == nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Usage == nil {
+			result.Usage = stats.Usage
+		}
+
+		if limit := stats.MaxRecordBytes; limit > 0 && (result.MaxRecordBytes == 0 || limit < result.MaxRecordBytes) {
+			result.MaxRecordBytes = limit
+		}
+	}
+
+	return result, nil
+}
+
+// ListRecordCIDs lists the records held by any of the stores that support listing.
+// Every such store is listed, so that records missing from the first store are not overlooked.
+func (s *multiStore) ListRecordCIDs(ctx context.Context) ([]string, error) {
+	var (
+		cids      []string
+		seen      = make(map[string]bool)
+		supported bool
+	)
+
+	for i, store := range s.stores {
+		lister, ok := store.(types.RecordListerAPI)
+		if !ok {
+			continue
+		}
+
+		supported = true
+
+		storeCIDs, err := lister.ListRecordCIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of store %d: %w", i, err)
+		}
+
+		for _, cid := range storeCIDs {
+			if !seen[cid] {
+				seen[cid] = true
+				cids = append(cids, cid)
+			}
+		}
+	}
+
+	if !supported {
+		return nil, status.Error(codes.Unimplemented, "listing records not supported by any store")
+	}
+
+	return cids, nil
+}
+
+// ResolvePrefix resolves an abbreviated CID using the first store that supports it and has a matching record.
+// Ambiguous prefixes and other errors than NotFound are returned as is.
+func (s *multiStore) ResolvePrefix(ctx context.Context, prefix string) (string, error) {
+	lastErr := status.Error(codes.Unimplemented, "resolving CID prefixes not supported by any store")
+
+	for _, store := range s.stores {
+		resolver, ok := store.(types.PrefixResolverAPI)
+		if !ok {
+			continue
+		}
+
+		cid, err := resolver.ResolvePrefix(ctx, prefix)
+		if err == nil {
+			return cid, nil
+		}
+
+		lastErr = err
+
+		if code := status.Code(err); code != codes.NotFound && code != codes.Unimplemented {
+			return "", err
+		}
+	}
+
+	return "", lastErr
+}
+
+// GarbageCollect deletes unreferenced blobs of all stores that support garbage collection.
+func (s *multiStore) GarbageCollect(ctx context.Context, dryRun bool) (*types.GarbageCollectResult, error) {
+	var (
+		result    = &types.GarbageCollectResult{}
+		supported bool
+	)
+
+	for i, store := range s.stores {
+		collector, ok := store.(types.GarbageCollectorAPI)
+		if !ok {
+			continue
+		}
+
+		storeResult, err := collector.GarbageCollect(ctx, dryRun)
+		if status.Code(err) == codes.FailedPrecondition {
+			// E.g. remote registries run their own garbage collection
+			logger.Debug("Skipping garbage collection of store", "index", i, "reason", err)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to garbage collect store %d: %w", i, err)
+		}
+
+		supported = true
+
+		result.DeletedBlobs = append(result.DeletedBlobs, storeResult.DeletedBlobs...)
+		result.ReclaimedBytes += storeResult.ReclaimedBytes
+	}
+
+	if !supported {
+		return nil, status.Error(codes.FailedPrecondition, "garbage collection not supported by any store")
+	}
+
+	return result, nil
+}
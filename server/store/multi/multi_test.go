@@ -0,0 +1,220 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package multi
+
+import (
+	"context"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/store/oci"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingStore is a store whose writes always fail.
+type failingStore struct {
+	types.StoreAPI
+}
+
+func (f *failingStore) Push(context.Context, *corev1.Record) (*corev1.RecordRef, error) {
+	return nil, status.Error(codes.Unavailable, "store is down")
+}
+
+func (f *failingStore) Lookup(context.Context, *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	return nil, status.Error(codes.Unavailable, "store is down")
+}
+
+func (f *failingStore) Delete(context.Context, *corev1.RecordRef) error {
+	return status.Error(codes.Unavailable, "store is down")
+}
+
+func newLocalStore(t *testing.T) types.StoreAPI {
+	t.Helper()
+
+	store, err := oci.New(ociconfig.Config{LocalDir: t.TempDir()})
+	require.NoError(t, err)
+
+	return store
+}
+
+func newTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+func TestNew(t *testing.T) {
+	store := newLocalStore(t)
+
+	_, err := New(nil, 0)
+	require.Error(t, err)
+
+	_, err = New([]types.StoreAPI{store}, 2)
+	require.Error(t, err)
+
+	_, err = New([]types.StoreAPI{store}, -1)
+	require.Error(t, err)
+
+	_, err = New([]types.StoreAPI{store}, 1)
+	require.NoError(t, err)
+}
+
+func TestPushFanOut(t *testing.T) {
+	ctx := t.Context()
+	first, second := newLocalStore(t), newLocalStore(t)
+
+	store, err := New([]types.StoreAPI{first, second}, 0)
+	require.NoError(t, err)
+
+	ref, err := store.Push(ctx, newTestRecord("fan-out"))
+	require.NoError(t, err)
+
+	for _, child := range []types.StoreAPI{first, second} {
+		_, err := child.Lookup(ctx, ref)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, store.Delete(ctx, ref))
+
+	for _, child := range []types.StoreAPI{first, second} {
+		_, err := child.Lookup(ctx, ref)
+		require.Error(t, err)
+	}
+}
+
+func TestPushQuorum(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("quorum reached", func(t *testing.T) {
+		healthy := newLocalStore(t)
+
+		store, err := New([]types.StoreAPI{&failingStore{}, healthy}, 1)
+		require.NoError(t, err)
+
+		ref, err := store.Push(ctx, newTestRecord("quorum-reached"))
+		require.NoError(t, err)
+
+		_, err = healthy.Lookup(ctx, ref)
+		require.NoError(t, err)
+	})
+
+	t.Run("quorum not reached rolls back", func(t *testing.T) {
+		healthy := newLocalStore(t)
+
+		store, err := New([]types.StoreAPI{healthy, &failingStore{}}, 0)
+		require.NoError(t, err)
+
+		record := newTestRecord("quorum-not-reached")
+
+		_, err = store.Push(ctx, record)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		_, err = healthy.Lookup(ctx, &corev1.RecordRef{Cid: record.GetCid()})
+		require.Error(t, err)
+	})
+
+	t.Run("quorum not reached keeps existing copies", func(t *testing.T) {
+		holder, fresh := newLocalStore(t), newLocalStore(t)
+		record := newTestRecord("already-stored")
+
+		// The first store already holds the record before the push
+		ref, err := holder.Push(ctx, record)
+		require.NoError(t, err)
+
+		store, err := New([]types.StoreAPI{holder, fresh, &failingStore{}}, 0)
+		require.NoError(t, err)
+
+		_, err = store.Push(ctx, record)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		// Only the copy written by this push is rolled back
+		_, err = holder.Lookup(ctx, ref)
+		require.NoError(t, err)
+
+		_, err = fresh.Lookup(ctx, ref)
+		require.Error(t, err)
+	})
+}
+
+func TestReadsTryStoresInOrder(t *testing.T) {
+	ctx := t.Context()
+	first, second := newLocalStore(t), newLocalStore(t)
+
+	// Only the second store has the record
+	ref, err := second.Push(ctx, newTestRecord("second-only"))
+	require.NoError(t, err)
+
+	store, err := New([]types.StoreAPI{first, second}, 0)
+	require.NoError(t, err)
+
+	record, err := store.Pull(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), record.GetCid())
+
+	meta, err := store.Lookup(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), meta.GetCid())
+
+	missing := &corev1.RecordRef{Cid: newTestRecord("missing").GetCid()}
+
+	metas, err := store.LookupMany(ctx, []*corev1.RecordRef{missing, ref})
+	require.NoError(t, err)
+	require.Len(t, metas, 2)
+	assert.Nil(t, metas[0])
+	assert.Equal(t, ref.GetCid(), metas[1].GetCid())
+
+	_, err = store.Pull(ctx, missing)
+	require.Error(t, err)
+}
+
+func TestForwardedListingAndMaintenance(t *testing.T) {
+	ctx := t.Context()
+	first, second := newLocalStore(t), newLocalStore(t)
+
+	firstRef, err := first.Push(ctx, newTestRecord("first-only"))
+	require.NoError(t, err)
+
+	secondRef, err := second.Push(ctx, newTestRecord("second-only"))
+	require.NoError(t, err)
+
+	store, err := New([]types.StoreAPI{first, second}, 0)
+	require.NoError(t, err)
+
+	t.Run("list records of all stores", func(t *testing.T) {
+		lister, ok := store.(types.RecordListerAPI)
+		require.True(t, ok)
+
+		cids, err := lister.ListRecordCIDs(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{firstRef.GetCid(), secondRef.GetCid()}, cids)
+	})
+
+	t.Run("resolve prefix in any store", func(t *testing.T) {
+		resolver, ok := store.(types.PrefixResolverAPI)
+		require.True(t, ok)
+
+		cid, err := resolver.ResolvePrefix(ctx, secondRef.GetCid()[:len(secondRef.GetCid())-4])
+		require.NoError(t, err)
+		assert.Equal(t, secondRef.GetCid(), cid)
+	})
+
+	t.Run("garbage collect all stores", func(t *testing.T) {
+		collector, ok := store.(types.GarbageCollectorAPI)
+		require.True(t, ok)
+
+		result, err := collector.GarbageCollect(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, result.DeletedBlobs)
+	})
+}
@@ -6,6 +6,7 @@ package oci
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types/adapters"
@@ -68,14 +69,23 @@ func extractManifestAnnotations(record *corev1.Record) map[string]string {
 		annotations[ManifestKeySkills] = strings.Join(skillNames, ",")
 	}
 
-	// Extract locator types
+	// Extract locator types, and artifact sizes in the same order if any are known
 	if locators := recordData.GetLocators(); len(locators) > 0 {
 		locatorTypes := make([]string, len(locators))
+		locatorSizes := make([]string, len(locators))
+		hasSizes := false
+
 		for i, locator := range locators {
 			locatorTypes[i] = locator.GetType()
+			locatorSizes[i] = strconv.FormatUint(locator.GetSize(), 10)
+			hasSizes = hasSizes || locator.GetSize() > 0
 		}
 
 		annotations[ManifestKeyLocatorTypes] = strings.Join(locatorTypes, ",")
+
+		if hasSizes {
+			annotations[ManifestKeyLocatorSizes] = strings.Join(locatorSizes, ",")
+		}
 	}
 
 	// Extract module names
@@ -117,6 +127,21 @@ func extractManifestAnnotations(record *corev1.Record) map[string]string {
 	return annotations
 }
 
+// addPushAnnotations adds the annotations attached on push to the manifest annotations as custom annotations,
+// so they are reported in the record metadata like record annotations. They take precedence over
+// record annotations of the same key. Manifest annotations do not affect the record CID.
+func addPushAnnotations(annotations map[string]string, pushAnnotations map[string]string) {
+	for key, value := range pushAnnotations {
+		annotations[ManifestKeyCustomPrefix+key] = value
+	}
+}
+
+// addPushExpiry records the expiry time set on push in the manifest annotations.
+// Like other manifest annotations, it does not affect the record CID.
+func addPushExpiry(annotations map[string]string, expiresAt time.Time) {
+	annotations[ManifestKeyExpiresAt] = expiresAt.UTC().Format(time.RFC3339)
+}
+
 // parseManifestAnnotations extracts structured metadata from manifest annotations.
 //
 //nolint:cyclop // Function handles multiple metadata extraction paths with justified complexity
@@ -142,6 +167,11 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 		recordMeta.CreatedAt = createdAt
 	}
 
+	// Extract expiry time set on push, if any
+	if expiresAt := annotations[ManifestKeyExpiresAt]; expiresAt != "" {
+		recordMeta.ExpiresAt = expiresAt
+	}
+
 	// Copy structured metadata into annotations for easy access
 	// Core identity - these will be easily accessible to consumers
 	if name := annotations[ManifestKeyName]; name != "" {
@@ -180,6 +210,10 @@ func parseManifestAnnotations(annotations map[string]string) *corev1.RecordMeta
 		recordMeta.Annotations[MetadataKeyLocatorTypesCount] = strconv.Itoa(len(locatorList))
 	}
 
+	if locatorSizes := annotations[ManifestKeyLocatorSizes]; locatorSizes != "" {
+		recordMeta.Annotations[MetadataKeyLocatorSizes] = locatorSizes // comma-separated, in locator order
+	}
+
 	if moduleNames := annotations[ManifestKeyModuleNames]; moduleNames != "" {
 		recordMeta.Annotations[MetadataKeyModuleNames] = moduleNames // comma-separated
 		moduleList := parseCommaSeparated(moduleNames)
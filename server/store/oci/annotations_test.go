@@ -137,6 +137,21 @@ func TestExtractManifestAnnotations(t *testing.T) {
 				ManifestKeyCustomPrefix + "custom2": "value2",
 			},
 		},
+		{
+			name: "Locators with artifact sizes",
+			record: corev1.New(&typesv1alpha0.Record{
+				Name:          "sized-agent",
+				SchemaVersion: "v0.3.1",
+				Locators: []*typesv1alpha0.Locator{
+					{Type: "docker", Size: uint64Ptr(1024)},
+					{Type: "helm"},
+				},
+			}),
+			contains: map[string]string{
+				ManifestKeyLocatorTypes: "docker,helm",
+				ManifestKeyLocatorSizes: "1024,0",
+			},
+		},
 		{
 			name: "V1 basic record",
 			record: corev1.New(&typesv1alpha1.Record{
@@ -297,6 +312,7 @@ func TestParseManifestAnnotations(t *testing.T) {
 				ManifestKeyName:         "full-agent",
 				ManifestKeySkills:       "nlp,ml",
 				ManifestKeyLocatorTypes: "docker,helm,k8s",
+				ManifestKeyLocatorSizes: "1024,0,2048",
 				ManifestKeyModuleNames:  "security,monitoring",
 				ManifestKeyPreviousCid:  "QmPrevious123",
 			},
@@ -308,6 +324,7 @@ func TestParseManifestAnnotations(t *testing.T) {
 					MetadataKeySkillsCount:       "2",
 					MetadataKeyLocatorTypes:      "docker,helm,k8s",
 					MetadataKeyLocatorTypesCount: "3",
+					MetadataKeyLocatorSizes:      "1024,0,2048",
 					MetadataKeyModuleNames:       "security,monitoring",
 					MetadataKeyModuleCount:       "2",
 					MetadataKeyPreviousCid:       "QmPrevious123",
@@ -407,3 +424,7 @@ func TestRoundTripConversion(t *testing.T) {
 	assert.Equal(t, "1", recordMeta.GetAnnotations()[MetadataKeySkillsCount])
 	assert.Equal(t, "value", recordMeta.GetAnnotations()["custom"])
 }
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
@@ -0,0 +1,148 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Supported record blob compression algorithms.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// maxDecompressedSize limits the size of a decompressed record blob
+// to protect against decompression bombs.
+const maxDecompressedSize = 64 * 1024 * 1024
+
+// validateCompression checks that the compression algorithm is supported.
+func validateCompression(algorithm string) error {
+	switch algorithm {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression %q, expected one of: %s, %s, %s",
+			algorithm, CompressionNone, CompressionGzip, CompressionZstd)
+	}
+}
+
+// isCompressed reports whether the algorithm changes the stored bytes.
+func isCompressed(algorithm string) bool {
+	return algorithm != "" && algorithm != CompressionNone
+}
+
+// recordBlobMediaType returns the media type of record blobs stored with the compression algorithm.
+// Compressed blobs carry the algorithm as a media type suffix, e.g. application/json+zstd,
+// so that registry clients do not mistake them for plain JSON.
+func recordBlobMediaType(algorithm string) string {
+	if !isCompressed(algorithm) {
+		return recordMediaType
+	}
+
+	return recordMediaType + "+" + algorithm
+}
+
+// isRecordMediaType reports whether the media type is the one of a record blob, compressed or not.
+func isRecordMediaType(mediaType string) bool {
+	return mediaType == recordMediaType || strings.HasPrefix(mediaType, recordMediaType+"+")
+}
+
+// blobCompression returns the compression algorithm of a record blob from its descriptor,
+// using the compression annotation or else the media type suffix.
+func blobCompression(desc ocispec.Descriptor) string {
+	if compression := desc.Annotations[DescriptorKeyCompression]; compression != "" {
+		return compression
+	}
+
+	if algorithm, ok := strings.CutPrefix(desc.MediaType, recordMediaType+"+"); ok {
+		return algorithm
+	}
+
+	return CompressionNone
+}
+
+// compressBlob compresses data with the given algorithm.
+func compressBlob(algorithm string, data []byte) ([]byte, error) {
+	switch algorithm {
+	case "", CompressionNone:
+		return data, nil
+
+	case CompressionGzip:
+		var buf bytes.Buffer
+
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to gzip blob: %w", err)
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to gzip blob: %w", err)
+		}
+
+		return buf.Bytes(), nil
+
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		defer encoder.Close()
+
+		return encoder.EncodeAll(data, nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", algorithm)
+	}
+}
+
+// decompressBlob reverses compressBlob for the given algorithm.
+func decompressBlob(algorithm string, data []byte) ([]byte, error) {
+	var reader io.Reader
+
+	switch algorithm {
+	case "", CompressionNone:
+		return data, nil
+
+	case CompressionGzip:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gzipReader.Close()
+
+		reader = gzipReader
+
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+		defer decoder.Close()
+
+		reader = decoder
+
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", algorithm)
+	}
+
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress blob: %w", err)
+	}
+
+	if len(decompressed) > maxDecompressedSize {
+		return nil, fmt.Errorf("decompressed blob exceeds %d bytes", maxDecompressedSize)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,109 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressBlob(t *testing.T) {
+	data := []byte(`{"name":"test-agent","description":"` + string(make([]byte, 1024)) + `"}`)
+
+	for _, algorithm := range []string{"", CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			compressed, err := compressBlob(algorithm, data)
+			require.NoError(t, err)
+
+			if isCompressed(algorithm) {
+				assert.Less(t, len(compressed), len(data))
+			} else {
+				assert.Equal(t, data, compressed)
+			}
+
+			decompressed, err := decompressBlob(algorithm, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		})
+	}
+
+	_, err := compressBlob("lz4", data)
+	require.Error(t, err)
+	require.Error(t, validateCompression("lz4"))
+}
+
+func TestBlobCompression(t *testing.T) {
+	assert.Equal(t, recordMediaType, recordBlobMediaType(CompressionNone))
+	assert.Equal(t, "application/json+zstd", recordBlobMediaType(CompressionZstd))
+
+	assert.True(t, isRecordMediaType(recordBlobMediaType(CompressionGzip)))
+	assert.False(t, isRecordMediaType(SignatureArtifactType))
+
+	assert.Equal(t, CompressionNone, blobCompression(ocispec.Descriptor{MediaType: recordMediaType}))
+
+	// The media type still identifies compressed blobs whose annotations were dropped
+	assert.Equal(t, CompressionGzip, blobCompression(ocispec.Descriptor{MediaType: recordBlobMediaType(CompressionGzip)}))
+	assert.False(t, isCIDAddressed(ocispec.Descriptor{MediaType: recordBlobMediaType(CompressionGzip), Digest: "sha256:0000"}))
+}
+
+func TestStoreCompression(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "compressed-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "A test agent stored with compression",
+	})
+
+	for _, algorithm := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir(), Compression: algorithm})
+			require.NoError(t, err)
+
+			// CID is computed over the uncompressed bytes
+			ref, err := recordStore.Push(testCtx, record)
+			require.NoError(t, err)
+			assert.Equal(t, record.GetCid(), ref.GetCid())
+
+			// The stored blob is annotated with the compression algorithm and has a distinct media type
+			ociStore, ok := recordStore.(*store)
+			require.True(t, ok)
+
+			manifest, _, err := ociStore.fetchAndParseManifest(testCtx, ref.GetCid())
+			require.NoError(t, err)
+			require.Len(t, manifest.Layers, 1)
+			assert.Equal(t, algorithm, manifest.Layers[0].Annotations[DescriptorKeyCompression])
+			assert.Equal(t, recordMediaType+"+"+algorithm, manifest.Layers[0].MediaType)
+
+			// Pull transparently decompresses the blob
+			pulled, err := recordStore.Pull(testCtx, ref)
+			require.NoError(t, err)
+			assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+			// The record blob is returned as the uncompressed bytes the CID was computed over
+			blob, err := ociStore.PullRecordBlob(testCtx, ref)
+			require.NoError(t, err)
+
+			recordBytes, err := record.Marshal()
+			require.NoError(t, err)
+			assert.Equal(t, recordBytes, blob)
+
+			// Delete removes the compressed blob
+			require.NoError(t, recordStore.Delete(testCtx, ref))
+
+			exists, err := ociStore.repo.Exists(testCtx, manifest.Layers[0])
+			require.NoError(t, err)
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestStoreInvalidCompression(t *testing.T) {
+	_, err := New(ociconfig.Config{LocalDir: t.TempDir(), Compression: "lz4"})
+	require.Error(t, err)
+}
@@ -7,6 +7,10 @@ const (
 	DefaultAuthConfigInsecure = true
 	DefaultRegistryAddress    = "127.0.0.1:5000"
 	DefaultRepositoryName     = "dir"
+	DefaultCompression        = "none"
+	DefaultMaxRecordBytes     = 4 * 1024 * 1024 // 4 MiB
+	DefaultCanonicalMode      = "sorted"
+	DefaultDigestAlgorithm    = "sha256"
 )
 
 type Config struct {
@@ -24,6 +28,27 @@ type Config struct {
 	// Repository name to connect to
 	RepositoryName string `json:"repository_name,omitempty" mapstructure:"repository_name"`
 
+	// Compression applied to record blobs before they are stored.
+	// Supported values are "none", "gzip" and "zstd".
+	// Record CIDs are always computed over the uncompressed bytes.
+	Compression string `json:"compression,omitempty" mapstructure:"compression"`
+
+	// Maximum size of a marshaled record accepted on push, in bytes.
+	// Zero disables the limit.
+	MaxRecordBytes int `json:"max_record_bytes,omitempty" mapstructure:"max_record_bytes"`
+
+	// Canonical JSON form records are stored and their CIDs computed in.
+	// Supported values are "sorted" (the default), "legacy" and "jcs" (RFC 8785).
+	// Changing the canonical form changes the CIDs of newly pushed records,
+	// records pushed before keep their CIDs.
+	CanonicalMode string `json:"canonical_mode,omitempty" mapstructure:"canonical_mode"`
+
+	// Digest algorithm of record blob descriptors.
+	// Supported values are "sha256", "sha384" and "sha512".
+	// Record CIDs always use a SHA2-256 multihash, so only "sha256" blobs are
+	// addressed by their CID. Other algorithms are for registries requiring them.
+	DigestAlgorithm string `json:"digest_algorithm,omitempty" mapstructure:"digest_algorithm"`
+
 	// Authentication configuration
 	AuthConfig `json:"auth_config,omitempty" mapstructure:"auth_config"`
 }
@@ -39,4 +64,27 @@ type AuthConfig struct {
 	RefreshToken string `json:"refresh_token,omitempty" mapstructure:"refresh_token"`
 
 	AccessToken string `json:"access_token,omitempty" mapstructure:"access_token"`
+
+	// OAuth2 client credentials used to obtain access tokens.
+	// Tokens are refreshed before they expire and whenever the registry rejects them.
+	OAuth2 OAuth2Config `json:"oauth2,omitempty" mapstructure:"oauth2"`
+}
+
+// OAuth2Config represents the configuration of the OAuth2 client credentials flow.
+type OAuth2Config struct {
+	// Token endpoint of the authorization server.
+	// If empty, the client credentials flow is not used.
+	TokenURL string `json:"token_url,omitempty" mapstructure:"token_url"`
+
+	ClientID string `json:"client_id,omitempty" mapstructure:"client_id"`
+
+	ClientSecret string `json:"client_secret,omitempty" mapstructure:"client_secret"`
+
+	// Space-separated scopes to request.
+	Scope string `json:"scope,omitempty" mapstructure:"scope"`
+}
+
+// Enabled reports whether access tokens are obtained with the client credentials flow.
+func (c OAuth2Config) Enabled() bool {
+	return c.TokenURL != ""
 }
@@ -24,11 +24,13 @@ const (
 	// Lifecycle (simple keys).
 	MetadataKeySchemaVersion = "schema-version"
 	MetadataKeyCreatedAt     = "created-at"
+	MetadataKeyExpiresAt     = "expires-at"
 	MetadataKeyAuthors       = "authors"
 
 	// Capability Discovery (simple keys).
 	MetadataKeySkills       = "skills"
 	MetadataKeyLocatorTypes = "locator-types"
+	MetadataKeyLocatorSizes = "locator-sizes"
 	MetadataKeyModuleNames  = "module-names"
 
 	// Security (simple keys).
@@ -62,11 +64,13 @@ const (
 	// Lifecycle Metadata (mixed: some derived, some standalone).
 	ManifestKeySchemaVersion = manifestDirObjectKeyPrefix + "/" + MetadataKeySchemaVersion
 	ManifestKeyCreatedAt     = manifestDirObjectKeyPrefix + "/" + MetadataKeyCreatedAt
+	ManifestKeyExpiresAt     = manifestDirObjectKeyPrefix + "/" + MetadataKeyExpiresAt
 	ManifestKeyAuthors       = manifestDirObjectKeyPrefix + "/" + MetadataKeyAuthors
 
 	// Capability Discovery (derived from MetadataKey constants).
 	ManifestKeySkills       = manifestDirObjectKeyPrefix + "/" + MetadataKeySkills
 	ManifestKeyLocatorTypes = manifestDirObjectKeyPrefix + "/" + MetadataKeyLocatorTypes
+	ManifestKeyLocatorSizes = manifestDirObjectKeyPrefix + "/" + MetadataKeyLocatorSizes
 	ManifestKeyModuleNames  = manifestDirObjectKeyPrefix + "/" + MetadataKeyModuleNames
 
 	// Security & Integrity (mixed: some derived, some standalone).
@@ -77,6 +81,16 @@ const (
 	// Versioning & Linking (standalone - no simple key equivalents).
 	ManifestKeyPreviousCid = manifestDirObjectKeyPrefix + "/" + MetadataKeyPreviousCid
 
+	// Record blob descriptor annotations.
+	DescriptorKeyCompression  = manifestDirObjectKeyPrefix + "/compression"
+	DescriptorKeyStoreVersion = manifestDirObjectKeyPrefix + "/store-version"
+
+	// Store versions recorded in DescriptorKeyStoreVersion, identifying the canonical
+	// JSON form of the record blob. Blobs without the annotation are StoreVersionLegacy.
+	StoreVersionLegacy = "v1"
+	StoreVersionSorted = "v1-sorted"
+	StoreVersionJCS    = "v2-jcs"
+
 	// Custom annotations prefix.
 	ManifestKeyCustomPrefix = manifestDirObjectKeyPrefix + "/custom."
 
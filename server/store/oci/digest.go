@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Supported record blob digest algorithms.
+const (
+	DigestSHA256 = string(digest.SHA256)
+	DigestSHA384 = string(digest.SHA384)
+	DigestSHA512 = string(digest.SHA512)
+)
+
+// validateDigestAlgorithm checks that the digest algorithm is supported.
+func validateDigestAlgorithm(algorithm string) error {
+	switch algorithm {
+	case "", DigestSHA256, DigestSHA384, DigestSHA512:
+		return nil
+	default:
+		return fmt.Errorf("unsupported digest algorithm %q, expected one of: %s, %s, %s",
+			algorithm, DigestSHA256, DigestSHA384, DigestSHA512)
+	}
+}
+
+// digestAlgorithm returns the configured digest algorithm, defaulting to SHA-256.
+func digestAlgorithm(algorithm string) digest.Algorithm {
+	if algorithm == "" {
+		return digest.SHA256
+	}
+
+	return digest.Algorithm(algorithm)
+}
+
+// isCIDAddressed reports whether a blob with the given descriptor is addressed by its record CID.
+// This holds for uncompressed blobs with a SHA-256 digest, the hash function of the CID multihash.
+func isCIDAddressed(desc ocispec.Descriptor) bool {
+	return !isCompressed(blobCompression(desc)) && desc.Digest.Algorithm() == digest.SHA256
+}
+
+// pushBlob pushes the blob with a descriptor digested by the given algorithm.
+// Blobs that already exist are not uploaded again.
+func pushBlob(ctx context.Context, pusher content.Pusher, mediaType string, algorithm digest.Algorithm, blob []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    algorithm.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+
+	if err := pusher.Push(ctx, desc, bytes.NewReader(blob)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return ocispec.Descriptor{}, err //nolint:wrapcheck
+	}
+
+	return desc, nil
+}
+
+// verifyPushedDigest derives the record CID from the descriptor of a pushed record blob
+// and checks it against the CID of the record.
+//
+// Blobs addressed by CID yield the CID from the descriptor digest, so a mismatch means
+// the blob bytes differ from the record's canonical bytes. Other blobs yield the CID of
+// the record bytes. On mismatch, the record bytes' digest and the descriptor digest and
+// algorithm are logged to tell a marshaling bug from a digest algorithm mismatch.
+func verifyPushedDigest(recordBytes []byte, layerDesc ocispec.Descriptor, algorithm digest.Algorithm, expectedCID string) (string, error) {
+	recordDigest, err := corev1.CalculateDigest(recordBytes)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to calculate record digest: %v", err)
+	}
+
+	recordBytesCID, err := corev1.ConvertDigestToCID(recordDigest)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to convert digest to CID: %v", err)
+	}
+
+	recordCID := recordBytesCID
+
+	var cause string
+
+	switch {
+	case layerDesc.Digest.Algorithm() != algorithm:
+		cause = fmt.Sprintf("descriptor digest algorithm %s differs from the configured %s", layerDesc.Digest.Algorithm(), algorithm)
+
+	case isCIDAddressed(layerDesc):
+		recordCID, err = corev1.ConvertDigestToCID(layerDesc.Digest)
+		if err != nil {
+			cause = fmt.Sprintf("descriptor digest cannot be converted to a CID: %v", err)
+		} else if recordCID != expectedCID && recordBytesCID == expectedCID {
+			cause = "descriptor digest differs from the record bytes digest"
+		}
+	}
+
+	if cause == "" && recordCID != expectedCID {
+		cause = "record bytes do not hash to the record CID, the record is marshaled inconsistently"
+	}
+
+	if cause == "" {
+		return recordCID, nil
+	}
+
+	logger.Error("CID mismatch on push",
+		"cause", cause,
+		"recordCid", expectedCID,
+		"recordBytesDigest", recordDigest.String(),
+		"recordBytesSize", len(recordBytes),
+		"descriptorDigest", layerDesc.Digest.String(),
+		"descriptorAlgorithm", layerDesc.Digest.Algorithm(),
+		"descriptorSize", layerDesc.Size,
+		"configuredAlgorithm", algorithm)
+
+	return "", status.Errorf(codes.Internal,
+		"CID mismatch for record %s: %s (record bytes digest %s, descriptor digest %s)",
+		expectedCID, cause, recordDigest, layerDesc.Digest)
+}
@@ -0,0 +1,117 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyPushedDigest(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "digest-agent",
+		SchemaVersion: "v0.3.1",
+	})
+
+	recordBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	descriptor := func(algorithm digest.Algorithm) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: recordMediaType,
+			Digest:    algorithm.FromBytes(recordBytes),
+			Size:      int64(len(recordBytes)),
+		}
+	}
+
+	t.Run("matching_sha256", func(t *testing.T) {
+		cid, err := verifyPushedDigest(recordBytes, descriptor(digest.SHA256), digest.SHA256, record.GetCid())
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), cid)
+	})
+
+	t.Run("matching_sha512", func(t *testing.T) {
+		cid, err := verifyPushedDigest(recordBytes, descriptor(digest.SHA512), digest.SHA512, record.GetCid())
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), cid)
+	})
+
+	t.Run("algorithm_mismatch", func(t *testing.T) {
+		// The registry digested the blob with SHA-512 while SHA-256 is configured
+		_, err := verifyPushedDigest(recordBytes, descriptor(digest.SHA512), digest.SHA256, record.GetCid())
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Contains(t, err.Error(), "descriptor digest algorithm sha512 differs from the configured sha256")
+		assert.Contains(t, err.Error(), digest.SHA256.FromBytes(recordBytes).String())
+	})
+
+	t.Run("descriptor_mismatch", func(t *testing.T) {
+		desc := descriptor(digest.SHA256)
+		desc.Digest = digest.SHA256.FromBytes([]byte("other"))
+
+		_, err := verifyPushedDigest(recordBytes, desc, digest.SHA256, record.GetCid())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "descriptor digest differs from the record bytes digest")
+	})
+
+	t.Run("marshaling_mismatch", func(t *testing.T) {
+		otherBytes := append([]byte(nil), recordBytes...)
+		otherBytes = append(otherBytes, '\n')
+
+		desc := ocispec.Descriptor{MediaType: recordMediaType, Digest: digest.SHA256.FromBytes(otherBytes)}
+
+		_, err := verifyPushedDigest(otherBytes, desc, digest.SHA256, record.GetCid())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the record is marshaled inconsistently")
+	})
+}
+
+func TestStoreDigestAlgorithm(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "sha512-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "A test agent stored with a SHA-512 blob digest",
+	})
+
+	recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir(), DigestAlgorithm: DigestSHA512})
+	require.NoError(t, err)
+
+	// CID is still derived from the SHA2-256 multihash
+	ref, err := recordStore.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+
+	ociStore, ok := recordStore.(*store)
+	require.True(t, ok)
+
+	manifest, _, err := ociStore.fetchAndParseManifest(testCtx, ref.GetCid())
+	require.NoError(t, err)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, digest.SHA512, manifest.Layers[0].Digest.Algorithm())
+
+	pulled, err := recordStore.Pull(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+	// Delete removes the blob, which is not addressed by the CID
+	require.NoError(t, recordStore.Delete(testCtx, ref))
+
+	exists, err := ociStore.repo.Exists(testCtx, manifest.Layers[0])
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestStoreInvalidDigestAlgorithm(t *testing.T) {
+	_, err := New(ociconfig.Config{LocalDir: t.TempDir(), DigestAlgorithm: "md5"})
+	require.Error(t, err)
+}
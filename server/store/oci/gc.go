@@ -0,0 +1,262 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+const (
+	// gcGracePeriod protects recently written blobs from garbage collection.
+	// A push writes the record blob before its manifest, so a fresh blob
+	// may legitimately be unreferenced for a short time.
+	gcGracePeriod = 10 * time.Minute
+
+	// gcMaxManifestSize is the maximum blob size inspected for manifest references.
+	gcMaxManifestSize = 4 * 1024 * 1024
+)
+
+// gcManifest captures the references of any OCI or Docker manifest or index.
+type gcManifest struct {
+	MediaType string               `json:"mediaType"`
+	Config    *ocispec.Descriptor  `json:"config,omitempty"`
+	Layers    []ocispec.Descriptor `json:"layers,omitempty"`
+	Manifests []ocispec.Descriptor `json:"manifests,omitempty"`
+	Blobs     []ocispec.Descriptor `json:"blobs,omitempty"`
+	Subject   *ocispec.Descriptor  `json:"subject,omitempty"`
+}
+
+// references returns all descriptors referenced by the manifest.
+func (m *gcManifest) references() []ocispec.Descriptor {
+	var refs []ocispec.Descriptor
+
+	if m.Config != nil {
+		refs = append(refs, *m.Config)
+	}
+
+	if m.Subject != nil {
+		refs = append(refs, *m.Subject)
+	}
+
+	refs = append(refs, m.Layers...)
+	refs = append(refs, m.Manifests...)
+	refs = append(refs, m.Blobs...)
+
+	return refs
+}
+
+// localBlob is a blob file of a local OCI layout.
+type localBlob struct {
+	size     int64
+	modTime  time.Time
+	manifest *gcManifest // nil if the blob is not a manifest
+}
+
+// GarbageCollect deletes blobs of a local OCI store that are not referenced by any manifest.
+//
+// Roots are the manifests listed in the OCI index and every manifest with a subject,
+// such as signatures and other referrers. Referrer manifests are roots because they are
+// not tagged, so blobs referenced by them, and the records they refer to, are never deleted.
+// Blobs written within gcGracePeriod are kept to avoid racing with in-flight pushes.
+//
+// Remote registries are not supported as they run their own garbage collection.
+func (s *store) GarbageCollect(ctx context.Context, dryRun bool) (*types.GarbageCollectResult, error) {
+	localStore, ok := s.repo.(*oci.Store)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "garbage collection is only supported for local OCI stores, got %T", s.repo)
+	}
+
+	// Make sure the index on disk reflects all tagged and untagged manifests
+	if err := localStore.SaveIndex(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save OCI index: %v", err)
+	}
+
+	blobs, err := listLocalBlobs(s.config.LocalDir)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list blobs: %v", err)
+	}
+
+	roots, err := readIndexManifests(s.config.LocalDir)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read OCI index: %v", err)
+	}
+
+	for dgst, blob := range blobs {
+		if blob.manifest != nil && blob.manifest.Subject != nil {
+			roots = append(roots, dgst)
+		}
+	}
+
+	referenced := markReferencedBlobs(roots, blobs)
+
+	result := &types.GarbageCollectResult{}
+
+	for dgst, blob := range blobs {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		if referenced[dgst] || time.Since(blob.modTime) < gcGracePeriod {
+			continue
+		}
+
+		if !dryRun {
+			if err := localStore.Delete(ctx, ocispec.Descriptor{Digest: dgst, Size: blob.size}); err != nil {
+				logger.Warn("Failed to delete unreferenced blob", "digest", dgst.String(), "error", err)
+
+				continue
+			}
+		}
+
+		result.DeletedBlobs = append(result.DeletedBlobs, dgst.String())
+		result.ReclaimedBytes += blob.size
+	}
+
+	logger.Info("Garbage collection completed",
+		"blobs", len(blobs),
+		"referenced", len(referenced),
+		"deleted", len(result.DeletedBlobs),
+		"reclaimedBytes", result.ReclaimedBytes,
+		"dryRun", dryRun)
+
+	return result, nil
+}
+
+// markReferencedBlobs returns the set of blobs reachable from the given roots.
+func markReferencedBlobs(roots []digest.Digest, blobs map[digest.Digest]*localBlob) map[digest.Digest]bool {
+	referenced := make(map[digest.Digest]bool)
+	pending := append([]digest.Digest(nil), roots...)
+
+	for len(pending) > 0 {
+		dgst := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if referenced[dgst] {
+			continue
+		}
+
+		referenced[dgst] = true
+
+		blob, ok := blobs[dgst]
+		if !ok || blob.manifest == nil {
+			continue
+		}
+
+		for _, ref := range blob.manifest.references() {
+			pending = append(pending, ref.Digest)
+		}
+	}
+
+	return referenced
+}
+
+// readIndexManifests returns the digests of all manifests listed in the OCI layout index.
+func readIndexManifests(layoutDir string) ([]digest.Digest, error) {
+	data, err := os.ReadFile(filepath.Join(layoutDir, ocispec.ImageIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index file: %w", err)
+	}
+
+	digests := make([]digest.Digest, 0, len(index.Manifests))
+	for _, desc := range index.Manifests {
+		digests = append(digests, desc.Digest)
+	}
+
+	return digests, nil
+}
+
+// listLocalBlobs lists all blobs of a local OCI layout and parses the ones that are manifests.
+func listLocalBlobs(layoutDir string) (map[digest.Digest]*localBlob, error) {
+	blobsDir := filepath.Join(layoutDir, ocispec.ImageBlobsDir)
+
+	algDirs, err := os.ReadDir(blobsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[digest.Digest]*localBlob{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read blobs directory: %w", err)
+	}
+
+	blobs := make(map[digest.Digest]*localBlob)
+
+	for _, algDir := range algDirs {
+		if !algDir.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(blobsDir, algDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blobs directory: %w", err)
+		}
+
+		for _, entry := range entries {
+			dgst := digest.NewDigestFromEncoded(digest.Algorithm(algDir.Name()), entry.Name())
+			if entry.IsDir() || dgst.Validate() != nil {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat blob %s: %w", dgst, err)
+			}
+
+			blob := &localBlob{
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			}
+
+			if info.Size() <= gcMaxManifestSize {
+				blob.manifest = parseManifestBlob(filepath.Join(blobsDir, algDir.Name(), entry.Name()))
+			}
+
+			blobs[dgst] = blob
+		}
+	}
+
+	return blobs, nil
+}
+
+// parseManifestBlob returns the parsed manifest stored at path,
+// or nil if the blob is not a manifest or index.
+func parseManifestBlob(path string) *gcManifest {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var manifest gcManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil
+	}
+
+	switch manifest.MediaType {
+	case ocispec.MediaTypeImageManifest,
+		ocispec.MediaTypeImageIndex,
+		"application/vnd.oci.artifact.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json":
+		return &manifest
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// ageBlobs moves the modification time of all blobs past the GC grace period.
+func ageBlobs(t *testing.T, layoutDir string) {
+	t.Helper()
+
+	past := time.Now().Add(-2 * gcGracePeriod)
+
+	err := filepath.WalkDir(filepath.Join(layoutDir, ocispec.ImageBlobsDir), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		return os.Chtimes(path, past, past)
+	})
+	require.NoError(t, err)
+}
+
+func TestStoreGarbageCollect(t *testing.T) {
+	layoutDir := t.TempDir()
+
+	repo, err := oci.New(layoutDir)
+	require.NoError(t, err)
+
+	store := &store{repo: repo, config: ociconfig.Config{LocalDir: layoutDir}}
+
+	// Push a record that must survive garbage collection
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+	})
+
+	recordRef, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+
+	recordManifest, err := repo.Resolve(testCtx, recordRef.GetCid())
+	require.NoError(t, err)
+
+	// Attach an untagged referrer (e.g. a signature) to the record
+	referrerLayer, err := oras.PushBytes(testCtx, repo, "application/vnd.test.signature", []byte("signature"))
+	require.NoError(t, err)
+
+	referrerManifest, err := oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, "application/vnd.test.signature",
+		oras.PackManifestOptions{
+			Subject: &recordManifest,
+			Layers:  []ocispec.Descriptor{referrerLayer},
+		},
+	)
+	require.NoError(t, err)
+
+	// Push an orphaned blob that is not referenced by any manifest
+	orphan, err := oras.PushBytes(testCtx, repo, "application/json", []byte(`{"orphan":true}`))
+	require.NoError(t, err)
+
+	t.Run("recent blobs are kept", func(t *testing.T) {
+		result, err := store.GarbageCollect(testCtx, true)
+		require.NoError(t, err)
+		assert.Empty(t, result.DeletedBlobs)
+	})
+
+	ageBlobs(t, layoutDir)
+
+	t.Run("dry run reports without deleting", func(t *testing.T) {
+		result, err := store.GarbageCollect(testCtx, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{orphan.Digest.String()}, result.DeletedBlobs)
+		assert.Equal(t, orphan.Size, result.ReclaimedBytes)
+
+		exists, err := repo.Exists(testCtx, orphan)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("unreferenced blobs are deleted", func(t *testing.T) {
+		result, err := store.GarbageCollect(testCtx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{orphan.Digest.String()}, result.DeletedBlobs)
+
+		exists, err := repo.Exists(testCtx, orphan)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("records and referrers are kept", func(t *testing.T) {
+		pulled, err := store.Pull(testCtx, recordRef)
+		require.NoError(t, err)
+		assert.Equal(t, recordRef.GetCid(), pulled.GetCid())
+
+		for _, desc := range []ocispec.Descriptor{referrerManifest, referrerLayer} {
+			exists, err := repo.Exists(testCtx, desc)
+			require.NoError(t, err)
+			assert.True(t, exists, "blob %s should be kept", desc.Digest)
+		}
+	})
+}
+
+// failingTagStore is a local OCI store whose tagging always fails.
+type failingTagStore struct {
+	*oci.Store
+}
+
+func (f *failingTagStore) Tag(context.Context, ocispec.Descriptor, string) error {
+	return errors.New("tag failed")
+}
+
+func TestStorePushCleansUpOnTagFailure(t *testing.T) {
+	layoutDir := t.TempDir()
+
+	repo, err := oci.New(layoutDir)
+	require.NoError(t, err)
+
+	store := &store{repo: &failingTagStore{Store: repo}, config: ociconfig.Config{LocalDir: layoutDir}}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+	})
+
+	_, err = store.Push(testCtx, record)
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Neither the record blob nor its manifest is left behind
+	blobs, err := listLocalBlobs(layoutDir)
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+
+	// A later push with working tags succeeds
+	store.repo = repo
+
+	ref, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+}
+
+func TestStoreGarbageCollectRemote(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	_, err := store.GarbageCollect(testCtx, false)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -6,6 +6,7 @@ package oci
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -14,7 +15,9 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 )
 
@@ -55,6 +58,94 @@ func (s *store) fetchAndParseManifest(ctx context.Context, cid string) (*ocispec
 	return manifest, &manifestDesc, nil
 }
 
+// checkTagConflict verifies that tag is either unused or already points to manifestDesc.
+// A tag pointing to a different manifest (e.g. a stale manifest left behind after a
+// partial delete) is reported as a conflict instead of being silently overwritten.
+func (s *store) checkTagConflict(ctx context.Context, tag string, manifestDesc ocispec.Descriptor) error {
+	existingDesc, err := s.repo.Resolve(ctx, tag)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return nil
+		}
+
+		return status.Errorf(codes.Internal, "failed to resolve tag %s: %v", tag, err)
+	}
+
+	if existingDesc.Digest != manifestDesc.Digest {
+		internalLogger.Warn("CID tag points to a different manifest",
+			"tag", tag,
+			"existing", existingDesc.Digest.String(),
+			"new", manifestDesc.Digest.String())
+
+		return status.Errorf(codes.AlreadyExists,
+			"tag %s already points to manifest %s, refusing to retag to %s",
+			tag, existingDesc.Digest.String(), manifestDesc.Digest.String())
+	}
+
+	return nil
+}
+
+// checkExistingPushAnnotations checks that a record pushed again is stored with the annotations set on push.
+// The manifest of an existing record is not rewritten, so other annotations would otherwise be silently dropped.
+func checkExistingPushAnnotations(meta *corev1.RecordMeta, pushAnnotations map[string]string) error {
+	for key, value := range pushAnnotations {
+		if stored, ok := meta.GetAnnotations()[key]; !ok || stored != value {
+			return status.Errorf(codes.FailedPrecondition,
+				"record %s already exists without the annotation %s=%s, annotations of existing records cannot be changed",
+				meta.GetCid(), key, value)
+		}
+	}
+
+	return nil
+}
+
+// existingPushExpiryError reports that a record pushed again with an expiry keeps its stored expiry.
+// The manifest of an existing record is not rewritten, so the expiry would otherwise be silently ignored.
+func existingPushExpiryError(meta *corev1.RecordMeta) error {
+	if meta.GetExpiresAt() == "" {
+		return status.Errorf(codes.FailedPrecondition,
+			"record %s already exists without an expiry, the expiry of existing records cannot be changed", meta.GetCid())
+	}
+
+	return status.Errorf(codes.FailedPrecondition,
+		"record %s already exists and expires at %s, the expiry of existing records cannot be changed",
+		meta.GetCid(), meta.GetExpiresAt())
+}
+
+// cleanupPartialPush removes the manifest and record blob of a push that failed to tag its manifest,
+// as they cannot be looked up without the tag. Remote registries cannot reliably delete blobs,
+// so their dangling digests are logged and left to the registry garbage collection.
+// Nothing is removed if the tag was created in the meantime, e.g. by a concurrent push of the same record.
+func (s *store) cleanupPartialPush(ctx context.Context, tag string, manifestDesc, blobDesc ocispec.Descriptor) {
+	if _, err := s.repo.Resolve(ctx, tag); err == nil {
+		return
+	}
+
+	deleter, ok := s.repo.(content.Deleter)
+	if _, isRemote := s.repo.(*remote.Repository); isRemote || !ok {
+		internalLogger.Warn("Partial push left untagged content for garbage collection",
+			"tag", tag,
+			"manifest", manifestDesc.Digest.String(),
+			"blob", blobDesc.Digest.String())
+
+		return
+	}
+
+	// Delete the manifest first, so that the blob is no longer referenced
+	for _, desc := range []ocispec.Descriptor{manifestDesc, blobDesc} {
+		if err := deleter.Delete(ctx, desc); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			internalLogger.Warn("Failed to delete content of partial push, left for garbage collection",
+				"tag", tag,
+				"digest", desc.Digest.String(),
+				"error", err)
+
+			continue
+		}
+
+		internalLogger.Debug("Deleted content of partial push", "tag", tag, "digest", desc.Digest.String())
+	}
+}
+
 // fetchAndParseManifestFromDescriptor fetches and parses a manifest when you already have the descriptor.
 func (s *store) fetchAndParseManifestFromDescriptor(ctx context.Context, manifestDesc ocispec.Descriptor) (*ocispec.Manifest, error) {
 	// Validate manifest size if available
@@ -105,7 +196,10 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 
 	internalLogger.Debug("Starting OCI store deletion", "cid", cid)
 
-	var errors []string
+	var (
+		errors           []string
+		unaddressedBlobs []ocispec.Descriptor
+	)
 
 	// Phase 1: Delete manifest (tags will be cleaned up by OCI GC)
 	internalLogger.Debug("Phase 1: Deleting manifest", "cid", cid)
@@ -116,6 +210,16 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 		internalLogger.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("manifest resolve: %v", err))
 	} else {
+		// Compressed blobs and blobs with a non-SHA-256 digest are not addressed by the CID,
+		// so collect them before the manifest is gone
+		if manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, manifestDesc); err == nil {
+			for _, layer := range manifest.Layers {
+				if isRecordMediaType(layer.MediaType) && !isCIDAddressed(layer) {
+					unaddressedBlobs = append(unaddressedBlobs, layer)
+				}
+			}
+		}
+
 		if err := store.Delete(ctx, manifestDesc); err != nil {
 			internalLogger.Warn("Failed to delete manifest", "cid", cid, "error", err)
 			errors = append(errors, fmt.Sprintf("manifest delete: %v", err))
@@ -127,9 +231,18 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 	// Phase 2: Remove blob data (local store - we have full control)
 	internalLogger.Debug("Phase 2: Deleting blob data", "cid", cid)
 
-	if err := s.deleteBlobForLocalStore(ctx, cid, store); err != nil {
-		internalLogger.Warn("Failed to delete blob", "cid", cid, "error", err)
-		errors = append(errors, fmt.Sprintf("blob delete: %v", err))
+	if len(unaddressedBlobs) == 0 {
+		if err := s.deleteBlobForLocalStore(ctx, cid, store); err != nil {
+			internalLogger.Warn("Failed to delete blob", "cid", cid, "error", err)
+			errors = append(errors, fmt.Sprintf("blob delete: %v", err))
+		}
+	}
+
+	for _, blobDesc := range unaddressedBlobs {
+		if err := store.Delete(ctx, blobDesc); err != nil {
+			internalLogger.Warn("Failed to delete record blob", "cid", cid, "digest", blobDesc.Digest.String(), "error", err)
+			errors = append(errors, fmt.Sprintf("record blob delete: %v", err))
+		}
 	}
 
 	// Log summary
@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"io"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/tracing"
+	"github.com/agntcy/dir/server/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordMediaType is the media type of the manifest layer holding the OASF record.
+const recordMediaType = "application/json"
+
+// recordLayer returns the layer of the manifest holding the OASF record.
+// Records may be stored alongside auxiliary artifacts as additional layers,
+// so the record layer is selected by media type rather than by position.
+// Compressed record layers have a media type suffix, see recordBlobMediaType.
+func recordLayer(cid string, manifest *ocispec.Manifest) (ocispec.Descriptor, error) {
+	var (
+		layer ocispec.Descriptor
+		found int
+	)
+
+	for _, desc := range manifest.Layers {
+		if !isRecordMediaType(desc.MediaType) {
+			continue
+		}
+
+		if found == 0 {
+			layer = desc
+		}
+
+		found++
+	}
+
+	if found == 0 {
+		return ocispec.Descriptor{}, status.Errorf(codes.Internal, "manifest has no %s layer for CID %s", recordMediaType, cid)
+	}
+
+	if found > 1 {
+		logger.Warn("Manifest has multiple record layers, using first one",
+			"cid", cid,
+			"recordLayerCount", found)
+	}
+
+	return layer, nil
+}
+
+// PullLayers returns the descriptors of all layers of the record manifest.
+func (s *store) PullLayers(ctx context.Context, ref *corev1.RecordRef) (_ []types.LayerInfo, err error) {
+	ctx, span := tracing.Start(ctx, "store.PullLayers", tracing.AttrCID.String(ref.GetCid()))
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make([]types.LayerInfo, 0, len(manifest.Layers))
+	for _, desc := range manifest.Layers {
+		layers = append(layers, types.LayerInfo{
+			Digest:      desc.Digest.String(),
+			MediaType:   desc.MediaType,
+			Size:        desc.Size,
+			Annotations: desc.Annotations,
+		})
+	}
+
+	logger.Debug("Listed record layers", "cid", ref.GetCid(), "layerCount", len(layers))
+
+	return layers, nil
+}
+
+// PullLayer returns the content of a layer of the record manifest.
+// Only layers referenced by the manifest can be pulled, and their content is returned as stored,
+// so a compressed record layer is not decompressed.
+func (s *store) PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (_ io.ReadCloser, err error) {
+	ctx, span := tracing.Start(ctx, "store.PullLayer", tracing.AttrCID.String(ref.GetCid()))
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	if digest == "" {
+		return nil, status.Error(codes.InvalidArgument, "layer digest cannot be empty") //nolint:wrapcheck
+	}
+
+	manifest, _, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range manifest.Layers {
+		if desc.Digest.String() != digest {
+			continue
+		}
+
+		reader, err := s.repo.Fetch(ctx, desc)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "layer %s not found for CID %s: %v", digest, ref.GetCid(), err)
+		}
+
+		logger.Debug("Fetching record layer", "cid", ref.GetCid(), "digest", digest, "mediaType", desc.MediaType, "size", desc.Size)
+
+		return reader, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "layer %s is not part of record %s", digest, ref.GetCid())
+}
+
+// PullRecordBlob returns the bytes of the record blob as stored, decompressed if stored compressed.
+func (s *store) PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "store.PullRecordBlob", tracing.AttrCID.String(ref.GetCid()))
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateRecordRef(ref); err != nil {
+		return nil, err
+	}
+
+	return s.pullRecordBlob(ctx, ref.GetCid())
+}
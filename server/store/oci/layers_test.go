@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"io"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestStoreMultiLayerManifest(t *testing.T) {
+	repo := memory.New()
+	store := &store{repo: repo}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "model-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "An agent shipped with its model",
+	})
+
+	recordBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	modelBytes := []byte("model weights")
+
+	// Store the record alongside a model, with the model as first layer
+	modelDesc, err := oras.PushBytes(testCtx, repo, "application/vnd.example.model", modelBytes)
+	require.NoError(t, err)
+
+	recordDesc, err := oras.PushBytes(testCtx, repo, recordMediaType, recordBytes)
+	require.NoError(t, err)
+
+	manifestDesc, err := oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{
+			Layers: []ocispec.Descriptor{modelDesc, recordDesc},
+		},
+	)
+	require.NoError(t, err)
+
+	err = repo.Tag(testCtx, manifestDesc, record.GetCid())
+	require.NoError(t, err)
+
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	t.Run("pull returns the record layer", func(t *testing.T) {
+		pulled, err := store.Pull(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCid(), pulled.GetCid())
+	})
+
+	t.Run("pull layers lists all layers", func(t *testing.T) {
+		layers, err := store.PullLayers(testCtx, ref)
+		require.NoError(t, err)
+		require.Len(t, layers, 2)
+
+		assert.Equal(t, modelDesc.Digest.String(), layers[0].Digest)
+		assert.Equal(t, "application/vnd.example.model", layers[0].MediaType)
+		assert.Equal(t, int64(len(modelBytes)), layers[0].Size)
+		assert.Equal(t, recordDesc.Digest.String(), layers[1].Digest)
+		assert.Equal(t, recordMediaType, layers[1].MediaType)
+	})
+
+	t.Run("pull layer returns the layer content", func(t *testing.T) {
+		reader, err := store.PullLayer(testCtx, ref, modelDesc.Digest.String())
+		require.NoError(t, err)
+
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, modelBytes, content)
+	})
+
+	t.Run("pull layer rejects layers of other records", func(t *testing.T) {
+		orphan, err := oras.PushBytes(testCtx, repo, "application/vnd.example.model", []byte("other model"))
+		require.NoError(t, err)
+
+		_, err = store.PullLayer(testCtx, ref, orphan.Digest.String())
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestStorePullWithoutRecordLayer(t *testing.T) {
+	repo := memory.New()
+	store := &store{repo: repo}
+
+	modelDesc, err := oras.PushBytes(testCtx, repo, "application/vnd.example.model", []byte("model weights"))
+	require.NoError(t, err)
+
+	manifestDesc, err := oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{
+			Layers: []ocispec.Descriptor{modelDesc},
+		},
+	)
+	require.NoError(t, err)
+
+	err = repo.Tag(testCtx, manifestDesc, "no-record")
+	require.NoError(t, err)
+
+	_, err = store.Pull(testCtx, &corev1.RecordRef{Cid: "no-record"})
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/opencontainers/go-digest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// MinCIDPrefixLength is the number of characters a CID prefix accepted by ResolvePrefix
+// must have after the prefix shared by all record CIDs.
+// Shorter prefixes match too many records to be useful.
+const MinCIDPrefixLength = 8
+
+// cidCommonPrefix is the prefix shared by all record CIDs (e.g. "baearei").
+// It encodes the CID version, codec and hash function, so it does not identify a record.
+var cidCommonPrefix = commonCIDPrefix()
+
+// commonCIDPrefix returns the characters shared by the CIDs of the lowest and highest digests,
+// which are the characters fully determined by the CID parameters.
+func commonCIDPrefix() string {
+	lowest, _ := corev1.ConvertDigestToCID(digest.NewDigestFromBytes(digest.SHA256, make([]byte, sha256.Size)))
+	highest, _ := corev1.ConvertDigestToCID(digest.NewDigestFromBytes(digest.SHA256, bytes.Repeat([]byte{0xff}, sha256.Size)))
+
+	i := 0
+	for i < len(lowest) && i < len(highest) && lowest[i] == highest[i] {
+		i++
+	}
+
+	return lowest[:i]
+}
+
+// ListRecordCIDs returns the CIDs of all records in the store, sorted.
+// Records are identified by their CID tags; other tags are ignored.
+func (s *store) ListRecordCIDs(ctx context.Context) ([]string, error) {
+	lister, ok := s.repo.(registry.TagLister)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "listing records is not supported by %T", s.repo)
+	}
+
+	tags, err := registry.Tags(ctx, lister)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	cids := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if corev1.IsValidCID(tag) {
+			cids = append(cids, tag)
+		}
+	}
+
+	slices.Sort(cids)
+
+	return cids, nil
+}
+
+// ResolvePrefix returns the CID of the only record in the store whose CID starts with prefix.
+// It fails with NotFound if no record matches and with InvalidArgument listing the candidates
+// if several do. Repositories that cannot enumerate their tags fail with Unimplemented.
+func (s *store) ResolvePrefix(ctx context.Context, prefix string) (string, error) {
+	// Only the characters after the common prefix tell records apart
+	if len(strings.TrimPrefix(prefix, cidCommonPrefix)) < MinCIDPrefixLength {
+		return "", status.Errorf(codes.InvalidArgument, "CID prefix %q is too short, at least %d characters after %q are required", prefix, MinCIDPrefixLength, cidCommonPrefix)
+	}
+
+	lister, ok := s.repo.(registry.TagLister)
+	if !ok {
+		return "", status.Errorf(codes.Unimplemented, "resolving CID prefixes is not supported by %T", s.repo)
+	}
+
+	tags, err := registry.Tags(ctx, lister)
+	if err != nil {
+		if isTagListingUnsupported(err) {
+			return "", status.Errorf(codes.Unimplemented, "resolving CID prefixes is not supported by the registry: %v", err)
+		}
+
+		return "", status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	var candidates []string
+
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, prefix) && corev1.IsValidCID(tag) {
+			candidates = append(candidates, tag)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", status.Errorf(codes.NotFound, "no record found with CID prefix %q", prefix)
+	case 1:
+		return candidates[0], nil
+	default:
+		slices.Sort(candidates)
+
+		return "", status.Errorf(codes.InvalidArgument, "CID prefix %q is ambiguous, candidates: %s", prefix, strings.Join(candidates, ", "))
+	}
+}
+
+// isTagListingUnsupported reports whether a remote registry rejected listing tags as unsupported.
+func isTagListingUnsupported(err error) bool {
+	var errResp *errcode.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+
+	if errResp.StatusCode == http.StatusNotFound || errResp.StatusCode == http.StatusMethodNotAllowed {
+		return true
+	}
+
+	for _, e := range errResp.Errors {
+		if e.Code == errcode.ErrorCodeUnsupported {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"crypto/sha256"
+	"slices"
+	"strings"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestStoreListRecordCIDs(t *testing.T) {
+	repo, err := oci.New(t.TempDir())
+	require.NoError(t, err)
+
+	store := &store{repo: repo}
+
+	var expected []string
+
+	for _, name := range []string{"agent-a", "agent-b"} {
+		ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          name,
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+
+		expected = append(expected, ref.GetCid())
+	}
+
+	slices.Sort(expected)
+
+	// Tags that are not CIDs must be ignored
+	manifest, err := repo.Resolve(testCtx, expected[0])
+	require.NoError(t, err)
+	_, err = oras.Tag(testCtx, repo, manifest.Digest.String(), "latest")
+	require.NoError(t, err)
+
+	cids, err := store.ListRecordCIDs(testCtx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, cids)
+}
+
+func TestStoreListRecordCIDsUnsupported(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	_, err := store.ListRecordCIDs(testCtx)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestStoreResolvePrefix(t *testing.T) {
+	repo, err := oci.New(t.TempDir())
+	require.NoError(t, err)
+
+	store := &store{repo: repo}
+
+	var cids []string
+
+	for _, name := range []string{"agent-a", "agent-b"} {
+		ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          name,
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+
+		cids = append(cids, ref.GetCid())
+	}
+
+	// Shortest accepted prefix unique to the first record
+	minLength := len(cidCommonPrefix) + MinCIDPrefixLength
+
+	length := minLength
+	for strings.HasPrefix(cids[1], cids[0][:length]) {
+		length++
+	}
+
+	t.Run("unique prefix", func(t *testing.T) {
+		cid, err := store.ResolvePrefix(testCtx, cids[0][:length])
+		require.NoError(t, err)
+		assert.Equal(t, cids[0], cid)
+	})
+
+	t.Run("full cid", func(t *testing.T) {
+		cid, err := store.ResolvePrefix(testCtx, cids[1])
+		require.NoError(t, err)
+		assert.Equal(t, cids[1], cid)
+	})
+
+	t.Run("ambiguous prefix", func(t *testing.T) {
+		// Tag a record with two CIDs of digests that only differ in their last byte
+		manifest, err := repo.Resolve(testCtx, cids[0])
+		require.NoError(t, err)
+
+		var similar []string
+
+		for _, last := range []byte{0x01, 0x02} {
+			hash := make([]byte, sha256.Size)
+			hash[len(hash)-1] = last
+
+			cid, err := corev1.ConvertDigestToCID(digest.NewDigestFromBytes(digest.SHA256, hash))
+			require.NoError(t, err)
+			_, err = oras.Tag(testCtx, repo, manifest.Digest.String(), cid)
+			require.NoError(t, err)
+
+			similar = append(similar, cid)
+		}
+
+		_, err = store.ResolvePrefix(testCtx, similar[0][:minLength])
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), similar[0])
+		assert.Contains(t, err.Error(), similar[1])
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		_, err := store.ResolvePrefix(testCtx, "bafyunknown")
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("too short prefix", func(t *testing.T) {
+		_, err := store.ResolvePrefix(testCtx, cids[0][:3])
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// The common prefix does not count towards the minimum length
+		require.True(t, strings.HasPrefix(cids[0], cidCommonPrefix))
+
+		_, err = store.ResolvePrefix(testCtx, cids[0][:minLength-1])
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestStoreResolvePrefixUnsupported(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	_, err := store.ResolvePrefix(testCtx, "bafybeigdyr")
+	require.Error(t, err)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
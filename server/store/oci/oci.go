@@ -5,33 +5,87 @@ package oci
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/datastore"
 	"github.com/agntcy/dir/server/store/cache"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/server/tracing"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/concurrency"
 	"github.com/agntcy/dir/utils/logging"
+	"github.com/agntcy/dir/utils/metrics"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 )
 
 var logger = logging.Logger("store/oci")
 
+// observeOperation records the duration and outcome of a store operation.
+func observeOperation(op string, start time.Time, err *error) {
+	metrics.Default().ObserveStoreOperation(op, time.Since(start), *err)
+}
+
+// lookupManyConcurrency is the maximum number of concurrent lookups in LookupMany.
+const lookupManyConcurrency = 8
+
 type store struct {
 	repo   oras.GraphTarget
 	config ociconfig.Config
 }
 
-func New(cfg ociconfig.Config) (types.StoreAPI, error) {
+// ValidateConfig checks the OCI store configuration without connecting to the registry.
+func ValidateConfig(cfg ociconfig.Config) error {
+	if err := validateCompression(cfg.Compression); err != nil {
+		return err
+	}
+
+	if err := validateDigestAlgorithm(cfg.DigestAlgorithm); err != nil {
+		return err
+	}
+
+	if _, err := corev1.ParseCanonicalMode(cfg.CanonicalMode); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if cfg.MaxRecordBytes < 0 {
+		return fmt.Errorf("invalid max record bytes: %d", cfg.MaxRecordBytes)
+	}
+
+	if cfg.OAuth2.Enabled() {
+		if cfg.OAuth2.ClientID == "" {
+			return errors.New("oauth2 client id is required when a token url is set")
+		}
+
+		if cfg.AccessToken != "" {
+			return errors.New("static access token and oauth2 client credentials are mutually exclusive")
+		}
+	}
+
+	if cfg.LocalDir == "" && cfg.RegistryAddress == "" {
+		return errors.New("either a local directory or a registry address is required")
+	}
+
+	return nil
+}
+
+// New creates an OCI store. Repository options only apply to remote registries.
+func New(cfg ociconfig.Config, opts ...RepositoryOption) (types.StoreAPI, error) {
 	logger.Debug("Creating OCI store with config", "config", cfg)
 
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
 	// if local dir used, return client for that local path.
 	// allows mounting of data via volumes
 	// allows S3 usage for backup store
@@ -47,7 +101,7 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 		}, nil
 	}
 
-	repo, err := NewORASRepository(cfg)
+	repo, err := NewORASRepository(cfg, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create remote repo: %w", err)
 	}
@@ -80,41 +134,102 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 // The tag for the manifest is: <CID of digest>.
 // The tag for the blob is needed to link the actual record with its associated metadata.
 // Note that metadata can be stored in a different store and only wrap this store.
+// If a record with the same CID already exists, no bytes are uploaded.
+// Its push annotations and expiry cannot be changed, so pushing it with other annotations
+// or with an expiry fails with FailedPrecondition.
 //
 // Ref: https://github.com/oras-project/oras-go/blob/main/docs/Modeling-Artifacts.md
-func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+func (s *store) Push(ctx context.Context, record *corev1.Record) (_ *corev1.RecordRef, err error) {
+	defer observeOperation(metrics.OpPush, time.Now(), &err)
+
+	ctx, span := tracing.Start(ctx, "store.Push")
+	defer func() { tracing.End(span, err) }()
+
 	logger.Debug("Pushing record to OCI store", "record", record)
 
 	// Marshal the record using canonical JSON marshaling first
 	// This ensures consistent bytes for both CID calculation and storage
-	recordBytes, err := record.Marshal()
+	canonicalMode := s.canonicalMode()
+
+	recordBytes, err := record.MarshalCanonical(canonicalMode)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to marshal record: %v", err)
 	}
 
-	// Step 1: Use oras.PushBytes to push the record data and get Layer Descriptor
-	layerDesc, err := oras.PushBytes(ctx, s.repo, "application/json", recordBytes)
+	if limit := s.config.MaxRecordBytes; limit > 0 && len(recordBytes) > limit {
+		return nil, status.Errorf(codes.InvalidArgument, "record size %d bytes exceeds the maximum of %d bytes", len(recordBytes), limit)
+	}
+
+	// Step 1: Calculate CID locally from the canonical bytes
+	localDigest, err := corev1.CalculateDigest(recordBytes)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to calculate record digest: %v", err)
 	}
 
-	// Step 2: Calculate CID from Layer Descriptor's digest using our new utility function
-	recordCID, err := corev1.ConvertDigestToCID(layerDesc.Digest)
+	localCID, err := corev1.ConvertDigestToCID(localDigest)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to convert digest to CID: %v", err)
 	}
 
+	span.SetAttributes(tracing.AttrCID.String(localCID))
+
+	// Check if record already exists before uploading any bytes.
+	// This avoids re-uploading blobs the registry already has.
+	if meta, err := s.Lookup(ctx, &corev1.RecordRef{Cid: localCID}); err == nil {
+		if err := checkExistingPushAnnotations(meta, types.PushAnnotationsFromContext(ctx)); err != nil {
+			return nil, err
+		}
+
+		if _, ok := types.PushExpiryFromContext(ctx); ok {
+			return nil, existingPushExpiryError(meta)
+		}
+
+		logger.Info("Record already exists in OCI store, skipping upload", "cid", localCID)
+
+		return &corev1.RecordRef{Cid: localCID}, nil
+	}
+
+	// Step 2: Compress the canonical bytes if requested.
+	// The CID is always derived from the uncompressed bytes, so compression only affects storage.
+	blobBytes, err := compressBlob(s.config.Compression, recordBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compress record: %v", err)
+	}
+
+	// Step 3: Push the record data digested with the configured algorithm and get Layer Descriptor
+	layerDesc, err := pushBlob(ctx, s.repo, recordBlobMediaType(s.config.Compression), digestAlgorithm(s.config.DigestAlgorithm), blobBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
+	}
+
+	// Record the canonical form of blobs not in the legacy form,
+	// so their CIDs can be reproduced from the stored bytes.
+	if canonicalMode != corev1.CanonicalModeLegacy {
+		layerDesc.Annotations = map[string]string{
+			DescriptorKeyStoreVersion: storeVersion(canonicalMode),
+		}
+	}
+
+	if isCompressed(s.config.Compression) {
+		if layerDesc.Annotations == nil {
+			layerDesc.Annotations = map[string]string{}
+		}
+
+		layerDesc.Annotations[DescriptorKeyCompression] = s.config.Compression
+	}
+
 	// Validate consistency: CID from ORAS digest should match CID from record
-	expectedCID := record.GetCid()
-	if recordCID != expectedCID {
-		return nil, status.Errorf(codes.Internal,
-			"CID mismatch: OCI digest CID (%s) != Record CID (%s)",
-			recordCID, expectedCID)
+	recordCID, err := verifyPushedDigest(recordBytes, layerDesc, digestAlgorithm(s.config.DigestAlgorithm), record.GetCanonicalCid(canonicalMode))
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Debug("CID validation successful",
 		"cid", recordCID,
 		"digest", layerDesc.Digest.String(),
+		"compression", s.config.Compression,
+		"digestAlgorithm", layerDesc.Digest.Algorithm(),
+		"canonicalMode", canonicalMode,
 		"validation", "ORAS digest CID matches Record CID")
 
 	logger.Debug("Calculated CID from ORAS digest", "cid", recordCID, "digest", layerDesc.Digest.String())
@@ -122,19 +237,17 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 	// Create record reference
 	recordRef := &corev1.RecordRef{Cid: recordCID}
 
-	// Check if record already exists
-	if _, err := s.Lookup(ctx, recordRef); err == nil {
-		logger.Info("Record already exists in OCI store", "cid", recordCID)
+	// Step 4: Construct manifest annotations and add CID to annotations
+	manifestAnnotations := extractManifestAnnotations(record)
+	addPushAnnotations(manifestAnnotations, types.PushAnnotationsFromContext(ctx))
 
-		return recordRef, nil
+	if expiresAt, ok := types.PushExpiryFromContext(ctx); ok {
+		addPushExpiry(manifestAnnotations, expiresAt)
 	}
-
-	// Step 3: Construct manifest annotations and add CID to annotations
-	manifestAnnotations := extractManifestAnnotations(record)
 	// Add the calculated CID to manifest annotations for discovery
 	manifestAnnotations[ManifestKeyCid] = recordCID
 
-	// Step 4: Pack manifest (in-memory only)
+	// Step 5: Pack manifest (in-memory only)
 	manifestDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
 		oras.PackManifestOptions{
 			ManifestAnnotations: manifestAnnotations,
@@ -147,14 +260,22 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 		return nil, status.Errorf(codes.Internal, "failed to pack manifest: %v", err)
 	}
 
-	// Step 5: Create CID tag for content-addressable storage
+	// Step 6: Create CID tag for content-addressable storage
 	cidTag := recordCID
 	logger.Debug("Generated CID tag", "cid", recordCID, "tag", cidTag)
 
-	// Step 6: Tag the manifest with CID tag
+	// Step 7: Refuse to move an existing CID tag to a different manifest
+	if err := s.checkTagConflict(ctx, cidTag, manifestDesc); err != nil {
+		return nil, err
+	}
+
+	// Step 8: Tag the manifest with CID tag
 	// => resolve manifest to record which can be looked up (lookup)
 	// => allows pulling record directly (pull)
 	if _, err := oras.Tag(ctx, s.repo, manifestDesc.Digest.String(), cidTag); err != nil {
+		// The untagged manifest and blob cannot be looked up, so do not leave them behind
+		s.cleanupPartialPush(context.WithoutCancel(ctx), cidTag, manifestDesc, layerDesc)
+
 		return nil, status.Errorf(codes.Internal, "failed to create CID tag: %v", err)
 	}
 
@@ -164,8 +285,33 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 	return recordRef, nil
 }
 
+// canonicalMode returns the canonical JSON form records are pushed in.
+// The config is validated on creation, so unknown modes fall back to the default form.
+func (s *store) canonicalMode() corev1.CanonicalMode {
+	mode, err := corev1.ParseCanonicalMode(s.config.CanonicalMode)
+	if err != nil {
+		return corev1.CanonicalModeDefault
+	}
+
+	return mode
+}
+
+// storeVersion returns the store version recorded for blobs in the given canonical form.
+func storeVersion(mode corev1.CanonicalMode) string {
+	switch mode {
+	case corev1.CanonicalModeSorted:
+		return StoreVersionSorted
+	case corev1.CanonicalModeJCS:
+		return StoreVersionJCS
+	default:
+		return StoreVersionLegacy
+	}
+}
+
 // Lookup checks if the ref exists as a tagged record.
-func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (_ *corev1.RecordMeta, err error) {
+	defer observeOperation(metrics.OpLookup, time.Now(), &err)
+
 	// Input validation using shared helper
 	if err := validateRecordRef(ref); err != nil {
 		return nil, err
@@ -200,7 +346,62 @@ func (s *store) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.Reco
 	return recordMeta, nil
 }
 
-func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+// Exists checks if the ref exists as a tagged record.
+// Only the CID tag is resolved, without fetching the manifest.
+func (s *store) Exists(ctx context.Context, ref *corev1.RecordRef) (_ bool, err error) {
+	defer observeOperation(metrics.OpExists, time.Now(), &err)
+
+	if err := validateRecordRef(ref); err != nil {
+		return false, err
+	}
+
+	if _, err := s.repo.Resolve(ctx, ref.GetCid()); err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, status.Errorf(codes.Internal, "failed to resolve record %s: %v", ref.GetCid(), err)
+	}
+
+	return true, nil
+}
+
+// LookupMany looks up multiple records concurrently with a bounded number of workers.
+// Missing records have a nil entry in the result.
+func (s *store) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	metas := make([]*corev1.RecordMeta, len(refs))
+	errs := make([]error, len(refs))
+
+	err := concurrency.ForEach(ctx, len(refs), lookupManyConcurrency, func(i int) {
+		meta, err := s.Lookup(ctx, refs[i])
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+
+		metas[i], errs[i] = meta, err
+	})
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	// Return the first error to preserve its status code
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debug("Looked up multiple records", "count", len(refs))
+
+	return metas, nil
+}
+
+func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (_ *corev1.Record, err error) {
+	defer observeOperation(metrics.OpPull, time.Now(), &err)
+
+	ctx, span := tracing.Start(ctx, "store.Pull", tracing.AttrCID.String(ref.GetCid()))
+	defer func() { tracing.End(span, err) }()
+
 	// Input validation using shared helper
 	if err := validateRecordRef(ref); err != nil {
 		return nil, err
@@ -208,37 +409,36 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 
 	logger.Debug("Starting record pull", "cid", ref.GetCid())
 
-	// Use shared helper to fetch and parse manifest (eliminates code duplication)
-	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, ref.GetCid())
+	recordData, err := s.pullRecordBlob(ctx, ref.GetCid())
 	if err != nil {
-		return nil, err // Error already has proper context from helper
+		return nil, err
 	}
 
-	// Validate manifest has layers
-	if len(manifest.Layers) == 0 {
-		return nil, status.Errorf(codes.Internal, "manifest has no layers for CID %s", ref.GetCid())
+	// Unmarshal canonical JSON data back to Record
+	record, err := corev1.UnmarshalRecord(recordData)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal record for CID %s: %v", ref.GetCid(), err)
 	}
 
-	// Handle multiple layers with warning
-	if len(manifest.Layers) > 1 {
-		logger.Warn("Manifest has multiple layers, using first layer",
-			"cid", ref.GetCid(),
-			"layerCount", len(manifest.Layers))
-	}
+	return record, nil
+}
 
-	// Get the blob descriptor from the first layer
-	blobDesc := manifest.Layers[0]
+// pullRecordBlob fetches the record blob of a CID and returns its bytes, decompressed if stored compressed.
+func (s *store) pullRecordBlob(ctx context.Context, cid string) ([]byte, error) {
+	// Use shared helper to fetch and parse manifest (eliminates code duplication)
+	manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, cid)
+	if err != nil {
+		return nil, err // Error already has proper context from helper
+	}
 
-	// Validate layer media type
-	if blobDesc.MediaType != "application/json" {
-		logger.Warn("Unexpected blob media type",
-			"cid", ref.GetCid(),
-			"expected", "application/json",
-			"actual", blobDesc.MediaType)
+	// Select the record layer, records may be stored alongside auxiliary layers
+	blobDesc, err := recordLayer(cid, manifest)
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Debug("Fetching record blob",
-		"cid", ref.GetCid(),
+		"cid", cid,
 		"blobDigest", blobDesc.Digest.String(),
 		"blobSize", blobDesc.Size,
 		"mediaType", blobDesc.MediaType)
@@ -246,40 +446,44 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 	// Fetch the record data using the correct blob descriptor from the manifest
 	reader, err := s.repo.Fetch(ctx, blobDesc)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "record blob not found for CID %s: %v", ref.GetCid(), err)
+		return nil, status.Errorf(codes.NotFound, "record blob not found for CID %s: %v", cid, err)
 	}
 	defer reader.Close()
 
 	// Read all data from the reader
 	recordData, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to read record data for CID %s: %v", ref.GetCid(), err)
+		return nil, status.Errorf(codes.Internal, "failed to read record data for CID %s: %v", cid, err)
 	}
 
 	// Validate blob size matches descriptor
 	if blobDesc.Size > 0 && int64(len(recordData)) != blobDesc.Size {
 		logger.Warn("Blob size mismatch",
-			"cid", ref.GetCid(),
+			"cid", cid,
 			"expected", blobDesc.Size,
 			"actual", len(recordData))
 	}
 
-	// Unmarshal canonical JSON data back to Record
-	record, err := corev1.UnmarshalRecord(recordData)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to unmarshal record for CID %s: %v", ref.GetCid(), err)
+	// Decompress the blob if it was stored compressed
+	if compression := blobCompression(blobDesc); isCompressed(compression) {
+		recordData, err = decompressBlob(compression, recordData)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decompress record data for CID %s: %v", cid, err)
+		}
 	}
 
 	logger.Debug("Record pulled successfully",
-		"cid", ref.GetCid(),
+		"cid", cid,
 		"blobSize", len(recordData),
 		"blobDigest", blobDesc.Digest.String(),
 		"manifestDigest", manifestDesc.Digest.String())
 
-	return record, nil
+	return recordData, nil
 }
 
-func (s *store) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+func (s *store) Delete(ctx context.Context, ref *corev1.RecordRef) (err error) {
+	defer observeOperation(metrics.OpDelete, time.Now(), &err)
+
 	logger.Debug("Deleting record from OCI store", "ref", ref)
 
 	// Input validation using shared helper
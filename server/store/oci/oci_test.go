@@ -6,16 +6,24 @@ package oci
 
 import (
 	"context"
+	"io"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
 	typesv1alpha1 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha1"
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
 )
 
 // TODO: this should be configurable to unified Storage API test flow.
@@ -91,6 +99,313 @@ func TestStorePushLookupPullDelete(t *testing.T) {
 	assert.ErrorContains(t, err, "not found")
 }
 
+func TestStoreLookupMany(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	var refs []*corev1.RecordRef
+
+	for _, name := range []string{"agent-1", "agent-2"} {
+		ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          name,
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+
+		refs = append(refs, ref)
+	}
+
+	missingRef := &corev1.RecordRef{Cid: "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"}
+
+	metas, err := store.LookupMany(testCtx, []*corev1.RecordRef{refs[0], missingRef, refs[1]})
+	require.NoError(t, err)
+	require.Len(t, metas, 3)
+	assert.Equal(t, refs[0].GetCid(), metas[0].GetCid())
+	assert.Nil(t, metas[1])
+	assert.Equal(t, refs[1].GetCid(), metas[2].GetCid())
+}
+
+func TestStoreExists(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+	}))
+	require.NoError(t, err)
+
+	exists, err := store.Exists(testCtx, ref)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	missingRef := &corev1.RecordRef{Cid: "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"}
+
+	exists, err = store.Exists(testCtx, missingRef)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.Exists(testCtx, &corev1.RecordRef{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStorePushAnnotations(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Annotations:   map[string]string{"team": "search", "env": "dev"},
+	})
+
+	ctx := types.WithPushAnnotations(testCtx, map[string]string{"env": "prod", "pushed-by": "ci"})
+
+	ref, err := store.Push(ctx, record)
+	require.NoError(t, err)
+
+	// Push annotations are not part of the record
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+
+	meta, err := store.Lookup(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "search", meta.GetAnnotations()["team"])
+	assert.Equal(t, "prod", meta.GetAnnotations()["env"])
+	assert.Equal(t, "ci", meta.GetAnnotations()["pushed-by"])
+
+	pulled, err := store.Pull(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, ref.GetCid(), pulled.GetCid())
+}
+
+func TestStorePushAnnotationsExistingRecord(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Annotations:   map[string]string{"team": "search"},
+	})
+
+	ref, err := store.Push(types.WithPushAnnotations(testCtx, map[string]string{"env": "prod"}), record)
+	require.NoError(t, err)
+
+	t.Run("same annotations", func(t *testing.T) {
+		// Annotations the record is already stored with are accepted, so that pushes can be retried
+		pushed, err := store.Push(types.WithPushAnnotations(testCtx, map[string]string{"env": "prod", "team": "search"}), record)
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), pushed.GetCid())
+
+		_, err = store.Push(testCtx, record)
+		require.NoError(t, err)
+	})
+
+	t.Run("other annotations", func(t *testing.T) {
+		for _, annotations := range []map[string]string{{"env": "dev"}, {"pushed-by": "ci"}} {
+			_, err := store.Push(types.WithPushAnnotations(testCtx, annotations), record)
+			require.Error(t, err)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		}
+
+		// The stored annotations are unchanged
+		meta, err := store.Lookup(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, "prod", meta.GetAnnotations()["env"])
+		assert.NotContains(t, meta.GetAnnotations(), "pushed-by")
+	})
+}
+
+func TestStorePushExpiry(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "ephemeral-agent",
+		SchemaVersion: "v0.3.1",
+	})
+
+	expiresAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ref, err := store.Push(types.WithPushExpiry(testCtx, expiresAt), record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+
+	meta, err := store.Lookup(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T12:00:00Z", meta.GetExpiresAt())
+	assert.True(t, meta.IsExpired(expiresAt))
+
+	// The expiry of an existing record cannot be changed
+	_, err = store.Push(types.WithPushExpiry(testCtx, expiresAt.Add(time.Hour)), record)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "expires at 2025-01-01T12:00:00Z")
+
+	// Pushes without an expiry are accepted
+	_, err = store.Push(testCtx, record)
+	require.NoError(t, err)
+
+	meta, err = store.Lookup(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T12:00:00Z", meta.GetExpiresAt())
+}
+
+func TestStorePushExpiryExistingRecord(t *testing.T) {
+	store := &store{repo: memory.New()}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "permanent-agent",
+		SchemaVersion: "v0.3.1",
+	})
+
+	_, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+
+	// Records stored without an expiry cannot be given one by a push
+	_, err = store.Push(types.WithPushExpiry(testCtx, time.Now().Add(time.Hour)), record)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "without an expiry")
+}
+
+// countingTarget wraps a graph target and counts record blob uploads.
+type countingTarget struct {
+	oras.GraphTarget
+	blobPushes atomic.Int32
+}
+
+func (c *countingTarget) Push(ctx context.Context, desc ocispec.Descriptor, content io.Reader) error {
+	if desc.MediaType == "application/json" {
+		c.blobPushes.Add(1)
+	}
+
+	return c.GraphTarget.Push(ctx, desc, content) //nolint:wrapcheck
+}
+
+func TestStorePushIdempotent(t *testing.T) {
+	repo := &countingTarget{GraphTarget: memory.New()}
+	store := &store{repo: repo}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "A test agent",
+	})
+
+	// First push uploads the record blob
+	firstRef, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), firstRef.GetCid())
+	assert.Equal(t, int32(1), repo.blobPushes.Load())
+
+	// Second push of the same record must short-circuit before uploading
+	secondRef, err := store.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, firstRef.GetCid(), secondRef.GetCid())
+	assert.Equal(t, int32(1), repo.blobPushes.Load(), "second push should not upload the blob again")
+}
+
+func TestStorePushTagConflict(t *testing.T) {
+	repo := memory.New()
+	store := &store{repo: repo}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "A test agent",
+	})
+
+	// Simulate a stale manifest that is already tagged with the record CID
+	staleDesc, err := oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{
+			ManifestAnnotations: map[string]string{"stale": "true"},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = oras.Tag(testCtx, repo, staleDesc.Digest.String(), record.GetCid())
+	require.NoError(t, err)
+
+	// Push must refuse to move the tag to the new manifest
+	_, err = store.Push(testCtx, record)
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// The tag must still point to the stale manifest
+	desc, err := repo.Resolve(testCtx, record.GetCid())
+	require.NoError(t, err)
+	assert.Equal(t, staleDesc.Digest, desc.Digest)
+}
+
+func TestStoreCanonicalMode(t *testing.T) {
+	record := corev1.New(&typesv1alpha1.Record{
+		Name:          "jcs-agent",
+		SchemaVersion: "0.7.0",
+		Version:       "1.0.0",
+		Description:   "Converts <html> & markdown",
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir(), CanonicalMode: string(corev1.CanonicalModeLegacy)})
+		require.NoError(t, err)
+
+		ref, err := recordStore.Push(testCtx, record)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCanonicalCid(corev1.CanonicalModeLegacy), ref.GetCid())
+		assert.True(t, record.HasCid(ref.GetCid()))
+
+		// Legacy blobs are not annotated, as those pushed before other forms existed
+		ociStore, ok := recordStore.(*store)
+		require.True(t, ok)
+
+		manifest, _, err := ociStore.fetchAndParseManifest(testCtx, ref.GetCid())
+		require.NoError(t, err)
+		assert.NotContains(t, manifest.Layers[0].Annotations, DescriptorKeyStoreVersion)
+	})
+
+	t.Run("jcs", func(t *testing.T) {
+		recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir(), CanonicalMode: string(corev1.CanonicalModeJCS)})
+		require.NoError(t, err)
+
+		ref, err := recordStore.Push(testCtx, record)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCanonicalCid(corev1.CanonicalModeJCS), ref.GetCid())
+		assert.NotEqual(t, record.GetCid(), ref.GetCid())
+
+		// The blob is annotated with its store version
+		ociStore, ok := recordStore.(*store)
+		require.True(t, ok)
+
+		manifest, _, err := ociStore.fetchAndParseManifest(testCtx, ref.GetCid())
+		require.NoError(t, err)
+		assert.Equal(t, StoreVersionJCS, manifest.Layers[0].Annotations[DescriptorKeyStoreVersion])
+
+		pulled, err := recordStore.Pull(testCtx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, ref.GetCid(), pulled.GetCanonicalCid(corev1.CanonicalModeJCS))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir()})
+		require.NoError(t, err)
+
+		// Records are pushed in the sorted form, under the CID all components compute
+		ref, err := recordStore.Push(testCtx, record)
+		require.NoError(t, err)
+		assert.Equal(t, record.GetCanonicalCid(corev1.CanonicalModeSorted), ref.GetCid())
+		assert.Equal(t, record.GetCid(), ref.GetCid())
+
+		// The blob is annotated with its store version
+		ociStore, ok := recordStore.(*store)
+		require.True(t, ok)
+
+		manifest, _, err := ociStore.fetchAndParseManifest(testCtx, ref.GetCid())
+		require.NoError(t, err)
+		assert.Equal(t, StoreVersionSorted, manifest.Layers[0].Annotations[DescriptorKeyStoreVersion])
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := New(ociconfig.Config{LocalDir: t.TempDir(), CanonicalMode: "unknown"})
+		require.ErrorContains(t, err, "unsupported canonical mode")
+	})
+}
+
 func BenchmarkLocalStore(b *testing.B) {
 	if !runLocal {
 		b.Skip()
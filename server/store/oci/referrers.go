@@ -159,7 +159,7 @@ func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerTyp
 			}
 
 			// Extract referrer data from manifest
-			referrer, err := s.extractReferrerFromManifest(ctx, referrerDesc, recordCID)
+			referrer, err := s.extractReferrerFromManifest(ctx, referrerDesc, recordManifestDesc, recordCID)
 			if err != nil {
 				referrersLogger.Error("Failed to extract referrer from manifest", "digest", referrerDesc.Digest.String(), "error", err)
 
@@ -193,12 +193,17 @@ func (s *store) WalkReferrers(ctx context.Context, recordCID string, referrerTyp
 }
 
 // extractReferrerFromManifest extracts the referrer data from a referrer manifest.
-func (s *store) extractReferrerFromManifest(ctx context.Context, manifestDesc ocispec.Descriptor, recordCID string) (*corev1.RecordReferrer, error) {
+// The manifest must refer to the record manifest it was listed for, see verifyReferrerSubject.
+func (s *store) extractReferrerFromManifest(ctx context.Context, manifestDesc, recordManifestDesc ocispec.Descriptor, recordCID string) (*corev1.RecordReferrer, error) {
 	manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, manifestDesc)
 	if err != nil {
 		return nil, err // Error already includes proper gRPC status
 	}
 
+	if err := verifyReferrerSubject(manifest, recordManifestDesc, recordCID); err != nil {
+		return nil, err
+	}
+
 	if len(manifest.Layers) == 0 {
 		return nil, status.Errorf(codes.Internal, "referrer manifest has no layers")
 	}
@@ -239,6 +244,22 @@ func (s *store) extractReferrerFromManifest(ctx context.Context, manifestDesc oc
 	return referrer, nil
 }
 
+// verifyReferrerSubject checks that the subject of a referrer manifest is the record manifest.
+// This prevents returning referrers, such as signatures, that were mis-associated with the record in the registry.
+func verifyReferrerSubject(manifest *ocispec.Manifest, recordManifestDesc ocispec.Descriptor, recordCID string) error {
+	if manifest.Subject == nil {
+		return status.Errorf(codes.FailedPrecondition, "referrer manifest has no subject, expected record manifest %s of CID %s",
+			recordManifestDesc.Digest.String(), recordCID)
+	}
+
+	if manifest.Subject.Digest != recordManifestDesc.Digest {
+		return status.Errorf(codes.FailedPrecondition, "referrer manifest subject %s does not match record manifest %s of CID %s",
+			manifest.Subject.Digest.String(), recordManifestDesc.Digest.String(), recordCID)
+	}
+
+	return nil
+}
+
 // MediaTypeReferrerMatcher creates a ReferrerMatcher that checks for a specific media type.
 func (s *store) MediaTypeReferrerMatcher(expectedMediaType string) ReferrerMatcher {
 	return func(ctx context.Context, referrer ocispec.Descriptor) bool {
@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// staticReferrersTarget lists the same referrers for every subject,
+// simulating a registry that mis-associates referrers.
+type staticReferrersTarget struct {
+	oras.GraphTarget
+	referrers []ocispec.Descriptor
+}
+
+func (s *staticReferrersTarget) Referrers(_ context.Context, _ ocispec.Descriptor, _ string, fn func(referrers []ocispec.Descriptor) error) error {
+	return fn(s.referrers)
+}
+
+// pushTestReferrer pushes a referrer manifest with the given subject.
+func pushTestReferrer(t *testing.T, repo oras.GraphTarget, subject ocispec.Descriptor, name string) ocispec.Descriptor {
+	t.Helper()
+
+	referrerBytes, err := protojson.Marshal(&corev1.RecordReferrer{
+		Type:        "test.referrer",
+		Annotations: map[string]string{"name": name},
+	})
+	require.NoError(t, err)
+
+	blobDesc, err := oras.PushBytes(testCtx, repo, DefaultReferrerArtifactMediaType, referrerBytes)
+	require.NoError(t, err)
+
+	manifestDesc, err := oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, ocispec.MediaTypeImageManifest,
+		oras.PackManifestOptions{
+			Subject: &subject,
+			Layers:  []ocispec.Descriptor{blobDesc},
+		},
+	)
+	require.NoError(t, err)
+
+	return manifestDesc
+}
+
+func TestWalkReferrersVerifiesSubject(t *testing.T) {
+	repo := &staticReferrersTarget{GraphTarget: memory.New()}
+	store := &store{repo: repo}
+
+	var (
+		cids      []string
+		manifests []ocispec.Descriptor
+	)
+
+	for _, name := range []string{"record-agent", "other-agent"} {
+		ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          name,
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+
+		manifestDesc, err := repo.Resolve(testCtx, ref.GetCid())
+		require.NoError(t, err)
+
+		cids = append(cids, ref.GetCid())
+		manifests = append(manifests, manifestDesc)
+	}
+
+	// The registry lists a referrer of the other record for every record
+	repo.referrers = []ocispec.Descriptor{
+		pushTestReferrer(t, repo, manifests[0], "linked"),
+		pushTestReferrer(t, repo, manifests[1], "mis-associated"),
+	}
+
+	var names []string
+
+	err := store.WalkReferrers(testCtx, cids[0], "", func(referrer *corev1.RecordReferrer) error {
+		names = append(names, referrer.GetAnnotations()["name"])
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"linked"}, names)
+}
+
+func TestVerifyReferrerSubject(t *testing.T) {
+	recordManifest := ocispec.Descriptor{Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+	otherManifest := ocispec.Descriptor{Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+
+	require.NoError(t, verifyReferrerSubject(&ocispec.Manifest{Subject: &recordManifest}, recordManifest, "cid"))
+
+	err := verifyReferrerSubject(&ocispec.Manifest{Subject: &otherManifest}, recordManifest, "cid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match record manifest")
+
+	err = verifyReferrerSubject(&ocispec.Manifest{}, recordManifest, "cid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no subject")
+}
@@ -155,8 +155,8 @@ func (s *store) convertCosignSignatureToReferrer(blobDesc ocispec.Descriptor, da
 	return referrer, nil
 }
 
-// VerifyWithZot queries zot's verification API to check if a signature is valid.
-func (s *store) VerifyWithZot(ctx context.Context, recordCID string) (bool, error) {
+// VerifyWithZot queries zot's verification API to check if the signatures of a record are valid.
+func (s *store) VerifyWithZot(ctx context.Context, recordCID string) (*zot.VerificationResult, error) {
 	verifyOpts := &zot.VerificationOptions{
 		Config:    s.buildZotConfig(),
 		RecordCID: recordCID,
@@ -164,9 +164,8 @@ func (s *store) VerifyWithZot(ctx context.Context, recordCID string) (bool, erro
 
 	result, err := zot.Verify(ctx, verifyOpts)
 	if err != nil {
-		return false, fmt.Errorf("failed to verify with zot: %w", err)
+		return nil, fmt.Errorf("failed to verify with zot: %w", err)
 	}
 
-	// Return the trusted status (which implies signed as well)
-	return result.IsTrusted, nil
+	return result, nil
 }
@@ -0,0 +1,200 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Stats reports the limits of the store and, if requested, its usage.
+func (s *store) Stats(ctx context.Context, includeUsage bool) (*types.StoreStats, error) {
+	stats := &types.StoreStats{
+		MaxRecordBytes: s.config.MaxRecordBytes,
+	}
+
+	if !includeUsage {
+		return stats, nil
+	}
+
+	var err error
+
+	switch repo := s.repo.(type) {
+	case *oci.Store:
+		stats.Usage, err = s.localUsage(ctx, repo)
+	case *remote.Repository:
+		stats.Usage, err = s.remoteUsage(ctx, repo)
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "usage is not supported by %T", s.repo)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// localUsage walks the blobs directory of a local OCI store.
+func (s *store) localUsage(ctx context.Context, localStore *oci.Store) (*types.StoreUsage, error) {
+	// Make sure the index on disk reflects all tagged and untagged manifests
+	if err := localStore.SaveIndex(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save OCI index: %v", err)
+	}
+
+	blobs, err := listLocalBlobs(s.config.LocalDir)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list blobs: %v", err)
+	}
+
+	cids, err := s.ListRecordCIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &types.StoreUsage{RecordCount: len(cids)}
+	signedRecords := make(map[digest.Digest]struct{})
+
+	for _, blob := range blobs {
+		usage.TotalBlobBytes += blob.size
+
+		if blob.manifest == nil {
+			continue
+		}
+
+		usage.ManifestCount++
+
+		if blob.manifest.Subject != nil && isSignatureManifest(blob.manifest.Layers) {
+			signedRecords[blob.manifest.Subject.Digest] = struct{}{}
+		}
+	}
+
+	usage.SignedRecordCount = len(signedRecords)
+
+	return usage, nil
+}
+
+// remoteUsage inspects every record of a remote repository through the tags and referrers APIs.
+// Blobs shared between records are counted once per record.
+func (s *store) remoteUsage(ctx context.Context, repo *remote.Repository) (*types.StoreUsage, error) {
+	cids, err := s.ListRecordCIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &types.StoreUsage{RecordCount: len(cids)}
+
+	supported, err := probeReferrersAPI(ctx, repo)
+	if err != nil {
+		logger.Warn("Failed to check referrers API support", "error", err)
+	} else {
+		usage.ReferrersAPISupported = &supported
+	}
+
+	for _, cid := range cids {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		manifest, manifestDesc, err := s.fetchAndParseManifest(ctx, cid)
+		if err != nil {
+			logger.Warn("Failed to fetch record manifest, skipping", "cid", cid, "error", err)
+
+			continue
+		}
+
+		usage.ManifestCount++
+		usage.TotalBlobBytes += manifestDesc.Size + manifestSize(manifest)
+
+		signed := false
+
+		err = repo.Referrers(ctx, *manifestDesc, "", func(referrers []ocispec.Descriptor) error {
+			for _, referrerDesc := range referrers {
+				usage.ManifestCount++
+				usage.TotalBlobBytes += referrerDesc.Size
+
+				referrer, err := s.fetchAndParseManifestFromDescriptor(ctx, referrerDesc)
+				if err != nil {
+					logger.Warn("Failed to fetch referrer manifest, skipping", "cid", cid, "digest", referrerDesc.Digest.String(), "error", err)
+
+					continue
+				}
+
+				usage.TotalBlobBytes += manifestSize(referrer)
+				signed = signed || isSignatureManifest(referrer.Layers)
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Warn("Failed to list referrers, skipping", "cid", cid, "error", err)
+		}
+
+		if signed {
+			usage.SignedRecordCount++
+		}
+	}
+
+	return usage, nil
+}
+
+// manifestSize returns the size of the blobs referenced by a manifest.
+func manifestSize(manifest *ocispec.Manifest) int64 {
+	size := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+
+	return size
+}
+
+// isSignatureManifest reports whether manifest layers hold a signature.
+func isSignatureManifest(layers []ocispec.Descriptor) bool {
+	return len(layers) > 0 && layers[0].MediaType == SignatureArtifactType
+}
+
+// probeReferrersAPI checks whether the registry serves the OCI referrers API.
+// Registries supporting it must answer with an empty index for unknown subjects.
+func probeReferrersAPI(ctx context.Context, repo *remote.Repository) (bool, error) {
+	scheme := "https"
+	if repo.PlainHTTP {
+		scheme = "http"
+	}
+
+	url := fmt.Sprintf("%s://%s/v2/%s/referrers/%s", scheme, repo.Reference.Host(), repo.Reference.Repository, digest.FromBytes(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := repo.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to query referrers API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"strings"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/server/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestStoreMaxRecordBytes(t *testing.T) {
+	const limit = 1024
+
+	recordStore, err := New(ociconfig.Config{LocalDir: t.TempDir(), MaxRecordBytes: limit})
+	require.NoError(t, err)
+
+	t.Run("limit is reported", func(t *testing.T) {
+		statsStore, ok := recordStore.(types.StoreStatsAPI)
+		require.True(t, ok)
+
+		stats, err := statsStore.Stats(testCtx, false)
+		require.NoError(t, err)
+		assert.Equal(t, limit, stats.MaxRecordBytes)
+		assert.Nil(t, stats.Usage)
+	})
+
+	t.Run("small record is accepted", func(t *testing.T) {
+		_, err := recordStore.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          "small-agent",
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("large record is rejected", func(t *testing.T) {
+		record := corev1.New(&typesv1alpha0.Record{
+			Name:          "large-agent",
+			SchemaVersion: "v0.3.1",
+			Description:   strings.Repeat("x", 2*limit),
+		})
+
+		_, err := recordStore.Push(testCtx, record)
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = recordStore.Lookup(testCtx, &corev1.RecordRef{Cid: record.GetCid()})
+		require.Error(t, err)
+	})
+
+	t.Run("negative limit is invalid", func(t *testing.T) {
+		_, err := New(ociconfig.Config{LocalDir: t.TempDir(), MaxRecordBytes: -1})
+		require.Error(t, err)
+	})
+}
+
+func TestStoreLocalUsage(t *testing.T) {
+	layoutDir := t.TempDir()
+
+	repo, err := oci.New(layoutDir)
+	require.NoError(t, err)
+
+	store := &store{repo: repo, config: ociconfig.Config{LocalDir: layoutDir}}
+
+	t.Run("empty store", func(t *testing.T) {
+		stats, err := store.Stats(testCtx, true)
+		require.NoError(t, err)
+		require.NotNil(t, stats.Usage)
+		assert.Equal(t, types.StoreUsage{}, *stats.Usage)
+	})
+
+	var recordManifests []ocispec.Descriptor
+
+	for _, name := range []string{"signed-agent", "unsigned-agent"} {
+		ref, err := store.Push(testCtx, corev1.New(&typesv1alpha0.Record{
+			Name:          name,
+			SchemaVersion: "v0.3.1",
+		}))
+		require.NoError(t, err)
+
+		manifestDesc, err := repo.Resolve(testCtx, ref.GetCid())
+		require.NoError(t, err)
+
+		recordManifests = append(recordManifests, manifestDesc)
+	}
+
+	// Sign the first record twice
+	for _, signature := range []string{"signature-1", "signature-2"} {
+		layer, err := oras.PushBytes(testCtx, repo, SignatureArtifactType, []byte(signature))
+		require.NoError(t, err)
+
+		_, err = oras.PackManifest(testCtx, repo, oras.PackManifestVersion1_1, SignatureArtifactType,
+			oras.PackManifestOptions{
+				Subject: &recordManifests[0],
+				Layers:  []ocispec.Descriptor{layer},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	stats, err := store.Stats(testCtx, true)
+	require.NoError(t, err)
+	require.NotNil(t, stats.Usage)
+
+	usage := stats.Usage
+	assert.Equal(t, 2, usage.RecordCount)
+	assert.Equal(t, 1, usage.SignedRecordCount)
+	assert.Equal(t, 4, usage.ManifestCount)
+	assert.Positive(t, usage.TotalBlobBytes)
+	assert.Nil(t, usage.ReferrersAPISupported)
+}
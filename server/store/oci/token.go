@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"golang.org/x/oauth2/clientcredentials"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed,
+// so that it does not expire while a request is in flight.
+const tokenExpiryDelta = 30 * time.Second
+
+// TokenSource provides the access tokens used to authenticate to the registry.
+type TokenSource interface {
+	// Token returns a valid access token.
+	Token(ctx context.Context) (string, error)
+
+	// Invalidate discards a token rejected by the registry,
+	// so that the next call to Token obtains a new one.
+	Invalidate(token string)
+}
+
+// TokenSourceFunc creates a TokenSource from a refresh callback.
+// The callback is called on first use and whenever the registry rejects the current token.
+func TokenSourceFunc(refresh func(ctx context.Context) (string, error)) TokenSource {
+	return &cachedTokenSource{
+		fetch: func(ctx context.Context) (string, time.Time, error) {
+			token, err := refresh(ctx)
+
+			return token, time.Time{}, err
+		},
+	}
+}
+
+// newOAuth2TokenSource creates a TokenSource using the OAuth2 client credentials flow.
+func newOAuth2TokenSource(cfg ociconfig.OAuth2Config) TokenSource {
+	credentials := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       strings.Fields(cfg.Scope),
+	}
+
+	return &cachedTokenSource{
+		fetch: func(ctx context.Context) (string, time.Time, error) {
+			token, err := credentials.Token(ctx)
+			if err != nil {
+				return "", time.Time{}, fmt.Errorf("failed to obtain token from %s: %w", cfg.TokenURL, err)
+			}
+
+			return token.AccessToken, token.Expiry, nil
+		},
+	}
+}
+
+// cachedTokenSource caches a token until it expires or is rejected.
+type cachedTokenSource struct {
+	fetch func(ctx context.Context) (string, time.Time, error)
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (s *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryDelta) {
+		return s.token, nil
+	}
+
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if token == "" {
+		return "", errors.New("token source returned an empty token")
+	}
+
+	s.token = token
+	s.expiry = expiry
+
+	return token, nil
+}
+
+func (s *cachedTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only discard the token if it was not refreshed by a concurrent request in the meantime
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// tokenClient authenticates registry requests with bearer tokens from a TokenSource.
+// A request rejected with 401 Unauthorized is retried once with a refreshed token.
+type tokenClient struct {
+	client remote.Client
+	source TokenSource
+}
+
+func (c *tokenClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	token, err := c.source.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry access token: %w", err)
+	}
+
+	resp, err := c.send(req, req.Body, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The request can only be retried if its body can be replayed
+	var body io.ReadCloser = http.NoBody
+	if req.GetBody != nil {
+		if body, err = req.GetBody(); err != nil {
+			return resp, nil //nolint:nilerr
+		}
+	} else if req.Body != nil && req.Body != http.NoBody {
+		return resp, nil
+	}
+
+	logger.Info("Registry rejected access token, refreshing it", "url", req.URL.Redacted())
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	c.source.Invalidate(token)
+
+	token, err = c.source.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh registry access token: %w", err)
+	}
+
+	return c.send(req, body, token)
+}
+
+// send sends a copy of the request with the given body and bearer token.
+func (c *tokenClient) send(req *http.Request, body io.ReadCloser, token string) (*http.Response, error) {
+	authReq := req.Clone(req.Context())
+	authReq.Body = body
+	authReq.Header.Set("Authorization", "Bearer "+token)
+
+	return c.client.Do(authReq) //nolint:wrapcheck
+}
@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRegistry serves a single manifest and only accepts the current bearer token.
+type mockRegistry struct {
+	mu         sync.Mutex
+	validToken string
+	rejected   int
+}
+
+func (m *mockRegistry) setValidToken(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.validToken = token
+}
+
+func (m *mockRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	authorized := r.Header.Get("Authorization") == "Bearer "+m.validToken
+
+	if !authorized {
+		m.rejected++
+	}
+	m.mu.Unlock()
+
+	if !authorized {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	manifest := []byte("{}")
+
+	w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+	w.Header().Set("Content-Length", strconv.Itoa(len(manifest)))
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestRegistryConfig(t *testing.T, registry http.Handler) ociconfig.Config {
+	t.Helper()
+
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+
+	return ociconfig.Config{
+		RegistryAddress: strings.TrimPrefix(server.URL, "http://"),
+		RepositoryName:  "test",
+		AuthConfig:      ociconfig.AuthConfig{Insecure: true},
+	}
+}
+
+func TestRepositoryRefreshesOAuth2Token(t *testing.T) {
+	var issued atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, issued.Add(1))
+	}))
+	defer tokenServer.Close()
+
+	registry := &mockRegistry{validToken: "token-1"}
+
+	cfg := newTestRegistryConfig(t, registry)
+	cfg.OAuth2 = ociconfig.OAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	}
+	require.NoError(t, ValidateConfig(cfg))
+
+	repo, err := NewORASRepository(cfg)
+	require.NoError(t, err)
+
+	_, err = repo.Resolve(t.Context(), "latest")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), issued.Load())
+
+	// The token is cached while it is accepted
+	_, err = repo.Resolve(t.Context(), "latest")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), issued.Load())
+
+	// Once the token expires on the registry side, it is refreshed and the request retried
+	registry.setValidToken("token-2")
+
+	_, err = repo.Resolve(t.Context(), "latest")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), issued.Load())
+	assert.Equal(t, 1, registry.rejected)
+}
+
+func TestRepositoryRefreshCallback(t *testing.T) {
+	registry := &mockRegistry{validToken: "fresh"}
+
+	var calls atomic.Int32
+
+	source := TokenSourceFunc(func(context.Context) (string, error) {
+		// The first token handed out is already expired
+		if calls.Add(1) == 1 {
+			return "expired", nil
+		}
+
+		return "fresh", nil
+	})
+
+	repo, err := NewORASRepository(newTestRegistryConfig(t, registry), WithTokenSource(source))
+	require.NoError(t, err)
+
+	_, err = repo.Resolve(t.Context(), "latest")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 1, registry.rejected)
+}
+
+func TestRepositoryTokenStillRejected(t *testing.T) {
+	registry := &mockRegistry{validToken: "never-issued"}
+
+	source := TokenSourceFunc(func(context.Context) (string, error) {
+		return "rejected", nil
+	})
+
+	repo, err := NewORASRepository(newTestRegistryConfig(t, registry), WithTokenSource(source))
+	require.NoError(t, err)
+
+	// A single refresh is attempted per request
+	_, err = repo.Resolve(t.Context(), "latest")
+	require.Error(t, err)
+	assert.Equal(t, 2, registry.rejected)
+}
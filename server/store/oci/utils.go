@@ -17,8 +17,29 @@ func stringPtr(s string) *string {
 	return &s
 }
 
+// RepositoryOption configures the ORAS repository client.
+type RepositoryOption func(*repositoryOptions)
+
+type repositoryOptions struct {
+	tokenSource TokenSource
+}
+
+// WithTokenSource authenticates to the registry with access tokens from the given source.
+// Tokens rejected by the registry are refreshed and the request is retried.
+// It takes precedence over the credentials of the configuration.
+func WithTokenSource(source TokenSource) RepositoryOption {
+	return func(opts *repositoryOptions) {
+		opts.tokenSource = source
+	}
+}
+
 // NewORASRepository creates a new ORAS repository client configured with authentication.
-func NewORASRepository(cfg ociconfig.Config) (*remote.Repository, error) {
+func NewORASRepository(cfg ociconfig.Config, opts ...RepositoryOption) (*remote.Repository, error) {
+	options := &repositoryOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", cfg.RegistryAddress, cfg.RepositoryName))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to remote repo: %w", err)
@@ -26,6 +47,27 @@ func NewORASRepository(cfg ociconfig.Config) (*remote.Repository, error) {
 
 	// Configure repository
 	repo.PlainHTTP = cfg.Insecure
+
+	if options.tokenSource == nil && cfg.OAuth2.Enabled() {
+		options.tokenSource = newOAuth2TokenSource(cfg.OAuth2)
+	}
+
+	// Access tokens from a token source are refreshed when rejected,
+	// so long-running servers keep working after tokens expire
+	if options.tokenSource != nil {
+		repo.Client = &tokenClient{
+			client: &auth.Client{
+				Client: retry.DefaultClient,
+				Header: http.Header{
+					"User-Agent": {"dir-client"},
+				},
+			},
+			source: options.tokenSource,
+		}
+
+		return repo, nil
+	}
+
 	repo.Client = &auth.Client{
 		Client: retry.DefaultClient,
 		Header: http.Header{
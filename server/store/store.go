@@ -4,29 +4,133 @@
 package store
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 
+	storeconfig "github.com/agntcy/dir/server/store/config"
+	"github.com/agntcy/dir/server/store/multi"
 	"github.com/agntcy/dir/server/store/oci"
+	"github.com/agntcy/dir/server/store/upstream"
+	"github.com/agntcy/dir/server/store/webhook"
 	"github.com/agntcy/dir/server/types"
 )
 
 type Provider string
 
 const (
-	OCI = Provider("oci")
+	OCI   = Provider("oci")
+	Multi = Provider("multi")
 )
 
+// ValidateConfig checks the store configuration without creating the store.
+func ValidateConfig(cfg storeconfig.Config) error {
+	if cfg.Webhook.Enabled() {
+		if u, err := url.Parse(cfg.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook: invalid url %q, expected an http or https URL", cfg.Webhook.URL)
+		}
+
+		if cfg.Webhook.MaxRetries < 0 {
+			return fmt.Errorf("webhook: max_retries must not be negative (%d)", cfg.Webhook.MaxRetries)
+		}
+	}
+
+	if cfg.Upstream.Enabled() && cfg.Upstream.Timeout < 0 {
+		return fmt.Errorf("upstream: timeout must not be negative (%s)", cfg.Upstream.Timeout)
+	}
+
+	return validateStoreConfig(cfg)
+}
+
+func validateStoreConfig(cfg storeconfig.Config) error {
+	switch provider := Provider(cfg.Provider); provider {
+	case OCI:
+		if err := oci.ValidateConfig(cfg.OCI); err != nil {
+			return fmt.Errorf("oci: %w", err)
+		}
+
+	case Multi:
+		if len(cfg.Multi.Stores) == 0 {
+			return errors.New("multi: at least one child store is required")
+		}
+
+		if cfg.Multi.Quorum < 0 || cfg.Multi.Quorum > len(cfg.Multi.Stores) {
+			return fmt.Errorf("multi: quorum %d must be between 0 and the number of child stores (%d)", cfg.Multi.Quorum, len(cfg.Multi.Stores))
+		}
+
+		for i, childCfg := range cfg.Multi.Stores {
+			if err := validateStoreConfig(childCfg); err != nil {
+				return fmt.Errorf("multi.stores[%d]: %w", i, err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported provider %q, expected one of: %s, %s", provider, OCI, Multi)
+	}
+
+	return nil
+}
+
 // TODO: add options for adding cache.
+// If a webhook is configured, the store notifies it of every successful push
+// and implements io.Closer to stop the notifications.
+// If an upstream directory is configured, records missing locally are fetched from it
+// and the store implements types.RecordIndexer to index them.
 func New(opts types.APIOptions) (types.StoreAPI, error) {
-	switch provider := Provider(opts.Config().Store.Provider); provider {
+	cfg := opts.Config().Store
+
+	base, err := newStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	store := base
+
+	if cfg.Webhook.Enabled() {
+		store = webhook.Wrap(store, cfg.Webhook)
+	}
+
+	// Fetched records are cached rather than pushed, so they are pushed to
+	// the base store directly and not sent to the webhook
+	if cfg.Upstream.Enabled() {
+		store, err = upstream.Wrap(store, cfg.Upstream, upstream.WithFetchTarget(base))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upstream store: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
+func newStore(cfg storeconfig.Config) (types.StoreAPI, error) {
+	switch provider := Provider(cfg.Provider); provider {
 	case OCI:
-		store, err := oci.New(opts.Config().Store.OCI)
+		store, err := oci.New(cfg.OCI)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create OCI store: %w", err)
 		}
 
 		return store, nil
 
+	case Multi:
+		stores := make([]types.StoreAPI, 0, len(cfg.Multi.Stores))
+
+		for i, childCfg := range cfg.Multi.Stores {
+			store, err := newStore(childCfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create child store %d: %w", i, err)
+			}
+
+			stores = append(stores, store)
+		}
+
+		store, err := multi.New(stores, cfg.Multi.Quorum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create multi store: %w", err)
+		}
+
+		return store, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported provider=%s", provider)
 	}
@@ -0,0 +1,29 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "time"
+
+const (
+	DefaultTimeout = 10 * time.Second
+	DefaultTLS     = false
+)
+
+// Config configures the upstream directory that records missing locally are fetched from.
+type Config struct {
+	// Address of the upstream directory API, e.g. "dir.example.com:8888".
+	// If empty, records missing locally are not fetched.
+	Address string `json:"address,omitempty" mapstructure:"address"`
+
+	// Timeout of a single fetch from the upstream directory.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// TLS connects to the upstream directory over TLS, verified with the system certificate pool.
+	TLS bool `json:"tls,omitempty" mapstructure:"tls"`
+}
+
+// Enabled reports whether an upstream directory is configured.
+func (c Config) Enabled() bool {
+	return c.Address != ""
+}
@@ -0,0 +1,366 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package upstream
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	upstreamconfig "github.com/agntcy/dir/server/store/upstream/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("store/upstream")
+
+// fetchFunc fetches a record from the upstream directory.
+type fetchFunc func(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error)
+
+// Option configures optional upstream store settings.
+type Option func(*upstreamStore)
+
+// WithFetchTarget pushes fetched records to target rather than to the source store.
+// The target must be a store wrapped by the source store, so that the source store
+// serves the fetched records. This keeps fetched records away from wrappers acting
+// on pushes, such as the webhook.
+func WithFetchTarget(target types.StoreAPI) Option {
+	return func(s *upstreamStore) {
+		if target != nil {
+			s.target = target
+		}
+	}
+}
+
+// upstreamStore wraps a StoreAPI and fetches records missing from it from an upstream directory.
+// Fetched records are pushed to the target store, by default the source store, so later requests are served locally.
+type upstreamStore struct {
+	source  types.StoreAPI
+	target  types.StoreAPI
+	fetch   fetchFunc
+	timeout time.Duration
+	conn    *grpc.ClientConn
+
+	// Concurrent requests for the same missing record share a single fetch
+	group singleflight.Group
+
+	mu    sync.RWMutex
+	index types.IndexFunc
+}
+
+// Wrap creates a pull-through store that fetches records missing from the source store
+// from the configured upstream directory. Only Pull and Lookup of full CIDs fetch records,
+// other operations, including Exists, only see the source store. Referrers such as
+// signatures are not fetched.
+// The returned store implements io.Closer to close the upstream connection and the source store.
+func Wrap(source types.StoreAPI, cfg upstreamconfig.Config, opts ...Option) (types.StoreAPI, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection to upstream directory %s: %w", cfg.Address, err)
+	}
+
+	client := storev1.NewStoreServiceClient(conn)
+
+	s := wrap(source, func(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+		return pullRecord(ctx, client, ref)
+	}, cfg.Timeout)
+	s.conn = conn
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// wrap creates a pull-through store using the given fetch function.
+func wrap(source types.StoreAPI, fetch fetchFunc, timeout time.Duration) *upstreamStore {
+	if timeout <= 0 {
+		timeout = upstreamconfig.DefaultTimeout
+	}
+
+	return &upstreamStore{
+		source:  source,
+		target:  source,
+		fetch:   fetch,
+		timeout: timeout,
+	}
+}
+
+// pullRecord pulls a single record from the upstream directory.
+func pullRecord(ctx context.Context, client storev1.StoreServiceClient, ref *corev1.RecordRef) (*corev1.Record, error) {
+	stream, err := client.Pull(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(ref); err != nil {
+		return nil, err
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return stream.Recv()
+}
+
+// SetIndexFunc sets the function indexing fetched records.
+func (s *upstreamStore) SetIndexFunc(fn types.IndexFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index = fn
+}
+
+// fetchRecord fetches a record from the upstream directory and stores it in the target store.
+func (s *upstreamStore) fetchRecord(ctx context.Context, cid string) (*corev1.Record, error) {
+	result, err, _ := s.group.Do(cid, func() (any, error) {
+		// The fetch is shared by all waiting requests, so it is not canceled with the first one
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+		defer cancel()
+
+		record, err := s.fetch(fetchCtx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			return nil, err
+		}
+
+		// Records are content-addressed, so any other CID means the record was altered
+		if !record.HasCid(cid) {
+			return nil, fmt.Errorf("upstream directory returned record %s instead of %s", record.GetCid(), cid)
+		}
+
+		pushedRef, err := s.target.Push(fetchCtx, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store fetched record: %w", err)
+		}
+
+		s.indexRecord(adapters.NewRecordAdapterWithCid(record, pushedRef.GetCid()))
+
+		logger.Info("Fetched record from upstream directory", "cid", cid)
+
+		return record, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record, _ := result.(*corev1.Record)
+
+	return record, nil
+}
+
+// indexRecord adds a fetched record to the search index, if an index function is set.
+func (s *upstreamStore) indexRecord(record types.Record) {
+	s.mu.RLock()
+	index := s.index
+	s.mu.RUnlock()
+
+	if index == nil {
+		return
+	}
+
+	if err := index(record); err != nil {
+		logger.Error("Failed to add fetched record to search index", "cid", record.GetCid(), "error", err)
+	}
+}
+
+// fetchMissing fetches the record of a failed local request if it is missing locally.
+// It reports whether the record was fetched. Fetch failures are logged and the local
+// error is kept, so callers see the same errors as without an upstream directory.
+func (s *upstreamStore) fetchMissing(ctx context.Context, ref *corev1.RecordRef, localErr error) (*corev1.Record, bool) {
+	if status.Code(localErr) != codes.NotFound || !corev1.IsValidCID(ref.GetCid()) {
+		return nil, false
+	}
+
+	record, err := s.fetchRecord(ctx, ref.GetCid())
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			logger.Debug("Record not found in upstream directory", "cid", ref.GetCid())
+		} else {
+			logger.Warn("Failed to fetch record from upstream directory", "cid", ref.GetCid(), "error", err)
+		}
+
+		return nil, false
+	}
+
+	return record, true
+}
+
+// Push pushes a record to the source store.
+func (s *upstreamStore) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	return s.source.Push(ctx, record)
+}
+
+// Pull pulls a record from the source store, fetching it from the upstream directory if missing.
+func (s *upstreamStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	record, err := s.source.Pull(ctx, ref)
+	if err == nil {
+		return record, nil
+	}
+
+	if fetched, ok := s.fetchMissing(ctx, ref, err); ok {
+		return fetched, nil
+	}
+
+	return nil, err
+}
+
+// Lookup looks up record metadata in the source store, fetching the record from the upstream directory if missing.
+func (s *upstreamStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	meta, err := s.source.Lookup(ctx, ref)
+	if err == nil {
+		return meta, nil
+	}
+
+	if _, ok := s.fetchMissing(ctx, ref, err); ok {
+		return s.source.Lookup(ctx, ref)
+	}
+
+	return nil, err
+}
+
+// LookupMany looks up metadata of multiple records in the source store.
+// Missing records are not fetched, so batch lookups only report local records.
+func (s *upstreamStore) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	return s.source.LookupMany(ctx, refs)
+}
+
+// Delete removes a record from the source store.
+func (s *upstreamStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+	return s.source.Delete(ctx, ref)
+}
+
+// Exists reports whether the record is in the source store.
+func (s *upstreamStore) Exists(ctx context.Context, ref *corev1.RecordRef) (bool, error) {
+	return types.RecordExists(ctx, s.source, ref)
+}
+
+// Close closes the upstream connection and the source store.
+func (s *upstreamStore) Close() error {
+	var errs []error
+
+	if s.conn != nil {
+		if err := s.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close upstream connection: %w", err))
+		}
+	}
+
+	if closer, ok := s.source.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PushReferrer pushes a referrer to the source store.
+func (s *upstreamStore) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
+	refStore, ok := s.source.(types.ReferrerStoreAPI)
+	if !ok {
+		return status.Error(codes.Unimplemented, "referrers not supported by source store")
+	}
+
+	return refStore.PushReferrer(ctx, recordCID, referrer)
+}
+
+// WalkReferrers walks the referrers of the source store.
+func (s *upstreamStore) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
+	refStore, ok := s.source.(types.ReferrerStoreAPI)
+	if !ok {
+		return status.Error(codes.Unimplemented, "referrers not supported by source store")
+	}
+
+	return refStore.WalkReferrers(ctx, recordCID, referrerType, walkFn)
+}
+
+// ListRecordCIDs lists the records of the source store.
+func (s *upstreamStore) ListRecordCIDs(ctx context.Context) ([]string, error) {
+	lister, ok := s.source.(types.RecordListerAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "listing records not supported by source store")
+	}
+
+	return lister.ListRecordCIDs(ctx)
+}
+
+// ResolvePrefix resolves an abbreviated CID using the source store.
+func (s *upstreamStore) ResolvePrefix(ctx context.Context, prefix string) (string, error) {
+	resolver, ok := s.source.(types.PrefixResolverAPI)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "resolving CID prefixes not supported by source store")
+	}
+
+	return resolver.ResolvePrefix(ctx, prefix)
+}
+
+// Stats reports the statistics and limits of the source store.
+func (s *upstreamStore) Stats(ctx context.Context, includeUsage bool) (*types.StoreStats, error) {
+	statsStore, ok := s.source.(types.StoreStatsAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "stats not supported by source store")
+	}
+
+	return statsStore.Stats(ctx, includeUsage)
+}
+
+// PullLayers lists the record manifest layers of the source store.
+func (s *upstreamStore) PullLayers(ctx context.Context, ref *corev1.RecordRef) ([]types.LayerInfo, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayers(ctx, ref)
+}
+
+// PullLayer pulls a record manifest layer from the source store.
+func (s *upstreamStore) PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (io.ReadCloser, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayer(ctx, ref, digest)
+}
+
+// PullRecordBlob pulls the stored record bytes from the source store.
+func (s *upstreamStore) PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) ([]byte, error) {
+	blobStore, ok := s.source.(types.RecordBlobAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record blobs not supported by source store")
+	}
+
+	return blobStore.PullRecordBlob(ctx, ref)
+}
+
+// GarbageCollect deletes unreferenced blobs of the source store.
+func (s *upstreamStore) GarbageCollect(ctx context.Context, dryRun bool) (*types.GarbageCollectResult, error) {
+	collector, ok := s.source.(types.GarbageCollectorAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "garbage collection not supported by source store")
+	}
+
+	return collector.GarbageCollect(ctx, dryRun)
+}
@@ -0,0 +1,276 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package upstream
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/store/webhook"
+	webhookconfig "github.com/agntcy/dir/server/store/webhook/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryStore keeps pushed records in memory.
+type memoryStore struct {
+	types.StoreAPI
+
+	mu      sync.Mutex
+	records map[string]*corev1.Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]*corev1.Record)}
+}
+
+func (m *memoryStore) Push(_ context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.GetCid()] = record
+
+	return &corev1.RecordRef{Cid: record.GetCid()}, nil
+}
+
+func (m *memoryStore) Pull(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[ref.GetCid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+
+	return record, nil
+}
+
+func (m *memoryStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	if _, err := m.Pull(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func newTestRecord(name string) *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          name,
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+// countingFetch serves the given records and counts the fetches.
+func countingFetch(count *atomic.Int32, records ...*corev1.Record) fetchFunc {
+	return func(_ context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+		count.Add(1)
+
+		for _, record := range records {
+			if record.GetCid() == ref.GetCid() {
+				return record, nil
+			}
+		}
+
+		return nil, status.Errorf(codes.NotFound, "record not found: %s", ref.GetCid())
+	}
+}
+
+func TestPullThrough(t *testing.T) {
+	record := newTestRecord("upstream-agent")
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	var fetches atomic.Int32
+
+	source := newMemoryStore()
+	store := wrap(source, countingFetch(&fetches, record), time.Second)
+
+	var indexed []string
+
+	store.SetIndexFunc(func(record types.Record) error {
+		indexed = append(indexed, record.GetCid())
+
+		return nil
+	})
+
+	// The first pull fetches the record from upstream
+	pulled, err := store.Pull(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// The record is stored and indexed locally
+	_, err = source.Pull(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []string{record.GetCid()}, indexed)
+
+	// Later requests are served locally
+	_, err = store.Pull(t.Context(), ref)
+	require.NoError(t, err)
+
+	meta, err := store.Lookup(t.Context(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), meta.GetCid())
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestPullThroughLookup(t *testing.T) {
+	record := newTestRecord("lookup-agent")
+
+	var fetches atomic.Int32
+
+	store := wrap(newMemoryStore(), countingFetch(&fetches, record), time.Second)
+
+	meta, err := store.Lookup(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), meta.GetCid())
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestPullThroughMissingUpstream(t *testing.T) {
+	record := newTestRecord("missing-agent")
+
+	var fetches atomic.Int32
+
+	store := wrap(newMemoryStore(), countingFetch(&fetches), time.Second)
+
+	_, err := store.Pull(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestPullThroughSkipsInvalidCIDs(t *testing.T) {
+	var fetches atomic.Int32
+
+	store := wrap(newMemoryStore(), countingFetch(&fetches), time.Second)
+
+	_, err := store.Pull(t.Context(), &corev1.RecordRef{Cid: "bafy"})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(0), fetches.Load())
+}
+
+func TestPullThroughRejectsAlteredRecord(t *testing.T) {
+	requested := newTestRecord("requested-agent")
+	other := newTestRecord("other-agent")
+
+	source := newMemoryStore()
+	store := wrap(source, func(context.Context, *corev1.RecordRef) (*corev1.Record, error) {
+		return other, nil
+	}, time.Second)
+
+	_, err := store.Pull(t.Context(), &corev1.RecordRef{Cid: requested.GetCid()})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Nothing was stored
+	_, err = source.Pull(t.Context(), &corev1.RecordRef{Cid: other.GetCid()})
+	require.Error(t, err)
+}
+
+func TestPullThroughSharesConcurrentFetches(t *testing.T) {
+	record := newTestRecord("shared-agent")
+	release := make(chan struct{})
+
+	var fetches atomic.Int32
+
+	fetch := countingFetch(&fetches, record)
+	store := wrap(newMemoryStore(), func(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+		<-release
+
+		return fetch(ctx, ref)
+	}, time.Second)
+
+	const pulls = 5
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, pulls)
+
+	for range pulls {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := store.Pull(t.Context(), &corev1.RecordRef{Cid: record.GetCid()})
+			errs <- err
+		}()
+	}
+
+	// Let all pulls reach the fetch before releasing it
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestPullThroughBypassesWebhook(t *testing.T) {
+	var notifications atomic.Int32
+
+	cids := make(chan string, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var notification webhook.Notification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+
+		notifications.Add(1)
+		cids <- notification.CID
+	}))
+	defer server.Close()
+
+	base := newMemoryStore()
+	hooked := webhook.Wrap(base, webhookconfig.Config{URL: server.URL})
+
+	defer hooked.(io.Closer).Close()
+
+	fetched := newTestRecord("fetched-agent")
+	pushed := newTestRecord("pushed-agent")
+
+	var fetches atomic.Int32
+
+	store := wrap(hooked, countingFetch(&fetches, fetched), time.Second)
+	WithFetchTarget(base)(store)
+
+	// Fetched records are stored in the base store and served through the webhook store
+	_, err := store.Pull(t.Context(), &corev1.RecordRef{Cid: fetched.GetCid()})
+	require.NoError(t, err)
+
+	_, err = hooked.Pull(t.Context(), &corev1.RecordRef{Cid: fetched.GetCid()})
+	require.NoError(t, err)
+
+	// Pushes are still notified, and notifications are delivered in order,
+	// so the only notification is the one of the pushed record
+	_, err = store.Push(t.Context(), pushed)
+	require.NoError(t, err)
+
+	select {
+	case cid := <-cids:
+		assert.Equal(t, pushed.GetCid(), cid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("push was not notified")
+	}
+
+	assert.Equal(t, int32(1), notifications.Load())
+	assert.Equal(t, int32(1), fetches.Load())
+}
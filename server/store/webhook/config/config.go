@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "time"
+
+const (
+	DefaultTimeout        = 10 * time.Second
+	DefaultQueueSize      = 1000
+	DefaultMaxRetries     = 5
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// Config configures the webhook notified after each successful push.
+type Config struct {
+	// URL the notifications are POSTed to.
+	// If empty, no notifications are sent.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// Timeout of a single delivery attempt.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// Maximum number of notifications waiting for delivery.
+	// Notifications are dropped while the queue is full, so a slow endpoint never blocks pushes.
+	QueueSize int `json:"queue_size,omitempty" mapstructure:"queue_size"`
+
+	// Maximum number of retries of a failed delivery.
+	MaxRetries int `json:"max_retries,omitempty" mapstructure:"max_retries"`
+
+	// Delay before the first retry, doubled on every further retry.
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty" mapstructure:"initial_backoff"`
+
+	// Upper bound of the delay between retries.
+	MaxBackoff time.Duration `json:"max_backoff,omitempty" mapstructure:"max_backoff"`
+}
+
+// Enabled reports whether a webhook is configured.
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
@@ -0,0 +1,339 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	webhookconfig "github.com/agntcy/dir/server/store/webhook/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("store/webhook")
+
+// Notification is the JSON payload POSTed to the webhook after a successful push.
+type Notification struct {
+	CID           string    `json:"cid"`
+	Name          string    `json:"name,omitempty"`
+	SchemaVersion string    `json:"schema_version,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// webhookStore wraps a StoreAPI and notifies a webhook of every successful push.
+// Notifications are delivered in the background, so pushes are never delayed by the webhook.
+type webhookStore struct {
+	source types.StoreAPI
+	config webhookconfig.Config
+	client *http.Client
+
+	queue     chan Notification
+	ctx       context.Context //nolint:containedctx
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Wrap creates a store that notifies the configured webhook after each successful push to the source store.
+// Notifications are queued and delivered by a background worker with retries and exponential backoff.
+// When the queue is full, new notifications are dropped.
+// The returned store implements io.Closer to stop the worker.
+func Wrap(source types.StoreAPI, cfg webhookconfig.Config) types.StoreAPI {
+	cfg = withDefaults(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &webhookStore{
+		source: source,
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		queue:  make(chan Notification, cfg.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// withDefaults fills unset settings with their defaults.
+func withDefaults(cfg webhookconfig.Config) webhookconfig.Config {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = webhookconfig.DefaultTimeout
+	}
+
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = webhookconfig.DefaultQueueSize
+	}
+
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = webhookconfig.DefaultInitialBackoff
+	}
+
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = max(cfg.InitialBackoff, webhookconfig.DefaultMaxBackoff)
+	}
+
+	return cfg
+}
+
+// Push pushes a record to the source store and queues a webhook notification on success.
+func (s *webhookStore) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	ref, err := s.source.Push(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	s.enqueue(newNotification(ref, record))
+
+	return ref, nil
+}
+
+// newNotification builds the notification of a pushed record.
+// Name and schema version are best-effort and left empty if the record cannot be decoded.
+func newNotification(ref *corev1.RecordRef, record *corev1.Record) Notification {
+	notification := Notification{
+		CID:       ref.GetCid(),
+		Timestamp: time.Now().UTC(),
+	}
+
+	if data, err := adapters.NewRecordAdapter(record).GetRecordData(); err == nil {
+		notification.Name = data.GetName()
+		notification.SchemaVersion = data.GetSchemaVersion()
+	}
+
+	return notification
+}
+
+// enqueue queues a notification without blocking, dropping it if the queue is full.
+func (s *webhookStore) enqueue(notification Notification) {
+	select {
+	case <-s.ctx.Done():
+		logger.Warn("Webhook closed, dropping notification", "cid", notification.CID)
+	case s.queue <- notification:
+	default:
+		logger.Warn("Webhook queue full, dropping notification", "cid", notification.CID, "queueSize", s.config.QueueSize)
+	}
+}
+
+// run delivers queued notifications until the store is closed.
+func (s *webhookStore) run() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case notification := <-s.queue:
+			s.deliver(notification)
+		}
+	}
+}
+
+// deliver sends a notification, retrying failed attempts with exponential backoff.
+func (s *webhookStore) deliver(notification Notification) {
+	backoff := s.config.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := s.send(notification)
+		if err == nil {
+			logger.Debug("Webhook notified", "cid", notification.CID, "attempt", attempt+1)
+
+			return
+		}
+
+		if attempt >= s.config.MaxRetries {
+			logger.Error("Failed to notify webhook, giving up", "cid", notification.CID, "attempts", attempt+1, "error", err)
+
+			return
+		}
+
+		logger.Warn("Failed to notify webhook, retrying", "cid", notification.CID, "attempt", attempt+1, "backoff", backoff, "error", err)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, s.config.MaxBackoff) //nolint:mnd
+	}
+}
+
+// send POSTs a notification to the webhook once. Any non-2xx response is a failure.
+func (s *webhookStore) send(notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Close stops the delivery worker. Notifications still queued are dropped.
+func (s *webhookStore) Close() error {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		<-s.done
+
+		if pending := len(s.queue); pending > 0 {
+			logger.Warn("Webhook closed with undelivered notifications", "count", pending)
+		}
+	})
+
+	return nil
+}
+
+// Pull pulls a record from the source store.
+func (s *webhookStore) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
+	return s.source.Pull(ctx, ref)
+}
+
+// Lookup looks up record metadata in the source store.
+func (s *webhookStore) Lookup(ctx context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	return s.source.Lookup(ctx, ref)
+}
+
+// LookupMany looks up metadata of multiple records in the source store.
+func (s *webhookStore) LookupMany(ctx context.Context, refs []*corev1.RecordRef) ([]*corev1.RecordMeta, error) {
+	return s.source.LookupMany(ctx, refs)
+}
+
+// Delete removes a record from the source store.
+func (s *webhookStore) Delete(ctx context.Context, ref *corev1.RecordRef) error {
+	return s.source.Delete(ctx, ref)
+}
+
+// Exists reports whether the record is in the source store.
+func (s *webhookStore) Exists(ctx context.Context, ref *corev1.RecordRef) (bool, error) {
+	return types.RecordExists(ctx, s.source, ref)
+}
+
+// PushReferrer pushes a referrer to the source store.
+func (s *webhookStore) PushReferrer(ctx context.Context, recordCID string, referrer *corev1.RecordReferrer) error {
+	refStore, ok := s.source.(types.ReferrerStoreAPI)
+	if !ok {
+		return status.Error(codes.Unimplemented, "referrers not supported by source store")
+	}
+
+	return refStore.PushReferrer(ctx, recordCID, referrer)
+}
+
+// WalkReferrers walks the referrers of the source store.
+func (s *webhookStore) WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error {
+	refStore, ok := s.source.(types.ReferrerStoreAPI)
+	if !ok {
+		return status.Error(codes.Unimplemented, "referrers not supported by source store")
+	}
+
+	return refStore.WalkReferrers(ctx, recordCID, referrerType, walkFn)
+}
+
+// ListRecordCIDs lists the records of the source store.
+func (s *webhookStore) ListRecordCIDs(ctx context.Context) ([]string, error) {
+	lister, ok := s.source.(types.RecordListerAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "listing records not supported by source store")
+	}
+
+	return lister.ListRecordCIDs(ctx)
+}
+
+// ResolvePrefix resolves an abbreviated CID using the source store.
+func (s *webhookStore) ResolvePrefix(ctx context.Context, prefix string) (string, error) {
+	resolver, ok := s.source.(types.PrefixResolverAPI)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "resolving CID prefixes not supported by source store")
+	}
+
+	return resolver.ResolvePrefix(ctx, prefix)
+}
+
+// Stats reports the statistics and limits of the source store.
+func (s *webhookStore) Stats(ctx context.Context, includeUsage bool) (*types.StoreStats, error) {
+	statsStore, ok := s.source.(types.StoreStatsAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "stats not supported by source store")
+	}
+
+	return statsStore.Stats(ctx, includeUsage)
+}
+
+// PullLayers lists the record manifest layers of the source store.
+func (s *webhookStore) PullLayers(ctx context.Context, ref *corev1.RecordRef) ([]types.LayerInfo, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayers(ctx, ref)
+}
+
+// PullLayer pulls a record manifest layer from the source store.
+func (s *webhookStore) PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (io.ReadCloser, error) {
+	layerStore, ok := s.source.(types.LayerStoreAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "layers not supported by source store")
+	}
+
+	return layerStore.PullLayer(ctx, ref, digest)
+}
+
+// PullRecordBlob pulls the stored record bytes from the source store.
+func (s *webhookStore) PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) ([]byte, error) {
+	blobStore, ok := s.source.(types.RecordBlobAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record blobs not supported by source store")
+	}
+
+	return blobStore.PullRecordBlob(ctx, ref)
+}
+
+// GarbageCollect deletes unreferenced blobs of the source store.
+func (s *webhookStore) GarbageCollect(ctx context.Context, dryRun bool) (*types.GarbageCollectResult, error) {
+	collector, ok := s.source.(types.GarbageCollectorAPI)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "garbage collection not supported by source store")
+	}
+
+	return collector.GarbageCollect(ctx, dryRun)
+}
@@ -0,0 +1,173 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	webhookconfig "github.com/agntcy/dir/server/store/webhook/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStore accepts every push and holds no records.
+type fakeStore struct {
+	types.StoreAPI
+
+	fail bool
+}
+
+func (f *fakeStore) Push(_ context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
+	if f.fail {
+		return nil, status.Error(codes.Unavailable, "store is down")
+	}
+
+	return &corev1.RecordRef{Cid: record.GetCid()}, nil
+}
+
+func newTestRecord() *corev1.Record {
+	return corev1.New(&typesv1alpha0.Record{
+		Name:          "webhook-agent",
+		Version:       "v1.0.0",
+		SchemaVersion: "v0.3.1",
+	})
+}
+
+func newTestConfig(url string) webhookconfig.Config {
+	return webhookconfig.Config{
+		URL:            url,
+		Timeout:        time.Second,
+		QueueSize:      10,
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+func TestWebhookNotifiesOnPush(t *testing.T) {
+	notifications := make(chan Notification, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var notification Notification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+
+		notifications <- notification
+	}))
+	defer server.Close()
+
+	store := Wrap(&fakeStore{}, newTestConfig(server.URL))
+	defer store.(io.Closer).Close()
+
+	record := newTestRecord()
+
+	ref, err := store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	select {
+	case notification := <-notifications:
+		assert.Equal(t, ref.GetCid(), notification.CID)
+		assert.Equal(t, "webhook-agent", notification.Name)
+		assert.Equal(t, "v0.3.1", notification.SchemaVersion)
+		assert.False(t, notification.Timestamp.IsZero())
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not notified")
+	}
+}
+
+func TestWebhookRetriesFailedDeliveries(t *testing.T) {
+	var attempts atomic.Int32
+
+	delivered := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		close(delivered)
+	}))
+	defer server.Close()
+
+	store := Wrap(&fakeStore{}, newTestConfig(server.URL))
+	defer store.(io.Closer).Close()
+
+	_, err := store.Push(t.Context(), newTestRecord())
+	require.NoError(t, err)
+
+	select {
+	case <-delivered:
+		assert.Equal(t, int32(3), attempts.Load())
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook delivery was not retried")
+	}
+}
+
+func TestWebhookDoesNotBlockPushes(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cfg := newTestConfig(server.URL)
+	cfg.QueueSize = 1
+	cfg.Timeout = time.Minute
+
+	store := Wrap(&fakeStore{}, cfg)
+	defer store.(io.Closer).Close()
+
+	// Pushes succeed while the endpoint hangs and the queue is full
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 10 {
+			_, err := store.Push(t.Context(), newTestRecord())
+			assert.NoError(t, err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pushes were blocked by the webhook")
+	}
+}
+
+func TestWebhookSkipsFailedPushes(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	store := Wrap(&fakeStore{fail: true}, newTestConfig(server.URL))
+
+	_, err := store.Push(t.Context(), newTestRecord())
+	require.Error(t, err)
+
+	require.NoError(t, store.(io.Closer).Close())
+	assert.Zero(t, requests.Load())
+}
@@ -13,6 +13,18 @@ const (
 	DefaultSyncSchedulerInterval = 30 * time.Second
 	DefaultSyncWorkerCount       = 1
 	DefaultSyncWorkerTimeout     = 10 * time.Minute
+	DefaultQuerySyncInterval     = 5 * time.Minute
+
+	// CredentialsStoreFile writes negotiated credentials to a file in CredentialsDir.
+	CredentialsStoreFile = "file"
+
+	// CredentialsStoreEnv reads credentials from the environment and writes them to a file in CredentialsDir.
+	CredentialsStoreEnv = "env"
+
+	// CredentialsStoreSecret uses the pre-provisioned credentials file at CredentialsSecretPath.
+	CredentialsStoreSecret = "secret"
+
+	DefaultCredentialsStore = CredentialsStoreFile
 )
 
 type Config struct {
@@ -27,6 +39,26 @@ type Config struct {
 	// Worker timeout.
 	WorkerTimeout time.Duration `json:"worker_timeout,omitempty" mapstructure:"worker_timeout"`
 
+	// TLS settings of synced registries.
+	// Registries without an entry are synced without TLS verification.
+	RegistryTLS []RegistryTLS `json:"registry_tls,omitempty" mapstructure:"registry_tls"`
+
+	// Credentials store.
+	// Controls where credentials for synced registries come from: file, env or secret.
+	CredentialsStore string `json:"credentials_store,omitempty" mapstructure:"credentials_store"`
+
+	// Directory where the zot credentials file is written.
+	// Created on demand if it does not exist. Defaults to the zot configuration directory.
+	CredentialsDir string `json:"credentials_dir,omitempty" mapstructure:"credentials_dir"`
+
+	// Path to a pre-provisioned zot credentials file (e.g. a mounted secret).
+	// Used when the credentials store is "secret".
+	CredentialsSecretPath string `json:"credentials_secret_path,omitempty" mapstructure:"credentials_secret_path"`
+
+	// Query-based syncs.
+	// Each keeps a synced registry limited to the records matching a set of routing queries.
+	QuerySyncs []QuerySync `json:"query_syncs,omitempty" mapstructure:"query_syncs"`
+
 	// Registry monitor configuration
 	RegistryMonitor monitor.Config `json:"registry_monitor,omitempty" mapstructure:"registry_monitor"`
 
@@ -34,6 +66,55 @@ type Config struct {
 	AuthConfig `json:"auth_config,omitempty" mapstructure:"auth_config"`
 }
 
+// RegistryTLS configures TLS for a synced registry.
+type RegistryTLS struct {
+	// URL of the remote registry, as returned by the remote Directory node.
+	RegistryURL string `json:"registry_url,omitempty" mapstructure:"registry_url"`
+
+	// TLS verification of the registry.
+	// Should be enabled when the registry is exposed over HTTPS.
+	TLSVerify bool `json:"tls_verify,omitempty" mapstructure:"tls_verify"`
+
+	// Directory with the CA certificate (ca.crt) used to verify the registry.
+	// Required for registries with certificates issued by a private CA.
+	CACertDir string `json:"ca_cert_dir,omitempty" mapstructure:"ca_cert_dir"`
+}
+
+// RegistryTLSFor returns the TLS settings of the registry, or no TLS verification if it has none.
+func (c Config) RegistryTLSFor(registryURL string) RegistryTLS {
+	for _, registryTLS := range c.RegistryTLS {
+		if registryTLS.RegistryURL == registryURL {
+			return registryTLS
+		}
+	}
+
+	return RegistryTLS{RegistryURL: registryURL}
+}
+
+// QuerySync mirrors the records of a synced registry that match a set of routing queries,
+// instead of a static list of CIDs.
+type QuerySync struct {
+	// URL of the remote registry.
+	// The registry must have been added to the sync configuration by a sync.
+	RegistryURL string `json:"registry_url,omitempty" mapstructure:"registry_url"`
+
+	// Routing queries that records must all match to be synced.
+	Queries []QuerySyncQuery `json:"queries,omitempty" mapstructure:"queries"`
+
+	// Interval at which the matching records are resolved again.
+	// If zero, DefaultQuerySyncInterval is used.
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+}
+
+// QuerySyncQuery is a routing query of a query-based sync.
+type QuerySyncQuery struct {
+	// Query type: skill, locator, domain or module.
+	Type string `json:"type,omitempty" mapstructure:"type"`
+
+	// Query value, with the same wildcards as routing searches.
+	Value string `json:"value,omitempty" mapstructure:"value"`
+}
+
 // AuthConfig represents the configuration for authentication.
 type AuthConfig struct {
 	Username string `json:"username,omitempty" mapstructure:"username"`
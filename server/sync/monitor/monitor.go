@@ -339,14 +339,17 @@ func (s *MonitorService) detectChanges(oldSnapshot, newSnapshot *RegistrySnapsho
 }
 
 // processChanges processes detected registry changes by indexing new records.
+// The records are added to the database in a single batch.
 func (s *MonitorService) processChanges(ctx context.Context, changes *RegistryChanges) {
+	records := make([]types.Record, 0, len(changes.NewTags))
+
 	for _, tag := range changes.NewTags {
-		// Index record
-		if err := s.indexRecord(ctx, tag); err != nil {
+		record, err := s.loadRecord(ctx, tag)
+		if err != nil {
 			// Warn but continue processing other records even if one fails
-			logger.Error("Failed to index record", "tag", tag, "error", err)
+			logger.Error("Failed to load record for indexing", "tag", tag, "error", err)
 		} else {
-			logger.Debug("Successfully indexed record", "tag", tag)
+			records = append(records, record)
 		}
 
 		// Upload public key to OCI store
@@ -354,45 +357,43 @@ func (s *MonitorService) processChanges(ctx context.Context, changes *RegistryCh
 			logger.Error("Failed to upload public key", "tag", tag, "error", err)
 		}
 	}
+
+	if len(records) == 0 {
+		return
+	}
+
+	// Records that are already indexed are skipped by the database
+	if err := s.db.AddRecords(records); err != nil {
+		logger.Error("Failed to index records", "count", len(records), "error", err)
+
+		return
+	}
+
+	logger.Info("Successfully indexed local records", "count", len(records))
 }
 
-// indexRecord indexes a single record from the registry into the database.
-func (s *MonitorService) indexRecord(ctx context.Context, tag string) error {
-	logger.Debug("Indexing record", "tag", tag)
+// loadRecord pulls a record from the local store and validates it for indexing.
+func (s *MonitorService) loadRecord(ctx context.Context, tag string) (types.Record, error) {
+	logger.Debug("Loading record for indexing", "tag", tag)
 
 	// Pull record from local store
 	recordRef := &corev1.RecordRef{Cid: tag}
 
 	record, err := s.store.Pull(ctx, recordRef)
 	if err != nil {
-		return fmt.Errorf("failed to pull record from local store: %w", err)
+		return nil, fmt.Errorf("failed to pull record from local store: %w", err)
 	}
 
 	isValid, validationErrors, err := record.Validate()
 	if err != nil {
-		return fmt.Errorf("failed to validate record: %w", err)
+		return nil, fmt.Errorf("failed to validate record: %w", err)
 	}
 
 	if !isValid {
-		return fmt.Errorf("record validation failed: %v", validationErrors)
-	}
-
-	// Add to database
-	recordAdapter := adapters.NewRecordAdapter(record)
-	if err := s.db.AddRecord(recordAdapter); err != nil {
-		// Check if this is a duplicate record error - if so, it's not really an error
-		if s.isDuplicateRecordError(err) {
-			logger.Debug("Record already indexed, skipping", "cid", tag)
-
-			return nil
-		}
-
-		return fmt.Errorf("failed to add record to database: %w", err)
+		return nil, fmt.Errorf("record validation failed: %v", validationErrors)
 	}
 
-	logger.Info("Successfully indexed local record", "cid", tag)
-
-	return nil
+	return adapters.NewRecordAdapterWithCid(record, tag), nil
 }
 
 // uploadPublicKey uploads a public key to the OCI store.
@@ -454,20 +455,6 @@ func (s *MonitorService) uploadPublicKey(ctx context.Context, tag string) error
 	return nil
 }
 
-// isDuplicateRecordError checks if the error indicates a duplicate record.
-func (s *MonitorService) isDuplicateRecordError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := strings.ToLower(err.Error())
-
-	return strings.Contains(errStr, "duplicate") ||
-		strings.Contains(errStr, "already exists") ||
-		strings.Contains(errStr, "unique constraint") ||
-		strings.Contains(errStr, "primary key")
-}
-
 // isRepositoryNotFoundError checks if the error is a "repository not found" (404) error.
 func isRepositoryNotFoundError(err error) bool {
 	if err == nil {
@@ -0,0 +1,197 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/agntcy/dir/server/sync/config"
+	"github.com/agntcy/dir/server/types"
+	zotutils "github.com/agntcy/dir/utils/zot"
+)
+
+// ResolveQueryCIDs resolves routing queries to the sorted, deduplicated list of matching record CIDs.
+// Resolution uses the locally cached network announcements and does not contact other peers.
+// All queries must match for a record to be included.
+func ResolveQueryCIDs(ctx context.Context, routing types.RoutingAPI, queries []*routingv1.RecordQuery) ([]string, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("at least one query is required")
+	}
+
+	minMatchScore := uint32(len(queries)) //nolint:gosec // number of queries is small
+
+	resultCh, err := routing.Search(ctx, &routingv1.SearchRequest{
+		Queries:       queries,
+		MinMatchScore: &minMatchScore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search records: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	cids := make([]string, 0)
+
+	for result := range resultCh {
+		cid := result.GetRecordRef().GetCid()
+		if cid == "" {
+			continue
+		}
+
+		if _, ok := seen[cid]; ok {
+			continue
+		}
+
+		seen[cid] = struct{}{}
+		cids = append(cids, cid)
+	}
+
+	slices.Sort(cids)
+
+	return cids, nil
+}
+
+// newQueryContentSyncers creates the content refreshers of the configured query-based syncs.
+func newQueryContentSyncers(routing types.RoutingAPI, querySyncs []config.QuerySync) ([]*QueryContentSyncer, error) {
+	syncers := make([]*QueryContentSyncer, 0, len(querySyncs))
+
+	for i, querySync := range querySyncs {
+		if querySync.RegistryURL == "" {
+			return nil, fmt.Errorf("query_syncs[%d]: registry URL is required", i)
+		}
+
+		queries, err := parseQuerySyncQueries(querySync.Queries)
+		if err != nil {
+			return nil, fmt.Errorf("query_syncs[%d]: %w", i, err)
+		}
+
+		interval := querySync.Interval
+		if interval <= 0 {
+			interval = config.DefaultQuerySyncInterval
+		}
+
+		syncers = append(syncers, NewQueryContentSyncer(routing, zotutils.DefaultZotConfigPath, querySync.RegistryURL, ociconfig.DefaultRepositoryName, queries, interval))
+	}
+
+	return syncers, nil
+}
+
+// parseQuerySyncQueries converts configured queries to routing queries.
+func parseQuerySyncQueries(queries []config.QuerySyncQuery) ([]*routingv1.RecordQuery, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("at least one query is required")
+	}
+
+	recordQueries := make([]*routingv1.RecordQuery, 0, len(queries))
+
+	for _, query := range queries {
+		queryType, ok := routingv1.RecordQueryType_value["RECORD_QUERY_TYPE_"+strings.ToUpper(query.Type)]
+		if !ok || queryType == int32(routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED) {
+			return nil, fmt.Errorf("unsupported query type %q, expected skill, locator, domain or module", query.Type)
+		}
+
+		if query.Value == "" {
+			return nil, fmt.Errorf("missing value for query type %q", query.Type)
+		}
+
+		recordQueries = append(recordQueries, &routingv1.RecordQuery{
+			Type:  routingv1.RecordQueryType(queryType),
+			Value: query.Value,
+		})
+	}
+
+	return recordQueries, nil
+}
+
+// QueryContentSyncer keeps the zot sync content of a registry in line with the
+// records matching a set of routing queries. This allows a downstream mirror to
+// follow a logical subset (e.g. all records with a given skill) instead of a static CID list.
+type QueryContentSyncer struct {
+	routing        types.RoutingAPI
+	configPath     string
+	registryURL    string
+	repositoryName string
+	queries        []*routingv1.RecordQuery
+	interval       time.Duration
+}
+
+// NewQueryContentSyncer creates a new query-based sync content refresher.
+// The registry must already be present in the zot sync configuration.
+func NewQueryContentSyncer(routing types.RoutingAPI, configPath, registryURL, repositoryName string, queries []*routingv1.RecordQuery, interval time.Duration) *QueryContentSyncer {
+	return &QueryContentSyncer{
+		routing:        routing,
+		configPath:     configPath,
+		registryURL:    registryURL,
+		repositoryName: repositoryName,
+		queries:        queries,
+		interval:       interval,
+	}
+}
+
+// Refresh resolves the queries and updates the registry sync content if the set of matching CIDs changed.
+// Registries that have not been added to the sync configuration yet are skipped.
+func (q *QueryContentSyncer) Refresh(ctx context.Context) error {
+	found, err := zotutils.HasSyncRegistry(q.configPath, q.registryURL)
+	if err != nil {
+		return fmt.Errorf("failed to check registry sync config: %w", err)
+	}
+
+	if !found {
+		logger.Debug("Skipping query-based sync content refresh of registry not synced yet", "registry_url", q.registryURL)
+
+		return nil
+	}
+
+	cids, err := ResolveQueryCIDs(ctx, q.routing, q.queries)
+	if err != nil {
+		return err
+	}
+
+	changed, err := zotutils.UpdateRegistrySyncContent(q.configPath, q.registryURL, q.repositoryName, cids)
+	if err != nil {
+		return fmt.Errorf("failed to update registry sync content: %w", err)
+	}
+
+	if changed {
+		logger.Info("Updated query-based sync content", "registry_url", q.registryURL, "cids", len(cids))
+	}
+
+	return nil
+}
+
+// Run refreshes the sync content immediately and then periodically until stopped.
+func (q *QueryContentSyncer) Run(ctx context.Context, stopCh <-chan struct{}) {
+	logger.Info("Starting query-based sync content refresher", "registry_url", q.registryURL, "interval", q.interval)
+
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	// Refresh immediately on start
+	if err := q.Refresh(ctx); err != nil {
+		logger.Error("Failed to refresh query-based sync content", "registry_url", q.registryURL, "error", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Query-based sync content refresher stopping due to context cancellation")
+
+			return
+		case <-stopCh:
+			logger.Info("Query-based sync content refresher stopping due to stop signal")
+
+			return
+		case <-ticker.C:
+			if err := q.Refresh(ctx); err != nil {
+				logger.Error("Failed to refresh query-based sync content", "registry_url", q.registryURL, "error", err)
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	synctypes "github.com/agntcy/dir/server/sync/types"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	zotutils "github.com/agntcy/dir/utils/zot"
 )
 
 var logger = logging.Logger("sync")
@@ -24,15 +25,28 @@ type Service struct {
 	config         config.Config
 	monitorService *monitor.MonitorService
 
-	scheduler *Scheduler
-	workers   []*Worker
+	scheduler    *Scheduler
+	workers      []*Worker
+	querySyncers []*QueryContentSyncer
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
 }
 
 // New creates a new sync service.
-func New(db types.DatabaseAPI, store types.StoreAPI, opts types.APIOptions) (*Service, error) {
+// Routing resolves the records of query-based syncs.
+func New(db types.DatabaseAPI, store types.StoreAPI, routing types.RoutingAPI, opts types.APIOptions) (*Service, error) {
+	querySyncers, err := newQueryContentSyncers(routing, opts.Config().Sync.QuerySyncs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query-based sync configuration: %w", err)
+	}
+
+	for i, registryTLS := range opts.Config().Sync.RegistryTLS {
+		if registryTLS.RegistryURL == "" {
+			return nil, fmt.Errorf("invalid registry TLS configuration: registry_tls[%d]: registry URL is required", i)
+		}
+	}
+
 	monitorService, err := monitor.NewMonitorService(db, store, opts.Config().Store.OCI, opts.Config().Sync.RegistryMonitor)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create registry monitor service: %w", err)
@@ -43,6 +57,7 @@ func New(db types.DatabaseAPI, store types.StoreAPI, opts types.APIOptions) (*Se
 		store:          store,
 		config:         opts.Config().Sync,
 		monitorService: monitorService,
+		querySyncers:   querySyncers,
 		stopCh:         make(chan struct{}),
 	}, nil
 }
@@ -57,10 +72,15 @@ func (s *Service) Start(ctx context.Context) error {
 	// Create and start scheduler
 	s.scheduler = NewScheduler(s.db, workQueue, s.config.SchedulerInterval)
 
+	credStore, err := newCredentialStore(s.config)
+	if err != nil {
+		return err
+	}
+
 	// Create and start workers
 	s.workers = make([]*Worker, s.config.WorkerCount)
 	for i := range s.config.WorkerCount {
-		s.workers[i] = NewWorker(i, s.db, s.store, workQueue, s.config.WorkerTimeout, s.monitorService)
+		s.workers[i] = NewWorker(i, s.db, s.store, workQueue, s.config.WorkerTimeout, s.monitorService, s.config.RegistryTLSFor, credStore)
 	}
 
 	// Start scheduler
@@ -83,6 +103,17 @@ func (s *Service) Start(ctx context.Context) error {
 		}(worker)
 	}
 
+	// Start query-based sync content refreshers
+	for _, querySyncer := range s.querySyncers {
+		s.wg.Add(1)
+
+		go func(q *QueryContentSyncer) {
+			defer s.wg.Done()
+
+			q.Run(ctx, s.stopCh)
+		}(querySyncer)
+	}
+
 	logger.Info("Sync service started successfully")
 
 	return nil
@@ -107,3 +138,21 @@ func (s *Service) Stop() error {
 
 	return nil
 }
+
+// newCredentialStore creates the credential store for synced registries from the configuration.
+func newCredentialStore(cfg config.Config) (zotutils.CredentialStore, error) {
+	switch cfg.CredentialsStore {
+	case "", config.CredentialsStoreFile:
+		return zotutils.FileCredentialStore{Dir: cfg.CredentialsDir}, nil
+	case config.CredentialsStoreEnv:
+		return zotutils.EnvCredentialStore{Dir: cfg.CredentialsDir}, nil
+	case config.CredentialsStoreSecret:
+		if cfg.CredentialsSecretPath == "" {
+			return nil, fmt.Errorf("credentials secret path is required for credentials store %q", config.CredentialsStoreSecret)
+		}
+
+		return zotutils.SecretCredentialStore{Path: cfg.CredentialsSecretPath}, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentials store %q", cfg.CredentialsStore)
+	}
+}
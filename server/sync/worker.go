@@ -28,10 +28,12 @@ type Worker struct {
 	workQueue      <-chan synctypes.WorkItem
 	timeout        time.Duration
 	monitorService *monitor.MonitorService
+	registryTLS    func(registryURL string) syncconfig.RegistryTLS
+	credStore      zotutils.CredentialStore
 }
 
 // NewWorker creates a new worker instance.
-func NewWorker(id int, db types.DatabaseAPI, store types.StoreAPI, workQueue <-chan synctypes.WorkItem, timeout time.Duration, monitorService *monitor.MonitorService) *Worker {
+func NewWorker(id int, db types.DatabaseAPI, store types.StoreAPI, workQueue <-chan synctypes.WorkItem, timeout time.Duration, monitorService *monitor.MonitorService, registryTLS func(registryURL string) syncconfig.RegistryTLS, credStore zotutils.CredentialStore) *Worker {
 	return &Worker{
 		id:             id,
 		db:             db,
@@ -39,6 +41,8 @@ func NewWorker(id int, db types.DatabaseAPI, store types.StoreAPI, workQueue <-c
 		workQueue:      workQueue,
 		timeout:        timeout,
 		monitorService: monitorService,
+		registryTLS:    registryTLS,
+		credStore:      credStore,
 	}
 }
 
@@ -148,11 +152,16 @@ func (w *Worker) addSync(ctx context.Context, item synctypes.WorkItem) error {
 		return fmt.Errorf("failed to update sync remote registry: %w", err)
 	}
 
-	// Update zot configuration with sync extension to trigger sync
+	// Update zot configuration with sync extension to trigger sync, using the TLS settings of the registry
+	registryTLS := w.registryTLS(remoteRegistryURL)
+
 	if err := zotutils.AddRegistryToSyncConfig(zotutils.DefaultZotConfigPath, remoteRegistryURL, ociconfig.DefaultRepositoryName, zotsyncconfig.Credentials{
 		Username: credentials.Username,
 		Password: credentials.Password,
-	}, item.CIDs); err != nil {
+	}, item.CIDs, zotutils.TLSOptions{
+		Verify:    registryTLS.TLSVerify,
+		CACertDir: registryTLS.CACertDir,
+	}, w.credStore); err != nil {
 		return fmt.Errorf("failed to add registry to zot sync: %w", err)
 	}
 
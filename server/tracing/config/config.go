@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+const (
+	// ExporterNone disables tracing. All spans are no-ops.
+	ExporterNone = ""
+
+	// ExporterLog writes finished spans to the server log.
+	ExporterLog = "log"
+)
+
+const (
+	DefaultTracingExporter    = ExporterNone
+	DefaultTracingSampleRatio = 1.0
+	DefaultTracingServiceName = "dir-apiserver"
+)
+
+type Config struct {
+	// Exporter used for finished spans.
+	// Tracing is disabled unless an exporter is configured.
+	Exporter string `json:"exporter,omitempty" mapstructure:"exporter"`
+
+	// SampleRatio is the fraction of new traces that are sampled, between 0 and 1.
+	// Traces started by a client are sampled according to the client's decision.
+	SampleRatio float64 `json:"sample_ratio,omitempty" mapstructure:"sample_ratio"`
+
+	// ServiceName reported on all spans.
+	ServiceName string `json:"service_name,omitempty" mapstructure:"service_name"`
+}
+
+// Enabled returns true if an exporter is configured.
+func (c Config) Enabled() bool {
+	return c.Exporter != ExporterNone
+}
@@ -0,0 +1,41 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// logExporter writes finished spans to the server log.
+type logExporter struct{}
+
+func (e *logExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	for _, span := range spans {
+		args := []any{
+			"name", span.Name(),
+			"trace_id", span.SpanContext().TraceID().String(),
+			"span_id", span.SpanContext().SpanID().String(),
+			"duration", span.EndTime().Sub(span.StartTime()),
+			"status", span.Status().Code.String(),
+		}
+
+		if parent := span.Parent(); parent.IsValid() {
+			args = append(args, "parent_span_id", parent.SpanID().String())
+		}
+
+		for _, attr := range span.Attributes() {
+			args = append(args, string(attr.Key), attr.Value.Emit())
+		}
+
+		logger.Info("Span finished", args...)
+	}
+
+	return nil
+}
+
+func (e *logExporter) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// captureLogs redirects the package logger to JSON lines in the returned buffer.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	prev := logger
+	logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	t.Cleanup(func() { logger = prev })
+
+	return &buf
+}
+
+func TestLogExporter(t *testing.T) {
+	logs := captureLogs(t)
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(&logExporter{}))
+	defer provider.Shutdown(t.Context()) //nolint:errcheck
+
+	tracer := provider.Tracer(tracerName)
+
+	ctx, parent := tracer.Start(t.Context(), "search")
+	_, child := tracer.Start(ctx, "pull")
+	child.SetAttributes(AttrCID.String("test-cid"), AttrResults.Int(3))
+	End(child, errors.New("pull failed"))
+	End(parent, nil)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var childEntry, parentEntry map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &childEntry))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &parentEntry))
+
+	assert.Equal(t, "Span finished", childEntry["msg"])
+	assert.Equal(t, "pull", childEntry["name"])
+	assert.Equal(t, "Error", childEntry["status"])
+	assert.Equal(t, "test-cid", childEntry[string(AttrCID)])
+	assert.Equal(t, "3", childEntry[string(AttrResults)])
+	assert.Equal(t, parentEntry["trace_id"], childEntry["trace_id"])
+	assert.Equal(t, parentEntry["span_id"], childEntry["parent_span_id"])
+
+	assert.Equal(t, "search", parentEntry["name"])
+	assert.Equal(t, "Unset", parentEntry["status"])
+	assert.NotContains(t, parentEntry, "parent_span_id")
+}
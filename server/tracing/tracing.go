@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing provides OpenTelemetry tracing for the server.
+//
+// Spans are created through the global tracer provider, which is a no-op
+// until a Service with a configured exporter is created.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/tracing/config"
+	"github.com/agntcy/dir/utils/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+)
+
+var logger = logging.Logger("tracing")
+
+const (
+	tracerName = "github.com/agntcy/dir/server"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// Span attribute keys shared across instrumented operations.
+const (
+	AttrCID       = attribute.Key("dir.cid")
+	AttrPeerID    = attribute.Key("dir.peer_id")
+	AttrNamespace = attribute.Key("dir.namespace")
+	AttrResults   = attribute.Key("dir.results")
+	AttrQueries   = attribute.Key("dir.queries")
+)
+
+// Start starts a span using the global tracer provider.
+// It is a no-op unless tracing is enabled.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...)) //nolint:spancheck
+}
+
+// SetError records err on the span and marks it as failed.
+func SetError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		SetError(span, err)
+	}
+
+	span.End()
+}
+
+// Service installs a tracer provider for the configured exporter.
+type Service struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New creates the tracing service and installs it as the global tracer provider.
+// Incoming W3C trace context is propagated so that client traces link to server-side spans.
+func New(cfg config.Config) (*Service, error) {
+	exporter, err := newExporter(cfg.Exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	logger.Info("Tracing enabled", "exporter", cfg.Exporter, "sample_ratio", cfg.SampleRatio)
+
+	return &Service{provider: provider}, nil
+}
+
+// GetServerOptions returns the gRPC server options that extract the incoming
+// trace context and create a span for each RPC.
+func (s *Service) GetServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(s.provider))),
+	}
+}
+
+// Stop flushes pending spans and restores the no-op tracer provider.
+func (s *Service) Stop() error {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+
+	return nil
+}
+
+func newExporter(name string) (sdktrace.SpanExporter, error) { //nolint:ireturn
+	switch name {
+	case config.ExporterLog:
+		return &logExporter{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %q", name)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/tracing/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	t.Run("unsupported exporter", func(t *testing.T) {
+		_, err := New(config.Config{Exporter: "zipkin", SampleRatio: 1})
+		require.Error(t, err)
+	})
+
+	t.Run("spans are recorded only while enabled", func(t *testing.T) {
+		captureLogs(t)
+
+		_, span := Start(t.Context(), "disabled")
+		assert.False(t, span.IsRecording())
+		span.End()
+
+		service, err := New(config.Config{Exporter: config.ExporterLog, SampleRatio: 1, ServiceName: "dir-test"})
+		require.NoError(t, err)
+
+		_, span = Start(t.Context(), "enabled", AttrCID.String("test-cid"))
+		assert.True(t, span.IsRecording())
+		span.End()
+
+		require.NoError(t, service.Stop())
+
+		// Stopping the service restores the no-op tracer provider
+		_, span = Start(t.Context(), "stopped")
+		assert.False(t, span.IsRecording())
+		span.End()
+	})
+}
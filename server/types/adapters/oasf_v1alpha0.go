@@ -348,6 +348,15 @@ func (m *V1Alpha0ModuleAdapter) GetName() string {
 	return m.extension.GetName()
 }
 
+// GetVersion implements types.Module interface.
+func (m *V1Alpha0ModuleAdapter) GetVersion() string {
+	if m.extension == nil {
+		return ""
+	}
+
+	return m.extension.GetVersion()
+}
+
 // GetData implements types.Module interface.
 func (m *V1Alpha0ModuleAdapter) GetData() map[string]any {
 	if m.extension == nil || m.extension.GetData() == nil {
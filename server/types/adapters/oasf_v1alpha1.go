@@ -395,6 +395,12 @@ func (m *V1Alpha1ModuleAdapter) GetName() string {
 	return m.module.GetName()
 }
 
+// GetVersion implements types.Module interface.
+// Modules are not versioned since OASF v1alpha1.
+func (m *V1Alpha1ModuleAdapter) GetVersion() string {
+	return ""
+}
+
 // GetData implements types.Module interface.
 func (m *V1Alpha1ModuleAdapter) GetData() map[string]any {
 	if m.module == nil || m.module.GetData() == nil {
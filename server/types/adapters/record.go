@@ -15,6 +15,7 @@ var _ types.Record = (*RecordAdapter)(nil)
 // RecordAdapter adapts corev1.Record to types.Record interface.
 type RecordAdapter struct {
 	record *corev1.Record
+	cid    string
 }
 
 // NewRecordAdapter creates a new RecordAdapter.
@@ -22,8 +23,19 @@ func NewRecordAdapter(record *corev1.Record) *RecordAdapter {
 	return &RecordAdapter{record: record}
 }
 
+// NewRecordAdapterWithCid creates a new RecordAdapter for a record stored under the given CID.
+// The CID of a stored record depends on the canonical form of the store,
+// so records with a known CID should be indexed and announced under it.
+func NewRecordAdapterWithCid(record *corev1.Record, cid string) *RecordAdapter {
+	return &RecordAdapter{record: record, cid: cid}
+}
+
 // GetCid implements types.Record interface.
 func (r *RecordAdapter) GetCid() string {
+	if r.cid != "" {
+		return r.cid
+	}
+
 	return r.record.GetCid()
 }
 
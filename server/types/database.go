@@ -4,12 +4,26 @@
 package types
 
 import (
+	"time"
+
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 )
 
+// IndexFunc adds a record to the search index.
+// Callers wrap records with adapters.NewRecordAdapterWithCid(), so that they are indexed under their stored CID.
+type IndexFunc func(record Record) error
+
+// RecordIndexer is implemented by components that store records received from other
+// directories, such as fetched or pulled records, and add them to the search index.
+type RecordIndexer interface {
+	// SetIndexFunc sets the function indexing the received records.
+	SetIndexFunc(fn IndexFunc)
+}
+
 type DatabaseAPI interface {
 	SearchDatabaseAPI
+	SkillCatalog
 	SyncDatabaseAPI
 	PublicationDatabaseAPI
 }
@@ -18,6 +32,10 @@ type SearchDatabaseAPI interface {
 	// AddRecord adds a new record to the search database.
 	AddRecord(record Record) error
 
+	// AddRecords adds many records to the search database in a single transaction.
+	// Records that already exist are skipped.
+	AddRecords(records []Record) error
+
 	// GetRecords retrieves records based on the provided RecordFilters.
 	GetRecords(opts ...FilterOption) ([]Record, error)
 
@@ -25,8 +43,44 @@ type SearchDatabaseAPI interface {
 	// This is more efficient than GetRecords when only CIDs are needed.
 	GetRecordCIDs(opts ...FilterOption) ([]string, error)
 
+	// GetRecordSummaries retrieves the CIDs and schema versions of the records matching the filters,
+	// in the same order as GetRecordCIDs.
+	GetRecordSummaries(opts ...FilterOption) ([]RecordSummary, error)
+
 	// RemoveRecord removes a record from the search database by CID.
 	RemoveRecord(cid string) error
+
+	// SetRecordExpiry sets the time after which a record is considered expired.
+	SetRecordExpiry(cid string, expiresAt time.Time) error
+
+	// Facets returns, per namespace (e.g. "skills"), the number of records
+	// matching the filters for each value in that namespace.
+	Facets(opts ...FilterOption) (map[string]map[string]int, error)
+}
+
+// RecordSummary is the projection of an indexed record returned by searches.
+type RecordSummary struct {
+	CID           string
+	SchemaVersion string
+}
+
+// SkillCatalog resolves skills between their IDs and names based on the indexed records.
+type SkillCatalog interface {
+	// GetSkillNames returns the distinct names used for the skill with the given ID.
+	GetSkillNames(id uint64) ([]string, error)
+
+	// GetSkillIDs returns the distinct IDs used for the skill with the given name (case-insensitive).
+	GetSkillIDs(name string) ([]uint64, error)
+
+	// ListSkills returns all distinct skills with the number of records using them.
+	ListSkills() ([]SkillInfo, error)
+}
+
+// SkillInfo describes a distinct skill of the indexed records.
+type SkillInfo struct {
+	ID          uint64
+	Name        string
+	RecordCount int64
 }
 
 type SyncDatabaseAPI interface {
@@ -8,9 +8,16 @@ package types
 // along with utilities for label extraction and manipulation.
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 )
 
 // LabelType represents the category of a label based on its namespace.
@@ -41,6 +48,7 @@ func (lt LabelType) Prefix() string {
 }
 
 // IsValid checks if the label type is one of the supported types.
+// Supported types are the built-in types and any registered custom types.
 func (lt LabelType) IsValid() bool {
 	switch lt {
 	case LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator:
@@ -48,13 +56,72 @@ func (lt LabelType) IsValid() bool {
 	case LabelTypeUnknown:
 		return false
 	default:
-		return false
+		return IsCustomLabelType(lt)
 	}
 }
 
+// BuiltinLabelTypes returns the label types supported out of the box.
+func BuiltinLabelTypes() []LabelType {
+	return []LabelType{LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator}
+}
+
 // AllLabelTypes returns all supported label types.
+// Built-in types come first, followed by custom types in registration order.
 func AllLabelTypes() []LabelType {
-	return []LabelType{LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator}
+	customLabelTypesMu.RLock()
+	defer customLabelTypesMu.RUnlock()
+
+	return append(BuiltinLabelTypes(), customLabelTypes...)
+}
+
+// Registry of custom label types defined by the deployment.
+var (
+	customLabelTypesMu sync.RWMutex
+	customLabelTypes   []LabelType
+)
+
+// RegisterLabelType registers an additional label namespace, e.g. "features" for "/features/".
+// It should be called at startup before the routing subsystem is created.
+// Registering an already registered custom type is a no-op.
+func RegisterLabelType(name string) (LabelType, error) {
+	name = strings.Trim(name, "/")
+	if name == "" {
+		return LabelTypeUnknown, errors.New("label type name cannot be empty")
+	}
+
+	if strings.ContainsAny(name, "/ ") {
+		return LabelTypeUnknown, fmt.Errorf("invalid label type name %q: must not contain slashes or spaces", name)
+	}
+
+	lt := LabelType(name)
+	if slices.Contains(BuiltinLabelTypes(), lt) {
+		return LabelTypeUnknown, fmt.Errorf("label type %q is built-in and cannot be registered", name)
+	}
+
+	customLabelTypesMu.Lock()
+	defer customLabelTypesMu.Unlock()
+
+	if !slices.Contains(customLabelTypes, lt) {
+		customLabelTypes = append(customLabelTypes, lt)
+	}
+
+	return lt, nil
+}
+
+// CustomLabelTypes returns the registered custom label types.
+func CustomLabelTypes() []LabelType {
+	customLabelTypesMu.RLock()
+	defer customLabelTypesMu.RUnlock()
+
+	return slices.Clone(customLabelTypes)
+}
+
+// IsCustomLabelType checks if the label type has been registered as a custom type.
+func IsCustomLabelType(lt LabelType) bool {
+	customLabelTypesMu.RLock()
+	defer customLabelTypesMu.RUnlock()
+
+	return slices.Contains(customLabelTypes, lt)
 }
 
 // ParseLabelType converts a string to LabelType if valid.
@@ -88,18 +155,13 @@ func (l Label) Bytes() []byte {
 func (l Label) Type() LabelType {
 	s := string(l)
 
-	switch {
-	case strings.HasPrefix(s, LabelTypeSkill.Prefix()):
-		return LabelTypeSkill
-	case strings.HasPrefix(s, LabelTypeDomain.Prefix()):
-		return LabelTypeDomain
-	case strings.HasPrefix(s, LabelTypeModule.Prefix()):
-		return LabelTypeModule
-	case strings.HasPrefix(s, LabelTypeLocator.Prefix()):
-		return LabelTypeLocator
-	default:
-		return LabelTypeUnknown
+	for _, lt := range AllLabelTypes() {
+		if strings.HasPrefix(s, lt.Prefix()) {
+			return lt
+		}
 	}
+
+	return LabelTypeUnknown
 }
 
 // Namespace returns the namespace prefix of the label.
@@ -119,12 +181,99 @@ func (l Label) Value() string {
 	return strings.TrimPrefix(string(l), namespace)
 }
 
+// LabelNormalization configures how label values are normalized
+// before they are used in keys and compared with queries.
+// The zero value leaves labels unchanged.
+type LabelNormalization struct {
+	// Apply Unicode NFC normalization, so that composed and decomposed
+	// forms of the same characters are the same label
+	Unicode bool
+
+	// Fold case, so that "/skills/AI" and "/skills/ai" are the same label
+	CaseFold bool
+}
+
+// Value applies the normalization to a label or query value.
+func (n LabelNormalization) Value(value string) string {
+	if n.CaseFold {
+		value = cases.Fold().String(value)
+	}
+
+	// Normalize after folding, which may decompose characters
+	if n.Unicode {
+		value = norm.NFC.String(value)
+	}
+
+	return value
+}
+
+// Label returns the label with the normalization applied to its value.
+// The namespace prefix is kept as is, so that the label type is preserved.
+func (n LabelNormalization) Label(l Label) Label {
+	namespace := l.Namespace()
+
+	return Label(namespace + n.Value(strings.TrimPrefix(string(l), namespace)))
+}
+
+// LabelMetadataVersion is the current format version of LabelMetadata.
+// Bump it when the format changes and add the upgrade of older entries to LabelMetadata.migrate.
+const LabelMetadataVersion = 1
+
 // LabelMetadata stores temporal information about a label announcement.
 // The label itself is stored in the datastore key structure: /skills/AI/CID123/Peer1
 // where the metadata tracks when the label was first announced and last seen.
 type LabelMetadata struct {
-	Timestamp time.Time `json:"timestamp"` // When label was first announced
-	LastSeen  time.Time `json:"last_seen"` // When label was last seen/refreshed
+	Version   int       `json:"version,omitempty"` // Format version, missing in legacy entries
+	Timestamp time.Time `json:"timestamp"`         // When label was first announced
+	LastSeen  time.Time `json:"last_seen"`         // When label was last seen/refreshed
+}
+
+// UnmarshalLabelMetadata decodes label metadata stored in the datastore.
+// Entries written in an older format are upgraded to the current one, defaulting missing fields.
+// It reports whether the entry was upgraded, so that callers can write it back.
+func UnmarshalLabelMetadata(data []byte) (*LabelMetadata, bool, error) {
+	var metadata LabelMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal label metadata: %w", err)
+	}
+
+	upgraded := metadata.migrate()
+
+	return &metadata, upgraded, nil
+}
+
+// Marshal encodes the label metadata in the current format for storing in the datastore.
+func (m *LabelMetadata) Marshal() ([]byte, error) {
+	m.Version = LabelMetadataVersion
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal label metadata: %w", err)
+	}
+
+	return data, nil
+}
+
+// migrate upgrades metadata of older formats to the current one.
+// It reports whether anything was upgraded.
+func (m *LabelMetadata) migrate() bool {
+	if m.Version >= LabelMetadataVersion {
+		return false
+	}
+
+	// Version 0 (versionless) entries may lack either timestamp
+	if m.Version < 1 {
+		switch {
+		case m.Timestamp.IsZero() && !m.LastSeen.IsZero():
+			m.Timestamp = m.LastSeen
+		case m.LastSeen.IsZero() && !m.Timestamp.IsZero():
+			m.LastSeen = m.Timestamp
+		}
+	}
+
+	m.Version = LabelMetadataVersion
+
+	return true
 }
 
 // Validate checks if the metadata is valid and all required fields are properly set.
@@ -196,3 +345,58 @@ func GetLabelsFromRecord(record Record) []Label {
 
 	return nil
 }
+
+// LimitLabels returns at most limit labels, dropping duplicates.
+// Labels are taken from each label type in turn, in the order the types first appear,
+// so a record with a huge number of labels of one type keeps labels of the other types.
+// Within a type, earlier labels are preferred. The result keeps the input order.
+// A non-positive limit keeps all unique labels.
+func LimitLabels(labels []Label, limit int) []Label {
+	seen := make(map[Label]bool, len(labels))
+
+	var (
+		typeOrder []LabelType
+		byType    = make(map[LabelType][]int)
+	)
+
+	for i, label := range labels {
+		if seen[label] {
+			continue
+		}
+
+		seen[label] = true
+
+		labelType := label.Type()
+		if _, ok := byType[labelType]; !ok {
+			typeOrder = append(typeOrder, labelType)
+		}
+
+		byType[labelType] = append(byType[labelType], i)
+	}
+
+	unique := len(seen)
+	if limit <= 0 || limit > unique {
+		limit = unique
+	}
+
+	// Select labels round-robin across types
+	selected := make(map[int]bool, limit)
+
+	for round := 0; len(selected) < limit; round++ {
+		for _, labelType := range typeOrder {
+			if indexes := byType[labelType]; round < len(indexes) && len(selected) < limit {
+				selected[indexes[round]] = true
+			}
+		}
+	}
+
+	result := make([]Label, 0, limit)
+
+	for i, label := range labels {
+		if selected[i] {
+			result = append(result, label)
+		}
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ package types_test
 
 import (
 	"testing"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
@@ -151,3 +152,165 @@ func TestGetLabelsFromRecord(t *testing.T) {
 		assert.Nil(t, labels)
 	})
 }
+
+func TestRegisterLabelType(t *testing.T) {
+	t.Run("registers_custom_namespace", func(t *testing.T) {
+		lt, err := types.RegisterLabelType("features")
+		require.NoError(t, err)
+
+		assert.Equal(t, types.LabelType("features"), lt)
+		assert.Equal(t, "/features/", lt.Prefix())
+		assert.True(t, lt.IsValid())
+		assert.True(t, types.IsCustomLabelType(lt))
+		assert.Contains(t, types.AllLabelTypes(), lt)
+		assert.Contains(t, types.CustomLabelTypes(), lt)
+
+		parsed, ok := types.ParseLabelType("features")
+		assert.True(t, ok)
+		assert.Equal(t, lt, parsed)
+
+		label := types.Label("/features/streaming/audio")
+		assert.Equal(t, lt, label.Type())
+		assert.Equal(t, "/features/", label.Namespace())
+		assert.Equal(t, "streaming/audio", label.Value())
+	})
+
+	t.Run("registration_is_idempotent", func(t *testing.T) {
+		_, err := types.RegisterLabelType("/capabilities/")
+		require.NoError(t, err)
+
+		_, err = types.RegisterLabelType("capabilities")
+		require.NoError(t, err)
+
+		count := 0
+
+		for _, lt := range types.CustomLabelTypes() {
+			if lt == "capabilities" {
+				count++
+			}
+		}
+
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("rejects_invalid_names", func(t *testing.T) {
+		for _, name := range []string{"", "/", "skills", "locators", "a/b", "with space"} {
+			_, err := types.RegisterLabelType(name)
+			assert.Error(t, err, "name %q should be rejected", name)
+		}
+	})
+
+	t.Run("builtin_types_unchanged", func(t *testing.T) {
+		assert.Len(t, types.BuiltinLabelTypes(), 4)
+		assert.False(t, types.IsCustomLabelType(types.LabelTypeSkill))
+	})
+}
+
+func TestLabelNormalization(t *testing.T) {
+	// "é" composed (U+00E9) and decomposed (e + U+0301)
+	composed := types.Label("/skills/Caf\u00e9")
+	decomposed := types.Label("/skills/Cafe\u0301")
+
+	t.Run("disabled_by_default", func(t *testing.T) {
+		var normalization types.LabelNormalization
+
+		assert.Equal(t, composed, normalization.Label(composed))
+		assert.Equal(t, decomposed, normalization.Label(decomposed))
+		assert.Equal(t, "AI/ML", normalization.Value("AI/ML"))
+	})
+
+	t.Run("unicode", func(t *testing.T) {
+		normalization := types.LabelNormalization{Unicode: true}
+
+		assert.Equal(t, composed, normalization.Label(decomposed))
+		assert.Equal(t, "AI/ML", normalization.Value("AI/ML"))
+	})
+
+	t.Run("case_fold", func(t *testing.T) {
+		normalization := types.LabelNormalization{Unicode: true, CaseFold: true}
+
+		assert.Equal(t, types.Label("/skills/ai/ml"), normalization.Label(types.Label("/skills/AI/ML")))
+		assert.Equal(t, types.Label("/skills/caf\u00e9"), normalization.Label(decomposed))
+		assert.Equal(t, types.LabelTypeSkill, normalization.Label(types.Label("/skills/AI")).Type())
+	})
+}
+
+func TestUnmarshalLabelMetadata(t *testing.T) {
+	t.Run("legacy entry is upgraded", func(t *testing.T) {
+		// Versionless metadata as written by earlier releases
+		legacy := []byte(`{"timestamp":"2024-01-01T00:00:00Z","last_seen":"2024-01-02T00:00:00Z"}`)
+
+		metadata, upgraded, err := types.UnmarshalLabelMetadata(legacy)
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+		assert.Equal(t, types.LabelMetadataVersion, metadata.Version)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), metadata.Timestamp.UTC())
+		assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), metadata.LastSeen.UTC())
+		require.NoError(t, metadata.Validate())
+	})
+
+	t.Run("legacy entry missing fields gets defaults", func(t *testing.T) {
+		legacy := []byte(`{"timestamp":"2024-01-01T00:00:00Z"}`)
+
+		metadata, upgraded, err := types.UnmarshalLabelMetadata(legacy)
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+		assert.Equal(t, metadata.Timestamp, metadata.LastSeen)
+		require.NoError(t, metadata.Validate())
+	})
+
+	t.Run("current entry is not upgraded", func(t *testing.T) {
+		now := time.Now()
+
+		data, err := (&types.LabelMetadata{Timestamp: now, LastSeen: now}).Marshal()
+		require.NoError(t, err)
+
+		metadata, upgraded, err := types.UnmarshalLabelMetadata(data)
+		require.NoError(t, err)
+		assert.False(t, upgraded)
+		assert.Equal(t, types.LabelMetadataVersion, metadata.Version)
+		assert.True(t, now.Equal(metadata.LastSeen))
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		_, _, err := types.UnmarshalLabelMetadata([]byte("not json"))
+		require.Error(t, err)
+	})
+}
+
+func TestLimitLabels(t *testing.T) {
+	labels := []types.Label{
+		"/skills/A",
+		"/skills/B",
+		"/skills/C",
+		"/skills/A",
+		"/domains/X",
+		"/locators/docker-image",
+	}
+
+	t.Run("under limit keeps unique labels", func(t *testing.T) {
+		assert.Equal(t, []types.Label{
+			"/skills/A", "/skills/B", "/skills/C", "/domains/X", "/locators/docker-image",
+		}, types.LimitLabels(labels, 10))
+	})
+
+	t.Run("non-positive limit keeps unique labels", func(t *testing.T) {
+		assert.Len(t, types.LimitLabels(labels, 0), 5)
+	})
+
+	t.Run("over limit keeps every type", func(t *testing.T) {
+		assert.Equal(t, []types.Label{
+			"/skills/A", "/domains/X", "/locators/docker-image",
+		}, types.LimitLabels(labels, 3))
+	})
+
+	t.Run("over limit prefers earlier labels of a type", func(t *testing.T) {
+		assert.Equal(t, []types.Label{
+			"/skills/A", "/skills/B", "/domains/X", "/locators/docker-image",
+		}, types.LimitLabels(labels, 4))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, types.LimitLabels(nil, 3))
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "context"
+
+// RateLimiterAPI reports the per-client rate limits of the API.
+type RateLimiterAPI interface {
+	// RateLimitStats returns the rate limits and their utilization,
+	// including the requests available to the client of the given request context.
+	RateLimitStats(ctx context.Context) *RateLimitStats
+}
+
+// RateLimitStats describes the per-client rate limits of the API and their utilization.
+type RateLimitStats struct {
+	// Sustained requests per second and burst allowed per client
+	ReadRequestsPerSecond  float64
+	ReadBurst              int
+	WriteRequestsPerSecond float64
+	WriteBurst             int
+
+	// Number of clients currently tracked
+	ActiveClients int
+
+	// Requests rejected since the server started
+	RejectedReadRequests  uint64
+	RejectedWriteRequests uint64
+
+	// Requests currently available to the calling client
+	CallerReadTokens  float64
+	CallerWriteTokens float64
+}
@@ -50,6 +50,7 @@ type Signature interface {
 
 type Module interface {
 	GetName() string
+	GetVersion() string
 	GetData() map[string]any
 }
 
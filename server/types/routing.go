@@ -6,6 +6,7 @@ package types
 import (
 	"context"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -24,6 +25,18 @@ type RoutingAPI interface {
 	// Search for records across the network using cached remote announcements
 	Search(context.Context, *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error)
 
+	// ListPeers returns the peers known to the routing layer (local-only operation)
+	ListPeers(context.Context, *routingv1.ListPeersRequest) (*routingv1.ListPeersResponse, error)
+
+	// ListProvided returns the records this peer has announced to the network (local-only operation)
+	ListProvided(context.Context, *routingv1.ListProvidedRequest) (*routingv1.ListProvidedResponse, error)
+
+	// PullFromPeer pulls a record directly from the peer at the given multiaddr, bypassing DHT discovery
+	PullFromPeer(context.Context, *routingv1.PullFromPeerRequest) (*corev1.Record, error)
+
+	// PullFromNetwork pulls a record from a provider discovered via DHT and caches it in the local store
+	PullFromNetwork(context.Context, *routingv1.PullFromNetworkRequest) (*corev1.Record, error)
+
 	// Unpublish record from the network
 	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
 	Unpublish(context.Context, Record) error
@@ -33,6 +46,29 @@ type RoutingAPI interface {
 	Stop() error
 }
 
+// unpublishPublishedOnlyKey is the context key of conditional unpublishing.
+type unpublishPublishedOnlyKey struct{}
+
+// WithUnpublishPublishedOnly returns a context whose unpublish calls skip records that are not published locally.
+// It is used when records are unpublished on expiry, as most expiring records were never published.
+func WithUnpublishPublishedOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unpublishPublishedOnlyKey{}, true)
+}
+
+// UnpublishPublishedOnlyFromContext reports whether unpublish calls with the context skip unpublished records.
+func UnpublishPublishedOnlyFromContext(ctx context.Context) bool {
+	publishedOnly, _ := ctx.Value(unpublishPublishedOnlyKey{}).(bool)
+
+	return publishedOnly
+}
+
+// BootstrapPeersAPI updates the bootstrap peers of running routing services.
+type BootstrapPeersAPI interface {
+	// UpdateBootstrapPeers replaces the bootstrap peers with the given multiaddrs.
+	// Newly added peers are connected to without disrupting existing connections.
+	UpdateBootstrapPeers(ctx context.Context, addrs []string) error
+}
+
 // PublicationAPI handles management of publication tasks.
 type PublicationAPI interface {
 	// CreatePublication creates a new publication task to be processed.
@@ -3,16 +3,61 @@
 
 package types
 
+import (
+	"time"
+
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+)
+
+// MaxFuzzyDistance is the maximum edit distance accepted by WithNameFuzzy.
+// Larger distances match almost any short name and make results meaningless.
+const MaxFuzzyDistance = 3
+
 type RecordFilters struct {
-	Limit        int
-	Offset       int
-	Name         string
-	Version      string
-	SkillIDs     []uint64
-	SkillNames   []string
-	LocatorTypes []string
-	LocatorURLs  []string
-	ModuleNames  []string
+	Limit                  int
+	Offset                 int
+	Name                   string
+	NameFuzzy              *FuzzyFilter
+	Version                string
+	SkillIDs               []uint64
+	SkillNames             []string
+	LocatorTypes           []string
+	LocatorURLs            []string
+	LocatorDigests         []string
+	ModuleNames            []string
+	ModuleData             []ModuleDataFilter
+	ExtensionVersionRanges []ExtensionVersionRangeFilter
+	Annotations            []AnnotationFilter
+	QueryExpression        *searchv1.QueryExpression
+	ExcludeExpiredAt       *time.Time
+	ExpiredAt              *time.Time
+	SortBy                 string
+	SortDesc               bool
+	Explain                bool
+}
+
+// FuzzyFilter matches values within an edit distance of a term.
+type FuzzyFilter struct {
+	Term        string
+	MaxDistance int
+}
+
+// ModuleDataFilter matches records with a module whose data has a value at a JSON path.
+type ModuleDataFilter struct {
+	Path  string
+	Value string
+}
+
+// ExtensionVersionRangeFilter matches records with an extension whose version satisfies a semver constraint.
+type ExtensionVersionRangeFilter struct {
+	Name       string
+	Constraint string
+}
+
+// AnnotationFilter matches records with an annotation whose value matches a pattern.
+type AnnotationFilter struct {
+	Key   string
+	Value string
 }
 
 type FilterOption func(*RecordFilters)
@@ -38,6 +83,23 @@ func WithName(name string) FilterOption {
 	}
 }
 
+// WithNameFuzzy RecordFilters records by name with typo tolerance.
+// A record matches if the Levenshtein distance between its name and the term is at most
+// maxDistance (case-insensitive). maxDistance is capped at MaxFuzzyDistance.
+// Matching records are ordered by ascending distance, overriding WithSortBy.
+//
+// The distance is computed in Go for every record that passes the other filters and
+// whose name length is within maxDistance of the term, so the cost grows linearly
+// with the number of such candidates. Combine with other filters on large databases.
+func WithNameFuzzy(term string, maxDistance int) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.NameFuzzy = &FuzzyFilter{
+			Term:        term,
+			MaxDistance: max(0, min(maxDistance, MaxFuzzyDistance)),
+		}
+	}
+}
+
 // WithVersion RecordFilters records by exact version.
 func WithVersion(version string) FilterOption {
 	return func(sc *RecordFilters) {
@@ -73,9 +135,91 @@ func WithLocatorURLs(urls ...string) FilterOption {
 	}
 }
 
+// WithLocatorDigest RecordFilters records by the digest of a locator artifact (exact match).
+// Can be used to find all records referencing the same artifact, e.g. "sha256:...".
+// Can be used multiple times, in which case any of the digests may match.
+func WithLocatorDigest(digests ...string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.LocatorDigests = append(sc.LocatorDigests, digests...)
+	}
+}
+
 // WithModuleNames RecordFilters records by module names.
 func WithModuleNames(names ...string) FilterOption {
 	return func(sc *RecordFilters) {
 		sc.ModuleNames = names
 	}
 }
+
+// WithModuleDataPath RecordFilters records by a value in module data.
+// The path addresses a nested field of the module data, e.g. "framework.version" or "$.framework.version".
+// Can be used multiple times, in which case all filters must match.
+func WithModuleDataPath(path, value string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.ModuleData = append(sc.ModuleData, ModuleDataFilter{Path: path, Value: value})
+	}
+}
+
+// WithExtensionVersionRange RecordFilters records by the version of an extension (module).
+// The constraint is a semver range, e.g. ">=1.0.0 <2.0.0" or "<1.0.0 || >=2.0.0".
+// The name supports wildcards, and the record matches if any extension with that name
+// has a version within the range. Extensions without a valid version never match.
+// Can be used multiple times, in which case all filters must match.
+//
+// Versions are compared in Go for every extension with a matching name,
+// so the cost grows linearly with the number of such extensions.
+func WithExtensionVersionRange(name, constraint string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.ExtensionVersionRanges = append(sc.ExtensionVersionRanges, ExtensionVersionRangeFilter{Name: name, Constraint: constraint})
+	}
+}
+
+// WithAnnotation RecordFilters records by an annotation value (partial match).
+// The key must match exactly, the value pattern supports wildcards.
+// Can be used multiple times, in which case all filters must match.
+func WithAnnotation(key, valuePattern string) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.Annotations = append(sc.Annotations, AnnotationFilter{Key: key, Value: valuePattern})
+	}
+}
+
+// WithQueryExpression RecordFilters records by a boolean combination of record queries.
+// The expression is combined with the other filters by AND.
+func WithQueryExpression(expr *searchv1.QueryExpression) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.QueryExpression = expr
+	}
+}
+
+// WithExcludeExpired RecordFilters out records whose expiry is at or before now.
+// Records without an expiry are kept.
+func WithExcludeExpired(now time.Time) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.ExcludeExpiredAt = &now
+	}
+}
+
+// WithExpired RecordFilters records whose expiry is at or before now.
+// Records without an expiry never match.
+func WithExpired(now time.Time) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.ExpiredAt = &now
+	}
+}
+
+// WithSortBy orders records by the given field.
+// Supported fields are "name", "version", "created_at" and "skill_count".
+func WithSortBy(field string, desc bool) FilterOption {
+	return func(sc *RecordFilters) {
+		sc.SortBy = field
+		sc.SortDesc = desc
+	}
+}
+
+// WithExplain logs the generated SQL, its arguments and the database query plan.
+// This is a debugging aid to check index usage of complex filter combinations.
+func WithExplain() FilterOption {
+	return func(sc *RecordFilters) {
+		sc.Explain = true
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNameFuzzy_CapsDistance(t *testing.T) {
+	cfg := &types.RecordFilters{}
+
+	types.WithNameFuzzy("agent", 100)(cfg)
+	assert.Equal(t, types.MaxFuzzyDistance, cfg.NameFuzzy.MaxDistance)
+
+	types.WithNameFuzzy("agent", -1)(cfg)
+	assert.Equal(t, 0, cfg.NameFuzzy.MaxDistance)
+
+	types.WithNameFuzzy("agent", 2)(cfg)
+	assert.Equal(t, "agent", cfg.NameFuzzy.Term)
+	assert.Equal(t, 2, cfg.NameFuzzy.MaxDistance)
+}
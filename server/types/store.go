@@ -5,8 +5,12 @@ package types
 
 import (
 	"context"
+	"io"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // StoreAPI handles management of content-addressable object storage.
@@ -20,6 +24,11 @@ type StoreAPI interface {
 	// Lookup metadata about the record from reference
 	Lookup(context.Context, *corev1.RecordRef) (*corev1.RecordMeta, error)
 
+	// LookupMany looks up metadata about multiple records.
+	// The result has one entry per reference, in order, with nil entries for missing records.
+	// It is used by server components only and is not exposed over the Store API.
+	LookupMany(context.Context, []*corev1.RecordRef) ([]*corev1.RecordMeta, error)
+
 	// Delete the record
 	Delete(context.Context, *corev1.RecordRef) error
 
@@ -28,6 +37,60 @@ type StoreAPI interface {
 	// List(context.Context, func(*corev1.RecordRef) error) error
 }
 
+// pushAnnotationsKey is the context key of the annotations attached to records on push.
+type pushAnnotationsKey struct{}
+
+// WithPushAnnotations returns a context attaching the given annotations to the records pushed with it.
+// Stores that support it keep the annotations alongside the record and report them in its metadata.
+// The annotations are not part of the record, so they do not affect its CID.
+func WithPushAnnotations(ctx context.Context, annotations map[string]string) context.Context {
+	if len(annotations) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, pushAnnotationsKey{}, annotations)
+}
+
+// PushAnnotationsFromContext returns the annotations attached to the records pushed with the context, if any.
+func PushAnnotationsFromContext(ctx context.Context) map[string]string {
+	annotations, _ := ctx.Value(pushAnnotationsKey{}).(map[string]string)
+
+	return annotations
+}
+
+// pushExpiryKey is the context key of the expiry time of records on push.
+type pushExpiryKey struct{}
+
+// WithPushExpiry returns a context setting the expiry time of the records pushed with it.
+// Stores that support it keep the expiry alongside the record and report it in its metadata.
+// Like push annotations, the expiry is not part of the record, so it does not affect its CID.
+func WithPushExpiry(ctx context.Context, expiresAt time.Time) context.Context {
+	return context.WithValue(ctx, pushExpiryKey{}, expiresAt)
+}
+
+// PushExpiryFromContext returns the expiry time of the records pushed with the context, if any.
+func PushExpiryFromContext(ctx context.Context) (time.Time, bool) {
+	expiresAt, ok := ctx.Value(pushExpiryKey{}).(time.Time)
+
+	return expiresAt, ok
+}
+
+// cacheBypassKey is the context key of the cache bypass of record reads.
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context whose record reads skip the store cache and read from the backing store.
+// Caching stores still cache the records read with it.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// CacheBypassFromContext reports whether record reads with the context skip the store cache.
+func CacheBypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+
+	return bypass
+}
+
 // ReferrerStoreAPI handles management of generic record referrers.
 type ReferrerStoreAPI interface {
 	// Push referrer to content store
@@ -36,3 +99,126 @@ type ReferrerStoreAPI interface {
 	// Walk referrers individually for a given record CID and optional type filter
 	WalkReferrers(ctx context.Context, recordCID string, referrerType string, walkFn func(*corev1.RecordReferrer) error) error
 }
+
+// GarbageCollectorAPI removes blobs that are no longer referenced from storage.
+type GarbageCollectorAPI interface {
+	// GarbageCollect deletes unreferenced blobs.
+	// If dryRun is true, blobs are reported but not deleted.
+	GarbageCollect(ctx context.Context, dryRun bool) (*GarbageCollectResult, error)
+}
+
+// GarbageCollectResult describes the blobs removed by a garbage collection run.
+type GarbageCollectResult struct {
+	// Digests of the deleted blobs
+	DeletedBlobs []string
+
+	// Total size of the deleted blobs in bytes
+	ReclaimedBytes int64
+}
+
+// RecordListerAPI enumerates the records held in storage.
+type RecordListerAPI interface {
+	// ListRecordCIDs returns the CIDs of all records in storage.
+	ListRecordCIDs(ctx context.Context) ([]string, error)
+}
+
+// PrefixResolverAPI resolves abbreviated record CIDs.
+type PrefixResolverAPI interface {
+	// ResolvePrefix returns the full CID of the only record whose CID starts with prefix.
+	// It fails if no record or several records match.
+	ResolvePrefix(ctx context.Context, prefix string) (string, error)
+}
+
+// RecordExistsAPI checks the existence of records without fetching their metadata.
+type RecordExistsAPI interface {
+	// Exists reports whether the record is held in storage.
+	// A missing record is not an error.
+	Exists(ctx context.Context, ref *corev1.RecordRef) (bool, error)
+}
+
+// RecordExists reports whether the record is held in the store.
+// Stores not implementing RecordExistsAPI are checked with a lookup.
+func RecordExists(ctx context.Context, store StoreAPI, ref *corev1.RecordRef) (bool, error) {
+	if checker, ok := store.(RecordExistsAPI); ok {
+		return checker.Exists(ctx, ref)
+	}
+
+	if _, err := store.Lookup(ctx, ref); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LayerStoreAPI gives access to all layers of a record manifest,
+// including auxiliary artifacts such as model files stored alongside the record.
+type LayerStoreAPI interface {
+	// PullLayers returns the descriptors of all layers of the record manifest, in manifest order.
+	PullLayers(ctx context.Context, ref *corev1.RecordRef) ([]LayerInfo, error)
+
+	// PullLayer returns the content of the record manifest layer with the given digest, as stored.
+	// The caller must close the returned reader.
+	PullLayer(ctx context.Context, ref *corev1.RecordRef, digest string) (io.ReadCloser, error)
+}
+
+// RecordBlobAPI gives access to the bytes a record is stored as,
+// for checks that must not depend on how the record is re-marshaled.
+type RecordBlobAPI interface {
+	// PullRecordBlob returns the bytes of the record blob as stored, decompressed if stored compressed.
+	PullRecordBlob(ctx context.Context, ref *corev1.RecordRef) ([]byte, error)
+}
+
+// LayerInfo describes a layer of a record manifest.
+type LayerInfo struct {
+	// Digest of the layer content
+	Digest string
+
+	// Media type of the layer, application/json for the record itself
+	MediaType string
+
+	// Size of the layer content in bytes
+	Size int64
+
+	// Annotations of the layer descriptor
+	Annotations map[string]string
+}
+
+// StoreStatsAPI reports statistics and limits of the storage.
+type StoreStatsAPI interface {
+	// Stats returns the statistics and limits of the storage.
+	// Storage usage is only computed if includeUsage is true, as it may require walking the whole storage.
+	Stats(ctx context.Context, includeUsage bool) (*StoreStats, error)
+}
+
+// StoreStats describes the statistics and limits of a storage.
+type StoreStats struct {
+	// Maximum size of a marshaled record accepted on push, in bytes.
+	// Zero means unlimited.
+	MaxRecordBytes int
+
+	// Storage usage, nil if not requested
+	Usage *StoreUsage
+}
+
+// StoreUsage describes how much a storage holds.
+type StoreUsage struct {
+	// Total size of all blobs in bytes
+	TotalBlobBytes int64
+
+	// Number of manifests, including referrer manifests such as signatures
+	ManifestCount int
+
+	// Number of records
+	RecordCount int
+
+	// Number of records with at least one signature
+	SignedRecordCount int
+
+	// Whether the registry supports the OCI referrers API.
+	// Nil if not applicable, e.g. for local storage.
+	ReferrersAPISupported *bool
+}
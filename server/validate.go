@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/config"
+	"github.com/agntcy/dir/server/database"
+	"github.com/agntcy/dir/server/routing"
+	"github.com/agntcy/dir/server/store"
+	tracingconfig "github.com/agntcy/dir/server/tracing/config"
+	"github.com/agntcy/dir/utils/cosign"
+)
+
+// ValidateConfig checks the server configuration without creating any services.
+// All problems are reported, each prefixed with the name of the offending setting.
+func ValidateConfig(cfg *config.Config) error {
+	var errs []error
+
+	errs = append(errs, validateAddress("listen_address", cfg.ListenAddress)...)
+	errs = append(errs, validateAddress("healthcheck_address", cfg.HealthCheckAddress)...)
+	errs = append(errs, validateSignatureAlgorithms("allowed_signature_algorithms", cfg.AllowedSignatureAlgorithms)...)
+
+	errs = append(errs, prefixErrors("authn", cfg.Authn.Validate())...)
+	errs = append(errs, prefixErrors("authz", cfg.Authz.Validate())...)
+	errs = append(errs, prefixErrors("rate_limit", cfg.RateLimit.Validate())...)
+	errs = append(errs, prefixErrors("store", store.ValidateConfig(cfg.Store))...)
+	errs = append(errs, prefixErrors("routing", routing.ValidateConfig(cfg.Routing))...)
+
+	if db := database.DB(cfg.Database.DBType); db != database.SQLite {
+		errs = append(errs, fmt.Errorf("database.db_type: unsupported database %q, expected %s", db, database.SQLite))
+	}
+
+	errs = append(errs, validateWorkers("sync", cfg.Sync.SchedulerInterval, cfg.Sync.WorkerCount, cfg.Sync.WorkerTimeout)...)
+	errs = append(errs, validateWorkers("publication", cfg.Publication.SchedulerInterval, cfg.Publication.WorkerCount, cfg.Publication.WorkerTimeout)...)
+
+	if interval := cfg.Expiry.SweepInterval; interval < 0 {
+		errs = append(errs, fmt.Errorf("expiry.sweep_interval: must not be negative (%s)", interval))
+	}
+
+	if cfg.Metrics.Enabled {
+		errs = append(errs, validateAddress("metrics.listen_address", cfg.Metrics.ListenAddress)...)
+	}
+
+	switch cfg.Tracing.Exporter {
+	case tracingconfig.ExporterNone, tracingconfig.ExporterLog:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unsupported exporter %q, expected %q or none", cfg.Tracing.Exporter, tracingconfig.ExporterLog))
+	}
+
+	if ratio := cfg.Tracing.SampleRatio; ratio < 0 || ratio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio: must be between 0 and 1 (%v)", ratio))
+	}
+
+	errs = append(errs, prefixErrors("logging", cfg.Logging.Validate())...)
+
+	return errors.Join(errs...)
+}
+
+// validateAddress checks that a listen address is in host:port form.
+func validateAddress(key, address string) []error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return []error{fmt.Errorf("%s: invalid address %q: %w", key, address, err)}
+	}
+
+	return nil
+}
+
+// validateSignatureAlgorithms checks that each allowed signature algorithm is known.
+func validateSignatureAlgorithms(key string, algorithms []string) []error {
+	var errs []error
+
+	for i, algorithm := range algorithms {
+		if !slices.ContainsFunc(cosign.SignatureAlgorithms, func(known string) bool { return strings.EqualFold(known, algorithm) }) {
+			errs = append(errs, fmt.Errorf("%s[%d]: unknown signature algorithm %q, expected one of: %s",
+				key, i, algorithm, strings.Join(cosign.SignatureAlgorithms, ", ")))
+		}
+	}
+
+	return errs
+}
+
+// validateWorkers checks the settings of a scheduler and its worker pool.
+func validateWorkers(key string, schedulerInterval time.Duration, workerCount int, workerTimeout time.Duration) []error {
+	var errs []error
+
+	if schedulerInterval <= 0 {
+		errs = append(errs, fmt.Errorf("%s.scheduler_interval: must be positive (%s)", key, schedulerInterval))
+	}
+
+	if workerCount <= 0 {
+		errs = append(errs, fmt.Errorf("%s.worker_count: must be positive (%d)", key, workerCount))
+	}
+
+	if workerTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("%s.worker_timeout: must be positive (%s)", key, workerTimeout))
+	}
+
+	return errs
+}
+
+// prefixErrors prefixes each of the possibly joined errors with the name of a config section.
+func prefixErrors(prefix string, err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if !ok {
+		return []error{fmt.Errorf("%s: %w", prefix, err)}
+	}
+
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, prefixErrors(prefix, err)...)
+	}
+
+	return errs
+}
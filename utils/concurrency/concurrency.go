@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package concurrency provides helpers for running bounded concurrent work.
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach calls fn for every index in [0, n) with at most limit calls running at once.
+// A limit below one runs the calls sequentially.
+// If ctx is done before all calls are started, ForEach waits for the started calls
+// to finish and returns the context error. Callers collect results and errors by index.
+func ForEach(ctx context.Context, n, limit int, fn func(i int)) error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+
+			return ctx.Err() //nolint:wrapcheck
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(i)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	t.Run("calls fn for every index", func(t *testing.T) {
+		results := make([]int, 10)
+
+		err := ForEach(t.Context(), len(results), 3, func(i int) {
+			results[i] = i * i
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}; !slices.Equal(results, expected) {
+			t.Errorf("expected %v, got %v", expected, results)
+		}
+	})
+
+	t.Run("bounds the running calls", func(t *testing.T) {
+		const (
+			calls = 8
+			limit = 2
+		)
+
+		var started, running atomic.Int32
+
+		release := make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- ForEach(t.Context(), calls, limit, func(int) {
+				started.Add(1)
+				running.Add(1)
+				defer running.Add(-1)
+
+				<-release
+			})
+		}()
+
+		// Hold the calls until the limit is reached
+		deadline := time.Now().Add(5 * time.Second)
+		for running.Load() < limit && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if n := running.Load(); n != limit {
+			t.Errorf("expected %d running calls, got %d", limit, n)
+		}
+
+		// No further call starts while the running ones are held
+		time.Sleep(50 * time.Millisecond)
+
+		if n := started.Load(); n != limit {
+			t.Errorf("expected %d started calls while the running ones are held, got %d", limit, n)
+		}
+
+		close(release)
+
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n := started.Load(); n != calls {
+			t.Errorf("expected %d calls, got %d", calls, n)
+		}
+	})
+
+	t.Run("stops starting calls when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		var calls atomic.Int32
+
+		err := ForEach(ctx, 10, 1, func(int) {
+			if calls.Add(1) == 2 {
+				cancel()
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		if n := calls.Load(); n >= 10 {
+			t.Errorf("expected fewer than 10 calls, got %d", n)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"crypto/rsa"
 	"crypto/sha256"
 	_ "crypto/sha512" // if user chooses SHA2-384 or SHA2-512 for hash
+	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -110,6 +111,61 @@ func (e *Keypair) GetKeyAlgorithm() string {
 	}
 }
 
+// SignatureAlgorithm returns the name of the algorithm cosign uses to sign with the given public key's
+// private key, e.g. "ECDSA_P256_SHA256".
+func SignatureAlgorithm(pubKey crypto.PublicKey) string {
+	switch pubKey := pubKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA_SHA256"
+	case *ecdsa.PublicKey:
+		switch pubKey.Curve.Params().Name {
+		case "P-256":
+			return "ECDSA_P256_SHA256"
+		case "P-384":
+			return "ECDSA_P384_SHA256"
+		case "P-521":
+			return "ECDSA_P521_SHA256"
+		default:
+			return "ECDSA_SHA256"
+		}
+	case ed25519.PublicKey:
+		return "ED25519"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SignatureAlgorithms lists the algorithm names returned by SignatureAlgorithm for the supported keys.
+var SignatureAlgorithms = []string{
+	"ED25519",
+	"ECDSA_P256_SHA256",
+	"ECDSA_P384_SHA256",
+	"ECDSA_P521_SHA256",
+	"RSA_SHA256",
+}
+
+// PublicKeySignatureAlgorithm returns the name of the algorithm cosign uses to sign
+// with the private key of the given PEM-encoded public key.
+func PublicKeySignatureAlgorithm(publicKeyPEM []byte) (string, error) {
+	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey(publicKeyPEM)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	return SignatureAlgorithm(pubKey), nil
+}
+
+// CertificateSignatureAlgorithm returns the name of the algorithm cosign uses to sign
+// with the private key of the given DER-encoded signing certificate.
+func CertificateSignatureAlgorithm(certificateDER []byte) (string, error) {
+	certificate, err := x509.ParseCertificate(certificateDER)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return SignatureAlgorithm(certificate.PublicKey), nil
+}
+
 func (e *Keypair) GetPublicKeyPem() (string, error) {
 	pubKeyBytes, err := cryptoutils.MarshalPublicKeyToPEM(e.privateKey.Public())
 	if err != nil {
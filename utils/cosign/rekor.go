@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/sigstore/pkg/tuf"
+)
+
+// VerifyRekorOptions contains options for verifying transparency log inclusion.
+type VerifyRekorOptions struct {
+	RekorURL string
+	Payload  []byte
+
+	// Base64-encoded signature of the payload
+	Signature string
+
+	// PEM-encoded signing certificate for keyless signatures, or public key otherwise
+	Verifier []byte
+
+	// PEM-encoded trusted public key of the transparency log.
+	// Required for logs other than the default one, whose keys are obtained from the Sigstore TUF root.
+	RekorPublicKey []byte
+}
+
+// VerifyRekorInclusion checks that the signature of the payload has been recorded
+// in the Rekor transparency log. The inclusion proof of the log entry is verified,
+// along with the signed entry timestamp against the trusted public key of the log.
+func VerifyRekorInclusion(ctx context.Context, opts *VerifyRekorOptions) error {
+	rekorURL := setOrDefault(opts.RekorURL, DefaultRekorURL)
+
+	logPubKeys, err := trustedRekorPublicKeys(ctx, rekorURL, opts.RekorPublicKey)
+	if err != nil {
+		return err
+	}
+
+	rekorClient, err := rekorclient.GetRekorClient(rekorURL)
+	if err != nil {
+		return fmt.Errorf("failed to create rekor client: %w", err)
+	}
+
+	logEntries, err := cosign.FindTlogEntry(ctx, rekorClient, opts.Signature, opts.Payload, opts.Verifier)
+	if err != nil {
+		return fmt.Errorf("failed to find transparency log entry: %w", err)
+	}
+
+	var errs []error
+
+	for _, logEntry := range logEntries {
+		err := cosign.VerifyTLogEntryOffline(ctx, &logEntry, logPubKeys, nil)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return errors.New("signature not found in transparency log")
+	}
+
+	return fmt.Errorf("failed to verify transparency log entry: %w", errors.Join(errs...))
+}
+
+// trustedRekorPublicKeys returns the trusted public keys of the transparency log at the given URL.
+// The keys are never fetched from the log itself, as a compromised log could serve a key of its own.
+func trustedRekorPublicKeys(ctx context.Context, rekorURL string, publicKey []byte) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	if len(publicKey) > 0 {
+		logPubKeys := cosign.NewTrustedTransparencyLogPubKeys()
+		if err := logPubKeys.AddTransparencyLogPubKey(publicKey, tuf.Active); err != nil {
+			return nil, fmt.Errorf("failed to load rekor public key: %w", err)
+		}
+
+		return &logPubKeys, nil
+	}
+
+	if rekorURL != DefaultRekorURL {
+		return nil, fmt.Errorf("a trusted public key is required to verify the transparency log at %s", rekorURL)
+	}
+
+	logPubKeys, err := cosign.GetRekorPubs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rekor public keys from the TUF root: %w", err)
+	}
+
+	return logPubKeys, nil
+}
@@ -58,8 +58,10 @@ type SignBlobOIDCOptions struct {
 
 // SignBlobOIDCResult contains the result of OIDC blob signing.
 type SignBlobOIDCResult struct {
-	Signature string
-	PublicKey string
+	Signature   string
+	PublicKey   string
+	Algorithm   string
+	Certificate string
 }
 
 // SignBlobWithOIDC signs a blob using OIDC authentication.
@@ -183,9 +185,24 @@ func SignBlobWithOIDC(_ context.Context, opts *SignBlobOIDCOptions) (*SignBlobOI
 		return nil, fmt.Errorf("failed to get public key: %w", err)
 	}
 
+	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(publicKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	// Fulcio issues either a single certificate or a chain, depending on the bundle version
+	verificationMaterial := sigBundle.GetVerificationMaterial()
+
+	certificate := verificationMaterial.GetCertificate().GetRawBytes()
+	if certificates := verificationMaterial.GetX509CertificateChain().GetCertificates(); certificate == nil && len(certificates) > 0 {
+		certificate = certificates[0].GetRawBytes()
+	}
+
 	return &SignBlobOIDCResult{
-		Signature: base64.StdEncoding.EncodeToString(sigBundle.GetMessageSignature().GetSignature()),
-		PublicKey: publicKeyPEM,
+		Signature:   base64.StdEncoding.EncodeToString(sigBundle.GetMessageSignature().GetSignature()),
+		PublicKey:   publicKeyPEM,
+		Algorithm:   SignatureAlgorithm(pubKey),
+		Certificate: base64.StdEncoding.EncodeToString(certificate),
 	}, nil
 }
 
@@ -200,6 +217,7 @@ type SignBlobKeyOptions struct {
 type SignBlobKeyResult struct {
 	Signature string
 	PublicKey string
+	Algorithm string
 }
 
 // SignBlobWithKey signs a blob using a private key.
@@ -229,6 +247,7 @@ func SignBlobWithKey(_ context.Context, opts *SignBlobKeyOptions) (*SignBlobKeyR
 	return &SignBlobKeyResult{
 		Signature: base64.StdEncoding.EncodeToString(sig),
 		PublicKey: string(publicKeyPEM),
+		Algorithm: SignatureAlgorithm(pubKey),
 	}, nil
 }
 
@@ -4,7 +4,9 @@
 package logging
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -14,11 +16,83 @@ import (
 const (
 	DefaultEnvPrefix = "DIRECTORY_LOGGER"
 	DefaultLogLevel  = "INFO"
+	DefaultLogFormat = FormatText
+)
+
+// Supported log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
 type Config struct {
 	LogFile  string `json:"log_file,omitempty"  mapstructure:"log_file"`
 	LogLevel string `json:"log_level,omitempty" mapstructure:"log_level"`
+
+	// Format of log lines, "text" or "json".
+	LogFormat string `json:"log_format,omitempty" mapstructure:"log_format"`
+
+	// Per-component log levels overriding LogLevel, as "component=level" entries,
+	// e.g. "routing/remote=debug". An entry also applies to the subcomponents of
+	// the component, so "routing=error" covers "routing/remote" and "routing/cleanup".
+	LogLevels []string `json:"log_levels,omitempty" mapstructure:"log_levels"`
+}
+
+// Validate checks the logging configuration.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, err := parseLevel(c.LogLevel, slog.LevelInfo); err != nil {
+		errs = append(errs, fmt.Errorf("log_level: %w", err))
+	}
+
+	switch c.LogFormat {
+	case "", FormatText, FormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("log_format: unsupported format %q, expected %s or %s", c.LogFormat, FormatText, FormatJSON))
+	}
+
+	if _, err := parseComponentLevels(c.LogLevels); err != nil {
+		errs = append(errs, fmt.Errorf("log_levels: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseLevel parses a log level name, case-insensitively.
+// An empty name is the fallback level.
+func parseLevel(name string, fallback slog.Level) (slog.Level, error) {
+	if name == "" {
+		return fallback, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+		return fallback, fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+
+	return level, nil
+}
+
+// parseComponentLevels parses "component=level" entries.
+func parseComponentLevels(entries []string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level, len(entries))
+
+	for _, entry := range entries {
+		component, name, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || component == "" || name == "" {
+			return nil, fmt.Errorf("invalid component log level %q: expected component=level", entry)
+		}
+
+		level, err := parseLevel(name, slog.LevelInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		levels[strings.Trim(component, "/")] = level
+	}
+
+	return levels, nil
 }
 
 func LoadConfig() (*Config, error) {
@@ -36,6 +110,11 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("log_level")
 	v.SetDefault("log_level", DefaultLogLevel)
 
+	_ = v.BindEnv("log_format")
+	v.SetDefault("log_format", DefaultLogFormat)
+
+	_ = v.BindEnv("log_levels")
+
 	// Load configuration into struct
 	decodeHooks := mapstructure.ComposeDecodeHookFunc(
 		mapstructure.TextUnmarshallerHookFunc(),
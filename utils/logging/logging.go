@@ -4,15 +4,69 @@
 package logging
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 const filePermission = 0o644
 
-var once sync.Once
+var (
+	once sync.Once
+
+	// configMu serializes configuration changes.
+	configMu sync.Mutex
+
+	// envConfig is the configuration loaded from the environment at startup.
+	envConfig Config
+
+	// current is the active logging setup, read by all loggers on each log call.
+	current atomic.Pointer[setup]
+)
+
+// setup is an active logging configuration.
+type setup struct {
+	base     slog.Handler
+	level    slog.Level
+	levels   map[string]slog.Level
+	logFile  string
+	output   io.Writer
+	revision uint64
+}
+
+// levelFor returns the minimum level of a component.
+// The override of the closest enclosing component applies, e.g. "routing" for "routing/remote".
+func (s *setup) levelFor(component string) slog.Level {
+	for name := component; name != ""; {
+		if level, ok := s.levels[name]; ok {
+			return level
+		}
+
+		i := strings.LastIndex(name, "/")
+		if i < 0 {
+			break
+		}
+
+		name = name[:i]
+	}
+
+	return s.level
+}
+
+// minLevel returns the lowest level enabled for any component.
+func (s *setup) minLevel() slog.Level {
+	level := s.level
+	for _, override := range s.levels {
+		level = min(level, override)
+	}
+
+	return level
+}
 
 // getLogOutput determines where logs should be written.
 func getLogOutput(logFilePath string) *os.File {
@@ -29,25 +83,108 @@ func getLogOutput(logFilePath string) *os.File {
 	return os.Stdout
 }
 
+// configure activates the given configuration.
+func configure(cfg *Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	level, _ := parseLevel(cfg.LogLevel, slog.LevelInfo)
+	levels, _ := parseComponentLevels(cfg.LogLevels)
+
+	next := &setup{
+		level:   level,
+		levels:  levels,
+		logFile: cfg.LogFile,
+	}
+
+	// Keep writing to the same output if the log file did not change
+	if prev := current.Load(); prev != nil {
+		next.revision = prev.revision + 1
+
+		if prev.logFile == cfg.LogFile {
+			next.output = prev.output
+		}
+	}
+
+	if next.output == nil {
+		next.output = getLogOutput(cfg.LogFile)
+	}
+
+	// Levels are checked per component, so the base handler accepts every enabled level
+	opts := &slog.HandlerOptions{Level: next.minLevel()}
+
+	if cfg.LogFormat == FormatJSON {
+		next.base = slog.NewJSONHandler(next.output, opts)
+	} else {
+		next.base = slog.NewTextHandler(next.output, opts)
+	}
+
+	current.Store(next)
+
+	return nil
+}
+
 func InitLogger(cfg *Config) {
 	once.Do(func() {
-		var logLevel slog.Level
+		configMu.Lock()
+		defer configMu.Unlock()
 
-		logOutput := getLogOutput(cfg.LogFile)
+		envConfig = *cfg
 
-		// Parse log level; default to INFO if invalid.
-		if err := logLevel.UnmarshalText([]byte(strings.ToLower(cfg.LogLevel))); err != nil {
-			slog.Warn("Invalid log level, defaulting to INFO", "error", err)
-			logLevel = slog.LevelInfo
+		// Fall back to the defaults for invalid settings
+		err := configure(&envConfig)
+		if err != nil {
+			envConfig = Config{LogFile: cfg.LogFile}
+			_ = configure(&envConfig)
 		}
 
 		// Set global logger before other packages initialize.
-		slog.SetDefault(slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: logLevel})))
+		slog.SetDefault(slog.New(&handler{}))
+
+		if err != nil {
+			slog.Warn("Invalid logging configuration, defaulting to INFO text logs", "error", err)
+		}
 	})
 }
 
+// Apply reconfigures logging with the given settings on top of the configuration
+// loaded from the environment at startup. Empty settings keep their startup value.
+// All loggers, including those created before, switch to the new configuration.
+func Apply(cfg Config) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	merged := envConfig
+
+	if cfg.LogFile != "" {
+		merged.LogFile = cfg.LogFile
+	}
+
+	if cfg.LogLevel != "" {
+		merged.LogLevel = cfg.LogLevel
+	}
+
+	if cfg.LogFormat != "" {
+		merged.LogFormat = cfg.LogFormat
+	}
+
+	if len(cfg.LogLevels) > 0 {
+		merged.LogLevels = cfg.LogLevels
+	}
+
+	if err := configure(&merged); err != nil {
+		return fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	return nil
+}
+
 func Logger(component string) *slog.Logger {
-	return slog.Default().With("component", component)
+	return slog.New(&handler{
+		component: component,
+		attrs:     []slog.Attr{slog.String("component", component)},
+	})
 }
 
 func init() {
@@ -59,3 +196,87 @@ func init() {
 
 	InitLogger(cfg)
 }
+
+// handler is the slog.Handler of all loggers. It filters records by the level of its
+// component and formats them with the handler of the active configuration, so that
+// loggers created at package initialization follow later configuration changes.
+type handler struct {
+	component string
+
+	// Attributes and groups added to the logger, applied in order
+	attrs  []slog.Attr
+	groups []handlerGroup
+
+	// Active base handler with attributes and groups applied
+	resolved atomic.Pointer[resolvedHandler]
+}
+
+// handlerGroup is a group opened after the first len(attrs) attributes were added.
+type handlerGroup struct {
+	name     string
+	numAttrs int
+}
+
+type resolvedHandler struct {
+	revision uint64
+	handler  slog.Handler
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= current.Load().levelFor(h.component)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	return h.resolve(current.Load()).Handle(ctx, record) //nolint:wrapcheck
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &handler{
+		component: h.component,
+		attrs:     append(h.attrs[:len(h.attrs):len(h.attrs)], attrs...),
+		groups:    h.groups,
+	}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &handler{
+		component: h.component,
+		attrs:     h.attrs,
+		groups:    append(h.groups[:len(h.groups):len(h.groups)], handlerGroup{name: name, numAttrs: len(h.attrs)}),
+	}
+}
+
+// resolve returns the base handler of the given setup with the attributes and groups of h applied.
+func (h *handler) resolve(s *setup) slog.Handler {
+	if resolved := h.resolved.Load(); resolved != nil && resolved.revision == s.revision {
+		return resolved.handler
+	}
+
+	base := s.base
+	applied := 0
+
+	for _, group := range h.groups {
+		if group.numAttrs > applied {
+			base = base.WithAttrs(h.attrs[applied:group.numAttrs])
+			applied = group.numAttrs
+		}
+
+		base = base.WithGroup(group.name)
+	}
+
+	if len(h.attrs) > applied {
+		base = base.WithAttrs(h.attrs[applied:])
+	}
+
+	h.resolved.Store(&resolvedHandler{revision: s.revision, handler: base})
+
+	return base
+}
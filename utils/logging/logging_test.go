@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureLogs activates the given configuration with its output captured for the duration of the test.
+func captureLogs(t *testing.T, cfg Config) *bytes.Buffer {
+	t.Helper()
+
+	prev := current.Load()
+	t.Cleanup(func() { current.Store(prev) })
+
+	if err := configure(&cfg); err != nil {
+		t.Fatalf("failed to configure logging: %v", err)
+	}
+
+	// Redirect the output of the new setup to a buffer
+	var buf bytes.Buffer
+
+	s := *current.Load()
+	s.output = &buf
+
+	opts := &slog.HandlerOptions{Level: s.minLevel()}
+	if cfg.LogFormat == FormatJSON {
+		s.base = slog.NewJSONHandler(&buf, opts)
+	} else {
+		s.base = slog.NewTextHandler(&buf, opts)
+	}
+
+	current.Store(&s)
+
+	return &buf
+}
+
+func TestComponentLevels(t *testing.T) {
+	buf := captureLogs(t, Config{
+		LogLevel:  "info",
+		LogLevels: []string{"routing=error", "routing/remote=debug"},
+	})
+
+	Logger("routing/remote").Debug("remote debug")
+	Logger("routing/cleanup").Warn("cleanup warning")
+	Logger("routing/cleanup").Error("cleanup error")
+	Logger("store").Debug("store debug")
+	Logger("store").Info("store info")
+
+	output := buf.String()
+
+	for _, msg := range []string{"remote debug", "cleanup error", "store info"} {
+		if !strings.Contains(output, msg) {
+			t.Errorf("expected %q to be logged, got:\n%s", msg, output)
+		}
+	}
+
+	for _, msg := range []string{"cleanup warning", "store debug"} {
+		if strings.Contains(output, msg) {
+			t.Errorf("expected %q not to be logged, got:\n%s", msg, output)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	logger := Logger("server").With("request", "abc").WithGroup("details")
+
+	buf := captureLogs(t, Config{LogFormat: FormatJSON})
+
+	// Loggers created before the configuration change follow it
+	logger.Info("handled", "status", 200)
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+
+	for key, expected := range map[string]string{"msg": "handled", "component": "server", "request": "abc"} {
+		if line[key] != expected {
+			t.Errorf("expected %s %q, got %v", key, expected, line[key])
+		}
+	}
+
+	details, ok := line["details"].(map[string]any)
+	if !ok || len(details) != 1 || details["status"] != float64(200) {
+		t.Errorf("expected details with status 200, got %v", line["details"])
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Config{LogLevel: "DEBUG", LogFormat: FormatJSON, LogLevels: []string{"routing/remote=warn"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := Config{LogLevel: "verbose", LogFormat: "xml", LogLevels: []string{"routing"}}
+
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, key := range []string{"log_level:", "log_format:", "log_levels:"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("missing %s in %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the instrumentation points used across Directory components.
+// Components record measurements through the global Recorder, which is a no-op
+// unless a concrete implementation (such as Prometheus) is installed with SetRecorder.
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Store operations.
+const (
+	OpPush   = "push"
+	OpPull   = "pull"
+	OpLookup = "lookup"
+	OpExists = "exists"
+	OpDelete = "delete"
+)
+
+// GossipSub message directions.
+const (
+	DirectionPublished = "published"
+	DirectionReceived  = "received"
+)
+
+// Recorder records Directory metrics.
+type Recorder interface {
+	// ObserveStoreOperation records the duration and outcome of a store operation.
+	ObserveStoreOperation(op string, duration time.Duration, err error)
+
+	// ObserveSearch records the number of results and the duration of a search.
+	ObserveSearch(source string, results int, duration time.Duration)
+
+	// IncGossipSubMessages counts a GossipSub message in the given direction.
+	IncGossipSubMessages(direction string)
+
+	// IncDatastoreQueries counts a datastore query for the given namespace.
+	IncDatastoreQueries(namespace string)
+}
+
+type recorderHolder struct {
+	Recorder
+}
+
+var current atomic.Pointer[recorderHolder]
+
+func init() {
+	current.Store(&recorderHolder{Noop{}})
+}
+
+// SetRecorder installs the global recorder.
+// Passing nil restores the no-op recorder.
+func SetRecorder(r Recorder) {
+	if r == nil {
+		r = Noop{}
+	}
+
+	current.Store(&recorderHolder{r})
+}
+
+// Default returns the global recorder.
+func Default() Recorder {
+	return current.Load().Recorder
+}
+
+// Noop is a Recorder that discards all measurements.
+type Noop struct{}
+
+func (Noop) ObserveStoreOperation(string, time.Duration, error) {}
+
+func (Noop) ObserveSearch(string, int, time.Duration) {}
+
+func (Noop) IncGossipSubMessages(string) {}
+
+func (Noop) IncDatastoreQueries(string) {}
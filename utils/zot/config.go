@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 
 	zotconfig "zotregistry.dev/zot/pkg/api/config"
@@ -47,15 +50,38 @@ func writeConfigFile(filePath string, zotConfig *zotconfig.Config) error {
 	return nil
 }
 
+// TLSOptions configures TLS for a synced registry.
+type TLSOptions struct {
+	// Verify enables TLS certificate verification for the remote registry.
+	Verify bool
+
+	// CACertDir is an optional directory with the CA certificate (ca.crt)
+	// used to verify the remote registry, as expected by zot.
+	CACertDir string
+}
+
 // addRegistryToSyncConfig adds a registry to the zot sync configuration.
-func AddRegistryToSyncConfig(filePath string, remoteRegistryURL string, remoteRepositoryName string, credentials zotsyncconfig.Credentials, cids []string) error {
-	logger.Debug("Adding registry to zot sync", "remote_url", remoteRegistryURL)
+// Credentials are persisted through credStore; a nil store writes them to DefaultCredentialsDir.
+// If the registry is already configured, only its TLS options are updated.
+func AddRegistryToSyncConfig(filePath string, remoteRegistryURL string, remoteRepositoryName string, credentials zotsyncconfig.Credentials, cids []string, tlsOpts TLSOptions, credStore CredentialStore) error {
+	logger.Debug("Adding registry to zot sync", "remote_url", remoteRegistryURL, "tls_verify", tlsOpts.Verify)
 
 	// Validate input
 	if remoteRegistryURL == "" {
 		return errors.New("remote registry URL cannot be empty")
 	}
 
+	if tlsOpts.CACertDir != "" {
+		info, err := os.Stat(tlsOpts.CACertDir)
+		if err != nil {
+			return fmt.Errorf("failed to access CA certificate directory: %w", err)
+		}
+
+		if !info.IsDir() {
+			return fmt.Errorf("CA certificate path %s must be a directory containing ca.crt", tlsOpts.CACertDir)
+		}
+	}
+
 	// Read current zot config
 	zotConfig, err := readConfigFile(filePath)
 	if err != nil {
@@ -76,16 +102,21 @@ func AddRegistryToSyncConfig(filePath string, remoteRegistryURL string, remoteRe
 
 	syncConfig.Enable = toPtr(true)
 
-	// Create credentials file if credentials are provided
-	credentialsFile := DefaultCredentialsPath
-	if credentials.Username != "" && credentials.Password != "" {
-		if err := updateCredentialsFile(credentialsFile, remoteRegistryURL, zotsyncconfig.Credentials{
-			Username: credentials.Username,
-			Password: credentials.Password,
-		}); err != nil {
-			return fmt.Errorf("failed to create credentials file: %w", err)
-		}
+	if credStore == nil {
+		credStore = FileCredentialStore{Dir: DefaultCredentialsDir}
+	}
+
+	// Let the credential store resolve the credentials, which may come from
+	// its own source (e.g. the environment) when none were negotiated
+	credentialsFile, err := credStore.Save(remoteRegistryURL, zotsyncconfig.Credentials{
+		Username: credentials.Username,
+		Password: credentials.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create credentials file: %w", err)
+	}
 
+	if credentialsFile != "" {
 		// Set credentials file path in sync config
 		syncConfig.CredentialsFile = credentialsFile
 	} else {
@@ -99,48 +130,37 @@ func AddRegistryToSyncConfig(filePath string, remoteRegistryURL string, remoteRe
 		return fmt.Errorf("failed to normalize registry URL: %w", err)
 	}
 
-	// Check if registry already exists
-	for _, existingRegistry := range syncConfig.Registries {
-		for _, existingURL := range existingRegistry.URLs {
-			if existingURL == registryURL {
-				logger.Debug("Registry already exists in zot config", "registry_url", registryURL)
+	// Check if registry already exists, and apply the TLS options to it,
+	// so that the options of a newer sync with the same registry are not ignored
+	if existingRegistry := findRegistry(syncConfig, registryURL); existingRegistry != nil {
+		logger.Debug("Registry already exists in zot config", "registry_url", registryURL)
 
-				return nil
-			}
+		if existingRegistry.TLSVerify != nil && *existingRegistry.TLSVerify == tlsOpts.Verify && existingRegistry.CertDir == tlsOpts.CACertDir {
+			return nil
 		}
-	}
-
-	var syncContent []zotsyncconfig.Content
 
-	if len(cids) > 0 {
-		// Create a regex to match the CIDs
-		cidsRegex := strings.Join(cids, "|")
-		regex := fmt.Sprintf("^(%s)$", cidsRegex)
+		existingRegistry.TLSVerify = toPtr(tlsOpts.Verify)
+		existingRegistry.CertDir = tlsOpts.CACertDir
 
-		// Add the regex to the sync content
-		syncContent = []zotsyncconfig.Content{
-			{
-				Prefix: remoteRepositoryName,
-				Tags: &zotsyncconfig.Tags{
-					Regex: &regex,
-				},
-			},
-		}
-	} else {
-		syncContent = []zotsyncconfig.Content{
-			{
-				Prefix: remoteRepositoryName,
-			},
+		if err := writeConfigFile(filePath, zotConfig); err != nil {
+			return err
 		}
+
+		logger.Info("Updated TLS options of registry in zot sync", "remote_url", remoteRegistryURL, "tls_verify", tlsOpts.Verify)
+
+		return nil
 	}
 
+	syncContent := buildSyncContent(remoteRepositoryName, cids)
+
 	registry := zotsyncconfig.RegistryConfig{
 		URLs:         []string{registryURL},
 		OnDemand:     false, // Disable OnDemand for proactive sync
 		PollInterval: DefaultPollInterval,
 		MaxRetries:   toPtr(DefaultMaxRetries),
 		RetryDelay:   toPtr(DefaultRetryDelay),
-		TLSVerify:    toPtr(false),
+		TLSVerify:    toPtr(tlsOpts.Verify),
+		CertDir:      tlsOpts.CACertDir,
 		Content:      syncContent,
 	}
 	syncConfig.Registries = append(syncConfig.Registries, registry)
@@ -157,6 +177,65 @@ func AddRegistryToSyncConfig(filePath string, remoteRegistryURL string, remoteRe
 	return nil
 }
 
+// UpdateRegistrySyncContent replaces the sync content of an existing registry with
+// a tag regex matching exactly the given CIDs. Unlike AddRegistryToSyncConfig, an
+// empty CID list matches no tags, so that a logical subset that is currently empty
+// does not turn into a full mirror. Returns true if the config file was changed.
+func UpdateRegistrySyncContent(filePath string, remoteRegistryURL string, remoteRepositoryName string, cids []string) (bool, error) {
+	logger.Debug("Updating registry sync content", "remote_url", remoteRegistryURL, "cids", len(cids))
+
+	// Read current zot config
+	zotConfig, err := readConfigFile(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	if zotConfig.Extensions == nil || zotConfig.Extensions.Sync == nil {
+		return false, errors.New("no sync configuration found")
+	}
+
+	registryURL, err := normalizeRegistryURL(remoteRegistryURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to normalize registry URL: %w", err)
+	}
+
+	registry := findRegistry(zotConfig.Extensions.Sync, registryURL)
+	if registry == nil {
+		return false, fmt.Errorf("registry %s not found in zot sync config", registryURL)
+	}
+
+	regex := noTagsRegex
+	if len(cids) > 0 {
+		regex = cidsTagRegex(cids)
+	}
+
+	syncContent := []zotsyncconfig.Content{
+		{
+			Prefix: remoteRepositoryName,
+			Tags: &zotsyncconfig.Tags{
+				Regex: &regex,
+			},
+		},
+	}
+
+	if reflect.DeepEqual(registry.Content, syncContent) {
+		logger.Debug("Registry sync content unchanged", "registry_url", registryURL)
+
+		return false, nil
+	}
+
+	registry.Content = syncContent
+
+	// Write the updated config back to the file
+	if err := writeConfigFile(filePath, zotConfig); err != nil {
+		return false, err
+	}
+
+	logger.Info("Successfully updated registry sync content", "registry_url", registryURL, "cids", len(cids))
+
+	return true, nil
+}
+
 // removeRegistryFromSyncConfig removes a registry from the zot sync configuration.
 func RemoveRegistryFromSyncConfig(filePath string, remoteRegistryURL string) error {
 	logger.Debug("Removing registry from zot sync", "remote_registry_url", remoteRegistryURL)
@@ -224,15 +303,171 @@ func RemoveRegistryFromSyncConfig(filePath string, remoteRegistryURL string) err
 	return nil
 }
 
+// noTagsRegex matches no tags, since tags are never empty.
+const noTagsRegex = "^$"
+
+// cidsTagRegex creates a regex matching exactly the given CIDs.
+func cidsTagRegex(cids []string) string {
+	return fmt.Sprintf("^(%s)$", strings.Join(cids, "|"))
+}
+
+// buildSyncContent creates the sync content for a repository.
+// If CIDs are given, only tags matching those CIDs are synced, otherwise all tags are synced.
+func buildSyncContent(remoteRepositoryName string, cids []string) []zotsyncconfig.Content {
+	if len(cids) == 0 {
+		return []zotsyncconfig.Content{
+			{
+				Prefix: remoteRepositoryName,
+			},
+		}
+	}
+
+	regex := cidsTagRegex(cids)
+
+	return []zotsyncconfig.Content{
+		{
+			Prefix: remoteRepositoryName,
+			Tags: &zotsyncconfig.Tags{
+				Regex: &regex,
+			},
+		},
+	}
+}
+
+// ListSyncRegistries returns the registries configured for zot sync.
+func ListSyncRegistries(filePath string) ([]zotsyncconfig.RegistryConfig, error) {
+	zotConfig, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if zotConfig.Extensions == nil || zotConfig.Extensions.Sync == nil {
+		return nil, nil
+	}
+
+	return zotConfig.Extensions.Sync.Registries, nil
+}
+
+// HasSyncRegistry reports whether the registry is already configured for zot sync.
+func HasSyncRegistry(filePath string, remoteRegistryURL string) (bool, error) {
+	registryURL, err := normalizeRegistryURL(remoteRegistryURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to normalize registry URL: %w", err)
+	}
+
+	registries, err := ListSyncRegistries(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	return findRegistry(&zotsyncconfig.Config{Registries: registries}, registryURL) != nil, nil
+}
+
+// findRegistry returns the registry with the given URL, or nil if not found.
+func findRegistry(syncConfig *zotsyncconfig.Config, registryURL string) *zotsyncconfig.RegistryConfig {
+	for i := range syncConfig.Registries {
+		for _, url := range syncConfig.Registries[i].URLs {
+			if url == registryURL {
+				return &syncConfig.Registries[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveContentFromSyncConfig removes a single content prefix from a registry in the zot sync configuration.
+// Other content entries of the registry are kept. The registry itself is removed only if no content remains.
+func RemoveContentFromSyncConfig(filePath string, remoteRegistryURL string, prefix string) error {
+	logger.Debug("Removing content from zot sync", "remote_registry_url", remoteRegistryURL, "prefix", prefix)
+
+	// Validate input
+	if remoteRegistryURL == "" {
+		return errors.New("remote registry URL cannot be empty")
+	}
+
+	if prefix == "" {
+		return errors.New("content prefix cannot be empty")
+	}
+
+	// Read current zot config
+	zotConfig, err := readConfigFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	// Check if sync config exists
+	if zotConfig.Extensions == nil || zotConfig.Extensions.Sync == nil {
+		logger.Debug("No sync configuration found")
+
+		return nil
+	}
+
+	syncConfig := zotConfig.Extensions.Sync
+
+	// Normalize the URL to match what would be stored
+	registryURL, err := normalizeRegistryURL(remoteRegistryURL)
+	if err != nil {
+		return fmt.Errorf("failed to normalize registry URL: %w", err)
+	}
+
+	registry := findRegistry(syncConfig, registryURL)
+	if registry == nil {
+		logger.Debug("Registry not found in zot config", "registry_url", registryURL)
+
+		return nil
+	}
+
+	// Drop matching content entries
+	var filteredContent []zotsyncconfig.Content
+
+	for _, content := range registry.Content {
+		if content.Prefix != prefix {
+			filteredContent = append(filteredContent, content)
+		}
+	}
+
+	if len(filteredContent) == len(registry.Content) {
+		logger.Debug("Content prefix not found in registry", "registry_url", registryURL, "prefix", prefix)
+
+		return nil
+	}
+
+	if len(filteredContent) > 0 {
+		registry.Content = filteredContent
+	} else {
+		// No content left, remove the whole registry entry
+		var filteredRegistries []zotsyncconfig.RegistryConfig
+
+		for _, existing := range syncConfig.Registries {
+			if !slices.Contains(existing.URLs, registryURL) {
+				filteredRegistries = append(filteredRegistries, existing)
+			}
+		}
+
+		syncConfig.Registries = filteredRegistries
+	}
+
+	// Write the updated config back to the file
+	if err := writeConfigFile(filePath, zotConfig); err != nil {
+		return err
+	}
+
+	logger.Info("Successfully removed content from zot sync", "registry_url", registryURL, "prefix", prefix)
+
+	return nil
+}
+
 // normalizeRegistryURL ensures the registry URL has the proper scheme for zot sync.
+// Scheme-less URLs default to http://, except for the standard TLS port 443 which yields https://.
 func normalizeRegistryURL(rawURL string) (string, error) {
 	if rawURL == "" {
 		return "", errors.New("registry URL cannot be empty")
 	}
 
-	// Add http:// scheme if not present for zot sync
-	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
-		return "http://" + rawURL, nil
+	// Add scheme if not present for zot sync
+	if !hasURLScheme(rawURL) {
+		return registryURLWithScheme(rawURL, false), nil
 	}
 
 	// Validate the URL format
@@ -243,6 +478,35 @@ func normalizeRegistryURL(rawURL string) (string, error) {
 	return rawURL, nil
 }
 
+// registryURLWithScheme prefixes a scheme-less registry address with http:// or https://.
+// HTTPS is used when secure is set or when the address uses port 443.
+// Addresses that already have a scheme are returned unchanged.
+func registryURLWithScheme(address string, secure bool) string {
+	if hasURLScheme(address) {
+		return address
+	}
+
+	if secure || hasTLSPort(address) {
+		return "https://" + address
+	}
+
+	return "http://" + address
+}
+
+// hasURLScheme reports whether the address starts with an http:// or https:// scheme.
+func hasURLScheme(address string) bool {
+	return strings.HasPrefix(address, "http://") || strings.HasPrefix(address, "https://")
+}
+
+// hasTLSPort reports whether a scheme-less address explicitly uses port 443.
+func hasTLSPort(address string) bool {
+	hostPort, _, _ := strings.Cut(address, "/")
+
+	_, port, err := net.SplitHostPort(hostPort)
+
+	return err == nil && port == "443"
+}
+
 func toPtr[T any](v T) *T {
 	return &v
 }
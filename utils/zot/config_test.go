@@ -7,6 +7,7 @@ package zot
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -185,6 +186,18 @@ func TestNormalizeRegistryURL(t *testing.T) {
 			expected: "http://registry.example.com:5000",
 			wantErr:  false,
 		},
+		{
+			name:     "URL with TLS port",
+			input:    "registry.example.com:443",
+			expected: "https://registry.example.com:443",
+			wantErr:  false,
+		},
+		{
+			name:     "URL with TLS port and path",
+			input:    "registry.example.com:443/dir",
+			expected: "https://registry.example.com:443/dir",
+			wantErr:  false,
+		},
 		{
 			name:    "empty URL",
 			input:   "",
@@ -347,6 +360,8 @@ func TestAddRegistryToZotSync(t *testing.T) {
 			"test/repo",
 			zotsyncconfig.Credentials{},
 			nil,
+			TLSOptions{},
+			nil,
 		)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
@@ -384,8 +399,8 @@ func TestAddRegistryToZotSync(t *testing.T) {
 		configPath := createBasicConfig()
 		defer os.Remove(configPath)
 
-		// This test will fail because the credentials directory doesn't exist
-		// but we can verify the error is handled properly
+		credDir := filepath.Join(t.TempDir(), "zot")
+
 		err := AddRegistryToSyncConfig(
 			configPath,
 			"registry.example.com",
@@ -395,13 +410,91 @@ func TestAddRegistryToZotSync(t *testing.T) {
 				Password: "testpass",
 			},
 			nil,
+			TLSOptions{},
+			FileCredentialStore{Dir: credDir},
 		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		expectedPath := filepath.Join(credDir, DefaultCredentialsFileName)
+		if config.Extensions.Sync.CredentialsFile != expectedPath {
+			t.Errorf("Expected credentials file %q, got %q", expectedPath, config.Extensions.Sync.CredentialsFile)
+		}
+
+		if _, err := os.Stat(expectedPath); err != nil {
+			t.Errorf("Expected credentials file to be created: %v", err)
+		}
+	})
+
+	t.Run("add registry with credentials from the environment only", func(t *testing.T) {
+		configPath := createBasicConfig()
+		defer os.Remove(configPath)
+
+		t.Setenv("TEST_REGISTRY_USERNAME", "envuser")
+		t.Setenv("TEST_REGISTRY_PASSWORD", "envpass")
+
+		credDir := filepath.Join(t.TempDir(), "zot")
+
+		err := AddRegistryToSyncConfig(
+			configPath,
+			"registry.example.com",
+			"test/repo",
+			zotsyncconfig.Credentials{},
+			nil,
+			TLSOptions{},
+			EnvCredentialStore{Dir: credDir, UsernameEnv: "TEST_REGISTRY_USERNAME", PasswordEnv: "TEST_REGISTRY_PASSWORD"},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		expectedPath := filepath.Join(credDir, DefaultCredentialsFileName)
+		if config.Extensions.Sync.CredentialsFile != expectedPath {
+			t.Errorf("Expected credentials file %q, got %q", expectedPath, config.Extensions.Sync.CredentialsFile)
+		}
+	})
+
+	t.Run("add registry with unwritable credentials directory", func(t *testing.T) {
+		configPath := createBasicConfig()
+		defer os.Remove(configPath)
 
-		// Expect an error because /etc/zot directory doesn't exist
+		// Use a regular file as parent so the directory can never be created
+		parent := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(parent, nil, 0o600); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+
+		credDir := filepath.Join(parent, "zot")
+
+		err := AddRegistryToSyncConfig(
+			configPath,
+			"registry.example.com",
+			"test/repo",
+			zotsyncconfig.Credentials{
+				Username: "testuser",
+				Password: "testpass",
+			},
+			nil,
+			TLSOptions{},
+			FileCredentialStore{Dir: credDir},
+		)
 		if err == nil {
-			t.Errorf("Expected error when credentials directory doesn't exist")
-		} else if !strings.Contains(err.Error(), "failed to create credentials file") {
-			t.Errorf("Expected credentials file error, got: %v", err)
+			t.Fatalf("Expected error when credentials directory cannot be created")
+		}
+
+		if !strings.Contains(err.Error(), credDir) {
+			t.Errorf("Expected error to include path %q, got: %v", credDir, err)
 		}
 	})
 
@@ -417,6 +510,8 @@ func TestAddRegistryToZotSync(t *testing.T) {
 			"test/repo",
 			zotsyncconfig.Credentials{},
 			cids,
+			TLSOptions{},
+			nil,
 		)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
@@ -454,6 +549,8 @@ func TestAddRegistryToZotSync(t *testing.T) {
 			"new/repo",
 			zotsyncconfig.Credentials{},
 			nil,
+			TLSOptions{},
+			nil,
 		)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
@@ -470,6 +567,37 @@ func TestAddRegistryToZotSync(t *testing.T) {
 		}
 	})
 
+	t.Run("add duplicate registry with other TLS options", func(t *testing.T) {
+		configPath := createBasicConfig()
+		caDir := t.TempDir()
+
+		for _, tlsOpts := range []TLSOptions{{}, {Verify: true, CACertDir: caDir}} {
+			err := AddRegistryToSyncConfig(configPath, "https://registry.example.com", "test/repo", zotsyncconfig.Credentials{}, nil, tlsOpts, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		// The TLS options of the last sync apply to the existing registry
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		if len(config.Extensions.Sync.Registries) != 1 {
+			t.Fatalf("Expected 1 registry (no duplicate), got %d", len(config.Extensions.Sync.Registries))
+		}
+
+		registry := config.Extensions.Sync.Registries[0]
+		if registry.TLSVerify == nil || !*registry.TLSVerify {
+			t.Errorf("Expected TLS verification to be enabled")
+		}
+
+		if registry.CertDir != caDir {
+			t.Errorf("Expected cert dir %q, got %q", caDir, registry.CertDir)
+		}
+	})
+
 	t.Run("empty registry URL", func(t *testing.T) {
 		configPath := createBasicConfig()
 		defer os.Remove(configPath)
@@ -480,6 +608,8 @@ func TestAddRegistryToZotSync(t *testing.T) {
 			"test/repo",
 			zotsyncconfig.Credentials{},
 			nil,
+			TLSOptions{},
+			nil,
 		)
 
 		if err == nil {
@@ -510,12 +640,86 @@ func TestAddRegistryToZotSync(t *testing.T) {
 			"test/repo",
 			zotsyncconfig.Credentials{},
 			nil,
+			TLSOptions{},
+			nil,
 		)
 
 		if err == nil {
 			t.Errorf("Expected error for invalid config file")
 		}
 	})
+
+	t.Run("add registry with TLS verification and CA directory", func(t *testing.T) {
+		configPath := createBasicConfig()
+		caDir := t.TempDir()
+
+		err := AddRegistryToSyncConfig(
+			configPath,
+			"https://registry.example.com",
+			"test/repo",
+			zotsyncconfig.Credentials{},
+			nil,
+			TLSOptions{Verify: true, CACertDir: caDir},
+			nil,
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		registry := config.Extensions.Sync.Registries[0]
+		if registry.TLSVerify == nil || !*registry.TLSVerify {
+			t.Errorf("Expected TLS verification to be enabled")
+		}
+
+		if registry.CertDir != caDir {
+			t.Errorf("Expected cert dir %q, got %q", caDir, registry.CertDir)
+		}
+	})
+
+	t.Run("TLS verification disabled by default", func(t *testing.T) {
+		configPath := createBasicConfig()
+
+		err := AddRegistryToSyncConfig(configPath, "registry.example.com", "test/repo", zotsyncconfig.Credentials{}, nil, TLSOptions{}, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		registry := config.Extensions.Sync.Registries[0]
+		if registry.TLSVerify == nil || *registry.TLSVerify {
+			t.Errorf("Expected TLS verification to be disabled")
+		}
+
+		if registry.CertDir != "" {
+			t.Errorf("Expected empty cert dir, got %q", registry.CertDir)
+		}
+	})
+
+	t.Run("missing CA directory", func(t *testing.T) {
+		configPath := createBasicConfig()
+
+		err := AddRegistryToSyncConfig(
+			configPath,
+			"registry.example.com",
+			"test/repo",
+			zotsyncconfig.Credentials{},
+			nil,
+			TLSOptions{Verify: true, CACertDir: "/nonexistent/ca"},
+			nil,
+		)
+		if err == nil || !strings.Contains(err.Error(), "CA certificate directory") {
+			t.Errorf("Expected CA directory error, got %v", err)
+		}
+	})
 }
 
 func TestRemoveRegistryFromZotSync(t *testing.T) {
@@ -692,3 +896,285 @@ func TestRemoveRegistryFromZotSync(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateRegistrySyncContent(t *testing.T) {
+	createConfigWithRegistry := func() string {
+		tmpFile, err := os.CreateTemp(t.TempDir(), "zot-config-*.json")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %v", err)
+		}
+		defer tmpFile.Close()
+
+		configWithRegistry := `{
+			"http": {
+				"address": "0.0.0.0",
+				"port": "5000"
+			},
+			"storage": {
+				"rootDirectory": "/var/lib/registry"
+			},
+			"extensions": {
+				"sync": {
+					"enable": true,
+					"registries": [
+						{
+							"urls": ["http://registry.example.com"],
+							"onDemand": false,
+							"content": [{"prefix": "test/repo"}]
+						}
+					]
+				}
+			}
+		}`
+
+		if _, err := tmpFile.WriteString(configWithRegistry); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		return tmpFile.Name()
+	}
+
+	t.Run("update content with CIDs", func(t *testing.T) {
+		configPath := createConfigWithRegistry()
+
+		changed, err := UpdateRegistrySyncContent(configPath, "registry.example.com", "test/repo", []string{"cid1", "cid2"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !changed {
+			t.Errorf("Expected config to be changed")
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		content := config.Extensions.Sync.Registries[0].Content
+		if len(content) != 1 || content[0].Tags == nil || content[0].Tags.Regex == nil {
+			t.Fatalf("Expected tag regex in content, got %v", content)
+		}
+
+		if *content[0].Tags.Regex != "^(cid1|cid2)$" {
+			t.Errorf("Expected regex ^(cid1|cid2)$, got %s", *content[0].Tags.Regex)
+		}
+	})
+
+	t.Run("unchanged content is not rewritten", func(t *testing.T) {
+		configPath := createConfigWithRegistry()
+
+		if _, err := UpdateRegistrySyncContent(configPath, "registry.example.com", "test/repo", []string{"cid1"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		changed, err := UpdateRegistrySyncContent(configPath, "registry.example.com", "test/repo", []string{"cid1"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if changed {
+			t.Errorf("Expected config to be unchanged")
+		}
+	})
+
+	t.Run("empty CIDs match no tags", func(t *testing.T) {
+		configPath := createConfigWithRegistry()
+
+		if _, err := UpdateRegistrySyncContent(configPath, "registry.example.com", "test/repo", nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		content := config.Extensions.Sync.Registries[0].Content
+		if len(content) != 1 || content[0].Tags == nil || *content[0].Tags.Regex != noTagsRegex {
+			t.Errorf("Expected content matching no tags, got %v", content)
+		}
+	})
+
+	t.Run("unknown registry", func(t *testing.T) {
+		configPath := createConfigWithRegistry()
+
+		_, err := UpdateRegistrySyncContent(configPath, "other.example.com", "test/repo", []string{"cid1"})
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestRemoveContentFromSyncConfig(t *testing.T) {
+	createConfigWithMultiPrefixRegistry := func() string {
+		tmpFile, err := os.CreateTemp(t.TempDir(), "zot-config-*.json")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %v", err)
+		}
+		defer tmpFile.Close()
+
+		configWithRegistries := `{
+			"http": {
+				"address": "0.0.0.0",
+				"port": "5000"
+			},
+			"storage": {
+				"rootDirectory": "/var/lib/registry"
+			},
+			"extensions": {
+				"sync": {
+					"enable": true,
+					"registries": [
+						{
+							"urls": ["http://registry1.example.com"],
+							"onDemand": false,
+							"content": [{"prefix": "repo1"}, {"prefix": "repo2"}]
+						},
+						{
+							"urls": ["http://registry2.example.com"],
+							"onDemand": false,
+							"content": [{"prefix": "repo3"}]
+						}
+					]
+				}
+			}
+		}`
+
+		if _, err := tmpFile.WriteString(configWithRegistries); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		return tmpFile.Name()
+	}
+
+	t.Run("remove one prefix from multi-prefix registry", func(t *testing.T) {
+		configPath := createConfigWithMultiPrefixRegistry()
+
+		if err := RemoveContentFromSyncConfig(configPath, "registry1.example.com", "repo1"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		if len(config.Extensions.Sync.Registries) != 2 {
+			t.Fatalf("Expected 2 registries, got %d", len(config.Extensions.Sync.Registries))
+		}
+
+		content := config.Extensions.Sync.Registries[0].Content
+		if len(content) != 1 || content[0].Prefix != "repo2" {
+			t.Errorf("Expected only repo2 to remain, got %v", content)
+		}
+	})
+
+	t.Run("remove all prefixes removes registry", func(t *testing.T) {
+		configPath := createConfigWithMultiPrefixRegistry()
+
+		for _, prefix := range []string{"repo1", "repo2"} {
+			if err := RemoveContentFromSyncConfig(configPath, "registry1.example.com", prefix); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		if len(config.Extensions.Sync.Registries) != 1 {
+			t.Fatalf("Expected 1 registry, got %d", len(config.Extensions.Sync.Registries))
+		}
+
+		if config.Extensions.Sync.Registries[0].URLs[0] != "http://registry2.example.com" {
+			t.Errorf("Wrong registry remained: %v", config.Extensions.Sync.Registries[0].URLs)
+		}
+	})
+
+	t.Run("remove single prefix registry", func(t *testing.T) {
+		configPath := createConfigWithMultiPrefixRegistry()
+
+		if err := RemoveContentFromSyncConfig(configPath, "registry2.example.com", "repo3"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		if len(config.Extensions.Sync.Registries) != 1 {
+			t.Errorf("Expected 1 registry, got %d", len(config.Extensions.Sync.Registries))
+		}
+	})
+
+	t.Run("non-existent prefix leaves config intact", func(t *testing.T) {
+		configPath := createConfigWithMultiPrefixRegistry()
+
+		if err := RemoveContentFromSyncConfig(configPath, "registry1.example.com", "unknown"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		config, err := readConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		if len(config.Extensions.Sync.Registries[0].Content) != 2 {
+			t.Errorf("Expected 2 content entries, got %d", len(config.Extensions.Sync.Registries[0].Content))
+		}
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		configPath := createConfigWithMultiPrefixRegistry()
+
+		if err := RemoveContentFromSyncConfig(configPath, "registry1.example.com", ""); err == nil {
+			t.Errorf("Expected error for empty prefix")
+		}
+	})
+}
+
+func TestListSyncRegistries(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"storage": {"rootDirectory": "/var/lib/registry"}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Run("no sync config", func(t *testing.T) {
+		registries, err := ListSyncRegistries(configPath)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(registries) != 0 {
+			t.Errorf("Expected no registries, got %d", len(registries))
+		}
+	})
+
+	t.Run("configured registry", func(t *testing.T) {
+		if err := AddRegistryToSyncConfig(configPath, "registry.example.com", "test/repo", zotsyncconfig.Credentials{}, nil, TLSOptions{}, nil); err != nil {
+			t.Fatalf("Failed to add registry: %v", err)
+		}
+
+		registries, err := ListSyncRegistries(configPath)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(registries) != 1 || registries[0].URLs[0] != "http://registry.example.com" {
+			t.Errorf("Unexpected registries: %+v", registries)
+		}
+
+		exists, err := HasSyncRegistry(configPath, "registry.example.com")
+		if err != nil || !exists {
+			t.Errorf("Expected registry to exist, got %t, %v", exists, err)
+		}
+
+		exists, err = HasSyncRegistry(configPath, "other.example.com")
+		if err != nil || exists {
+			t.Errorf("Expected registry not to exist, got %t, %v", exists, err)
+		}
+	})
+}
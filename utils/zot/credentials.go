@@ -8,16 +8,128 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	zotsyncconfig "zotregistry.dev/zot/pkg/extensions/config/sync"
 )
 
 const (
+	// DefaultCredentialsDir is the default directory holding the zot credentials file.
+	DefaultCredentialsDir = "/etc/zot"
+
+	// DefaultCredentialsFileName is the name of the zot credentials file.
+	DefaultCredentialsFileName = "credentials.json"
+
 	// DefaultCredentialsPath is the default path to the zot credentials file.
-	DefaultCredentialsPath = "/etc/zot/credentials.json" //nolint:gosec
+	DefaultCredentialsPath = DefaultCredentialsDir + "/" + DefaultCredentialsFileName //nolint:gosec
+
+	// DefaultUsernameEnv is the default environment variable holding the remote registry username.
+	DefaultUsernameEnv = "DIRECTORY_SYNC_REGISTRY_USERNAME"
+
+	// DefaultPasswordEnv is the default environment variable holding the remote registry password.
+	DefaultPasswordEnv = "DIRECTORY_SYNC_REGISTRY_PASSWORD" //nolint:gosec
 )
 
+// CredentialStore makes remote registry credentials available to zot sync.
+type CredentialStore interface {
+	// Save stores the credentials for the remote registry and returns
+	// the path of the credentials file that zot should read.
+	// The provided credentials may be empty; the returned path is empty
+	// when the store has no credentials for the registry.
+	Save(remoteRegistryURL string, credentials zotsyncconfig.Credentials) (string, error)
+}
+
+// FileCredentialStore writes credentials to a credentials file in Dir.
+// The directory is created if it does not exist.
+type FileCredentialStore struct {
+	Dir string
+}
+
+// Save implements CredentialStore.
+func (s FileCredentialStore) Save(remoteRegistryURL string, credentials zotsyncconfig.Credentials) (string, error) {
+	if credentials.Username == "" || credentials.Password == "" {
+		return "", nil
+	}
+
+	dir := s.Dir
+	if dir == "" {
+		dir = DefaultCredentialsDir
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:mnd
+		return "", fmt.Errorf("failed to create credentials directory %s: %w; configure a writable credentials directory", dir, err)
+	}
+
+	filePath := filepath.Join(dir, DefaultCredentialsFileName)
+	if err := updateCredentialsFile(filePath, remoteRegistryURL, credentials); err != nil {
+		return "", fmt.Errorf("failed to write credentials to %s: %w", filePath, err)
+	}
+
+	return filePath, nil
+}
+
+// EnvCredentialStore reads credentials from environment variables instead of
+// using the provided ones, and writes them to a credentials file in Dir.
+// Provided credentials are used as a fallback when the variables are not set,
+// so the store also works for registries that do not negotiate credentials.
+type EnvCredentialStore struct {
+	Dir         string
+	UsernameEnv string
+	PasswordEnv string
+}
+
+// Save implements CredentialStore.
+func (s EnvCredentialStore) Save(remoteRegistryURL string, credentials zotsyncconfig.Credentials) (string, error) {
+	usernameEnv := s.UsernameEnv
+	if usernameEnv == "" {
+		usernameEnv = DefaultUsernameEnv
+	}
+
+	passwordEnv := s.PasswordEnv
+	if passwordEnv == "" {
+		passwordEnv = DefaultPasswordEnv
+	}
+
+	username, hasUsername := os.LookupEnv(usernameEnv)
+	password, hasPassword := os.LookupEnv(passwordEnv)
+
+	if hasUsername && hasPassword {
+		credentials = zotsyncconfig.Credentials{
+			Username: username,
+			Password: password,
+		}
+	} else {
+		logger.Debug("Registry credentials not found in environment, using provided credentials", "username_env", usernameEnv, "password_env", passwordEnv)
+	}
+
+	return FileCredentialStore{Dir: s.Dir}.Save(remoteRegistryURL, credentials)
+}
+
+// SecretCredentialStore references a pre-provisioned credentials file,
+// such as a mounted Kubernetes secret, without writing to it.
+type SecretCredentialStore struct {
+	Path string
+}
+
+// Save implements CredentialStore.
+func (s SecretCredentialStore) Save(_ string, _ zotsyncconfig.Credentials) (string, error) {
+	if s.Path == "" {
+		return "", errors.New("credentials secret path cannot be empty")
+	}
+
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to access credentials secret %s: %w", s.Path, err)
+	}
+
+	if info.IsDir() {
+		return "", fmt.Errorf("credentials secret path %s must be a file", s.Path)
+	}
+
+	return s.Path, nil
+}
+
 // updateCredentialsFile updates a credentials file for zot sync.
 func updateCredentialsFile(filePath string, remoteRegistryURL string, credentials zotsyncconfig.Credentials) error {
 	// Load existing credentials or create empty map
@@ -362,3 +362,77 @@ func getKeys(credData zotsyncconfig.CredentialsFile) []string {
 
 	return keys
 }
+
+func TestEnvCredentialStore(t *testing.T) {
+	t.Setenv("TEST_REGISTRY_USERNAME", "envuser")
+	t.Setenv("TEST_REGISTRY_PASSWORD", "envpass")
+
+	store := EnvCredentialStore{
+		Dir:         t.TempDir(),
+		UsernameEnv: "TEST_REGISTRY_USERNAME",
+		PasswordEnv: "TEST_REGISTRY_PASSWORD",
+	}
+
+	credPath, err := store.Save("registry.example.com", zotsyncconfig.Credentials{Username: "user", Password: "pass"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(credPath)
+	if err != nil {
+		t.Fatalf("Failed to read credentials file: %v", err)
+	}
+
+	var credData zotsyncconfig.CredentialsFile
+	if err := json.Unmarshal(data, &credData); err != nil {
+		t.Fatalf("Failed to unmarshal credentials: %v", err)
+	}
+
+	if credData["registry.example.com"].Username != "envuser" || credData["registry.example.com"].Password != "envpass" {
+		t.Errorf("Expected credentials from environment, got %+v", credData["registry.example.com"])
+	}
+}
+
+func TestEnvCredentialStoreWithoutCredentials(t *testing.T) {
+	store := EnvCredentialStore{
+		Dir:         t.TempDir(),
+		UsernameEnv: "TEST_REGISTRY_USERNAME_UNSET",
+		PasswordEnv: "TEST_REGISTRY_PASSWORD_UNSET",
+	}
+
+	credPath, err := store.Save("registry.example.com", zotsyncconfig.Credentials{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if credPath != "" {
+		t.Errorf("Expected no credentials file, got %q", credPath)
+	}
+}
+
+func TestSecretCredentialStore(t *testing.T) {
+	t.Run("existing secret file", func(t *testing.T) {
+		secretPath := filepath.Join(t.TempDir(), "credentials.json")
+		if err := os.WriteFile(secretPath, []byte("{}"), 0o600); err != nil {
+			t.Fatalf("Failed to write secret file: %v", err)
+		}
+
+		credPath, err := SecretCredentialStore{Path: secretPath}.Save("registry.example.com", zotsyncconfig.Credentials{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if credPath != secretPath {
+			t.Errorf("Expected path %q, got %q", secretPath, credPath)
+		}
+	})
+
+	t.Run("missing secret file", func(t *testing.T) {
+		secretPath := filepath.Join(t.TempDir(), "missing.json")
+
+		_, err := SecretCredentialStore{Path: secretPath}.Save("registry.example.com", zotsyncconfig.Credentials{})
+		if err == nil || !strings.Contains(err.Error(), secretPath) {
+			t.Errorf("Expected error including %q, got %v", secretPath, err)
+		}
+	})
+}
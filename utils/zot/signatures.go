@@ -0,0 +1,168 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package zot
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"time"
+)
+
+const (
+	// cosignSignatureArtifactType is the artifact type of cosign signatures stored as OCI referrers.
+	cosignSignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+
+	// notationSignatureArtifactType is the artifact type of notation signatures.
+	notationSignatureArtifactType = "application/vnd.cncf.notary.signature"
+
+	// createdAnnotation is the OCI annotation holding the creation time of an artifact.
+	createdAnnotation = "org.opencontainers.image.created"
+)
+
+// SignatureDetail contains the verification details of a single signature.
+type SignatureDetail struct {
+	// Tool is the signing tool, e.g. cosign or notation.
+	Tool string
+
+	// IsTrusted is true when the signature was verified with a trusted key or certificate.
+	IsTrusted bool
+
+	// Author is the signer as reported by zot: the public key for key-based
+	// signatures or the certificate identity for keyless signatures.
+	Author string
+
+	// KeyID is the hex-encoded SHA-256 fingerprint of the signing public key.
+	// Empty for keyless signatures.
+	KeyID string
+
+	// CertificateIdentity is the signer identity of keyless signatures.
+	// Empty for key-based signatures.
+	CertificateIdentity string
+
+	// Digest is the digest of the signature artifact, when it can be attributed to the signature.
+	Digest string
+
+	// SignedAt is the signing time, when it can be attributed to the signature.
+	SignedAt time.Time
+}
+
+// zotSignatureInfo is a signature entry of the zot GraphQL ImageSummary.
+type zotSignatureInfo struct {
+	Tool      string `json:"Tool"`
+	IsTrusted bool   `json:"IsTrusted"`
+	Author    string `json:"Author"`
+}
+
+// zotReferrer is a referrer entry of the zot GraphQL ImageSummary.
+type zotReferrer struct {
+	Digest       string `json:"Digest"`
+	ArtifactType string `json:"ArtifactType"`
+	Annotations  []struct {
+		Key   string `json:"Key"`
+		Value string `json:"Value"`
+	} `json:"Annotations"`
+}
+
+// buildSignatureDetails builds one detail per signature reported by zot.
+// Zot does not report the descriptor of each signature, and the order of signatures and
+// referrers is unrelated, so a signature is only given the digest and timestamp of a referrer
+// when it is the only signature of its tool and a single referrer descriptor of that tool exists.
+// Otherwise they cannot be attributed and are left empty.
+func buildSignatureDetails(signatures []zotSignatureInfo, referrers []zotReferrer) []SignatureDetail {
+	// Index signature referrers by tool and descriptor digest, as a referrer may be listed more than once
+	referrersByTool := make(map[string]map[string]zotReferrer)
+
+	for _, ref := range referrers {
+		tool := signatureTool(ref.ArtifactType)
+		if tool == "" || ref.Digest == "" {
+			continue
+		}
+
+		if referrersByTool[tool] == nil {
+			referrersByTool[tool] = make(map[string]zotReferrer)
+		}
+
+		referrersByTool[tool][ref.Digest] = ref
+	}
+
+	signaturesByTool := make(map[string]int)
+	for _, sig := range signatures {
+		signaturesByTool[strings.ToLower(sig.Tool)]++
+	}
+
+	details := make([]SignatureDetail, 0, len(signatures))
+
+	for _, sig := range signatures {
+		detail := SignatureDetail{
+			Tool:      sig.Tool,
+			IsTrusted: sig.IsTrusted,
+			Author:    sig.Author,
+		}
+
+		if keyID, ok := publicKeyID(sig.Author); ok {
+			detail.KeyID = keyID
+		} else {
+			detail.CertificateIdentity = sig.Author
+		}
+
+		tool := strings.ToLower(sig.Tool)
+		if refs := referrersByTool[tool]; len(refs) == 1 && signaturesByTool[tool] == 1 {
+			for digest, ref := range refs {
+				detail.Digest = digest
+				detail.SignedAt = referrerCreatedAt(ref)
+			}
+		}
+
+		details = append(details, detail)
+	}
+
+	return details
+}
+
+// signatureTool returns the signing tool for a signature artifact type.
+func signatureTool(artifactType string) string {
+	switch artifactType {
+	case cosignSignatureArtifactType:
+		return "cosign"
+	case notationSignatureArtifactType:
+		return "notation"
+	default:
+		return ""
+	}
+}
+
+// referrerCreatedAt returns the creation time annotation of a referrer, if any.
+func referrerCreatedAt(ref zotReferrer) time.Time {
+	for _, annotation := range ref.Annotations {
+		if annotation.Key != createdAnnotation {
+			continue
+		}
+
+		if t, err := time.Parse(time.RFC3339, annotation.Value); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// publicKeyID returns the SHA-256 fingerprint of a PEM-encoded public key.
+// It returns false if the author is not a public key.
+func publicKeyID(author string) (string, bool) {
+	block, _ := pem.Decode([]byte(author))
+	if block == nil {
+		return "", false
+	}
+
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(block.Bytes)
+
+	return hex.EncodeToString(sum[:]), true
+}
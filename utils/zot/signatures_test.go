@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package zot
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPublicKeyPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestBuildSignatureDetails(t *testing.T) {
+	publicKey := testPublicKeyPEM(t)
+	signedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	created := []struct {
+		Key   string `json:"Key"`
+		Value string `json:"Value"`
+	}{{Key: createdAnnotation, Value: signedAt.Format(time.RFC3339)}}
+
+	referrers := []zotReferrer{
+		{Digest: "sha256:other", ArtifactType: "application/vnd.example+json"},
+		{Digest: "sha256:cosign1", ArtifactType: cosignSignatureArtifactType, Annotations: created},
+		{Digest: "sha256:notation1", ArtifactType: notationSignatureArtifactType, Annotations: created},
+		{Digest: "sha256:notation1", ArtifactType: notationSignatureArtifactType, Annotations: created},
+	}
+
+	details := buildSignatureDetails([]zotSignatureInfo{
+		{Tool: "cosign", IsTrusted: true, Author: publicKey},
+		{Tool: "cosign", IsTrusted: false, Author: "user@example.com"},
+		{Tool: "notation", IsTrusted: false, Author: "CN=example"},
+	}, referrers)
+
+	if len(details) != 3 {
+		t.Fatalf("Expected 3 signature details, got %d", len(details))
+	}
+
+	if details[0].KeyID == "" || details[0].CertificateIdentity != "" {
+		t.Errorf("Expected key ID for key-based signature, got %+v", details[0])
+	}
+
+	if details[1].KeyID != "" || details[1].CertificateIdentity != "user@example.com" {
+		t.Errorf("Expected certificate identity for keyless signature, got %+v", details[1])
+	}
+
+	// Two cosign signatures cannot be told apart by a single cosign referrer
+	for _, detail := range details[:2] {
+		if detail.Digest != "" || !detail.SignedAt.IsZero() {
+			t.Errorf("Expected no digest for ambiguous cosign signature, got %+v", detail)
+		}
+	}
+
+	// A notation referrer listed twice is a single descriptor
+	if details[2].Digest != "sha256:notation1" || !details[2].SignedAt.Equal(signedAt) {
+		t.Errorf("Expected notation referrer details, got %+v", details[2])
+	}
+
+	// A single signature with several referrers of its tool cannot be attributed either
+	details = buildSignatureDetails([]zotSignatureInfo{{Tool: "cosign", Author: publicKey}}, append(referrers,
+		zotReferrer{Digest: "sha256:cosign2", ArtifactType: cosignSignatureArtifactType},
+	))
+
+	if details[0].Digest != "" {
+		t.Errorf("Expected no digest with several cosign referrers, got %q", details[0].Digest)
+	}
+
+	details = buildSignatureDetails([]zotSignatureInfo{{Tool: "cosign", Author: publicKey}}, referrers)
+
+	if details[0].Digest != "sha256:cosign1" || !details[0].SignedAt.Equal(signedAt) {
+		t.Errorf("Expected cosign referrer details, got %+v", details[0])
+	}
+}
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"Image": map[string]interface{}{
+					"Digest":   "sha256:abcdef123456",
+					"IsSigned": true,
+					"Tag":      "test-cid",
+					"SignatureInfo": []map[string]interface{}{
+						{"Tool": "cosign", "IsTrusted": false, "Author": "first@example.com"},
+						{"Tool": "cosign", "IsTrusted": true, "Author": "second@example.com"},
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	result, err := Verify(t.Context(), &VerificationOptions{
+		Config: &VerifyConfig{
+			RegistryAddress: strings.TrimPrefix(server.URL, "http://"),
+			RepositoryName:  "test-repo",
+			Insecure:        true,
+		},
+		RecordCID: "test-cid",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Signatures) != 2 {
+		t.Fatalf("Expected 2 signatures, got %d", len(result.Signatures))
+	}
+
+	// Top-level fields keep describing the first signature
+	if result.Author != "first@example.com" || result.IsTrusted {
+		t.Errorf("Expected top-level fields from first signature, got %+v", result)
+	}
+
+	if !result.Signatures[1].IsTrusted || result.Signatures[1].Author != "second@example.com" {
+		t.Errorf("Unexpected second signature: %+v", result.Signatures[1])
+	}
+}
@@ -31,6 +31,9 @@ const (
 
 	// DefaultMaxRetries is the default maximum number of retries.
 	DefaultMaxRetries = 3
+
+	// DefaultRequestTimeout is the default timeout for requests to zot.
+	DefaultRequestTimeout = 30 * time.Second
 )
 
 // VerifyConfig contains configuration for zot verification.
@@ -41,6 +44,9 @@ type VerifyConfig struct {
 	Password        string
 	AccessToken     string
 	Insecure        bool
+
+	// Timeout bounds each request to zot. Defaults to DefaultRequestTimeout if zero.
+	Timeout time.Duration
 }
 
 // UploadPublicKeyOptions contains options for uploading public keys to zot.
@@ -56,11 +62,14 @@ type VerificationOptions struct {
 }
 
 // VerificationResult contains the result of zot verification.
+// IsTrusted, Author and Tool describe the first signature; all signatures
+// are listed in Signatures.
 type VerificationResult struct {
-	IsSigned  bool
-	IsTrusted bool
-	Author    string
-	Tool      string
+	IsSigned   bool
+	IsTrusted  bool
+	Author     string
+	Tool       string
+	Signatures []SignatureDetail
 }
 
 // UploadPublicKey uploads a public key to zot for signature verification.
@@ -76,6 +85,9 @@ func UploadPublicKey(ctx context.Context, opts *UploadPublicKeyOptions) error {
 	registryURL := buildRegistryURL(opts.Config)
 	uploadEndpoint := registryURL + "/v2/_zot/ext/cosign"
 
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(opts.Config))
+	defer cancel()
+
 	// Create HTTP request with public key as body
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadEndpoint, strings.NewReader(opts.PublicKey))
 	if err != nil {
@@ -126,6 +138,14 @@ func Verify(ctx context.Context, opts *VerificationOptions) (*VerificationResult
 				IsTrusted
 				Author
 			}
+			Referrers {
+				Digest
+				ArtifactType
+				Annotations {
+					Key
+					Value
+				}
+			}
 		}
 	}`, opts.Config.RepositoryName, opts.RecordCID)
 
@@ -138,6 +158,9 @@ func Verify(ctx context.Context, opts *VerificationOptions) (*VerificationResult
 		return nil, fmt.Errorf("failed to marshal GraphQL query: %w", err)
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(opts.Config))
+	defer cancel()
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, searchEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -174,14 +197,11 @@ func Verify(ctx context.Context, opts *VerificationOptions) (*VerificationResult
 	var graphqlResp struct {
 		Data struct {
 			Image struct {
-				Digest        string `json:"Digest"`
-				IsSigned      bool   `json:"IsSigned"`
-				Tag           string `json:"Tag"`
-				SignatureInfo []struct {
-					Tool      string `json:"Tool"`
-					IsTrusted bool   `json:"IsTrusted"`
-					Author    string `json:"Author"`
-				} `json:"SignatureInfo"`
+				Digest        string             `json:"Digest"`
+				IsSigned      bool               `json:"IsSigned"`
+				Tag           string             `json:"Tag"`
+				SignatureInfo []zotSignatureInfo `json:"SignatureInfo"`
+				Referrers     []zotReferrer      `json:"Referrers"`
 			} `json:"Image"`
 		} `json:"data"`
 	}
@@ -192,35 +212,36 @@ func Verify(ctx context.Context, opts *VerificationOptions) (*VerificationResult
 
 	// Build result
 	result := &VerificationResult{
-		IsSigned:  graphqlResp.Data.Image.IsSigned,
-		IsTrusted: false,
+		IsSigned:   graphqlResp.Data.Image.IsSigned,
+		IsTrusted:  false,
+		Signatures: buildSignatureDetails(graphqlResp.Data.Image.SignatureInfo, graphqlResp.Data.Image.Referrers),
 	}
 
-	// Extract signature info if available
-	if len(graphqlResp.Data.Image.SignatureInfo) > 0 {
-		sigInfo := graphqlResp.Data.Image.SignatureInfo[0]
+	// Keep the first signature in the top-level fields for compatibility
+	if len(result.Signatures) > 0 {
+		sigInfo := result.Signatures[0]
 		result.IsTrusted = sigInfo.IsTrusted
 		result.Author = sigInfo.Author
 		result.Tool = sigInfo.Tool
 	}
 
-	logger.Debug("Zot verification result", "recordCID", opts.RecordCID, "isSigned", result.IsSigned, "isTrusted", result.IsTrusted)
+	logger.Debug("Zot verification result", "recordCID", opts.RecordCID, "isSigned", result.IsSigned, "isTrusted", result.IsTrusted, "signatures", len(result.Signatures))
 
 	return result, nil
 }
 
-// buildRegistryURL constructs the registry URL with proper protocol.
-func buildRegistryURL(config *VerifyConfig) string {
-	registryURL := config.RegistryAddress
-	if !strings.HasPrefix(registryURL, "http://") && !strings.HasPrefix(registryURL, "https://") {
-		if config.Insecure {
-			registryURL = "http://" + registryURL
-		} else {
-			registryURL = "https://" + registryURL
-		}
+// requestTimeout returns the configured request timeout or the default.
+func requestTimeout(config *VerifyConfig) time.Duration {
+	if config.Timeout > 0 {
+		return config.Timeout
 	}
 
-	return registryURL
+	return DefaultRequestTimeout
+}
+
+// buildRegistryURL constructs the registry URL with proper protocol.
+func buildRegistryURL(config *VerifyConfig) string {
+	return registryURLWithScheme(config.RegistryAddress, !config.Insecure)
 }
 
 // addAuthentication adds authentication headers to HTTP requests.
@@ -5,11 +5,14 @@
 package zot
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestBuildRegistryURL(t *testing.T) {
@@ -66,6 +69,14 @@ func TestBuildRegistryURL(t *testing.T) {
 			},
 			expected: "http://registry.example.com:5000",
 		},
+		{
+			name: "address with TLS port, insecure",
+			config: &VerifyConfig{
+				RegistryAddress: "registry.example.com:443",
+				Insecure:        true,
+			},
+			expected: "https://registry.example.com:443",
+		},
 	}
 
 	for _, tt := range tests {
@@ -491,6 +502,69 @@ func TestVerify(t *testing.T) {
 	})
 }
 
+func TestRequestTimeout(t *testing.T) {
+	// slowServer never answers before the client gives up
+	slowServer := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+	}
+
+	t.Run("verify aborts on context deadline", func(t *testing.T) {
+		server := slowServer()
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err := Verify(ctx, &VerificationOptions{
+			Config: &VerifyConfig{
+				RegistryAddress: strings.TrimPrefix(server.URL, "http://"),
+				RepositoryName:  "test-repo",
+				Insecure:        true,
+			},
+			RecordCID: "test-cid",
+		})
+		if err == nil {
+			t.Fatalf("Expected error for slow registry")
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded error, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("Expected call to abort quickly, took %v", elapsed)
+		}
+	})
+
+	t.Run("upload aborts on configured timeout", func(t *testing.T) {
+		server := slowServer()
+		defer server.Close()
+
+		err := UploadPublicKey(t.Context(), &UploadPublicKeyOptions{
+			Config: &VerifyConfig{
+				RegistryAddress: strings.TrimPrefix(server.URL, "http://"),
+				Insecure:        true,
+				Timeout:         100 * time.Millisecond,
+			},
+			PublicKey: "test-public-key",
+		})
+		if err == nil {
+			t.Fatalf("Expected error for slow registry")
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded error, got %v", err)
+		}
+	})
+}
+
 func TestStructs(t *testing.T) {
 	t.Run("VerifyConfig", func(t *testing.T) {
 		config := &VerifyConfig{